@@ -0,0 +1,90 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationKeywords(t *testing.T) {
+	t.Run("examples", func(t *testing.T) {
+		s := mustParseSchema(t, `{"type": "object", "examples": [{"name": "Jo"}, "x", 1, null]}`)
+		require.True(t, s.HasExamples())
+		require.Equal(t, []any{map[string]any{"name": "Jo"}, "x", float64(1), nil}, s.Examples())
+
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type": "object", "examples": [{"name": "Jo"}, "x", 1, null]}`, string(buf))
+
+		built := schema.NewBuilder().Examples("a", "b").MustBuild()
+		require.Equal(t, []any{"a", "b"}, built.Examples())
+		require.False(t, schema.NewBuilder().Clone(built).ResetExamples().MustBuild().HasExamples())
+
+		// examples is an annotation: it never affects validation.
+		v, err := validator.Compile(t.Context(), mustParseSchema(t, `{"examples": [1]}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "anything")
+		require.NoError(t, err)
+	})
+
+	t.Run("readOnly and writeOnly", func(t *testing.T) {
+		s := mustParseSchema(t, `{"properties": {
+			"id": {"type": "string", "readOnly": true, "writeOnly": false},
+			"password": {"type": "string", "writeOnly": true}
+		}}`)
+		id := s.Properties()["id"]
+		require.True(t, id.HasReadOnly())
+		require.True(t, id.ReadOnly())
+		require.True(t, id.HasWriteOnly(), "false must be kept, not dropped")
+		require.False(t, id.WriteOnly())
+		require.False(t, s.Properties()["password"].HasReadOnly())
+
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"properties": {
+			"id": {"type": "string", "readOnly": true, "writeOnly": false},
+			"password": {"type": "string", "writeOnly": true}
+		}}`, string(buf))
+
+		built := schema.NewBuilder().ReadOnly(false).WriteOnly(true).MustBuild()
+		require.True(t, built.HasReadOnly())
+		require.False(t, built.ReadOnly())
+		require.True(t, built.WriteOnly())
+	})
+
+	t.Run("title and deprecated", func(t *testing.T) {
+		const src = `{"title": "Legacy ID", "deprecated": true, "$comment": "kept for v1 clients"}`
+		s := mustParseSchema(t, src)
+		require.Equal(t, "Legacy ID", s.Title())
+		require.True(t, s.Deprecated())
+
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, src, string(buf))
+
+		built := schema.NewBuilder().Title("Name").Deprecated(false).MustBuild()
+		require.Equal(t, "Name", built.Title())
+		require.True(t, built.HasDeprecated())
+		require.False(t, built.Deprecated())
+		require.False(t, schema.NewBuilder().Clone(built).ResetTitle().MustBuild().HasTitle())
+	})
+
+	t.Run("description", func(t *testing.T) {
+		const src = `{"title": "Age", "description": "Age in whole years"}`
+		s := mustParseSchema(t, src)
+		require.True(t, s.HasDescription())
+		require.Equal(t, "Age in whole years", s.Description())
+		require.Empty(t, s.Extensions(), "description must not be kept as an extension")
+
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, src, string(buf))
+
+		built := schema.NewBuilder().Description("Name").MustBuild()
+		require.Equal(t, "Name", built.Description())
+		require.False(t, schema.NewBuilder().Clone(built).ResetDescription().MustBuild().HasDescription())
+	})
+}
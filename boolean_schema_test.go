@@ -222,3 +222,119 @@ func TestBooleanSchemaValidation(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSchemaIsEmptyTrueFalse(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, json.Unmarshal([]byte(src), &s))
+		return &s
+	}
+
+	testcases := []struct {
+		schema string
+		empty  bool
+		false_ bool
+	}{
+		{schema: `{}`, empty: true},
+		{schema: `{"not": {}}`, false_: true},
+		{schema: `{"not": {"type": "string"}}`},
+		{schema: `{"not": {}, "title": "never"}`},
+		{schema: `{"type": "string"}`},
+		{schema: `{"x-internal": true}`},
+		{schema: `{"$comment": "anything goes"}`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.schema, func(t *testing.T) {
+			s := parse(t, tc.schema)
+			require.Equal(t, tc.empty, s.IsEmpty())
+			require.Equal(t, tc.empty, s.IsTrue())
+			require.Equal(t, tc.false_, s.IsFalse())
+		})
+	}
+
+	t.Run("boolean subschemas", func(t *testing.T) {
+		s := parse(t, `{"not": true}`)
+		require.True(t, s.Not().IsTrue())
+
+		s = parse(t, `{"properties": {"yes": true, "no": false}}`)
+		require.True(t, s.Properties()["yes"].IsTrue())
+		require.True(t, s.Properties()["no"].IsFalse())
+	})
+
+	t.Run("built", func(t *testing.T) {
+		require.True(t, schema.New().IsEmpty())
+		require.True(t, schema.NewBuilder().Not(schema.New()).MustBuild().IsFalse())
+	})
+}
+
+func TestAsSchema(t *testing.T) {
+	s, ok := schema.AsSchema(schema.TrueSchema())
+	require.True(t, ok)
+	require.True(t, s.IsTrue())
+
+	s, ok = schema.AsSchema(schema.FalseSchema())
+	require.True(t, ok)
+	require.True(t, s.IsFalse())
+
+	str := schema.NewBuilder().Types(schema.StringType).MustBuild()
+	s, ok = schema.AsSchema(str)
+	require.True(t, ok)
+	require.Same(t, str, s)
+
+	for _, v := range []schema.SchemaOrBool{nil, (*schema.Schema)(nil), schema.TupleItems{str}} {
+		_, ok = schema.AsSchema(v)
+		require.False(t, ok, "%#v", v)
+	}
+
+	t.Run("the results validate like the boolean schemas", func(t *testing.T) {
+		for _, b := range []bool{true, false} {
+			s, _ := schema.AsSchema(schema.BoolSchema(b))
+			v, err := validator.Compile(t.Context(), s)
+			require.NoError(t, err)
+			_, err = v.Validate(t.Context(), map[string]any{"a": 1})
+			require.Equal(t, b, err == nil)
+		}
+	})
+}
+
+func TestSchemaOrBoolAccessors(t *testing.T) {
+	s := mustParseSchema(t, `{"items":{"type":"string"},"additionalProperties":false,"if":true,"then":{"minLength":1}}`)
+
+	items, ok := s.ItemsAsSchema()
+	require.True(t, ok)
+	require.True(t, items.ContainsType(schema.StringType))
+	_, ok = s.ItemsAsBool()
+	require.False(t, ok)
+
+	additional, ok := s.AdditionalPropertiesAsBool()
+	require.True(t, ok)
+	require.False(t, additional)
+	_, ok = s.AdditionalPropertiesAsSchema()
+	require.False(t, ok)
+
+	cond, ok := s.IfAsBool()
+	require.True(t, ok)
+	require.True(t, cond)
+	then, ok := s.ThenAsSchema()
+	require.True(t, ok)
+	require.True(t, then.HasMinLength())
+
+	t.Run("unset", func(t *testing.T) {
+		_, ok := s.ContainsAsSchema()
+		require.False(t, ok)
+		_, ok = s.ContainsAsBool()
+		require.False(t, ok)
+		_, ok = s.ElseAsSchema()
+		require.False(t, ok)
+	})
+
+	t.Run("tuple items", func(t *testing.T) {
+		s := mustParseSchema(t, `{"$schema":"http://json-schema.org/draft-07/schema#","items":[{"type":"string"}]}`)
+		require.True(t, s.HasItems())
+		_, ok := s.ItemsAsSchema()
+		require.False(t, ok)
+		_, ok = s.ItemsAsBool()
+		require.False(t, ok)
+	})
+}
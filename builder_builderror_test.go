@@ -42,3 +42,44 @@ func TestBuilderErrorPropagation(t *testing.T) {
 		})
 	})
 }
+
+func TestBuilderBoundsCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		builder *schema.Builder
+		message string
+	}{
+		{"negative minLength", schema.NewBuilder().MinLength(-1), "minLength must not be negative"},
+		{"negative maxLength", schema.NewBuilder().MaxLength(-2), "maxLength must not be negative"},
+		{"minLength above maxLength", schema.NewBuilder().MinLength(5).MaxLength(3), "minLength (5) must not be greater than maxLength (3)"},
+		{"minimum above maximum", schema.NewBuilder().Minimum(10).Maximum(1.5), "minimum (10) must not be greater than maximum (1.5)"},
+		{"empty exclusive range", schema.NewBuilder().ExclusiveMinimum(1).ExclusiveMaximum(1), "exclusiveMinimum (1) must be less than exclusiveMaximum (1)"},
+		{"multipleOf zero", schema.NewBuilder().MultipleOf(0), "multipleOf must be greater than 0"},
+		{"minItems above maxItems", schema.NewBuilder().MinItems(3).MaxItems(2), "minItems (3) must not be greater than maxItems (2)"},
+		{"minContains above maxContains", schema.NewBuilder().MinContains(2).MaxContains(1), "minContains (2) must not be greater than maxContains (1)"},
+		{"minProperties above maxProperties", schema.NewBuilder().MinProperties(4).MaxProperties(0), "minProperties (4) must not be greater than maxProperties (0)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := tc.builder.Build()
+			require.ErrorContains(t, err, tc.message)
+			require.Nil(t, s)
+			require.Panics(t, func() { tc.builder.MustBuild() })
+
+			s, err = tc.builder.Build(schema.WithBoundsCheck(false))
+			require.NoError(t, err)
+			require.NotNil(t, s)
+		})
+	}
+
+	t.Run("equal bounds", func(t *testing.T) {
+		_, err := schema.NewBuilder().MinLength(2).MaxLength(2).Minimum(1).Maximum(1).MinItems(0).MaxItems(0).Build()
+		require.NoError(t, err)
+	})
+
+	t.Run("unmarshaled schemas are not checked", func(t *testing.T) {
+		s := mustParseSchema(t, `{"minLength": 5, "maxLength": 3}`)
+		merged, err := schema.Merge(s, mustParseSchema(t, `{"type": "string"}`))
+		require.NoError(t, err)
+		require.Equal(t, 5, merged.MinLength())
+	})
+}
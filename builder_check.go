@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/lestrrat-go/option/v3"
+)
+
+// BuildOption configures Builder.Build.
+type BuildOption interface {
+	option.Interface
+	buildOption()
+}
+
+type buildOption struct{ option.Interface }
+
+func (buildOption) buildOption() {}
+
+type identBoundsCheck struct{}
+
+// WithBoundsCheck, given false, lets Build produce a schema whose bounds
+// contradict each other. By default Build fails for a negative minLength or
+// maxLength, a lower bound above its upper bound (minLength above maxLength,
+// minimum above maximum, and likewise for items, contains and properties), an
+// exclusiveMinimum at or above exclusiveMaximum, and a multipleOf that is not
+// greater than 0. Such a schema is valid JSON Schema, but no value satisfies
+// it, so it is more often a typo than intended.
+func WithBoundsCheck(v bool) BuildOption {
+	return buildOption{option.New(identBoundsCheck{}, v)}
+}
+
+// checkBuild runs the checks options call for on s, the schema Build is
+// about to return.
+func checkBuild(s *Schema, options []BuildOption) error {
+	check := true
+	for _, o := range options {
+		if o.Ident() == (identBoundsCheck{}) {
+			check = option.MustGet[bool](o)
+		}
+	}
+	if !check {
+		return nil
+	}
+	return checkBounds(s)
+}
+
+func checkBounds(s *Schema) error {
+	for _, l := range []struct {
+		name string
+		has  bool
+		v    func() int
+	}{
+		{"minLength", s.HasMinLength(), s.MinLength},
+		{"maxLength", s.HasMaxLength(), s.MaxLength},
+	} {
+		if l.has && l.v() < 0 {
+			return fmt.Errorf(`%s must not be negative, got %d`, l.name, l.v())
+		}
+	}
+	if s.HasMinLength() && s.HasMaxLength() && s.MinLength() > s.MaxLength() {
+		return boundsError("minLength", s.MinLength(), "maxLength", s.MaxLength())
+	}
+	if s.HasMinimum() && s.HasMaximum() && s.Minimum() > s.Maximum() {
+		return boundsError("minimum", s.Minimum(), "maximum", s.Maximum())
+	}
+	if s.HasExclusiveMinimum() && s.HasExclusiveMaximum() && s.ExclusiveMinimum() >= s.ExclusiveMaximum() {
+		return fmt.Errorf(`exclusiveMinimum (%v) must be less than exclusiveMaximum (%v)`, s.ExclusiveMinimum(), s.ExclusiveMaximum())
+	}
+	if s.HasMultipleOf() && s.MultipleOf() <= 0 {
+		return fmt.Errorf(`multipleOf must be greater than 0, got %v`, s.MultipleOf())
+	}
+	for _, c := range []struct {
+		minName, maxName string
+		hasMin, hasMax   bool
+		min, max         func() uint
+	}{
+		{"minItems", "maxItems", s.HasMinItems(), s.HasMaxItems(), s.MinItems, s.MaxItems},
+		{"minContains", "maxContains", s.HasMinContains(), s.HasMaxContains(), s.MinContains, s.MaxContains},
+		{"minProperties", "maxProperties", s.HasMinProperties(), s.HasMaxProperties(), s.MinProperties, s.MaxProperties},
+	} {
+		if c.hasMin && c.hasMax && c.min() > c.max() {
+			return boundsError(c.minName, c.min(), c.maxName, c.max())
+		}
+	}
+	return nil
+}
+
+func boundsError(minName string, minValue any, maxName string, maxValue any) error {
+	return fmt.Errorf(`%s (%v) must not be greater than %s (%v): no value can satisfy both`, minName, minValue, maxName, maxValue)
+}
@@ -2,7 +2,14 @@
 
 package schema
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/lestrrat-go/json-schema/keywords"
+)
 
 type propPair struct {
 	Name   string
@@ -32,18 +39,23 @@ type Builder struct {
 	contentSchema         *Schema
 	defaultValue          *any
 	definitions           []*propPair
+	dependencies          map[string]any
 	dependentRequired     map[string][]string
 	dependentSchemas      map[string]SchemaOrBool
+	deprecated            *bool
+	description           *string
 	dynamicAnchor         *string
 	dynamicReference      *string
 	elseSchema            SchemaOrBool
 	enum                  []any
+	examples              []any
 	exclusiveMaximum      *float64
 	exclusiveMinimum      *float64
 	format                *string
 	id                    *string
 	ifSchema              SchemaOrBool
 	items                 SchemaOrBool
+	legacyDefinitions     []*propPair
 	maxContains           *uint
 	maxItems              *uint
 	maxLength             *int
@@ -62,15 +74,22 @@ type Builder struct {
 	prefixItems           []SchemaOrBool
 	properties            []*propPair
 	propertyNames         *Schema
+	readOnly              *bool
+	recursiveAnchor       *bool
+	recursiveReference    *string
 	reference             *string
 	required              []string
 	schema                *string
 	thenSchema            SchemaOrBool
+	title                 *string
 	types                 PrimitiveTypes
 	unevaluatedItems      SchemaOrBool
 	unevaluatedProperties SchemaOrBool
 	uniqueItems           *bool
 	vocabulary            map[string]bool
+	writeOnly             *bool
+	extensions            map[string]json.RawMessage
+	numbers               map[string]json.Number
 }
 
 func NewBuilder() *Builder {
@@ -212,6 +231,33 @@ func (b *Builder) Definitions(n string, v *Schema) *Builder {
 	return b
 }
 
+// DefinitionsMap adds every entry of v, as Definitions does, in key order.
+// Build reports an error for a name that was added more than once.
+func (b *Builder) DefinitionsMap(v map[string]*Schema) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	for _, n := range slices.Sorted(maps.Keys(v)) {
+		b.definitions = append(b.definitions, &propPair{Name: n, Schema: v[n]})
+	}
+	return b
+}
+
+// Dependencies sets the dependencies field of the schema being built.
+// dependencies is the draft-07 (and earlier) form of dependentRequired
+// and dependentSchemas. Each value is either a []string listing the
+// properties required when the key is present, or a SchemaOrBool that
+// the whole object must satisfy when the key is present.
+func (b *Builder) Dependencies(v map[string]any) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.dependencies = v
+	return b
+}
+
 // DependentRequired sets the dependentRequired field of the schema being built.
 func (b *Builder) DependentRequired(v map[string][]string) *Builder {
 	if b.err != nil {
@@ -232,6 +278,26 @@ func (b *Builder) DependentSchemas(v map[string]SchemaOrBool) *Builder {
 	return b
 }
 
+// Deprecated sets the deprecated field of the schema being built.
+func (b *Builder) Deprecated(v bool) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.deprecated = &v
+	return b
+}
+
+// Description sets the description field of the schema being built.
+func (b *Builder) Description(v string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.description = &v
+	return b
+}
+
 // DynamicAnchor sets the $dynamicAnchor field of the schema being built.
 func (b *Builder) DynamicAnchor(v string) *Builder {
 	if b.err != nil {
@@ -269,6 +335,15 @@ func (b *Builder) Enum(v ...any) *Builder {
 	return b
 }
 
+func (b *Builder) Examples(v ...any) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.examples = v
+	return b
+}
+
 // ExclusiveMaximum sets the exclusiveMaximum field of the schema being built.
 func (b *Builder) ExclusiveMaximum(v float64) *Builder {
 	if b.err != nil {
@@ -276,6 +351,7 @@ func (b *Builder) ExclusiveMaximum(v float64) *Builder {
 	}
 
 	b.exclusiveMaximum = &v
+	delete(b.numbers, keywords.ExclusiveMaximum)
 	return b
 }
 
@@ -286,6 +362,7 @@ func (b *Builder) ExclusiveMinimum(v float64) *Builder {
 	}
 
 	b.exclusiveMinimum = &v
+	delete(b.numbers, keywords.ExclusiveMinimum)
 	return b
 }
 
@@ -325,6 +402,28 @@ func (b *Builder) Items(v SchemaOrBool) *Builder {
 	return b
 }
 
+func (b *Builder) LegacyDefinitions(n string, v *Schema) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.legacyDefinitions = append(b.legacyDefinitions, &propPair{Name: n, Schema: v})
+	return b
+}
+
+// LegacyDefinitionsMap adds every entry of v, as LegacyDefinitions does, in key order.
+// Build reports an error for a name that was added more than once.
+func (b *Builder) LegacyDefinitionsMap(v map[string]*Schema) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	for _, n := range slices.Sorted(maps.Keys(v)) {
+		b.legacyDefinitions = append(b.legacyDefinitions, &propPair{Name: n, Schema: v[n]})
+	}
+	return b
+}
+
 // MaxContains sets the maxContains field of the schema being built.
 func (b *Builder) MaxContains(v uint) *Builder {
 	if b.err != nil {
@@ -372,6 +471,7 @@ func (b *Builder) Maximum(v float64) *Builder {
 	}
 
 	b.maximum = &v
+	delete(b.numbers, keywords.Maximum)
 	return b
 }
 
@@ -422,6 +522,7 @@ func (b *Builder) Minimum(v float64) *Builder {
 	}
 
 	b.minimum = &v
+	delete(b.numbers, keywords.Minimum)
 	return b
 }
 
@@ -432,6 +533,7 @@ func (b *Builder) MultipleOf(v float64) *Builder {
 	}
 
 	b.multipleOf = &v
+	delete(b.numbers, keywords.MultipleOf)
 	return b
 }
 
@@ -480,6 +582,19 @@ func (b *Builder) PatternProperty(n string, v *Schema) *Builder {
 	return b
 }
 
+// PatternProperties adds every entry of v, as PatternProperty does, in key order.
+// Build reports an error for a name that was added more than once.
+func (b *Builder) PatternProperties(v map[string]*Schema) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	for _, n := range slices.Sorted(maps.Keys(v)) {
+		b.patternProperties = append(b.patternProperties, &propPair{Name: n, Schema: v[n]})
+	}
+	return b
+}
+
 func (b *Builder) PrefixItems(v ...SchemaOrBool) *Builder {
 	if b.err != nil {
 		return b
@@ -505,6 +620,19 @@ func (b *Builder) Property(n string, v *Schema) *Builder {
 	return b
 }
 
+// Properties adds every entry of v, as Property does, in key order.
+// Build reports an error for a name that was added more than once.
+func (b *Builder) Properties(v map[string]*Schema) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	for _, n := range slices.Sorted(maps.Keys(v)) {
+		b.properties = append(b.properties, &propPair{Name: n, Schema: v[n]})
+	}
+	return b
+}
+
 // PropertyNames sets the propertyNames field of the schema being built.
 func (b *Builder) PropertyNames(v *Schema) *Builder {
 	if b.err != nil {
@@ -515,6 +643,39 @@ func (b *Builder) PropertyNames(v *Schema) *Builder {
 	return b
 }
 
+// ReadOnly sets the readOnly field of the schema being built.
+func (b *Builder) ReadOnly(v bool) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.readOnly = &v
+	return b
+}
+
+// RecursiveAnchor sets the $recursiveAnchor field of the schema being built.
+func (b *Builder) RecursiveAnchor(v bool) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.recursiveAnchor = &v
+	return b
+}
+
+// RecursiveReference sets the $recursiveRef field of the schema being built.
+// $recursiveRef is the draft 2019-09 predecessor of $dynamicRef. It is
+// only honored by the validator for schemas that declare 2019-09 in
+// $schema.
+func (b *Builder) RecursiveReference(v string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.recursiveReference = &v
+	return b
+}
+
 // Reference sets the $ref field of the schema being built.
 func (b *Builder) Reference(v string) *Builder {
 	if b.err != nil {
@@ -559,6 +720,16 @@ func (b *Builder) ThenSchema(v SchemaOrBool) *Builder {
 	return b
 }
 
+// Title sets the title field of the schema being built.
+func (b *Builder) Title(v string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.title = &v
+	return b
+}
+
 func (b *Builder) Types(v ...PrimitiveType) *Builder {
 	if b.err != nil {
 		return b
@@ -604,6 +775,16 @@ func (b *Builder) Vocabulary(v map[string]bool) *Builder {
 	return b
 }
 
+// WriteOnly sets the writeOnly field of the schema being built.
+func (b *Builder) WriteOnly(v bool) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	b.writeOnly = &v
+	return b
+}
+
 func (b *Builder) Clone(original *Schema) *Builder {
 	if b.err != nil {
 		return b
@@ -666,6 +847,10 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		}
 	}
 
+	if original.HasDependencies() {
+		b.dependencies = original.dependencies
+	}
+
 	if original.HasDependentRequired() {
 		b.dependentRequired = original.dependentRequired
 	}
@@ -674,6 +859,14 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		b.dependentSchemas = original.dependentSchemas
 	}
 
+	if original.HasDeprecated() {
+		b.deprecated = original.deprecated
+	}
+
+	if original.HasDescription() {
+		b.description = original.description
+	}
+
 	if original.HasDynamicAnchor() {
 		b.dynamicAnchor = original.dynamicAnchor
 	}
@@ -690,12 +883,18 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		b.enum = original.enum
 	}
 
+	if original.HasExamples() {
+		b.examples = original.examples
+	}
+
 	if original.HasExclusiveMaximum() {
 		b.exclusiveMaximum = original.exclusiveMaximum
+		b.setNumber(keywords.ExclusiveMaximum, original.numbers[keywords.ExclusiveMaximum])
 	}
 
 	if original.HasExclusiveMinimum() {
 		b.exclusiveMinimum = original.exclusiveMinimum
+		b.setNumber(keywords.ExclusiveMinimum, original.numbers[keywords.ExclusiveMinimum])
 	}
 
 	if original.HasFormat() {
@@ -714,6 +913,12 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		b.items = original.items
 	}
 
+	if original.HasLegacyDefinitions() {
+		for name, schema := range original.legacyDefinitions {
+			b.legacyDefinitions = append(b.legacyDefinitions, &propPair{Name: name, Schema: schema})
+		}
+	}
+
 	if original.HasMaxContains() {
 		b.maxContains = original.maxContains
 	}
@@ -732,6 +937,7 @@ func (b *Builder) Clone(original *Schema) *Builder {
 
 	if original.HasMaximum() {
 		b.maximum = original.maximum
+		b.setNumber(keywords.Maximum, original.numbers[keywords.Maximum])
 	}
 
 	if original.HasMinContains() {
@@ -752,10 +958,12 @@ func (b *Builder) Clone(original *Schema) *Builder {
 
 	if original.HasMinimum() {
 		b.minimum = original.minimum
+		b.setNumber(keywords.Minimum, original.numbers[keywords.Minimum])
 	}
 
 	if original.HasMultipleOf() {
 		b.multipleOf = original.multipleOf
+		b.setNumber(keywords.MultipleOf, original.numbers[keywords.MultipleOf])
 	}
 
 	if original.HasNot() {
@@ -790,6 +998,18 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		b.propertyNames = original.propertyNames
 	}
 
+	if original.HasReadOnly() {
+		b.readOnly = original.readOnly
+	}
+
+	if original.HasRecursiveAnchor() {
+		b.recursiveAnchor = original.recursiveAnchor
+	}
+
+	if original.HasRecursiveReference() {
+		b.recursiveReference = original.recursiveReference
+	}
+
 	if original.HasReference() {
 		b.reference = original.reference
 	}
@@ -806,6 +1026,10 @@ func (b *Builder) Clone(original *Schema) *Builder {
 		b.thenSchema = original.thenSchema
 	}
 
+	if original.HasTitle() {
+		b.title = original.title
+	}
+
 	if original.HasTypes() {
 		b.types = original.types
 	}
@@ -825,6 +1049,17 @@ func (b *Builder) Clone(original *Schema) *Builder {
 	if original.HasVocabulary() {
 		b.vocabulary = original.vocabulary
 	}
+
+	if original.HasWriteOnly() {
+		b.writeOnly = original.writeOnly
+	}
+
+	if original.extensions != nil {
+		if b.extensions == nil {
+			b.extensions = make(map[string]json.RawMessage)
+		}
+		maps.Copy(b.extensions, original.extensions)
+	}
 	return b
 }
 
@@ -932,6 +1167,14 @@ func (b *Builder) ResetDefinitions() *Builder {
 	return b
 }
 
+func (b *Builder) ResetDependencies() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.dependencies = nil
+	return b
+}
+
 func (b *Builder) ResetDependentRequired() *Builder {
 	if b.err != nil {
 		return b
@@ -948,6 +1191,22 @@ func (b *Builder) ResetDependentSchemas() *Builder {
 	return b
 }
 
+func (b *Builder) ResetDeprecated() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.deprecated = nil
+	return b
+}
+
+func (b *Builder) ResetDescription() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.description = nil
+	return b
+}
+
 func (b *Builder) ResetDynamicAnchor() *Builder {
 	if b.err != nil {
 		return b
@@ -980,11 +1239,20 @@ func (b *Builder) ResetEnum() *Builder {
 	return b
 }
 
+func (b *Builder) ResetExamples() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.examples = nil
+	return b
+}
+
 func (b *Builder) ResetExclusiveMaximum() *Builder {
 	if b.err != nil {
 		return b
 	}
 	b.exclusiveMaximum = nil
+	delete(b.numbers, keywords.ExclusiveMaximum)
 	return b
 }
 
@@ -993,6 +1261,7 @@ func (b *Builder) ResetExclusiveMinimum() *Builder {
 		return b
 	}
 	b.exclusiveMinimum = nil
+	delete(b.numbers, keywords.ExclusiveMinimum)
 	return b
 }
 
@@ -1028,6 +1297,14 @@ func (b *Builder) ResetItems() *Builder {
 	return b
 }
 
+func (b *Builder) ResetLegacyDefinitions() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.legacyDefinitions = nil
+	return b
+}
+
 func (b *Builder) ResetMaxContains() *Builder {
 	if b.err != nil {
 		return b
@@ -1065,6 +1342,7 @@ func (b *Builder) ResetMaximum() *Builder {
 		return b
 	}
 	b.maximum = nil
+	delete(b.numbers, keywords.Maximum)
 	return b
 }
 
@@ -1105,6 +1383,7 @@ func (b *Builder) ResetMinimum() *Builder {
 		return b
 	}
 	b.minimum = nil
+	delete(b.numbers, keywords.Minimum)
 	return b
 }
 
@@ -1113,6 +1392,7 @@ func (b *Builder) ResetMultipleOf() *Builder {
 		return b
 	}
 	b.multipleOf = nil
+	delete(b.numbers, keywords.MultipleOf)
 	return b
 }
 
@@ -1172,6 +1452,30 @@ func (b *Builder) ResetPropertyNames() *Builder {
 	return b
 }
 
+func (b *Builder) ResetReadOnly() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.readOnly = nil
+	return b
+}
+
+func (b *Builder) ResetRecursiveAnchor() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.recursiveAnchor = nil
+	return b
+}
+
+func (b *Builder) ResetRecursiveReference() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.recursiveReference = nil
+	return b
+}
+
 func (b *Builder) ResetReference() *Builder {
 	if b.err != nil {
 		return b
@@ -1204,6 +1508,14 @@ func (b *Builder) ResetThenSchema() *Builder {
 	return b
 }
 
+func (b *Builder) ResetTitle() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.title = nil
+	return b
+}
+
 func (b *Builder) ResetTypes() *Builder {
 	if b.err != nil {
 		return b
@@ -1244,6 +1556,14 @@ func (b *Builder) ResetVocabulary() *Builder {
 	return b
 }
 
+func (b *Builder) ResetWriteOnly() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.writeOnly = nil
+	return b
+}
+
 // Reset clears the builder fields identified by the given flags.
 // For example, b.Reset(AnchorField | PropertiesField) clears both anchor and properties.
 func (b *Builder) Reset(flags FieldFlag) *Builder {
@@ -1289,12 +1609,21 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & DefinitionsField) != 0 {
 		b.definitions = nil
 	}
+	if (flags & DependenciesField) != 0 {
+		b.dependencies = nil
+	}
 	if (flags & DependentRequiredField) != 0 {
 		b.dependentRequired = nil
 	}
 	if (flags & DependentSchemasField) != 0 {
 		b.dependentSchemas = nil
 	}
+	if (flags & DeprecatedField) != 0 {
+		b.deprecated = nil
+	}
+	if (flags & DescriptionField) != 0 {
+		b.description = nil
+	}
 	if (flags & DynamicAnchorField) != 0 {
 		b.dynamicAnchor = nil
 	}
@@ -1307,11 +1636,16 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & EnumField) != 0 {
 		b.enum = nil
 	}
+	if (flags & ExamplesField) != 0 {
+		b.examples = nil
+	}
 	if (flags & ExclusiveMaximumField) != 0 {
 		b.exclusiveMaximum = nil
+		delete(b.numbers, keywords.ExclusiveMaximum)
 	}
 	if (flags & ExclusiveMinimumField) != 0 {
 		b.exclusiveMinimum = nil
+		delete(b.numbers, keywords.ExclusiveMinimum)
 	}
 	if (flags & FormatField) != 0 {
 		b.format = nil
@@ -1325,6 +1659,9 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & ItemsField) != 0 {
 		b.items = nil
 	}
+	if (flags & LegacyDefinitionsField) != 0 {
+		b.legacyDefinitions = nil
+	}
 	if (flags & MaxContainsField) != 0 {
 		b.maxContains = nil
 	}
@@ -1339,6 +1676,7 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	}
 	if (flags & MaximumField) != 0 {
 		b.maximum = nil
+		delete(b.numbers, keywords.Maximum)
 	}
 	if (flags & MinContainsField) != 0 {
 		b.minContains = nil
@@ -1354,9 +1692,11 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	}
 	if (flags & MinimumField) != 0 {
 		b.minimum = nil
+		delete(b.numbers, keywords.Minimum)
 	}
 	if (flags & MultipleOfField) != 0 {
 		b.multipleOf = nil
+		delete(b.numbers, keywords.MultipleOf)
 	}
 	if (flags & NotField) != 0 {
 		b.not = nil
@@ -1379,6 +1719,15 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & PropertyNamesField) != 0 {
 		b.propertyNames = nil
 	}
+	if (flags & ReadOnlyField) != 0 {
+		b.readOnly = nil
+	}
+	if (flags & RecursiveAnchorField) != 0 {
+		b.recursiveAnchor = nil
+	}
+	if (flags & RecursiveReferenceField) != 0 {
+		b.recursiveReference = nil
+	}
 	if (flags & ReferenceField) != 0 {
 		b.reference = nil
 	}
@@ -1391,6 +1740,9 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & ThenSchemaField) != 0 {
 		b.thenSchema = nil
 	}
+	if (flags & TitleField) != 0 {
+		b.title = nil
+	}
 	if (flags & TypesField) != 0 {
 		b.types = nil
 	}
@@ -1406,10 +1758,16 @@ func (b *Builder) Reset(flags FieldFlag) *Builder {
 	if (flags & VocabularyField) != 0 {
 		b.vocabulary = nil
 	}
+	if (flags & WriteOnlyField) != 0 {
+		b.writeOnly = nil
+	}
 	return b
 }
 
-func (b *Builder) Build() (*Schema, error) {
+// Build returns the schema. It fails with the first error that a setter
+// recorded, and with an error for bounds that no value can satisfy (see
+// WithBoundsCheck).
+func (b *Builder) Build(options ...BuildOption) (*Schema, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
@@ -1473,6 +1831,10 @@ func (b *Builder) Build() (*Schema, error) {
 		}
 		s.populatedFields |= DefinitionsField
 	}
+	if b.dependencies != nil {
+		s.dependencies = b.dependencies
+		s.populatedFields |= DependenciesField
+	}
 	if b.dependentRequired != nil {
 		s.dependentRequired = b.dependentRequired
 		s.populatedFields |= DependentRequiredField
@@ -1481,6 +1843,14 @@ func (b *Builder) Build() (*Schema, error) {
 		s.dependentSchemas = b.dependentSchemas
 		s.populatedFields |= DependentSchemasField
 	}
+	if b.deprecated != nil {
+		s.deprecated = b.deprecated
+		s.populatedFields |= DeprecatedField
+	}
+	if b.description != nil {
+		s.description = b.description
+		s.populatedFields |= DescriptionField
+	}
 	if b.dynamicAnchor != nil {
 		s.dynamicAnchor = b.dynamicAnchor
 		s.populatedFields |= DynamicAnchorField
@@ -1497,6 +1867,10 @@ func (b *Builder) Build() (*Schema, error) {
 		s.enum = b.enum
 		s.populatedFields |= EnumField
 	}
+	if b.examples != nil {
+		s.examples = b.examples
+		s.populatedFields |= ExamplesField
+	}
 	if b.exclusiveMaximum != nil {
 		s.exclusiveMaximum = b.exclusiveMaximum
 		s.populatedFields |= ExclusiveMaximumField
@@ -1521,6 +1895,17 @@ func (b *Builder) Build() (*Schema, error) {
 		s.items = b.items
 		s.populatedFields |= ItemsField
 	}
+
+	if b.legacyDefinitions != nil {
+		s.legacyDefinitions = make(map[string]*Schema)
+		for _, pair := range b.legacyDefinitions {
+			if _, ok := s.legacyDefinitions[pair.Name]; ok {
+				return nil, fmt.Errorf(`duplicate key %q in "definitions"`, pair.Name)
+			}
+			s.legacyDefinitions[pair.Name] = pair.Schema
+		}
+		s.populatedFields |= LegacyDefinitionsField
+	}
 	if b.maxContains != nil {
 		s.maxContains = b.maxContains
 		s.populatedFields |= MaxContainsField
@@ -1607,6 +1992,18 @@ func (b *Builder) Build() (*Schema, error) {
 		s.propertyNames = b.propertyNames
 		s.populatedFields |= PropertyNamesField
 	}
+	if b.readOnly != nil {
+		s.readOnly = b.readOnly
+		s.populatedFields |= ReadOnlyField
+	}
+	if b.recursiveAnchor != nil {
+		s.recursiveAnchor = b.recursiveAnchor
+		s.populatedFields |= RecursiveAnchorField
+	}
+	if b.recursiveReference != nil {
+		s.recursiveReference = b.recursiveReference
+		s.populatedFields |= RecursiveReferenceField
+	}
 	if b.reference != nil {
 		s.reference = b.reference
 		s.populatedFields |= ReferenceField
@@ -1623,6 +2020,10 @@ func (b *Builder) Build() (*Schema, error) {
 		s.thenSchema = b.thenSchema
 		s.populatedFields |= ThenSchemaField
 	}
+	if b.title != nil {
+		s.title = b.title
+		s.populatedFields |= TitleField
+	}
 	if b.types != nil {
 		s.types = b.types
 		s.populatedFields |= TypesField
@@ -1643,11 +2044,24 @@ func (b *Builder) Build() (*Schema, error) {
 		s.vocabulary = b.vocabulary
 		s.populatedFields |= VocabularyField
 	}
+	if b.writeOnly != nil {
+		s.writeOnly = b.writeOnly
+		s.populatedFields |= WriteOnlyField
+	}
+	if len(b.extensions) > 0 {
+		s.extensions = maps.Clone(b.extensions)
+	}
+	if len(b.numbers) > 0 {
+		s.numbers = maps.Clone(b.numbers)
+	}
+	if err := checkBuild(s, options); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
 
-func (b *Builder) MustBuild() *Schema {
-	s, err := b.Build()
+func (b *Builder) MustBuild(options ...BuildOption) *Schema {
+	s, err := b.Build(options...)
 	if err != nil {
 		panic(fmt.Errorf(`failed to build schema: %w`, err))
 	}
@@ -86,3 +86,54 @@ func TestCloneBuilderWithCompositeValidator(t *testing.T) {
 	require.Equal(t, schemaWithRef.Properties(), withoutRef.Properties())
 	require.Equal(t, schemaWithRef.Required(), withoutRef.Required())
 }
+
+func TestBuilderBulkSetters(t *testing.T) {
+	str := NewBuilder().Types(StringType).MustBuild()
+	num := NewBuilder().Types(NumberType).MustBuild()
+
+	t.Run("Properties and PatternProperties", func(t *testing.T) {
+		s, err := NewBuilder().
+			Property("id", num).
+			Properties(map[string]*Schema{"name": str, "email": str}).
+			PatternProperties(map[string]*Schema{"^x-": str, "^n_": num}).
+			Build()
+		require.NoError(t, err)
+		require.Len(t, s.Properties(), 3)
+		require.Same(t, num, s.Properties()["id"])
+		require.Same(t, str, s.Properties()["email"])
+		require.Len(t, s.PatternProperties(), 2)
+		require.Same(t, num, s.PatternProperties()["^n_"])
+	})
+
+	t.Run("DefinitionsMap", func(t *testing.T) {
+		s, err := NewBuilder().
+			Definitions("name", str).
+			DefinitionsMap(map[string]*Schema{"amount": num, "label": str}).
+			LegacyDefinitionsMap(map[string]*Schema{"old": str}).
+			Build()
+		require.NoError(t, err)
+		require.Len(t, s.Definitions(), 3)
+		require.Same(t, num, s.Definitions()["amount"])
+		require.Same(t, str, s.LegacyDefinitions()["old"])
+
+		_, err = NewBuilder().
+			DefinitionsMap(map[string]*Schema{"name": num}).
+			Definitions("name", str).
+			Build()
+		require.ErrorContains(t, err, `duplicate key "name" in "$defs"`)
+	})
+
+	t.Run("duplicates are reported by Build", func(t *testing.T) {
+		_, err := NewBuilder().
+			Property("name", str).
+			Properties(map[string]*Schema{"name": num}).
+			Build()
+		require.ErrorContains(t, err, `duplicate key "name" in "properties"`)
+
+		_, err = NewBuilder().
+			PatternProperties(map[string]*Schema{"^x-": str}).
+			PatternProperty("^x-", num).
+			Build()
+		require.ErrorContains(t, err, `duplicate key "^x-" in "patternProperties"`)
+	})
+}
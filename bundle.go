@@ -0,0 +1,270 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat-go/option/v3"
+)
+
+// BundleOption configures Bundle.
+type BundleOption interface {
+	option.Interface
+	bundleOption()
+}
+
+type bundleOption struct{ option.Interface }
+
+func (bundleOption) bundleOption() {}
+
+type identBundleResolver struct{}
+type identBundleBaseURI struct{}
+
+// WithBundleResolver sets the Resolver Bundle retrieves referenced documents
+// with. Without it, Bundle uses NewResolver(), which only knows the documents
+// in memory, so any external reference fails.
+func WithBundleResolver(r *Resolver) BundleOption {
+	return bundleOption{option.New(identBundleResolver{}, r)}
+}
+
+// WithBundleBaseURI sets the URI the schema was retrieved from, such as
+// "file:///schemas/main.json", against which its relative references (and its
+// "$id", if relative) are resolved.
+func WithBundleBaseURI(uri string) BundleOption {
+	return bundleOption{option.New(identBundleBaseURI{}, uri)}
+}
+
+// Bundle returns a copy of s that references no other document: every
+// document reached through a "$ref" to another URI, directly or from another
+// referenced document, is embedded under the "$defs" of the result.
+//
+// Each embedded document keeps its "$id", resolved against its retrieval URI
+// if relative, or is given its retrieval URI as "$id", so that the
+// references inside it resolve as they did before.
+// References from s itself are rewritten to JSON Pointers such as
+// "#/$defs/person/properties/name"; references from within embedded
+// documents, and references to anchors, are rewritten to the "$id" of their
+// target instead. The names under "$defs" come from the last segment of the
+// document URIs, made unique. If an embedded document refers back to s and s
+// has no "$id", the result is given the base URI as "$id"; a relative "$id"
+// of s is resolved against the base URI.
+//
+// Bundle fails if a referenced document cannot be retrieved. s is not
+// modified.
+func Bundle(ctx context.Context, s *Schema, options ...BundleOption) (*Schema, error) {
+	var resolver *Resolver
+	var baseURI string
+	for _, o := range options {
+		switch o.Ident() {
+		case identBundleResolver{}:
+			resolver = option.MustGet[*Resolver](o)
+		case identBundleBaseURI{}:
+			baseURI = option.MustGet[string](o)
+		}
+	}
+	if resolver == nil {
+		resolver = NewResolver()
+	}
+
+	root := s.DeepClone()
+	retrieval, _, _ := splitFragment(baseURI)
+	rootBase := retrieval
+	if root.HasID() && IsResourceID(root.ID()) {
+		resolved := resolveURI(retrieval, root.ID())
+		rootBase, _, _ = splitFragment(resolved)
+		// References are rewritten against the resolved $id, which the
+		// bundle must then carry.
+		root.id = &resolved
+	}
+
+	b := &bundler{
+		ctx:       ctx,
+		resolver:  resolver,
+		rootBase:  rootBase,
+		canonical: make(map[string]string),
+		names:     make(map[string]string),
+		usedNames: make(map[string]struct{}),
+	}
+	for name := range root.Definitions() {
+		b.usedNames[name] = struct{}{}
+	}
+	// The $id of root is resolved against the base URI by both of these, as
+	// any other $id is against the base of its parent.
+	b.addResources(root, retrieval)
+	if retrieval != "" {
+		b.canonical[retrieval] = rootBase
+	}
+
+	if err := b.rewrite(root, retrieval, true); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(b.embedded); i++ {
+		doc := b.embedded[i]
+		if err := b.rewrite(doc.schema, doc.uri, false); err != nil {
+			return nil, err
+		}
+	}
+	if len(b.embedded) == 0 {
+		return root, nil
+	}
+
+	builder := NewBuilder().Clone(root)
+	for _, doc := range b.embedded {
+		builder.Definitions(doc.name, doc.schema)
+	}
+	if b.rootNeedsID && !root.HasID() {
+		builder.ID(rootBase)
+	}
+	return builder.Build(WithBoundsCheck(false))
+}
+
+type bundler struct {
+	ctx         context.Context
+	resolver    *Resolver
+	rootBase    string
+	canonical   map[string]string // URI of every resource in the bundle -> its $id
+	names       map[string]string // $id of an embedded document -> its name under $defs
+	usedNames   map[string]struct{}
+	embedded    []*bundledDocument
+	rootNeedsID bool // an embedded document refers back to s, which has no $id
+}
+
+type bundledDocument struct {
+	uri    string // the $id
+	name   string
+	schema *Schema
+}
+
+// addResources records the $id resources of the tree at s, whose base URI
+// is base, as already part of the bundle.
+func (b *bundler) addResources(s *Schema, base string) {
+	idx := newResourceIndex()
+	idx.index(s, base, make(map[*Schema]struct{}))
+	for uri := range idx.byURI {
+		b.canonical[uri] = uri
+	}
+	if base != "" {
+		b.canonical[base] = base
+	}
+}
+
+// rewrite rewrites the references of the tree at s. base is the base URI in
+// effect for s, and rootScope reports whether that is still the base of the
+// schema being bundled.
+func (b *bundler) rewrite(s *Schema, base string, rootScope bool) error {
+	if s.HasID() && IsResourceID(s.ID()) {
+		if id, _, _ := splitFragment(resolveURI(base, s.ID())); id != base {
+			base = id
+			rootScope = rootScope && id == b.rootBase
+		}
+	}
+	if s.HasReference() {
+		ref, err := b.reference(s.Reference(), base, rootScope)
+		if err != nil {
+			return err
+		}
+		s.reference = &ref
+	}
+	for _, child := range childSchemas(s) {
+		if err := b.rewrite(child, base, rootScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reference returns the form ref takes in the bundle, retrieving and
+// embedding the document it points to if it is not part of the bundle yet.
+func (b *bundler) reference(ref, base string, rootScope bool) (string, error) {
+	if strings.HasPrefix(ref, "#") {
+		return ref, nil
+	}
+	uri, fragment, _ := splitFragment(resolveURI(base, ref))
+	canonical, ok := b.canonical[uri]
+	if !ok {
+		var err error
+		if canonical, err = b.embed(uri); err != nil {
+			return "", fmt.Errorf(`json-schema: Bundle: failed to resolve reference %q: %w`, ref, err)
+		}
+	}
+
+	isPointer := fragment == "" || strings.HasPrefix(fragment, "/")
+	switch name, embedded := b.names[canonical]; {
+	case canonical == b.rootBase && rootScope:
+		return "#" + fragment, nil
+	case canonical == b.rootBase:
+		b.rootNeedsID = true
+	case embedded && rootScope && isPointer:
+		return "#/$defs/" + pointerEscaper.Replace(name) + fragment, nil
+	}
+	if u, err := url.Parse(canonical); err == nil && !u.IsAbs() {
+		// Without an absolute base the canonical URI is relative to the
+		// root, not to base; ref, unchanged, still resolves against base.
+		return ref, nil
+	}
+	if fragment == "" {
+		return canonical, nil
+	}
+	return canonical + "#" + fragment, nil
+}
+
+// embed retrieves the document at uri and queues it for embedding. It returns
+// the $id of the document.
+func (b *bundler) embed(uri string) (string, error) {
+	var doc Schema
+	if err := b.resolver.ResolveJSONReference(b.ctx, &doc, uri, nil); err != nil {
+		return "", err
+	}
+	canonical := uri
+	schema := &doc
+	if doc.HasID() && IsResourceID(doc.ID()) {
+		resolved := resolveURI(uri, doc.ID())
+		canonical, _, _ = splitFragment(resolved)
+		if resolved != doc.ID() {
+			// A relative $id would resolve against the bundle instead.
+			var err error
+			if schema, err = NewBuilder().Clone(&doc).ID(resolved).Build(WithBoundsCheck(false)); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		var err error
+		if schema, err = NewBuilder().Clone(&doc).ID(uri).Build(WithBoundsCheck(false)); err != nil {
+			return "", err
+		}
+	}
+	b.canonical[uri] = canonical
+	if _, ok := b.names[canonical]; ok {
+		return canonical, nil // also retrieved from another URI
+	}
+	b.addResources(schema, canonical)
+
+	name := b.definitionName(canonical)
+	b.names[canonical] = name
+	b.embedded = append(b.embedded, &bundledDocument{uri: canonical, name: name, schema: schema})
+	return canonical, nil
+}
+
+// definitionName derives a name under "$defs" from the last path segment of
+// uri, without its extension.
+func (b *bundler) definitionName(uri string) string {
+	base := path.Base(uri)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	base = nonIdentifierChars.ReplaceAllString(base, "_")
+	if base == "" || base == "_" {
+		base = "schema"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := b.usedNames[name]; !taken {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+	b.usedNames[name] = struct{}{}
+	return name
+}
@@ -0,0 +1,163 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundle(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schemas/main.json": {Data: []byte(`{
+			"type": "object",
+			"properties": {
+				"owner": {"$ref": "person.json"},
+				"email": {"$ref": "common/types.json#/$defs/email"},
+				"tags": {"$ref": "common/types.json#tag"},
+				"self": {"$ref": "main.json#/properties/email"}
+			}
+		}`)},
+		"schemas/person.json": {Data: []byte(`{
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"email": {"$ref": "common/types.json#/$defs/email"},
+				"friend": {"$ref": "#"}
+			},
+			"required": ["name"]
+		}`)},
+		"schemas/common/types.json": {Data: []byte(`{
+			"$id": "https://example.com/types",
+			"$defs": {
+				"email": {"type": "string", "format": "email", "maxLength": 64},
+				"tag": {"$anchor": "tag", "type": "array", "items": {"type": "string"}}
+			}
+		}`)},
+		"schemas/broken.json": {Data: []byte(`{"$ref": "missing.json"}`)},
+	}
+	var main schema.Schema
+	require.NoError(t, main.UnmarshalJSON(fsys["schemas/main.json"].Data))
+	resolver := func() *schema.Resolver {
+		return schema.NewResolver(schema.WithResolver(schema.FSResolver(fsys)))
+	}
+
+	bundled, err := schema.Bundle(t.Context(), &main,
+		schema.WithBundleResolver(resolver()),
+		schema.WithBundleBaseURI("file:///schemas/main.json"),
+	)
+	require.NoError(t, err)
+
+	buf, err := json.Marshal(bundled)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"owner": {"$ref": "#/$defs/person"},
+			"email": {"$ref": "#/$defs/types/$defs/email"},
+			"tags": {"$ref": "https://example.com/types#tag"},
+			"self": {"$ref": "#/properties/email"}
+		},
+		"$defs": {
+			"person": {
+				"$id": "file:///schemas/person.json",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"email": {"$ref": "https://example.com/types#/$defs/email"},
+					"friend": {"$ref": "#"}
+				},
+				"required": ["name"]
+			},
+			"types": {
+				"$id": "https://example.com/types",
+				"$defs": {
+					"email": {"type": "string", "format": "email", "maxLength": 64},
+					"tag": {"$anchor": "tag", "type": "array", "items": {"type": "string"}}
+				}
+			}
+		}
+	}`, string(buf))
+
+	// The bundle compiles without access to the files.
+	v, err := validator.Compile(t.Context(), bundled)
+	require.NoError(t, err)
+	_, err = v.Validate(t.Context(), map[string]any{
+		"owner": map[string]any{"name": "Ada", "email": "ada@example.com", "friend": map[string]any{"name": "Bob"}},
+		"email": "x@example.com",
+		"tags":  []any{"a"},
+	})
+	require.NoError(t, err)
+	_, err = v.Validate(t.Context(), map[string]any{"owner": map[string]any{"friend": map[string]any{}}})
+	require.Error(t, err)
+	_, err = v.Validate(t.Context(), map[string]any{"tags": []any{1}})
+	require.Error(t, err)
+
+	t.Run("unresolvable reference", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON(fsys["schemas/broken.json"].Data))
+		_, err := schema.Bundle(t.Context(), &s,
+			schema.WithBundleResolver(resolver()),
+			schema.WithBundleBaseURI("file:///schemas/broken.json"),
+		)
+		require.ErrorContains(t, err, `failed to resolve reference "missing.json"`)
+	})
+
+	t.Run("reference back to the entry document", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a.json": {Data: []byte(`{"properties": {"b": {"$ref": "b.json"}, "n": {"type": "integer"}}}`)},
+			"b.json": {Data: []byte(`{"properties": {"n": {"$ref": "a.json#/properties/n"}}}`)},
+		}
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON(fsys["a.json"].Data))
+		bundled, err := schema.Bundle(t.Context(), &s,
+			schema.WithBundleResolver(schema.NewResolver(schema.WithResolver(schema.FSResolver(fsys)))),
+			schema.WithBundleBaseURI("file:///a.json"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "file:///a.json", bundled.ID(), "the entry gets an $id to be referenced by")
+		require.Equal(t, "file:///a.json#/properties/n", bundled.Definitions()["b"].Properties()["n"].Reference())
+
+		v, err := validator.Compile(t.Context(), bundled)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"b": map[string]any{"n": "x"}})
+		require.Error(t, err)
+	})
+
+	t.Run("relative $id", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a.json": {Data: []byte(`{"$id": "rel/", "$defs": {"s": {"$id": "x.json", "type": "string"}}, "properties": {"s": {"$ref": "x.json"}, "b": {"$ref": "../b.json"}}}`)},
+			"b.json": {Data: []byte(`{"$id": "nested/b.json", "type": "integer"}`)},
+		}
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON(fsys["a.json"].Data))
+		bundled, err := schema.Bundle(t.Context(), &s,
+			schema.WithBundleResolver(schema.NewResolver(schema.WithResolver(schema.FSResolver(fsys)))),
+			schema.WithBundleBaseURI("file:///a.json"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "file:///rel/", bundled.ID(), "the $id is resolved against the base URI")
+		require.Equal(t, "file:///rel/x.json", bundled.Properties()["s"].Reference())
+		require.Equal(t, "#/$defs/b", bundled.Properties()["b"].Reference())
+		require.Equal(t, "file:///nested/b.json", bundled.Definitions()["b"].ID(), "a relative $id is resolved against the retrieval URI")
+
+		v, err := validator.Compile(t.Context(), bundled)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"s": "a string", "b": 1})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"s": 12})
+		require.Error(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"b": "x"})
+		require.Error(t, err)
+	})
+
+	t.Run("no external references", func(t *testing.T) {
+		s := schema.NewBuilder().Reference("#/$defs/a").Definitions("a", schema.NewBuilder().MinLength(1).MustBuild()).MustBuild()
+		bundled, err := schema.Bundle(t.Context(), s)
+		require.NoError(t, err)
+		require.True(t, s.Equal(bundled))
+	})
+}
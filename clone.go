@@ -0,0 +1,141 @@
+package schema
+
+import "slices"
+
+// DeepClone returns a copy of s that shares no memory with it: every nested
+// subschema, slice and map is duplicated, recursively, so changes made to the
+// maps and slices returned by the accessors of one tree are never seen by the
+// other. DeepClone of a nil schema is nil.
+//
+// This differs from Builder.Clone, which copies the keyword values of a
+// schema into a builder as they are. A schema built from that builder shares
+// its subschemas, maps and slices with the original, which is fine as long as
+// neither is modified, and cheaper.
+//
+// Values held by "const", "default", "enum", "examples" and "dependencies"
+// are copied through their JSON shapes: map[string]any, []any, []string and
+// nested schemas are duplicated, anything else (strings, numbers, booleans,
+// and arbitrary Go values given to the builder) is copied as-is.
+func (s *Schema) DeepClone() *Schema {
+	if s == nil {
+		return nil
+	}
+	var c Schema
+	s.deepCopyFields(&c)
+	return &c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneValuePtr(p *any) *any {
+	if p == nil {
+		return nil
+	}
+	v := cloneValue(*p)
+	return &v
+}
+
+func cloneValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return cloneValueMap(val)
+	case []any:
+		return cloneValueSlice(val)
+	case []string:
+		return slices.Clone(val)
+	case SchemaOrBool:
+		return cloneSchemaOrBool(val)
+	default:
+		return v
+	}
+}
+
+func cloneValueSlice(list []any) []any {
+	if list == nil {
+		return nil
+	}
+	out := make([]any, len(list))
+	for i, v := range list {
+		out[i] = cloneValue(v)
+	}
+	return out
+}
+
+func cloneValueMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+func cloneSchemaOrBool(v SchemaOrBool) SchemaOrBool {
+	switch val := v.(type) {
+	case *Schema:
+		if val == nil {
+			return val
+		}
+		return val.DeepClone()
+	case TupleItems:
+		if val == nil {
+			return val
+		}
+		return TupleItems(cloneSchemaOrBoolSlice(val))
+	default:
+		// BoolSchema, and nil
+		return v
+	}
+}
+
+func cloneSchemaOrBoolSlice(list []SchemaOrBool) []SchemaOrBool {
+	if list == nil {
+		return nil
+	}
+	out := make([]SchemaOrBool, len(list))
+	for i, v := range list {
+		out[i] = cloneSchemaOrBool(v)
+	}
+	return out
+}
+
+func cloneSchemaMap(m map[string]*Schema) map[string]*Schema {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]*Schema, len(m))
+	for k, v := range m {
+		out[k] = v.DeepClone()
+	}
+	return out
+}
+
+func cloneSchemaOrBoolMap(m map[string]SchemaOrBool) map[string]SchemaOrBool {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]SchemaOrBool, len(m))
+	for k, v := range m {
+		out[k] = cloneSchemaOrBool(v)
+	}
+	return out
+}
+
+func cloneStringSliceMap(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string][]string, len(m))
+	for k, v := range m {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
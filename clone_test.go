@@ -0,0 +1,54 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepClone(t *testing.T) {
+	const src = `{
+		"$id": "https://example.com/root.json",
+		"type": ["object", "null"],
+		"required": ["name"],
+		"properties": {"name": {"type": "string", "enum": ["a", {"k": [1]}]}},
+		"allOf": [{"minProperties": 1}, true],
+		"dependentRequired": {"name": ["id"]},
+		"dependentSchemas": {"id": {"required": ["name"]}},
+		"default": {"name": "x", "tags": ["a"]},
+		"items": [{"type": "string"}],
+		"$defs": {"n": {"type": "number"}}
+	}`
+	orig := mustParseSchema(t, src)
+	c := orig.DeepClone()
+
+	want, err := json.Marshal(orig)
+	require.NoError(t, err)
+	got, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(got))
+
+	require.NotSame(t, orig.Properties()["name"], c.Properties()["name"])
+	require.NotSame(t, orig.Definitions()["n"], c.Definitions()["n"])
+	require.NotSame(t, orig.AllOf()[0], c.AllOf()[0])
+	require.NotSame(t, orig.DependentSchemas()["id"], c.DependentSchemas()["id"])
+	require.NotSame(t, orig.Items().(schema.TupleItems)[0], c.Items().(schema.TupleItems)[0])
+
+	// Mutate everything reachable through the clone's accessors.
+	c.Properties()["extra"] = schema.New()
+	c.Properties()["name"].Enum()[1].(map[string]any)["k"].([]any)[0] = 2
+	c.Required()[0] = "changed"
+	c.Types()[0] = schema.StringType
+	c.AllOf()[1] = schema.FalseSchema()
+	c.DependentRequired()["name"][0] = "changed"
+	c.Default().(map[string]any)["tags"].([]any)[0] = "changed"
+	c.Definitions()["n"].Types()[0] = schema.StringType
+
+	after, err := json.Marshal(orig)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(after), "mutating the clone must not affect the original")
+
+	require.Nil(t, (*schema.Schema)(nil).DeepClone())
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	schema "github.com/lestrrat-go/json-schema"
+)
+
+func fmtCommand(_ context.Context, c *cli.Command) error {
+	filenames := c.Args().Slice()
+	if len(filenames) == 0 {
+		return fmt.Errorf("filename is required (use '-' for stdin)")
+	}
+	write := c.Bool("write")
+	indent := strings.Repeat(" ", c.Int("indent"))
+	switch n := c.Int("indent"); {
+	case n < 0:
+		return fmt.Errorf("--indent must not be negative")
+	case n == 0:
+		indent = "\t"
+	}
+
+	for _, filename := range filenames {
+		var data []byte
+		var err error
+		if filename == "-" {
+			if write {
+				return fmt.Errorf("cannot write the result back to stdin")
+			}
+			data, err = io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read from stdin: %w", err)
+			}
+		} else {
+			data, err = os.ReadFile(filename)
+			if err != nil {
+				return fmt.Errorf("failed to read file %s: %w", filename, err)
+			}
+		}
+
+		out, err := formatSchema(data, indent, c.Bool("sort-properties"))
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", filename, err)
+		}
+
+		if !write {
+			if _, err := os.Stdout.Write(out); err != nil {
+				return err
+			}
+			continue
+		}
+		if bytes.Equal(out, data) {
+			continue
+		}
+		info, err := os.Stat(filename)
+		if err != nil {
+			return fmt.Errorf("failed to stat file %s: %w", filename, err)
+		}
+		if err := os.WriteFile(filename, out, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// formatSchema parses data as a schema and re-encodes it with the keywords in
+// canonical order, indented by indent and followed by a newline. Unless
+// sortProperties is set, the names under each "properties" keep their order
+// in data.
+func formatSchema(data []byte, indent string, sortProperties bool) ([]byte, error) {
+	var s schema.Schema
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema: %w", err)
+	}
+	buf, err := s.MarshalJSONWith(schema.WithKeyOrder(schema.KeywordOrderCanonical))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+
+	if !sortProperties {
+		// The encoded schema has the structure of data, so the locations of
+		// "properties" found by Walk are the same in both.
+		properties := make(map[string]struct{})
+		_ = s.Walk(func(path string, sub *schema.Schema) error {
+			if sub.HasProperties() {
+				properties[path+"/properties"] = struct{}{}
+			}
+			return nil
+		})
+		var reordered bytes.Buffer
+		if err := keepMemberOrder(&reordered, buf, data, "", properties); err != nil {
+			return nil, fmt.Errorf("failed to encode schema: %w", err)
+		}
+		buf = reordered.Bytes()
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf, "", indent); err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// keepMemberOrder writes the JSON value value to dst, with the members of each
+// object located at one of the JSON Pointers in objects put in the order they
+// have in src, the same value as originally written. Members missing from src
+// follow, in their order in value.
+func keepMemberOrder(dst *bytes.Buffer, value, src json.RawMessage, path string, objects map[string]struct{}) error {
+	switch {
+	case bytes.HasPrefix(value, []byte("{")):
+		members, err := objectMembers(value)
+		if err != nil {
+			return err
+		}
+		srcMembers, _ := objectMembers(src)
+		if _, ok := objects[path]; ok {
+			reordered := make([]jsonMember, 0, len(members))
+			for _, m := range srcMembers {
+				if i := memberIndex(members, m.name); i >= 0 {
+					reordered = append(reordered, members[i])
+				}
+			}
+			for _, m := range members {
+				if memberIndex(srcMembers, m.name) < 0 {
+					reordered = append(reordered, m)
+				}
+			}
+			members = reordered
+		}
+		dst.WriteByte('{')
+		for i, m := range members {
+			if i > 0 {
+				dst.WriteByte(',')
+			}
+			name, err := json.Marshal(m.name)
+			if err != nil {
+				return err
+			}
+			dst.Write(name)
+			dst.WriteByte(':')
+			var srcValue json.RawMessage
+			if j := memberIndex(srcMembers, m.name); j >= 0 {
+				srcValue = srcMembers[j].value
+			}
+			if err := keepMemberOrder(dst, m.value, srcValue, path+"/"+pointerEscaper.Replace(m.name), objects); err != nil {
+				return err
+			}
+		}
+		dst.WriteByte('}')
+	case bytes.HasPrefix(value, []byte("[")):
+		var elems, srcElems []json.RawMessage
+		if err := json.Unmarshal(value, &elems); err != nil {
+			return err
+		}
+		_ = json.Unmarshal(src, &srcElems)
+		dst.WriteByte('[')
+		for i, elem := range elems {
+			if i > 0 {
+				dst.WriteByte(',')
+			}
+			var srcElem json.RawMessage
+			if i < len(srcElems) {
+				srcElem = srcElems[i]
+			}
+			if err := keepMemberOrder(dst, elem, srcElem, path+"/"+strconv.Itoa(i), objects); err != nil {
+				return err
+			}
+		}
+		dst.WriteByte(']')
+	default:
+		dst.Write(value)
+	}
+	return nil
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+type jsonMember struct {
+	name  string
+	value json.RawMessage
+}
+
+// objectMembers splits the JSON object data into its members, in order.
+func objectMembers(data json.RawMessage) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	var members []jsonMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var m jsonMember
+		m.name, _ = tok.(string)
+		if err := dec.Decode(&m.value); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func memberIndex(members []jsonMember, name string) int {
+	return slices.IndexFunc(members, func(m jsonMember) bool { return m.name == name })
+}
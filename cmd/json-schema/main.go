@@ -3,15 +3,20 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
 	"io"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/urfave/cli/v3"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/meta"
 	"github.com/lestrrat-go/json-schema/validator"
 )
 
@@ -24,7 +29,80 @@ func main() {
 				Name:      "lint",
 				Usage:     "report formatting errors found in schema file",
 				ArgsUsage: "[filename]",
-				Action:    lintCommand,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "also report keywords that are not part of JSON Schema, such as misspelled ones",
+					},
+					&cli.BoolFlag{
+						Name:  "meta",
+						Value: true,
+						Usage: "validate 2020-12 schemas against the meta-schema before compiling them",
+					},
+				},
+				Action: lintCommand,
+			},
+			{
+				Name:      "fmt",
+				Usage:     "rewrite schema files in canonical form",
+				ArgsUsage: "[filename...]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "write",
+						Aliases: []string{"w"},
+						Usage:   "write the result back to each file instead of stdout",
+					},
+					&cli.IntFlag{
+						Name:  "indent",
+						Value: 2,
+						Usage: "indent with this many spaces, or a tab if 0",
+					},
+					&cli.BoolFlag{
+						Name:  "sort-properties",
+						Value: true,
+						Usage: "sort the names under properties; set to false to keep their order in the file",
+					},
+				},
+				Action: fmtCommand,
+			},
+			{
+				Name:      "validate",
+				Usage:     "validate JSON documents against a schema file",
+				ArgsUsage: "[filename...]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "schema",
+						Aliases:  []string{"s"},
+						Usage:    "the schema file to validate against",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "print structured output in the given format: flag, basic or detailed",
+					},
+				},
+				Action: validateCommand,
+			},
+			{
+				Name:  "bundle",
+				Usage: "inline the documents a schema references into a single schema",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "entry",
+						Usage:    "the schema file to bundle",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "write the bundled schema to this file instead of stdout",
+					},
+					&cli.BoolFlag{
+						Name:  "allow-remote",
+						Usage: "also retrieve references over HTTP and HTTPS",
+					},
+				},
+				Action: bundleCommand,
 			},
 			{
 				Name:      "gen-validator",
@@ -48,7 +126,7 @@ func main() {
 	}
 }
 
-func lintCommand(_ context.Context, c *cli.Command) error {
+func lintCommand(ctx context.Context, c *cli.Command) error {
 	filename := c.Args().First()
 	if filename == "" {
 		return fmt.Errorf("filename is required (use '-' for stdin)")
@@ -74,14 +152,48 @@ func lintCommand(_ context.Context, c *cli.Command) error {
 		source = filename
 	}
 
+	// Check the structure of the document against the meta-schema first, as
+	// it pinpoints mistakes that parsing reports less clearly. The meta-schema
+	// only describes 2020-12 schemas.
+	if c.Bool("meta") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			return fmt.Errorf("failed to parse JSON schema: %w", err)
+		}
+		draft := schema.DraftUnknown
+		if obj, ok := doc.(map[string]any); ok {
+			if uri, ok := obj["$schema"].(string); ok {
+				draft = schema.DetectDraft(uri)
+			}
+		}
+		if draft == schema.Draft202012 || draft == schema.DraftUnknown {
+			if _, err := meta.Validator().Validate(ctx, doc, validator.WithCollectAllErrors(true)); err != nil {
+				printValidationErrors(os.Stderr, source, err)
+				return fmt.Errorf("schema %s does not conform to the meta-schema", source)
+			}
+		}
+	}
+
 	// Parse the JSON schema
 	var s schema.Schema
-	if err := s.UnmarshalJSON(data); err != nil {
+	if c.Bool("strict") {
+		var uerr *schema.UnknownKeywordsError
+		if err := schema.UnmarshalStrict(data, &s); errors.As(err, &uerr) {
+			for _, kw := range uerr.Keywords {
+				fmt.Fprintf(os.Stderr, "%s:%d:%d: unknown keyword %q\n", source, kw.Line, kw.Column, kw.Name)
+			}
+			return fmt.Errorf("schema %s uses %d unknown keyword(s)", source, len(uerr.Keywords))
+		} else if err != nil {
+			return fmt.Errorf("failed to parse JSON schema: %w", err)
+		}
+	} else if err := s.UnmarshalJSON(data); err != nil {
 		return fmt.Errorf("failed to parse JSON schema: %w", err)
 	}
 
 	// Try to compile the validator to check for semantic errors
-	_, err = validator.Compile(context.Background(), &s)
+	_, err = validator.Compile(ctx, &s)
 	if err != nil {
 		return fmt.Errorf("schema validation failed: %w", err)
 	}
@@ -90,6 +202,174 @@ func lintCommand(_ context.Context, c *cli.Command) error {
 	return nil
 }
 
+func validateCommand(ctx context.Context, c *cli.Command) error {
+	var format validator.OutputFormat
+	output := c.String("output")
+	switch output {
+	case "":
+	case "flag":
+		format = validator.OutputFlag
+	case "basic":
+		format = validator.OutputBasic
+	case "detailed":
+		format = validator.OutputDetailed
+	default:
+		return fmt.Errorf("unknown output format %q (want flag, basic or detailed)", output)
+	}
+
+	filenames := c.Args().Slice()
+	if len(filenames) == 0 {
+		return fmt.Errorf("filename is required (use '-' for stdin)")
+	}
+
+	schemaFile := c.String("schema")
+	if schemaFile == "-" {
+		return fmt.Errorf("the schema must be read from a file, not stdin")
+	}
+	data, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", schemaFile, err)
+	}
+	var s schema.Schema
+	if err := s.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("failed to parse JSON schema: %w", err)
+	}
+	v, err := validator.Compile(ctx, &s)
+	if err != nil {
+		return fmt.Errorf("failed to compile validator: %w", err)
+	}
+
+	var invalid int
+	for _, filename := range filenames {
+		var source string
+		if filename == "-" {
+			data, err = io.ReadAll(os.Stdin)
+			source = "stdin"
+		} else {
+			data, err = os.ReadFile(filename)
+			source = filename
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", source, err)
+		}
+
+		valid, err := validateDocument(ctx, v, source, data, output != "", format)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d document(s) failed validation", invalid, len(filenames))
+	}
+	return nil
+}
+
+// validateDocument validates one document and prints the outcome: a line per
+// failure, or the structured output in format if structured is set. The error
+// is only for documents that are not JSON, or output that cannot be written.
+func validateDocument(ctx context.Context, v validator.Interface, source string, data []byte, structured bool, format validator.OutputFormat) (bool, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var instance any
+	if err := dec.Decode(&instance); err != nil {
+		return false, fmt.Errorf("failed to decode %s: %w", source, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return false, fmt.Errorf("failed to decode %s: trailing data after top-level value", source)
+	}
+
+	if structured {
+		out, err := validator.ValidateWithOutput(ctx, v, instance, format, validator.WithCollectAllErrors(true))
+		if err != nil {
+			return false, err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return false, fmt.Errorf("failed to write output: %w", err)
+		}
+		return out.Valid, nil
+	}
+
+	_, err := v.Validate(ctx, instance, validator.WithCollectAllErrors(true))
+	if err == nil {
+		fmt.Printf("%s: valid\n", source)
+		return true, nil
+	}
+	printValidationErrors(os.Stdout, source, err)
+	return false, nil
+}
+
+// printValidationErrors prints a line per failure in err, as returned by
+// Validate with WithCollectAllErrors, with its location in the document.
+func printValidationErrors(w io.Writer, source string, err error) {
+	// One *validator.Error, or several joined together
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err := range errs {
+		var verr *validator.Error
+		if !errors.As(err, &verr) {
+			fmt.Fprintf(w, "%s: %v\n", source, err)
+			continue
+		}
+		location := verr.InstanceLocation()
+		if location == "" {
+			location = "(root)"
+		}
+		fmt.Fprintf(w, "%s: %s: %s (keyword %s)\n", source, location, verr.Message(), verr.KeywordLocation())
+	}
+}
+
+func bundleCommand(ctx context.Context, c *cli.Command) error {
+	entry, err := filepath.Abs(c.String("entry"))
+	if err != nil {
+		return fmt.Errorf("failed to locate %s: %w", c.String("entry"), err)
+	}
+	data, err := os.ReadFile(entry)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", entry, err)
+	}
+	var s schema.Schema
+	if err := s.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("failed to parse JSON schema: %w", err)
+	}
+
+	// References are resolved against the file:// URI of the entry, so the
+	// filesystem resolver is rooted at "/" to accept absolute paths.
+	resolvers := []schema.ResolverOption{schema.WithResolver(schema.DirResolver("/"))}
+	if c.Bool("allow-remote") {
+		resolvers = append(resolvers, schema.WithResolver(schema.HTTPResolver()))
+	}
+	bundled, err := schema.Bundle(ctx, &s,
+		schema.WithBundleResolver(schema.NewResolver(resolvers...)),
+		schema.WithBundleBaseURI((&url.URL{Scheme: "file", Path: filepath.ToSlash(entry)}).String()),
+	)
+	if err != nil {
+		return err
+	}
+
+	out, err := bundled.MarshalJSONIndent("", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundled schema: %w", err)
+	}
+	out = append(out, '\n')
+
+	if filename := c.String("out"); filename != "" {
+		if err := os.WriteFile(filename, out, 0o644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", filename, err)
+		}
+		return nil
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
 func genValidatorCommand(_ context.Context, c *cli.Command) error {
 	filename := c.Args().First()
 	if filename == "" {
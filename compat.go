@@ -0,0 +1,564 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat-go/json-schema/keywords"
+)
+
+// IsBackwardCompatible reports whether every value valid under oldSchema is
+// still valid under newSchema, and otherwise lists why not: one reason per
+// breaking change, each prefixed with the JSON Pointer of the change (see
+// Diff). Adding an optional property, loosening a bound, widening "type" or
+// "enum" and dropping a "required" name are compatible; requiring a new
+// property, tightening "maximum" or "minLength", or removing an "anyOf"
+// branch are breaking.
+//
+// The check reasons keyword by keyword about the changes Diff reports, and is
+// conservative: a change it cannot prove harmless, such as a new "pattern" or
+// "$ref", or anything changed under "not", "if" or "oneOf", or under
+// "contains" next to "maxContains", counts as breaking. It assumes that a property the old schema does not describe is
+// not in use, so that describing it is compatible. Annotations (title,
+// description, default, examples and the like), "format", the content
+// keywords and unknown keywords never break compatibility, as they do not
+// assert by default.
+func IsBackwardCompatible(oldSchema, newSchema *Schema) (bool, []string) {
+	changes, err := Diff(oldSchema, newSchema)
+	if err != nil {
+		return false, []string{err.Error()}
+	}
+	o, _ := diffValue(oldSchema)
+	n, _ := diffValue(newSchema)
+	c := compatChecker{old: o, new: n}
+	var reasons []string
+	for _, change := range changes {
+		if reason := c.check(change); reason != "" {
+			reasons = append(reasons, change.Path+": "+reason)
+		}
+	}
+	return len(reasons) == 0, reasons
+}
+
+// annotationKeywords do not affect which values are valid.
+var annotationKeywords = map[string]struct{}{
+	keywords.Title:             {},
+	keywords.Description:       {},
+	keywords.Comment:           {},
+	keywords.Default:           {},
+	keywords.Examples:          {},
+	keywords.Deprecated:        {},
+	keywords.ReadOnly:          {},
+	keywords.WriteOnly:         {},
+	keywords.Format:            {},
+	keywords.ContentEncoding:   {},
+	keywords.ContentMediaType:  {},
+	keywords.ContentSchema:     {},
+	keywords.Definitions:       {},
+	keywords.LegacyDefinitions: {},
+}
+
+// lowerBounds and upperBounds are the keywords whose value a valid instance
+// must not go below, or above.
+var lowerBounds = map[string]struct{}{
+	keywords.Minimum:          {},
+	keywords.ExclusiveMinimum: {},
+	keywords.MinLength:        {},
+	keywords.MinItems:         {},
+	keywords.MinProperties:    {},
+	keywords.MinContains:      {},
+}
+
+var upperBounds = map[string]struct{}{
+	keywords.Maximum:          {},
+	keywords.ExclusiveMaximum: {},
+	keywords.MaxLength:        {},
+	keywords.MaxItems:         {},
+	keywords.MaxProperties:    {},
+	keywords.MaxContains:      {},
+}
+
+// opaqueKeywords hold subschemas whose changes cannot be judged from the
+// change alone: "not" inverts them, "if" selects between "then" and "else",
+// and a "oneOf" branch accepting more can make a value match two branches.
+// "contains" is opaque too where maxContains bounds how many items it may
+// match (see hasMaxContains).
+var opaqueKeywords = map[string]struct{}{
+	keywords.Not:   {},
+	keywords.If:    {},
+	keywords.OneOf: {},
+}
+
+type compatChecker struct {
+	old, new any // the schemas in the JSON form Diff compares
+}
+
+// changeSite is where a change happened: in the schema at schema (tokens from
+// the root), at keyword, or at its entry when the keyword holds a map or
+// list of subschemas.
+type changeSite struct {
+	schema   []string
+	keyword  string
+	entry    string
+	hasEntry bool
+	opaque   string // the innermost opaque keyword above the change, if any
+}
+
+func (c *compatChecker) site(path string) (changeSite, bool) {
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, tok := range tokens {
+		tokens[i] = pointerUnescaper.Replace(tok)
+	}
+	var site changeSite
+	for i := 0; i < len(tokens); {
+		keyword := tokens[i]
+		if i == len(tokens)-1 {
+			site.schema = tokens[:i]
+			site.keyword = keyword
+			return site, true
+		}
+		if _, ok := opaqueKeywords[keyword]; ok {
+			site.opaque = keyword
+		}
+		if keyword == keywords.Contains && c.hasMaxContains(tokens[:i]) {
+			// Under maxContains, a "contains" accepting more can count more
+			// items than allowed.
+			site.opaque = keyword
+		}
+		shape, ok := keywordShapes[keyword]
+		if !ok {
+			return site, false
+		}
+		entries := shape == shapeSchemaList || shape == shapeSchemaMap || shape == shapeDependencies
+		if shape == shapeSchema {
+			// Legacy "items" may be a list of schemas.
+			_, entries = c.lookupEither(tokens[:i+1]).([]any)
+		}
+		if !entries {
+			i++
+			continue
+		}
+		if i+1 == len(tokens)-1 {
+			site.schema = tokens[:i]
+			site.keyword = keyword
+			site.entry = tokens[i+1]
+			site.hasEntry = true
+			return site, true
+		}
+		i += 2
+	}
+	return site, false
+}
+
+func lookup(v any, tokens []string) any {
+	for _, tok := range tokens {
+		switch val := v.(type) {
+		case map[string]any:
+			v = val[tok]
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(val) {
+				return nil
+			}
+			v = val[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// hasMaxContains reports whether the schema at tokens sets maxContains in
+// either version.
+func (c *compatChecker) hasMaxContains(tokens []string) bool {
+	for _, v := range []any{lookup(c.old, tokens), lookup(c.new, tokens)} {
+		if m, ok := v.(map[string]any); ok {
+			if _, ok := m[keywords.MaxContains]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *compatChecker) lookupEither(tokens []string) any {
+	if v := lookup(c.old, tokens); v != nil {
+		return v
+	}
+	return lookup(c.new, tokens)
+}
+
+// check returns why change breaks compatibility, or "" when it does not.
+func (c *compatChecker) check(change Change) string {
+	site, ok := c.site(change.Path)
+	if !ok {
+		return "cannot tell what changed"
+	}
+	if site.keyword == keywords.Definitions || site.keyword == keywords.LegacyDefinitions {
+		// A definition applies only through a reference to it, which may
+		// stop resolving, or now reject what it accepted.
+		switch {
+		case change.Kind == ChangeRemoved:
+			return "definition removed"
+		case change.Kind == ChangeModified && site.hasEntry:
+			return c.checkEntry(site, change)
+		}
+		return ""
+	}
+	if _, ok := annotationKeywords[site.keyword]; ok {
+		return ""
+	}
+	if _, known := keywordShapes[site.keyword]; !known {
+		return "" // unknown keywords do not assert
+	}
+	if site.opaque != "" {
+		return fmt.Sprintf("changed under %q, which cannot be checked", site.opaque)
+	}
+	if site.hasEntry {
+		return c.checkEntry(site, change)
+	}
+	return c.checkKeyword(site, change)
+}
+
+// checkEntry judges an entry of a keyword holding subschemas that was added
+// or removed; changes within an entry are judged where they happened.
+func (c *compatChecker) checkEntry(site changeSite, change Change) string {
+	if change.Kind == ChangeModified {
+		// A boolean schema changed for a schema object, or back.
+		if isTrivialSchema(change.New) || change.Old == false {
+			return ""
+		}
+		return fmt.Sprintf("%s entry %s now rejects values it accepted", site.keyword, site.entry)
+	}
+	newSchema, _ := lookup(c.new, site.schema).(map[string]any)
+	switch site.keyword {
+	case keywords.Properties:
+		if change.Kind == ChangeAdded {
+			return ""
+		}
+		// The removed property falls to additionalProperties and the like.
+		if !isTrivialSchema(newSchema[keywords.AdditionalProperties]) || !isTrivialSchema(newSchema[keywords.UnevaluatedProperties]) {
+			return fmt.Sprintf("property %s removed while other properties are restricted", site.entry)
+		}
+		return ""
+	case keywords.PatternProperties:
+		if change.Kind == ChangeAdded {
+			return breakingUnlessTrivial(change.New, "pattern property %s added", site.entry)
+		}
+		if !isTrivialSchema(newSchema[keywords.AdditionalProperties]) || !isTrivialSchema(newSchema[keywords.UnevaluatedProperties]) {
+			return fmt.Sprintf("pattern property %s removed while other properties are restricted", site.entry)
+		}
+		return ""
+	case keywords.AnyOf:
+		if change.Kind == ChangeAdded {
+			return ""
+		}
+		return "anyOf branch removed"
+	case keywords.AllOf, keywords.DependentSchemas:
+		if change.Kind == ChangeAdded {
+			return breakingUnlessTrivial(change.New, "%s entry %s added", site.keyword, site.entry)
+		}
+		return ""
+	case keywords.PrefixItems, keywords.Items:
+		if change.Kind == ChangeAdded {
+			return breakingUnlessTrivial(change.New, "item schema %s added", site.entry)
+		}
+		// The item falls to the schema for the remaining items.
+		rest := newSchema[keywords.Items]
+		if site.keyword == keywords.Items {
+			rest = newSchema[keywords.AdditionalItems]
+		}
+		if !isTrivialSchema(rest) || !isTrivialSchema(newSchema[keywords.UnevaluatedItems]) {
+			return fmt.Sprintf("item schema %s removed while other items are restricted", site.entry)
+		}
+		return ""
+	case keywords.Dependencies:
+		if change.Kind == ChangeRemoved {
+			return ""
+		}
+		if names, ok := change.New.([]any); ok && len(names) == 0 {
+			return ""
+		}
+		return breakingUnlessTrivial(change.New, "dependency on %s added", site.entry)
+	default:
+		return fmt.Sprintf("%s entry %s %s", site.keyword, site.entry, change.Kind)
+	}
+}
+
+func (c *compatChecker) checkKeyword(site changeSite, change Change) string {
+	keyword := site.keyword
+	if change.Kind == ChangeRemoved {
+		switch keyword {
+		case keywords.ID, keywords.Anchor, keywords.DynamicAnchor, keywords.RecursiveAnchor, keywords.Schema, keywords.Vocabulary:
+			return fmt.Sprintf("%s removed", keyword)
+		}
+		return c.checkRemoved(site, change)
+	}
+
+	_, lower := lowerBounds[keyword]
+	_, upper := upperBounds[keyword]
+	switch {
+	case lower || upper:
+		if change.Kind == ChangeAdded {
+			return fmt.Sprintf("%s %s added", keyword, changeValue(change.New))
+		}
+		o, ok1 := numberValue(change.Old)
+		n, ok2 := numberValue(change.New)
+		if !ok1 || !ok2 {
+			return fmt.Sprintf("%s changed", keyword)
+		}
+		if (lower && n.Cmp(o) > 0) || (upper && n.Cmp(o) < 0) {
+			return fmt.Sprintf("%s tightened from %s to %s", keyword, changeValue(change.Old), changeValue(change.New))
+		}
+		return ""
+	}
+
+	switch keyword {
+	case keywords.Type:
+		if change.Kind == ChangeAdded {
+			return fmt.Sprintf("type %s added", changeValue(change.New))
+		}
+		var lost []string
+		for _, typ := range typeList(change.Old) {
+			newTypes := typeList(change.New)
+			if slices.Contains(newTypes, typ) || (typ == "integer" && slices.Contains(newTypes, "number")) {
+				continue
+			}
+			lost = append(lost, typ)
+		}
+		if len(lost) > 0 {
+			return fmt.Sprintf("type no longer allows %s", strings.Join(lost, ", "))
+		}
+		return ""
+	case keywords.Enum:
+		if change.Kind == ChangeAdded {
+			return "enum added"
+		}
+		oldValues, _ := change.Old.([]any)
+		newValues, _ := change.New.([]any)
+		var lost []string
+		for _, v := range oldValues {
+			if !slices.ContainsFunc(newValues, func(w any) bool { return reflect.DeepEqual(v, w) }) {
+				lost = append(lost, changeValue(v))
+			}
+		}
+		if len(lost) > 0 {
+			return fmt.Sprintf("enum no longer allows %s", strings.Join(lost, ", "))
+		}
+		return ""
+	case keywords.Required:
+		added := addedNames(change.Old, change.New)
+		if len(added) > 0 {
+			return fmt.Sprintf("%s now required", strings.Join(added, ", "))
+		}
+		return ""
+	case keywords.DependentRequired:
+		oldDeps, _ := change.Old.(map[string]any)
+		newDeps, _ := change.New.(map[string]any)
+		var reasons []string
+		for _, trigger := range sortedUnion(newDeps, nil) {
+			if added := addedNames(oldDeps[trigger], newDeps[trigger]); len(added) > 0 {
+				reasons = append(reasons, fmt.Sprintf("%s now required with %s", strings.Join(added, ", "), trigger))
+			}
+		}
+		return strings.Join(reasons, "; ")
+	case keywords.MultipleOf:
+		if change.Kind == ChangeAdded {
+			return fmt.Sprintf("multipleOf %s added", changeValue(change.New))
+		}
+		o, ok1 := numberValue(change.Old)
+		n, ok2 := numberValue(change.New)
+		if ok1 && ok2 && n.Sign() != 0 && new(big.Rat).Quo(o, n).IsInt() {
+			return "" // every multiple of the old value is a multiple of the new one
+		}
+		return fmt.Sprintf("multipleOf changed from %s to %s", changeValue(change.Old), changeValue(change.New))
+	case keywords.UniqueItems:
+		if change.New == true {
+			return "uniqueItems required"
+		}
+		return ""
+	case keywords.Properties:
+		return "" // describing properties is compatible, as for each entry
+	case keywords.PatternProperties, keywords.DependentSchemas, keywords.AllOf, keywords.PrefixItems:
+		if allEntriesTrivial(change.New) {
+			return ""
+		}
+		return fmt.Sprintf("%s %s", keyword, change.Kind)
+	case keywords.AdditionalProperties, keywords.UnevaluatedProperties, keywords.AdditionalItems,
+		keywords.UnevaluatedItems, keywords.Items, keywords.PropertyNames, keywords.Then, keywords.Else:
+		// These only ever restrict, through their subschemas: one that accepts
+		// everything adds nothing. A boolean changed for a schema is judged
+		// as the schema it now is.
+		if change.Kind == ChangeModified && isTrivialSchema(change.New) {
+			return ""
+		}
+		if change.Kind == ChangeModified && change.Old == false {
+			return ""
+		}
+		return breakingUnlessTrivial(change.New, "%s %s", keyword, change.Kind)
+	case keywords.AnyOf:
+		if change.Kind == ChangeAdded {
+			return breakingUnlessAnyTrivial(change.New)
+		}
+		return "anyOf changed"
+	default:
+		// const, pattern, contains, $ref and the rest: assume a change
+		// restricts.
+		return fmt.Sprintf("%s %s", keyword, change.Kind)
+	}
+}
+
+// checkRemoved judges a keyword removed as a whole. Most only ever restrict,
+// but the entries of some fall to another keyword, as in checkEntry, and
+// minContains falls back to its default of 1.
+func (c *compatChecker) checkRemoved(site changeSite, change Change) string {
+	newSchema, _ := lookup(c.new, site.schema).(map[string]any)
+	switch keyword := site.keyword; keyword {
+	case keywords.ID, keywords.Anchor, keywords.DynamicAnchor, keywords.RecursiveAnchor, keywords.Schema, keywords.Vocabulary:
+		return fmt.Sprintf("%s removed", keyword)
+	case keywords.Properties, keywords.PatternProperties:
+		if emptyEntries(change.Old) {
+			return ""
+		}
+		if !isTrivialSchema(newSchema[keywords.AdditionalProperties]) || !isTrivialSchema(newSchema[keywords.UnevaluatedProperties]) {
+			return fmt.Sprintf("%s removed while other properties are restricted", keyword)
+		}
+	case keywords.PrefixItems, keywords.Items:
+		if emptyEntries(change.Old) {
+			return ""
+		}
+		// The items fall to the schema for the remaining items. Legacy
+		// "additionalItems" applies only next to a list of "items".
+		rest := newSchema[keywords.Items]
+		if keyword == keywords.Items {
+			if _, ok := change.Old.([]any); !ok {
+				return ""
+			}
+			rest = nil
+		}
+		if !isTrivialSchema(rest) || !isTrivialSchema(newSchema[keywords.UnevaluatedItems]) {
+			return fmt.Sprintf("%s removed while other items are restricted", keyword)
+		}
+	case keywords.MinContains:
+		if _, ok := newSchema[keywords.Contains]; !ok {
+			return ""
+		}
+		o, ok := numberValue(change.Old)
+		if !ok {
+			return "minContains removed"
+		}
+		if o.Cmp(big.NewRat(1, 1)) < 0 {
+			return fmt.Sprintf("minContains tightened from %s to the default of 1", changeValue(change.Old))
+		}
+	}
+	return ""
+}
+
+// emptyEntries reports whether v, the JSON form of a keyword holding a map
+// or list of subschemas, has no entries.
+func emptyEntries(v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	}
+	return false
+}
+
+// isTrivialSchema reports whether v, a subschema in JSON form, accepts every
+// value: true, {}, or absent.
+func isTrivialSchema(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return val
+	case map[string]any:
+		for name := range val {
+			if _, ok := annotationKeywords[name]; ok {
+				continue
+			}
+			if _, known := keywordShapes[name]; known {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// allEntriesTrivial reports whether every subschema in v, a list or map of
+// them, accepts every value.
+func allEntriesTrivial(v any) bool {
+	switch val := v.(type) {
+	case []any:
+		return !slices.ContainsFunc(val, func(e any) bool { return !isTrivialSchema(e) })
+	case map[string]any:
+		for _, e := range val {
+			if !isTrivialSchema(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func breakingUnlessTrivial(v any, format string, args ...any) string {
+	if isTrivialSchema(v) {
+		return ""
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func breakingUnlessAnyTrivial(v any) string {
+	list, _ := v.([]any)
+	if slices.ContainsFunc(list, isTrivialSchema) {
+		return ""
+	}
+	return "anyOf added"
+}
+
+func numberValue(v any) (*big.Rat, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(n.String())
+}
+
+func typeList(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []any:
+		types := make([]string, 0, len(val))
+		for _, t := range val {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// addedNames lists the names in the new list of property names that the old
+// one does not have.
+func addedNames(o, n any) []string {
+	oldNames, _ := o.([]any)
+	newNames, _ := n.([]any)
+	var added []string
+	for _, name := range newNames {
+		if s, ok := name.(string); ok && !slices.Contains(oldNames, name) {
+			added = append(added, s)
+		}
+	}
+	return added
+}
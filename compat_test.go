@@ -0,0 +1,219 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBackwardCompatible(t *testing.T) {
+	testcases := []struct {
+		name    string
+		old     string
+		new     string
+		reasons []string
+	}{
+		{
+			name: "optional property added",
+			old:  `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+			new:  `{"type": "object", "properties": {"name": {"type": "string"}, "email": {"type": "string", "format": "email"}}}`,
+		},
+		{
+			name:    "required property added",
+			old:     `{"type": "object", "required": ["name"]}`,
+			new:     `{"type": "object", "required": ["name", "email"]}`,
+			reasons: []string{"/required: email now required"},
+		},
+		{
+			name: "required property dropped",
+			old:  `{"required": ["name", "email"]}`,
+			new:  `{"required": ["name"]}`,
+		},
+		{
+			name:    "bounds tightened",
+			old:     `{"properties": {"age": {"maximum": 150}, "name": {"minLength": 1}}}`,
+			new:     `{"properties": {"age": {"maximum": 120}, "name": {"minLength": 2}}}`,
+			reasons: []string{"/properties/age/maximum: maximum tightened from 150 to 120", "/properties/name/minLength: minLength tightened from 1 to 2"},
+		},
+		{
+			name: "bounds loosened or dropped",
+			old:  `{"maximum": 120, "minimum": 0, "maxLength": 10}`,
+			new:  `{"maximum": 150.5, "maxLength": 20}`,
+		},
+		{
+			name:    "bound added",
+			old:     `{"type": "array"}`,
+			new:     `{"type": "array", "maxItems": 3}`,
+			reasons: []string{"/maxItems: maxItems 3 added"},
+		},
+		{
+			name: "type widened",
+			old:  `{"type": "integer"}`,
+			new:  `{"type": ["number", "null"]}`,
+		},
+		{
+			name:    "type narrowed",
+			old:     `{"type": ["string", "null"]}`,
+			new:     `{"type": "string"}`,
+			reasons: []string{"/type: type no longer allows null"},
+		},
+		{
+			name:    "enum value removed",
+			old:     `{"enum": ["a", "b", 1]}`,
+			new:     `{"enum": ["a", "c"]}`,
+			reasons: []string{`/enum: enum no longer allows "b", 1`},
+		},
+		{
+			name: "multipleOf loosened",
+			old:  `{"multipleOf": 4}`,
+			new:  `{"multipleOf": 2}`,
+		},
+		{
+			name:    "multipleOf tightened",
+			old:     `{"multipleOf": 2}`,
+			new:     `{"multipleOf": 4}`,
+			reasons: []string{"/multipleOf: multipleOf changed from 2 to 4"},
+		},
+		{
+			name:    "additional properties forbidden",
+			old:     `{"properties": {"a": {}}}`,
+			new:     `{"properties": {"a": {}}, "additionalProperties": false}`,
+			reasons: []string{"/additionalProperties: additionalProperties added"},
+		},
+		{
+			name:    "property removed under additionalProperties false",
+			old:     `{"properties": {"a": {}, "b": {}}, "additionalProperties": false}`,
+			new:     `{"properties": {"a": {}}, "additionalProperties": false}`,
+			reasons: []string{"/properties/b: property b removed while other properties are restricted"},
+		},
+		{
+			name: "additional properties allowed again",
+			old:  `{"additionalProperties": false}`,
+			new:  `{"additionalProperties": {"type": "string"}}`,
+		},
+		{
+			name: "anyOf branch added",
+			old:  `{"anyOf": [{"type": "string"}]}`,
+			new:  `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+		},
+		{
+			name:    "anyOf branch removed",
+			old:     `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			new:     `{"anyOf": [{"type": "string"}]}`,
+			reasons: []string{"/anyOf/1: anyOf branch removed"},
+		},
+		{
+			name:    "change under not",
+			old:     `{"not": {"type": "string"}}`,
+			new:     `{"not": {"type": ["string", "null"]}}`,
+			reasons: []string{`/not/type: changed under "not", which cannot be checked`},
+		},
+		{
+			name: "annotations and unknown keywords",
+			old:  `{"title": "A", "description": "old", "default": 1, "format": "email", "x-vendor": 1}`,
+			new:  `{"title": "B", "examples": [2], "deprecated": true, "format": "uri", "x-vendor": 2}`,
+		},
+		{
+			name:    "definition removed",
+			old:     `{"$defs": {"id": {"type": "string"}, "unused": {}}}`,
+			new:     `{"$defs": {"id": {"type": ["string", "integer"]}}}`,
+			reasons: []string{"/$defs/unused: definition removed"},
+		},
+		{
+			name:    "properties removed under additionalProperties false",
+			old:     `{"properties": {"a": {}}, "additionalProperties": false}`,
+			new:     `{"additionalProperties": false}`,
+			reasons: []string{"/properties: properties removed while other properties are restricted"},
+		},
+		{
+			name:    "patternProperties removed under additionalProperties false",
+			old:     `{"patternProperties": {"^x-": {}}, "additionalProperties": false}`,
+			new:     `{"additionalProperties": false}`,
+			reasons: []string{"/patternProperties: patternProperties removed while other properties are restricted"},
+		},
+		{
+			name: "properties removed along with additionalProperties",
+			old:  `{"properties": {"a": {}}, "additionalProperties": false}`,
+			new:  `{}`,
+		},
+		{
+			name:    "prefixItems removed while items restricts",
+			old:     `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`,
+			new:     `{"items": {"type": "integer"}}`,
+			reasons: []string{"/prefixItems: prefixItems removed while other items are restricted"},
+		},
+		{
+			name: "prefixItems removed",
+			old:  `{"prefixItems": [{"type": "string"}]}`,
+			new:  `{}`,
+		},
+		{
+			name:    "minContains 0 removed",
+			old:     `{"contains": {"type": "string"}, "minContains": 0}`,
+			new:     `{"contains": {"type": "string"}}`,
+			reasons: []string{"/minContains: minContains tightened from 0 to the default of 1"},
+		},
+		{
+			name: "minContains 2 removed",
+			old:  `{"contains": {"type": "string"}, "minContains": 2}`,
+			new:  `{"contains": {"type": "string"}}`,
+		},
+		{
+			name:    "contains loosened under maxContains",
+			old:     `{"contains": {"type": "string", "minLength": 5}, "maxContains": 1}`,
+			new:     `{"contains": {"type": "string"}, "maxContains": 1}`,
+			reasons: []string{`/contains/minLength: changed under "contains", which cannot be checked`},
+		},
+		{
+			name:    "contains loosened as maxContains is added",
+			old:     `{"contains": {"type": "string", "minLength": 5}}`,
+			new:     `{"contains": {"type": "string"}, "maxContains": 1}`,
+			reasons: []string{"/contains/minLength: changed under \"contains\", which cannot be checked", "/maxContains: maxContains 1 added"},
+		},
+		{
+			name: "contains loosened",
+			old:  `{"contains": {"type": "string", "minLength": 5}}`,
+			new:  `{"contains": {"type": "string"}}`,
+		},
+		{
+			name:    "definition made false",
+			old:     `{"$ref": "#/$defs/x", "$defs": {"x": true}}`,
+			new:     `{"$ref": "#/$defs/x", "$defs": {"x": false}}`,
+			reasons: []string{"/$defs/x: $defs entry x now rejects values it accepted"},
+		},
+		{
+			name: "definition made true",
+			old:  `{"$ref": "#/$defs/x", "$defs": {"x": false}}`,
+			new:  `{"$ref": "#/$defs/x", "$defs": {"x": {"type": "string"}}}`,
+		},
+		{
+			name:    "nested items",
+			old:     `{"items": {"properties": {"n": {"type": "number"}}}}`,
+			new:     `{"items": {"properties": {"n": {"type": "integer"}}}}`,
+			reasons: []string{"/items/properties/n/type: type no longer allows number"},
+		},
+		{
+			name:    "schema made false",
+			old:     `{}`,
+			new:     `{"not": {}}`,
+			reasons: []string{"/not: not added"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, reasons := schema.IsBackwardCompatible(mustParseSchema(t, tc.old), mustParseSchema(t, tc.new))
+			require.Equal(t, tc.reasons, reasons)
+			require.Equal(t, len(tc.reasons) == 0, ok)
+		})
+	}
+
+	t.Run("nil is the empty schema", func(t *testing.T) {
+		ok, reasons := schema.IsBackwardCompatible(nil, mustParseSchema(t, `{"type": "string"}`))
+		require.False(t, ok)
+		require.Equal(t, []string{`/type: type "string" added`}, reasons)
+
+		ok, _ = schema.IsBackwardCompatible(mustParseSchema(t, `{"type": "string"}`), nil)
+		require.True(t, ok)
+	})
+}
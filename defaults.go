@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/lestrrat-go/option/v3"
+)
+
+// ApplyDefaultsOption configures ApplyDefaults.
+type ApplyDefaultsOption interface {
+	option.Interface
+	applyDefaultsOption()
+}
+
+type applyDefaultsOption struct{ option.Interface }
+
+func (applyDefaultsOption) applyDefaultsOption() {}
+
+type identDefaultsResolver struct{}
+
+// WithDefaultsResolver sets the Resolver used to follow "$ref" while applying
+// defaults. Without it, only references within the schema itself resolve.
+func WithDefaultsResolver(r *Resolver) ApplyDefaultsOption {
+	return applyDefaultsOption{option.New(identDefaultsResolver{}, r)}
+}
+
+// ApplyDefaults returns a copy of v in which every object property that is
+// missing, but whose subschema declares a "default", is set to (a copy of)
+// that default. Existing values are never overwritten, even when they are
+// null.
+//
+// Defaults are looked up through "properties" for objects and through
+// "prefixItems" and "items" for the elements an array already has, descending
+// into nested values, including defaults just inserted. Within an inserted
+// default, a "$ref" is followed only once along each path, so that a
+// recursive schema whose default is an object or array stops after one level
+// of recursion instead of inserting defaults forever. Subschemas reached
+// through "allOf" and "$ref" contribute their defaults too; "anyOf", "oneOf"
+// and "if"/"then"/"else" do not, as which of their branches applies depends on
+// validation.
+//
+// v is expected to be a decoded JSON value (map[string]any, []any and
+// scalars); other types are returned unchanged. v itself is not modified,
+// although parts of it that receive no defaults may be shared with the result.
+func (s *Schema) ApplyDefaults(v any, options ...ApplyDefaultsOption) (any, error) {
+	var resolver *Resolver
+	for _, o := range options {
+		if o.Ident() == (identDefaultsResolver{}) {
+			resolver = option.MustGet[*Resolver](o)
+		}
+	}
+	if resolver == nil {
+		resolver = NewResolver()
+	}
+	resolver.RegisterRoot(s)
+
+	// apply resolves the $id of s itself.
+	st := &defaultsState{resolver: resolver}
+	return st.apply(s, v, s, "", nil, false)
+}
+
+type defaultsState struct {
+	resolver *Resolver
+}
+
+// apply fills in the defaults of s within v. base and baseURI identify the
+// enclosing schema resource for "$ref" resolution; refs lists the references
+// already followed for this same value, to stop reference cycles that do not
+// descend into the data. generated reports that v came from a "default": its
+// subvalues then keep refs, since a recursive schema can otherwise insert
+// defaults into defaults without end.
+func (st *defaultsState) apply(s *Schema, v any, base *Schema, baseURI string, refs []string, generated bool) (any, error) {
+	if s == nil {
+		return v, nil
+	}
+	if s.HasID() && IsResourceID(s.ID()) {
+		base = s
+		baseURI = ResolveURI(baseURI, s.ID())
+	}
+
+	var err error
+	switch val := v.(type) {
+	case map[string]any:
+		v, err = st.applyObject(s, val, base, baseURI, refs, generated)
+	case []any:
+		v, err = st.applyArray(s, val, base, baseURI, refs, generated)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sub := range s.AllOf() {
+		if sub, ok := sub.(*Schema); ok {
+			if v, err = st.apply(sub, v, base, baseURI, refs, generated); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.HasReference() {
+		reference := s.Reference()
+		if slices.Contains(refs, reference) {
+			return v, nil
+		}
+		var target Schema
+		if err := st.resolver.ResolveReference(context.Background(), &target, reference, base, baseURI); err != nil {
+			return nil, fmt.Errorf("failed to resolve reference %s: %w", reference, err)
+		}
+		targetBase, targetURI := base, baseURI
+		if abs, _, _ := splitFragment(ResolveURI(baseURI, reference)); abs != baseURI {
+			if resource := st.resolver.ResourceFor(abs); resource != nil {
+				targetBase, targetURI = resource, abs
+			}
+		}
+		return st.apply(&target, v, targetBase, targetURI, append(slices.Clip(refs), reference), generated)
+	}
+	return v, nil
+}
+
+func (st *defaultsState) applyObject(s *Schema, obj map[string]any, base *Schema, baseURI string, refs []string, generated bool) (map[string]any, error) {
+	if !s.HasProperties() {
+		return obj, nil
+	}
+	childRefs := childReferences(refs, generated)
+	out := maps.Clone(obj)
+	for name, prop := range s.Properties() {
+		value, ok := out[name]
+		inserted := !ok
+		if inserted {
+			if !prop.HasDefault() {
+				continue
+			}
+			value = cloneJSONValue(prop.Default())
+		}
+		value, err := st.apply(prop, value, base, baseURI, childRefs, generated || inserted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply defaults to property %q: %w", name, err)
+		}
+		out[name] = value
+	}
+	return out, nil
+}
+
+func (st *defaultsState) applyArray(s *Schema, arr []any, base *Schema, baseURI string, refs []string, generated bool) ([]any, error) {
+	prefix := s.PrefixItems()
+	items := s.Items()
+	if tuple, ok := items.(TupleItems); ok {
+		prefix, items = tuple, nil
+	}
+	if len(prefix) == 0 && items == nil {
+		return arr, nil
+	}
+
+	childRefs := childReferences(refs, generated)
+	out := slices.Clone(arr)
+	for i, value := range out {
+		var sub SchemaOrBool = items
+		if i < len(prefix) {
+			sub = prefix[i]
+		}
+		subSchema, ok := sub.(*Schema)
+		if !ok {
+			continue
+		}
+		value, err := st.apply(subSchema, value, base, baseURI, childRefs, generated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply defaults to item %d: %w", i, err)
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+// childReferences returns the references that the subvalues of a value start
+// with: none for the caller's own data, whose depth is finite, and refs for a
+// value that came from a "default".
+func childReferences(refs []string, generated bool) []string {
+	if generated {
+		return refs
+	}
+	return nil
+}
+
+// cloneJSONValue deep-copies the containers of a decoded JSON value, so that
+// a default inserted into several values is not shared between them.
+func cloneJSONValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, elem := range val {
+			out[k] = cloneJSONValue(elem)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, elem := range val {
+			out[i] = cloneJSONValue(elem)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,133 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"type": "object",
+		"properties": {
+			"port": {"type": "integer", "default": 8080},
+			"host": {"type": "string", "default": "localhost"},
+			"tls": {
+				"type": "object",
+				"default": {},
+				"properties": {"enabled": {"default": false}}
+			},
+			"servers": {
+				"type": "array",
+				"items": {"$ref": "#/$defs/server"}
+			}
+		},
+		"allOf": [{"properties": {"debug": {"default": false}}}],
+		"$defs": {
+			"server": {"properties": {"weight": {"default": 1}}}
+		}
+	}`)
+
+	input := map[string]any{
+		"host":    "example.com",
+		"servers": []any{map[string]any{"name": "a"}, map[string]any{"name": "b", "weight": 5}},
+	}
+	got, err := s.ApplyDefaults(input)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"port":  float64(8080),
+		"host":  "example.com",
+		"tls":   map[string]any{"enabled": false},
+		"debug": false,
+		"servers": []any{
+			map[string]any{"name": "a", "weight": float64(1)},
+			map[string]any{"name": "b", "weight": 5},
+		},
+	}, got)
+
+	// The input is left untouched.
+	require.Len(t, input, 2)
+	require.NotContains(t, input["servers"].([]any)[0], "weight")
+
+	t.Run("existing values are kept", func(t *testing.T) {
+		got, err := s.ApplyDefaults(map[string]any{"port": nil, "tls": map[string]any{"enabled": true}})
+		require.NoError(t, err)
+		obj := got.(map[string]any)
+		require.Nil(t, obj["port"])
+		require.Equal(t, map[string]any{"enabled": true}, obj["tls"])
+	})
+
+	t.Run("defaults are not shared", func(t *testing.T) {
+		a, err := s.ApplyDefaults(map[string]any{})
+		require.NoError(t, err)
+		a.(map[string]any)["tls"].(map[string]any)["enabled"] = true
+		b, err := s.ApplyDefaults(map[string]any{})
+		require.NoError(t, err)
+		require.Equal(t, false, b.(map[string]any)["tls"].(map[string]any)["enabled"])
+	})
+
+	t.Run("non-container values pass through", func(t *testing.T) {
+		got, err := s.ApplyDefaults("hello")
+		require.NoError(t, err)
+		require.Equal(t, "hello", got)
+	})
+
+	t.Run("remote reference through a resolver", func(t *testing.T) {
+		remote := mustParseSchema(t, `{"properties": {"level": {"default": "info"}}}`)
+		r := schema.NewResolver()
+		r.RegisterDocument("https://example.com/logging.json", remote)
+
+		s := mustParseSchema(t, `{"properties": {"log": {"$ref": "https://example.com/logging.json"}}}`)
+		got, err := s.ApplyDefaults(map[string]any{"log": map[string]any{}}, schema.WithDefaultsResolver(r))
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"log": map[string]any{"level": "info"}}, got)
+
+		_, err = s.ApplyDefaults(map[string]any{"log": map[string]any{}})
+		require.Error(t, err, "an unpreloaded remote reference cannot be resolved")
+	})
+
+	t.Run("relative $id on the root", func(t *testing.T) {
+		s := mustParseSchema(t, `{
+			"$id": "rel/",
+			"$defs": {"logging": {"$id": "logging.json", "properties": {"level": {"default": "info"}}}},
+			"properties": {"log": {"$ref": "logging.json"}}
+		}`)
+		got, err := s.ApplyDefaults(map[string]any{"log": map[string]any{}})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"log": map[string]any{"level": "info"}}, got)
+	})
+
+	t.Run("recursive schema", func(t *testing.T) {
+		s := mustParseSchema(t, `{
+			"properties": {
+				"name": {"default": "node"},
+				"children": {"items": {"$ref": "#"}}
+			}
+		}`)
+		got, err := s.ApplyDefaults(map[string]any{"children": []any{map[string]any{}}})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{
+			"name":     "node",
+			"children": []any{map[string]any{"name": "node"}},
+		}, got)
+	})
+
+	t.Run("recursive schema with an object default", func(t *testing.T) {
+		s := mustParseSchema(t, `{"properties":{"child":{"$ref":"#","default":{}}}}`)
+		got, err := s.ApplyDefaults(map[string]any{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"child": map[string]any{"child": map[string]any{}}}, got)
+
+		got, err = s.ApplyDefaults(map[string]any{"child": map[string]any{"child": map[string]any{}}})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"child": map[string]any{"child": map[string]any{"child": map[string]any{"child": map[string]any{}}}}}, got, "existing values are descended into as deep as they go")
+	})
+
+	t.Run("recursive schema with an array default", func(t *testing.T) {
+		s := mustParseSchema(t, `{"properties":{"children":{"default":[{}],"items":{"$ref":"#"}}}}`)
+		got, err := s.ApplyDefaults(map[string]any{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"children": []any{map[string]any{"children": []any{map[string]any{}}}}}, got)
+	})
+}
@@ -0,0 +1,228 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// ChangeKind is what a Change did to the keyword or subschema at its Path.
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota + 1
+	ChangeRemoved
+	ChangeModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is one difference Diff found between two schemas.
+type Change struct {
+	// Path is the JSON Pointer, from the root of the schemas, to the keyword
+	// that changed, such as "/properties/name/maxLength", or to the entry of
+	// a keyword holding subschemas that was added or removed, such as
+	// "/properties/email" or "/allOf/2".
+	Path string
+	Kind ChangeKind
+	// Old and New are the values before and after, in their JSON form as
+	// json.Unmarshal decodes them with UseNumber: a subschema is a
+	// map[string]any (or a bool), a number a json.Number. Old is nil for an
+	// addition, New for a removal.
+	Old any
+	New any
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s: added %s", path, changeValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("%s: removed %s", path, changeValue(c.Old))
+	default:
+		return fmt.Sprintf("%s: %s -> %s", path, changeValue(c.Old), changeValue(c.New))
+	}
+}
+
+func changeValue(v any) string {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(buf)
+}
+
+// Diff reports how newSchema differs from oldSchema, keyword by keyword. It
+// follows the structure of the schemas instead of their text: it descends into
+// every keyword that holds subschemas, such as properties, $defs,
+// patternProperties, items and prefixItems, allOf/anyOf/oneOf, not and
+// if/then/else, and compares the other keywords by value. A keyword or entry
+// present on one side only is a single Added or Removed change, with the
+// whole value; a keyword whose value changed is Modified. So tightening
+// maxLength of a property is reported at ".../maxLength", while adding a
+// property is reported at "/properties/<name>".
+//
+// Values are compared by their JSON form, and the changes are listed depth
+// first with keywords and entries in key order. Diff does not follow $ref,
+// and does not try to match list entries that moved: allOf entries are
+// compared by position. Equal schemas yield no changes.
+func Diff(oldSchema, newSchema *Schema) ([]Change, error) {
+	o, err := diffValue(oldSchema)
+	if err != nil {
+		return nil, fmt.Errorf(`json-schema: Diff: old schema: %w`, err)
+	}
+	n, err := diffValue(newSchema)
+	if err != nil {
+		return nil, fmt.Errorf(`json-schema: Diff: new schema: %w`, err)
+	}
+	var d differ
+	d.schema("", o, n)
+	return d.changes, nil
+}
+
+// diffValue returns s in its JSON form; a nil schema is the empty schema.
+func diffValue(s *Schema) (any, error) {
+	if s == nil {
+		return map[string]any{}, nil
+	}
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(path string, kind ChangeKind, o, n any) {
+	d.changes = append(d.changes, Change{Path: path, Kind: kind, Old: o, New: n})
+}
+
+func (d *differ) value(path string, o, n any) {
+	if !reflect.DeepEqual(o, n) {
+		d.add(path, ChangeModified, o, n)
+	}
+}
+
+// schema compares two schemas in JSON form. A boolean schema is compared as
+// a value: changing true to a schema object is one Modified change.
+func (d *differ) schema(path string, o, n any) {
+	om, ok1 := o.(map[string]any)
+	nm, ok2 := n.(map[string]any)
+	if !ok1 || !ok2 {
+		d.value(path, o, n)
+		return
+	}
+	for _, name := range sortedUnion(om, nm) {
+		kwpath := path + "/" + pointerEscaper.Replace(name)
+		ov, inOld := om[name]
+		nv, inNew := nm[name]
+		switch {
+		case !inOld:
+			d.add(kwpath, ChangeAdded, nil, nv)
+			continue
+		case !inNew:
+			d.add(kwpath, ChangeRemoved, ov, nil)
+			continue
+		}
+		switch keywordShapes[name] {
+		case shapeSchema:
+			// Legacy "items" may be a list of schemas.
+			_, l1 := ov.([]any)
+			_, l2 := nv.([]any)
+			if l1 && l2 {
+				d.schemaList(kwpath, ov, nv)
+			} else {
+				d.schema(kwpath, ov, nv)
+			}
+		case shapeSchemaList:
+			d.schemaList(kwpath, ov, nv)
+		case shapeSchemaMap:
+			d.schemaMap(kwpath, ov, nv)
+		case shapeDependencies:
+			d.schemaMap(kwpath, ov, nv) // property name lists compare as values
+		default:
+			d.value(kwpath, ov, nv)
+		}
+	}
+}
+
+func (d *differ) schemaList(path string, o, n any) {
+	ol, ok1 := o.([]any)
+	nl, ok2 := n.([]any)
+	if !ok1 || !ok2 {
+		d.value(path, o, n)
+		return
+	}
+	for i := range max(len(ol), len(nl)) {
+		ipath := path + "/" + strconv.Itoa(i)
+		switch {
+		case i >= len(ol):
+			d.add(ipath, ChangeAdded, nil, nl[i])
+		case i >= len(nl):
+			d.add(ipath, ChangeRemoved, ol[i], nil)
+		default:
+			d.schema(ipath, ol[i], nl[i])
+		}
+	}
+}
+
+func (d *differ) schemaMap(path string, o, n any) {
+	om, ok1 := o.(map[string]any)
+	nm, ok2 := n.(map[string]any)
+	if !ok1 || !ok2 {
+		d.value(path, o, n)
+		return
+	}
+	for _, name := range sortedUnion(om, nm) {
+		epath := path + "/" + pointerEscaper.Replace(name)
+		ov, inOld := om[name]
+		nv, inNew := nm[name]
+		switch {
+		case !inOld:
+			d.add(epath, ChangeAdded, nil, nv)
+		case !inNew:
+			d.add(epath, ChangeRemoved, ov, nil)
+		default:
+			d.schema(epath, ov, nv)
+		}
+	}
+}
+
+func sortedUnion(a, b map[string]any) []string {
+	names := slices.Collect(maps.Keys(a))
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
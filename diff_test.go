@@ -0,0 +1,94 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	diff := func(t *testing.T, o, n string) []schema.Change {
+		t.Helper()
+		changes, err := schema.Diff(mustParseSchema(t, o), mustParseSchema(t, n))
+		require.NoError(t, err)
+		return changes
+	}
+
+	t.Run("keywords and properties", func(t *testing.T) {
+		changes := diff(t, `{
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "maxLength": 100},
+				"age": {"type": "integer"}
+			},
+			"required": ["name"]
+		}`, `{
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "maxLength": 50, "minLength": 1},
+				"email": {"type": "string", "format": "email"}
+			},
+			"required": ["name", "email"],
+			"additionalProperties": false
+		}`)
+		require.Equal(t, []schema.Change{
+			{Path: "/additionalProperties", Kind: schema.ChangeAdded, New: false},
+			{Path: "/properties/age", Kind: schema.ChangeRemoved, Old: map[string]any{"type": "integer"}},
+			{Path: "/properties/email", Kind: schema.ChangeAdded, New: map[string]any{"type": "string", "format": "email"}},
+			{Path: "/properties/name/maxLength", Kind: schema.ChangeModified, Old: json.Number("100"), New: json.Number("50")},
+			{Path: "/properties/name/minLength", Kind: schema.ChangeAdded, New: json.Number("1")},
+			{Path: "/required", Kind: schema.ChangeModified, Old: []any{"name"}, New: []any{"name", "email"}},
+		}, changes)
+		require.Equal(t, "/properties/name/maxLength: 100 -> 50", changes[3].String())
+		require.Equal(t, `/properties/age: removed {"type":"integer"}`, changes[1].String())
+	})
+
+	t.Run("subschema keywords", func(t *testing.T) {
+		changes := diff(t, `{
+			"allOf": [{"minimum": 0}, {"multipleOf": 2}],
+			"items": {"type": "string"},
+			"$defs": {"id": {"type": "string"}, "a~b": {}},
+			"not": true
+		}`, `{
+			"allOf": [{"minimum": 1}],
+			"items": {"type": "integer"},
+			"$defs": {"id": {"type": "integer"}},
+			"not": {"type": "null"}
+		}`)
+		paths := make([]string, 0, len(changes))
+		for _, c := range changes {
+			paths = append(paths, c.Kind.String()+" "+c.Path)
+		}
+		require.Equal(t, []string{
+			"removed /$defs/a~0b",
+			"modified /$defs/id/type",
+			"modified /allOf/0/minimum",
+			"removed /allOf/1",
+			"modified /items/type",
+			"modified /not",
+		}, paths)
+	})
+
+	t.Run("legacy tuple items", func(t *testing.T) {
+		changes := diff(t, `{"items": [{"type": "string"}]}`, `{"items": [{"type": "string"}, {"type": "integer"}]}`)
+		require.Len(t, changes, 1)
+		require.Equal(t, "/items/1", changes[0].Path)
+		require.Equal(t, schema.ChangeAdded, changes[0].Kind)
+	})
+
+	t.Run("equal schemas", func(t *testing.T) {
+		s := `{"properties": {"a": {"enum": [1, "x"]}}, "x-vendor": {"k": 1}}`
+		require.Empty(t, diff(t, s, s))
+		changes, err := schema.Diff(nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("nil is the empty schema", func(t *testing.T) {
+		changes, err := schema.Diff(nil, mustParseSchema(t, `{"type": "string"}`))
+		require.NoError(t, err)
+		require.Equal(t, []schema.Change{{Path: "/type", Kind: schema.ChangeAdded, New: "string"}}, changes)
+	})
+}
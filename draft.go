@@ -0,0 +1,74 @@
+package schema
+
+import "strings"
+
+// Draft identifies the JSON Schema specification version a schema is written
+// against, as declared by its "$schema" keyword.
+type Draft int
+
+const (
+	// DraftUnknown means no recognized "$schema" was declared. The validator
+	// applies JSON Schema 2020-12 semantics in that case.
+	DraftUnknown Draft = iota
+	Draft04
+	Draft06
+	Draft07
+	Draft201909
+	Draft202012
+)
+
+var draftURIs = map[Draft]string{
+	Draft04:     "http://json-schema.org/draft-04/schema#",
+	Draft06:     "http://json-schema.org/draft-06/schema#",
+	Draft07:     "http://json-schema.org/draft-07/schema#",
+	Draft201909: "https://json-schema.org/draft/2019-09/schema",
+	Draft202012: Version,
+}
+
+// DetectDraft returns the Draft named by a "$schema" URI. The comparison
+// ignores the scheme (http vs https) and a trailing empty fragment, both of
+// which vary in the wild. Unrecognized URIs, including custom meta-schemas,
+// yield DraftUnknown.
+func DetectDraft(uri string) Draft {
+	normalized := normalizeDraftURI(uri)
+	if normalized == "" {
+		return DraftUnknown
+	}
+	for d, known := range draftURIs {
+		if normalizeDraftURI(known) == normalized {
+			return d
+		}
+	}
+	return DraftUnknown
+}
+
+func normalizeDraftURI(uri string) string {
+	uri = strings.TrimSuffix(uri, "#")
+	if rest, ok := strings.CutPrefix(uri, "https://"); ok {
+		return rest
+	}
+	return strings.TrimPrefix(uri, "http://")
+}
+
+// URI returns the canonical meta-schema URI of the draft, suitable for the
+// "$schema" keyword, or an empty string for DraftUnknown.
+func (d Draft) URI() string {
+	return draftURIs[d]
+}
+
+func (d Draft) String() string {
+	switch d {
+	case Draft04:
+		return "draft-04"
+	case Draft06:
+		return "draft-06"
+	case Draft07:
+		return "draft-07"
+	case Draft201909:
+		return "2019-09"
+	case Draft202012:
+		return "2020-12"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,125 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDraft(t *testing.T) {
+	testCases := []struct {
+		uri  string
+		want schema.Draft
+	}{
+		{"http://json-schema.org/draft-04/schema#", schema.Draft04},
+		{"http://json-schema.org/draft-06/schema#", schema.Draft06},
+		{"http://json-schema.org/draft-07/schema#", schema.Draft07},
+		{"https://json-schema.org/draft-07/schema", schema.Draft07},
+		{"https://json-schema.org/draft/2019-09/schema", schema.Draft201909},
+		{"https://json-schema.org/draft/2020-12/schema", schema.Draft202012},
+		{"https://json-schema.org/draft/2020-12/schema#", schema.Draft202012},
+		{"https://example.com/custom-meta", schema.DraftUnknown},
+		{"", schema.DraftUnknown},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.uri, func(t *testing.T) {
+			require.Equal(t, tc.want, schema.DetectDraft(tc.uri))
+		})
+	}
+	require.Equal(t, schema.Draft07, schema.DetectDraft(schema.Draft07.URI()))
+}
+
+func TestLegacyKeywordsRoundTrip(t *testing.T) {
+	const src = `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"definitions": {"name": {"type": "string"}},
+		"items": [{"type": "string"}, true],
+		"additionalItems": false,
+		"dependencies": {"a": ["b", "c"], "d": {"required": ["e"]}, "f": false}
+	}`
+
+	var s schema.Schema
+	require.NoError(t, json.Unmarshal([]byte(src), &s))
+
+	require.Contains(t, s.LegacyDefinitions(), "name")
+
+	tuple, ok := s.Items().(schema.TupleItems)
+	require.True(t, ok, "array-valued items should decode as TupleItems")
+	require.Len(t, tuple, 2)
+	require.Equal(t, schema.BoolSchema(true), tuple[1])
+
+	deps := s.Dependencies()
+	require.Equal(t, []string{"b", "c"}, deps["a"])
+	require.IsType(t, (*schema.Schema)(nil), deps["d"])
+	require.Equal(t, schema.BoolSchema(false), deps["f"])
+
+	buf, err := json.Marshal(&s)
+	require.NoError(t, err)
+	require.JSONEq(t, src, string(buf))
+}
+
+func TestDraft04ExclusiveBounds(t *testing.T) {
+	t.Run("exclusive", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"minimum": 0,
+			"exclusiveMinimum": true,
+			"maximum": 10,
+			"exclusiveMaximum": true
+		}`)))
+		require.False(t, s.HasMinimum())
+		require.False(t, s.HasMaximum())
+		require.Equal(t, 0.0, s.ExclusiveMinimum())
+		require.Equal(t, 10.0, s.ExclusiveMaximum())
+
+		buf, err := json.Marshal(&s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"exclusiveMinimum": 0,
+			"exclusiveMaximum": 10
+		}`, string(buf))
+
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 5)
+		require.NoError(t, err)
+		for _, value := range []any{0, 10} {
+			_, err = v.Validate(t.Context(), value)
+			require.Error(t, err, "%v", value)
+		}
+	})
+
+	t.Run("inclusive", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"minimum": 0, "exclusiveMinimum": false, "maximum": 10}`)))
+		require.Equal(t, 0.0, s.Minimum())
+		require.Equal(t, 10.0, s.Maximum())
+		require.False(t, s.HasExclusiveMinimum())
+		require.False(t, s.HasExclusiveMaximum())
+	})
+
+	t.Run("key order does not matter", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"exclusiveMaximum": true, "maximum": 10}`)))
+		require.Equal(t, 10.0, s.ExclusiveMaximum())
+		require.False(t, s.HasMaximum())
+	})
+
+	t.Run("numeric form is unchanged", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"minimum": 1, "exclusiveMinimum": 0}`)))
+		require.Equal(t, 1.0, s.Minimum())
+		require.Equal(t, 0.0, s.ExclusiveMinimum())
+	})
+
+	t.Run("missing bound", func(t *testing.T) {
+		var s schema.Schema
+		require.ErrorContains(t, s.UnmarshalJSON([]byte(`{"exclusiveMinimum": true}`)), `"exclusiveMinimum": true requires "minimum"`)
+		require.ErrorContains(t, s.UnmarshalJSON([]byte(`{"exclusiveMaximum": true}`)), `"exclusiveMaximum": true requires "maximum"`)
+	})
+}
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Equal reports whether s and other describe the same schema: they set the
+// same keywords, to the same values, recursively. Two schemas are equal when
+// MarshalJSON would encode them identically, so the order in which keywords,
+// properties or definitions were added does not matter, nor does whether a
+// nested schema is the same pointer or a copy. Values are compared by their
+// JSON form, so a "const" of int 1 equals one of float64 1.
+//
+// Two nil schemas are equal; a nil schema is not equal to a non-nil one, not
+// even an empty one.
+func (s *Schema) Equal(other *Schema) bool {
+	switch {
+	case s == other:
+		return true
+	case s == nil || other == nil:
+		return false
+	case s.populatedFields != other.populatedFields:
+		return false
+	}
+
+	a, err := json.Marshal(s)
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
@@ -0,0 +1,63 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqual(t *testing.T) {
+	str := func() *schema.Schema { return schema.NewBuilder().Types(schema.StringType).MustBuild() }
+
+	t.Run("insertion order and pointer identity do not matter", func(t *testing.T) {
+		a := schema.NewBuilder().
+			Property("a", str()).
+			Property("b", schema.NewBuilder().Types(schema.IntegerType).Minimum(0).MustBuild()).
+			Definitions("x", str()).
+			Definitions("y", str()).
+			MustBuild()
+		b := schema.NewBuilder().
+			Definitions("y", str()).
+			Definitions("x", str()).
+			Property("b", schema.NewBuilder().Minimum(0).Types(schema.IntegerType).MustBuild()).
+			Property("a", str()).
+			MustBuild()
+		require.True(t, a.Equal(b))
+		require.True(t, b.Equal(a))
+		require.True(t, a.Equal(a.DeepClone()))
+		require.True(t, a.Equal(mustParseSchema(t, `{
+			"$defs": {"x": {"type": "string"}, "y": {"type": "string"}},
+			"properties": {"a": {"type": "string"}, "b": {"type": "integer", "minimum": 0}}
+		}`)))
+	})
+
+	t.Run("values are compared by their JSON form", func(t *testing.T) {
+		require.True(t, schema.NewBuilder().Const(1).MustBuild().Equal(mustParseSchema(t, `{"const": 1}`)))
+		require.True(t, schema.NewBuilder().Enum("a", map[string]any{"k": []any{1}}).MustBuild().
+			Equal(mustParseSchema(t, `{"enum": ["a", {"k": [1]}]}`)))
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		base := `{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["a"]}`
+		for _, other := range []string{
+			`{"type": "object", "properties": {"a": {"type": "string"}}}`,
+			`{"type": "object", "properties": {"a": {"type": "number"}}, "required": ["a"]}`,
+			`{"type": "object", "properties": {"a": {"type": "string"}, "b": {}}, "required": ["a"]}`,
+			`{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["b"]}`,
+			`{"type": "array", "properties": {"a": {"type": "string"}}, "required": ["a"]}`,
+		} {
+			require.False(t, mustParseSchema(t, base).Equal(mustParseSchema(t, other)), other)
+		}
+		require.False(t, schema.NewBuilder().AdditionalProperties(schema.FalseSchema()).MustBuild().
+			Equal(schema.NewBuilder().AdditionalProperties(schema.TrueSchema()).MustBuild()))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var nilSchema *schema.Schema
+		require.True(t, nilSchema.Equal(nil))
+		require.False(t, nilSchema.Equal(schema.New()))
+		require.False(t, schema.New().Equal(nil))
+		require.True(t, schema.New().Equal(schema.New()))
+	})
+}
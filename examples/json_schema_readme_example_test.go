@@ -83,5 +83,5 @@ func Example() {
 	//   "type": "object"
 	// }
 	// User data is valid!
-	// validation failed as expected: invalid value passed to ObjectValidator: property validation failed for name: invalid value passed to StringValidator: string length (0) shorter then minLength (1)
+	// validation failed as expected: /name: invalid value passed to ObjectValidator: property validation failed for name: invalid value passed to StringValidator: string length (0) shorter than minLength (1)
 }
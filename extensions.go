@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Extensions returns the keywords of s that are not JSON Schema keywords known
+// to this package, such as OpenAPI's "nullable" or vendor "x-" keywords, with
+// their values as they appeared in the JSON document. UnmarshalJSON collects
+// them and MarshalJSON writes them back, so they survive a load/save cycle;
+// they play no part in validation.
+//
+// The map is nil when there are none. Like the other accessors, it is the
+// schema's own map and must not be modified.
+func (s *Schema) Extensions() map[string]json.RawMessage {
+	return s.extensions
+}
+
+// HasExtensions reports whether s holds any unknown keywords.
+func (s *Schema) HasExtensions() bool {
+	return len(s.extensions) > 0
+}
+
+// Extension sets the unknown keyword key to value, encoded with
+// json.Marshal; a json.RawMessage is used as it is. Setting the same key
+// again replaces its value. Build fails if value cannot be encoded, or if key
+// is a keyword Builder has its own method for.
+func (b *Builder) Extension(key string, value any) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if _, ok := keywordShapes[key]; ok {
+		b.err = fmt.Errorf(`invalid key in Extension: %q is a keyword with its own builder method`, key)
+		return b
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		b.err = fmt.Errorf(`invalid value in Extension %q: %w`, key, err)
+		return b
+	}
+	if b.extensions == nil {
+		b.extensions = make(map[string]json.RawMessage)
+	}
+	b.extensions[key] = raw
+	return b
+}
+
+// ResetExtensions removes every unknown keyword set with Extension or copied
+// by Clone.
+func (b *Builder) ResetExtensions() *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.extensions = nil
+	return b
+}
+
+// extensionFields appends the unknown keywords of s to fields, for
+// marshalJSON.
+func (s *Schema) extensionFields(fields []pair) []pair {
+	for name, raw := range s.extensions {
+		fields = append(fields, pair{Name: name, Value: raw})
+	}
+	return fields
+}
+
+func cloneExtensions(m map[string]json.RawMessage) map[string]json.RawMessage {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]json.RawMessage, len(m))
+	for k, v := range m {
+		out[k] = bytes.Clone(v)
+	}
+	return out
+}
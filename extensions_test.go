@@ -0,0 +1,86 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtensions(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		const doc = `{
+			"type": "object",
+			"nullable": true,
+			"x-go-name": "User",
+			"properties": {
+				"id": {"type": "string", "x-order": 1, "x-meta": {"tags": ["a", "b"]}}
+			}
+		}`
+		s := mustParseSchema(t, doc)
+		require.Equal(t, map[string]json.RawMessage{
+			"nullable":  json.RawMessage(`true`),
+			"x-go-name": json.RawMessage(`"User"`),
+		}, s.Extensions())
+		require.True(t, s.HasExtensions())
+		require.Equal(t, json.RawMessage(`{"tags": ["a", "b"]}`), s.Properties()["id"].Extensions()["x-meta"])
+
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, doc, string(buf))
+		require.Equal(t, `{"nullable":true,"properties":{"id":{"type":"string","x-meta":{"tags":["a","b"]},"x-order":1}},"type":"object","x-go-name":"User"}`, string(buf))
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			Types(schema.StringType).
+			Extension("x-order", 2).
+			Extension("x-raw", json.RawMessage(`{"a": 1}`)).
+			Extension("x-order", 3).
+			Build()
+		require.NoError(t, err)
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.Equal(t, `{"type":"string","x-order":3,"x-raw":{"a":1}}`, string(buf))
+
+		cloned, err := schema.NewBuilder().Clone(s).Extension("x-new", "v").Build()
+		require.NoError(t, err)
+		require.Len(t, cloned.Extensions(), 3)
+		require.Len(t, s.Extensions(), 2, "the original is unaffected")
+
+		reset, err := schema.NewBuilder().Clone(s).ResetExtensions().Build()
+		require.NoError(t, err)
+		require.False(t, reset.HasExtensions())
+	})
+
+	t.Run("builder errors", func(t *testing.T) {
+		_, err := schema.NewBuilder().Extension("minLength", 1).Build()
+		require.ErrorContains(t, err, `"minLength" is a keyword`)
+
+		_, err = schema.NewBuilder().Extension("x-bad", func() {}).Build()
+		require.ErrorContains(t, err, `invalid value in Extension "x-bad"`)
+	})
+
+	t.Run("equal and clone", func(t *testing.T) {
+		a := mustParseSchema(t, `{"type": "string", "x-a": 1}`)
+		require.True(t, a.Equal(mustParseSchema(t, `{"x-a": 1, "type": "string"}`)))
+		require.False(t, a.Equal(mustParseSchema(t, `{"type": "string", "x-a": 2}`)))
+		require.False(t, a.Equal(mustParseSchema(t, `{"type": "string"}`)))
+
+		c := a.DeepClone()
+		require.True(t, a.Equal(c))
+		c.Extensions()["x-a"][0] = '9'
+		require.Equal(t, json.RawMessage(`1`), a.Extensions()["x-a"])
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		a := mustParseSchema(t, `{"minLength": 1, "x-a": 1, "x-both": "a"}`)
+		b := mustParseSchema(t, `{"minLength": 2, "x-b": 2, "x-both": "b"}`)
+		m, err := schema.Merge(a, b)
+		require.NoError(t, err)
+		buf, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.Equal(t, `{"minLength":2,"x-a":1,"x-b":2,"x-both":"b"}`, string(buf))
+	})
+}
@@ -0,0 +1,33 @@
+package schema
+
+import "errors"
+
+var errFrozen = errors.New(`json-schema: cannot unmarshal into a frozen schema`)
+
+// Freeze returns a deep copy of s (see DeepClone) that is read-only, along
+// with every subschema nested in it. A frozen schema refuses UnmarshalJSON,
+// and nothing else in this module modifies a schema once it is built, so the
+// only way to change one is to modify the maps and slices its accessors
+// return, which callers must not do. Freeze of a nil schema is nil.
+//
+// Because the copy shares no memory with s, changes made to s afterwards are
+// not seen by the frozen schema. This makes a frozen schema safe to compile
+// and validate against from any number of goroutines at once: validators
+// compiled from it, including the references they resolve on first use, only
+// read it. DeepClone of a frozen schema returns a copy that can be modified
+// again, and a schema built from Builder.Clone of a frozen schema shares its
+// frozen subschemas.
+func Freeze(s *Schema) *Schema {
+	c := s.DeepClone()
+	_ = c.Walk(func(_ string, sub *Schema) error {
+		sub.frozen = true
+		return nil
+	})
+	return c
+}
+
+// IsFrozen reports whether s was returned by Freeze, or is nested in a
+// schema that was.
+func (s *Schema) IsFrozen() bool {
+	return s.frozen
+}
@@ -0,0 +1,56 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	orig := mustParseSchema(t, `{
+		"type": "object",
+		"properties": {"name": {"type": "string"}},
+		"items": [{"type": "integer"}],
+		"$defs": {"n": {"type": "number"}}
+	}`)
+	want, err := json.Marshal(orig)
+	require.NoError(t, err)
+
+	frozen := schema.Freeze(orig)
+	require.False(t, orig.IsFrozen(), "the original is not modified")
+	got, err := json.Marshal(frozen)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(got))
+
+	t.Run("subschemas are frozen", func(t *testing.T) {
+		require.True(t, frozen.IsFrozen())
+		require.True(t, frozen.Properties()["name"].IsFrozen())
+		require.True(t, frozen.Definitions()["n"].IsFrozen())
+		require.True(t, frozen.Items().(schema.TupleItems)[0].(*schema.Schema).IsFrozen())
+	})
+
+	t.Run("shares nothing with the original", func(t *testing.T) {
+		require.NotSame(t, orig.Properties()["name"], frozen.Properties()["name"])
+		orig.Properties()["extra"] = schema.New()
+		require.NotContains(t, frozen.Properties(), "extra")
+	})
+
+	t.Run("refuses UnmarshalJSON", func(t *testing.T) {
+		require.ErrorContains(t, frozen.UnmarshalJSON([]byte(`{"type": "string"}`)), "frozen")
+		require.ErrorContains(t, frozen.Properties()["name"].UnmarshalJSON([]byte(`{}`)), "frozen")
+		got, err := json.Marshal(frozen)
+		require.NoError(t, err)
+		require.JSONEq(t, string(want), string(got))
+	})
+
+	t.Run("DeepClone thaws", func(t *testing.T) {
+		c := frozen.DeepClone()
+		require.False(t, c.IsFrozen())
+		require.False(t, c.Properties()["name"].IsFrozen())
+		require.NoError(t, c.UnmarshalJSON([]byte(`{"minProperties": 1}`)))
+	})
+
+	require.Nil(t, schema.Freeze(nil))
+}
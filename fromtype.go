@@ -0,0 +1,541 @@
+package schema
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/json-schema/keywords"
+	"github.com/lestrrat-go/option/v3"
+)
+
+// FromTypeOption configures FromType and FromValue.
+type FromTypeOption interface {
+	option.Interface
+	fromTypeOption()
+}
+
+type fromTypeOption struct{ option.Interface }
+
+func (fromTypeOption) fromTypeOption() {}
+
+type identAdditionalProperties struct{}
+
+// WithAdditionalProperties sets whether the schemas derived from structs
+// accept properties the struct has no field for. The default is true, as
+// encoding/json ignores such properties; with false every struct schema gets
+// "additionalProperties": false.
+func WithAdditionalProperties(allow bool) FromTypeOption {
+	return fromTypeOption{option.New(identAdditionalProperties{}, allow)}
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonNumberType    = reflect.TypeFor[json.Number]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// FromType derives a schema for the JSON that encoding/json produces from
+// values of type t:
+//
+//   - bool, integers, floats and strings map to "boolean", "integer" (with
+//     "minimum": 0 for unsigned types), "number" and "string"
+//   - slices and arrays map to "array" with "items", arrays also with their
+//     length as "minItems" and "maxItems"; []byte, which is encoded as
+//     base64, maps to a string with "contentEncoding": "base64"
+//   - maps map to "object" with "additionalProperties"
+//   - structs map to "object" with one property per exported field, named by
+//     its json tag and honoring "-" and ",string". Fields of embedded structs
+//     are promoted, the fields of the outer struct taking precedence
+//   - time.Time maps to a "date-time" string and encoding.TextMarshaler
+//     implementations to strings; other json.Marshaler implementations,
+//     interfaces and json.RawMessage accept any value
+//   - pointers map to the schema of the type they point to. As struct fields
+//     without omitempty or omitzero, which encoding/json writes as null when
+//     nil, they accept null as well
+//
+// A struct field is required unless it is a pointer or tagged omitempty or
+// omitzero. Tags can override this and add constraints, in the form
+//
+//	Email string `json:"email" jsonschema:"format=email,maxLength=254"`
+//
+// The keys understood are the keywords title, description, format, pattern,
+// minLength, maxLength, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, minItems, maxItems, uniqueItems, minProperties, maxProperties,
+// deprecated, readOnly, writeOnly, enum and default, plus required and
+// optional. Boolean keys may be given without a value to mean true. enum
+// values are separated by "|". enum and default values are taken as strings
+// for string fields and as JSON otherwise. A literal comma in a value is
+// written as "\,".
+//
+// A named struct type that refers to itself is defined once under "$defs"
+// and referenced with "$ref" ("#" when it is t itself). Channels, functions
+// and complex numbers cannot be represented and make FromType fail.
+//
+// Note that encoding/json writes nil slices and maps as null too, which the
+// derived schema does not accept.
+func FromType(t reflect.Type, options ...FromTypeOption) (*Schema, error) {
+	if t == nil {
+		return nil, fmt.Errorf(`json-schema: FromType: type must not be nil`)
+	}
+	g := &typeSchemaGenerator{
+		root:                 derefType(t),
+		additionalProperties: true,
+		inProgress:           make(map[reflect.Type]struct{}),
+		recursive:            make(map[reflect.Type]struct{}),
+		defNames:             make(map[reflect.Type]string),
+		defs:                 make(map[string]*Schema),
+	}
+	for _, o := range options {
+		switch o.Ident() {
+		case identAdditionalProperties{}:
+			g.additionalProperties = option.MustGet[bool](o)
+		}
+	}
+
+	b, err := g.typeSchema(t)
+	if err != nil {
+		return nil, fmt.Errorf(`json-schema: FromType: %w`, err)
+	}
+	if len(g.defs) > 0 {
+		b.DefinitionsMap(g.defs)
+	}
+	return b.Build()
+}
+
+// FromValue is FromType for the type of v.
+func FromValue(v any, options ...FromTypeOption) (*Schema, error) {
+	if v == nil {
+		return nil, fmt.Errorf(`json-schema: FromValue: value must not be nil`)
+	}
+	return FromType(reflect.TypeOf(v), options...)
+}
+
+type typeSchemaGenerator struct {
+	root                 reflect.Type
+	additionalProperties bool
+	inProgress           map[reflect.Type]struct{} // structs being derived
+	recursive            map[reflect.Type]struct{} // structs that refer to themselves
+	defNames             map[reflect.Type]string
+	defs                 map[string]*Schema
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// typeSchema returns a builder holding the schema of t, to which the caller
+// may add the constraints of a struct tag.
+func (g *typeSchemaGenerator) typeSchema(t reflect.Type) (*Builder, error) {
+	t = derefType(t)
+	switch t {
+	case timeType:
+		return NewBuilder().Types(StringType).Format("date-time"), nil
+	case rawMessageType:
+		return NewBuilder(), nil
+	case jsonNumberType:
+		return NewBuilder().Types(NumberType), nil
+	}
+	switch {
+	case implements(t, jsonMarshalerType):
+		return NewBuilder(), nil // the shape is up to MarshalJSON
+	case implements(t, textMarshalerType):
+		return NewBuilder().Types(StringType), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewBuilder().Types(BooleanType), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewBuilder().Types(IntegerType), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return NewBuilder().Types(IntegerType).Minimum(0), nil
+	case reflect.Float32, reflect.Float64:
+		return NewBuilder().Types(NumberType), nil
+	case reflect.String:
+		return NewBuilder().Types(StringType), nil
+	case reflect.Interface:
+		return NewBuilder(), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) && !implements(t.Elem(), textMarshalerType) {
+			return NewBuilder().Types(StringType).ContentEncoding("base64"), nil
+		}
+		items, err := g.subschema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewBuilder().Types(ArrayType).Items(items), nil
+	case reflect.Array:
+		items, err := g.subschema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		n := uint(t.Len())
+		return NewBuilder().Types(ArrayType).Items(items).MinItems(n).MaxItems(n), nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !implements(t.Key(), textMarshalerType) {
+				return nil, fmt.Errorf(`unsupported map key type %s`, t.Key())
+			}
+		}
+		values, err := g.subschema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewBuilder().Types(ObjectType).AdditionalProperties(values), nil
+	case reflect.Struct:
+		return g.structReference(t)
+	default:
+		return nil, fmt.Errorf(`unsupported type %s`, t)
+	}
+}
+
+func (g *typeSchemaGenerator) subschema(t reflect.Type) (*Schema, error) {
+	b, err := g.typeSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	return b.Build()
+}
+
+// structReference returns the schema of the struct type t, or a "$ref" to it
+// when t refers to itself.
+func (g *typeSchemaGenerator) structReference(t reflect.Type) (*Builder, error) {
+	if _, ok := g.inProgress[t]; ok {
+		g.recursive[t] = struct{}{}
+		return NewBuilder().Reference(g.reference(t)), nil
+	}
+	g.inProgress[t] = struct{}{}
+	b, err := g.structSchema(t)
+	delete(g.inProgress, t)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := g.recursive[t]; !ok || t == g.root {
+		return b, nil
+	}
+	def, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	g.defs[g.defNames[t]] = def
+	return NewBuilder().Reference(g.reference(t)), nil
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// reference returns the "$ref" value for the struct type t, picking its name
+// under "$defs" on first use.
+func (g *typeSchemaGenerator) reference(t reflect.Type) string {
+	if t == g.root {
+		return "#"
+	}
+	name, ok := g.defNames[t]
+	if !ok {
+		base := nonIdentifierChars.ReplaceAllString(t.Name(), "_")
+		name = base
+		for i := 2; g.nameTaken(name); i++ {
+			name = base + strconv.Itoa(i)
+		}
+		g.defNames[t] = name
+	}
+	return "#/$defs/" + name
+}
+
+func (g *typeSchemaGenerator) nameTaken(name string) bool {
+	for _, taken := range g.defNames {
+		if taken == name {
+			return true
+		}
+	}
+	return false
+}
+
+// structField is a field of a struct as encoding/json sees it, after
+// promotion of embedded structs.
+type structField struct {
+	name     string
+	field    reflect.StructField
+	asString bool // the ",string" option
+	optional bool
+	nullable bool // a pointer written as null when nil
+}
+
+func (g *typeSchemaGenerator) structSchema(t reflect.Type) (*Builder, error) {
+	b := NewBuilder().Types(ObjectType)
+	var required []string
+	for _, f := range structFields(t, false, nil, nil) {
+		var fb *Builder
+		if f.asString {
+			fb = NewBuilder().Types(StringType)
+		} else {
+			var err error
+			fb, err = g.typeSchema(f.field.Type)
+			if err != nil {
+				return nil, fmt.Errorf(`field %s.%s: %w`, t.Name(), f.field.Name, err)
+			}
+		}
+		isRequired := !f.optional
+		if tag, ok := f.field.Tag.Lookup("jsonschema"); ok {
+			var err error
+			isRequired, err = applySchemaTag(fb, tag, derefType(f.field.Type), isRequired)
+			if err != nil {
+				return nil, fmt.Errorf(`field %s.%s: invalid jsonschema tag: %w`, t.Name(), f.field.Name, err)
+			}
+		}
+		if f.nullable {
+			var err error
+			if fb, err = allowNull(fb); err != nil {
+				return nil, fmt.Errorf(`field %s.%s: %w`, t.Name(), f.field.Name, err)
+			}
+		}
+		s, err := fb.Build()
+		if err != nil {
+			return nil, fmt.Errorf(`field %s.%s: %w`, t.Name(), f.field.Name, err)
+		}
+		b.Property(f.name, s)
+		if isRequired {
+			required = append(required, f.name)
+		}
+	}
+	if len(required) > 0 {
+		b.Required(required...)
+	}
+	if !g.additionalProperties {
+		b.AdditionalProperties(FalseSchema())
+	}
+	return b, nil
+}
+
+// allowNull makes the schema being built by b accept null too.
+func allowNull(b *Builder) (*Builder, error) {
+	switch {
+	case b.reference != nil:
+		ref, err := b.Build()
+		if err != nil {
+			return nil, err
+		}
+		return NewBuilder().AnyOf(ref, NewBuilder().Types(NullType).MustBuild()), nil
+	case len(b.types) > 0 && !slices.Contains(b.types, NullType):
+		b.Types(append(slices.Clone(b.types), NullType)...)
+		if b.enum != nil && !slices.Contains(b.enum, nil) {
+			b.Enum(append(slices.Clone(b.enum), nil)...)
+		}
+	}
+	return b, nil
+}
+
+// structFields appends the JSON fields of the struct type t to fields, skipping
+// names in seen. optional is set when t is reached through an embedded
+// pointer, which may be nil.
+func structFields(t reflect.Type, optional bool, seen map[string]struct{}, fields []structField) []structField {
+	if seen == nil {
+		seen = make(map[string]struct{})
+	}
+	var embedded []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			if ft := derefType(f.Type); ft.Kind() == reflect.Struct {
+				embedded = append(embedded, f)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		isPointer := f.Type.Kind() == reflect.Pointer
+		sf := structField{name: name, field: f, optional: optional || isPointer, nullable: isPointer}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty", "omitzero":
+				sf.optional = true
+				sf.nullable = false
+			case "string":
+				switch derefType(f.Type).Kind() {
+				case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+					sf.asString = true
+				}
+			}
+		}
+		fields = append(fields, sf)
+	}
+	// Fields of the outer struct shadow those of embedded ones.
+	for _, f := range embedded {
+		fields = structFields(derefType(f.Type), optional || f.Type.Kind() == reflect.Pointer, seen, fields)
+	}
+	return fields
+}
+
+// applySchemaTag adds the constraints of a jsonschema struct tag to b. t is
+// the field type, which enum and default values are parsed for. It returns
+// whether the field is required, given that it is by default if required is
+// true.
+func applySchemaTag(b *Builder, tag string, t reflect.Type, required bool) (bool, error) {
+	for _, item := range splitSchemaTag(tag) {
+		key, value, hasValue := strings.Cut(item, "=")
+		var err error
+		switch key {
+		case "":
+			continue
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case keywords.Title:
+			b.Title(value)
+		case keywords.Description:
+			b.Description(value)
+		case keywords.Format:
+			b.Format(value)
+		case keywords.Pattern:
+			b.Pattern(value)
+		case keywords.MinLength, keywords.MaxLength:
+			var n int
+			if n, err = strconv.Atoi(value); err == nil {
+				if key == keywords.MinLength {
+					b.MinLength(n)
+				} else {
+					b.MaxLength(n)
+				}
+			}
+		case keywords.MinItems, keywords.MaxItems, keywords.MinProperties, keywords.MaxProperties:
+			var n uint64
+			if n, err = strconv.ParseUint(value, 10, 0); err == nil {
+				switch key {
+				case keywords.MinItems:
+					b.MinItems(uint(n))
+				case keywords.MaxItems:
+					b.MaxItems(uint(n))
+				case keywords.MinProperties:
+					b.MinProperties(uint(n))
+				default:
+					b.MaxProperties(uint(n))
+				}
+			}
+		case keywords.Minimum, keywords.Maximum, keywords.ExclusiveMinimum, keywords.ExclusiveMaximum, keywords.MultipleOf:
+			var f float64
+			if f, err = strconv.ParseFloat(value, 64); err == nil {
+				switch key {
+				case keywords.Minimum:
+					b.Minimum(f)
+				case keywords.Maximum:
+					b.Maximum(f)
+				case keywords.ExclusiveMinimum:
+					b.ExclusiveMinimum(f)
+				case keywords.ExclusiveMaximum:
+					b.ExclusiveMaximum(f)
+				default:
+					b.MultipleOf(f)
+				}
+			}
+		case keywords.UniqueItems, keywords.Deprecated, keywords.ReadOnly, keywords.WriteOnly:
+			v := true
+			if hasValue {
+				v, err = strconv.ParseBool(value)
+			}
+			if err == nil {
+				switch key {
+				case keywords.UniqueItems:
+					b.UniqueItems(v)
+				case keywords.Deprecated:
+					b.Deprecated(v)
+				case keywords.ReadOnly:
+					b.ReadOnly(v)
+				default:
+					b.WriteOnly(v)
+				}
+			}
+		case keywords.Enum:
+			var values []any
+			for _, elem := range strings.Split(value, "|") {
+				var v any
+				if v, err = parseTagValue(elem, t); err != nil {
+					break
+				}
+				values = append(values, v)
+			}
+			b.Enum(values...)
+		case keywords.Default:
+			var v any
+			if v, err = parseTagValue(value, t); err == nil {
+				b.Default(v)
+			}
+		default:
+			return false, fmt.Errorf(`unknown key %q`, key)
+		}
+		if err != nil {
+			return false, fmt.Errorf(`invalid value for %q: %w`, key, err)
+		}
+	}
+	return required, nil
+}
+
+// splitSchemaTag splits a jsonschema tag at its commas, except those escaped
+// as "\,".
+func splitSchemaTag(tag string) []string {
+	var items []string
+	var sb strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch c := tag[i]; {
+		case c == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			sb.WriteByte(',')
+			i++
+		case c == ',':
+			items = append(items, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return append(items, sb.String())
+}
+
+// parseTagValue interprets an enum or default value of a jsonschema tag: as
+// is for string fields, as JSON for the others.
+func parseTagValue(s string, t reflect.Type) (any, error) {
+	if t.Kind() == reflect.String {
+		return s, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return preserveLargeIntegers(v), nil
+}
@@ -0,0 +1,177 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+type fromTypeAddress struct {
+	City string `json:"city" jsonschema:"minLength=1"`
+	Zip  string `json:"zip,omitempty" jsonschema:"pattern=^[0-9]{3}\\,[0-9]{4}$"`
+}
+
+type fromTypeBase struct {
+	ID      int64     `json:"id" jsonschema:"minimum=1"`
+	Created time.Time `json:"created"`
+}
+
+type fromTypeUser struct {
+	fromTypeBase
+	Email    string            `json:"email" jsonschema:"format=email,maxLength=254"`
+	Nickname *string           `json:"nickname" jsonschema:"description=Shown instead of the email"`
+	Age      uint8             `json:"age,omitempty" jsonschema:"required,maximum=150"`
+	Role     string            `json:"role" jsonschema:"enum=admin|user,default=user,optional"`
+	Scores   []float64         `json:"scores" jsonschema:"uniqueItems"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Address  fromTypeAddress   `json:"address"`
+	Avatar   []byte            `json:"avatar,omitempty"`
+	Count    int               `json:"count,string"`
+	Extra    any               `json:"extra,omitempty"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+type fromTypeNode struct {
+	Value    int             `json:"value"`
+	Children []*fromTypeNode `json:"children,omitempty"`
+}
+
+type fromTypeTree struct {
+	Root *fromTypeNode `json:"root"`
+}
+
+func TestFromType(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		s, err := schema.FromType(reflect.TypeFor[fromTypeUser]())
+		require.NoError(t, err)
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "minimum": 1},
+				"created": {"type": "string", "format": "date-time"},
+				"email": {"type": "string", "format": "email", "maxLength": 254},
+				"nickname": {"type": ["string", "null"], "description": "Shown instead of the email"},
+				"age": {"type": "integer", "minimum": 0, "maximum": 150},
+				"role": {"type": "string", "enum": ["admin", "user"], "default": "user"},
+				"scores": {"type": "array", "items": {"type": "number"}, "uniqueItems": true},
+				"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+				"address": {
+					"type": "object",
+					"properties": {
+						"city": {"type": "string", "minLength": 1},
+						"zip": {"type": "string", "pattern": "^[0-9]{3},[0-9]{4}$"}
+					},
+					"required": ["city"]
+				},
+				"avatar": {"type": "string", "contentEncoding": "base64"},
+				"count": {"type": "string"},
+				"extra": {}
+			},
+			"required": ["email", "age", "scores", "address", "count", "id", "created"]
+		}`, string(buf))
+	})
+
+	t.Run("validates encoded values", func(t *testing.T) {
+		s, err := schema.FromValue(fromTypeUser{})
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		user := fromTypeUser{
+			fromTypeBase: fromTypeBase{ID: 1, Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			Email:        "ada@example.com",
+			Age:          36,
+			Role:         "admin",
+			Scores:       []float64{1, 2},
+			Address:      fromTypeAddress{City: "London"},
+			Count:        3,
+		}
+		buf, err := json.Marshal(user)
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, buf)
+		require.NoError(t, err)
+
+		user.Role = "root"
+		buf, err = json.Marshal(user)
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, buf)
+		require.Error(t, err)
+	})
+
+	t.Run("recursive types", func(t *testing.T) {
+		s, err := schema.FromType(reflect.TypeFor[*fromTypeNode]())
+		require.NoError(t, err)
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "object",
+			"properties": {
+				"value": {"type": "integer"},
+				"children": {"type": "array", "items": {"$ref": "#"}}
+			},
+			"required": ["value"]
+		}`, string(buf))
+
+		s, err = schema.FromValue(fromTypeTree{})
+		require.NoError(t, err)
+		buf, err = json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "object",
+			"properties": {"root": {"anyOf": [{"$ref": "#/$defs/fromTypeNode"}, {"type": "null"}]}},
+			"$defs": {
+				"fromTypeNode": {
+					"type": "object",
+					"properties": {
+						"value": {"type": "integer"},
+						"children": {"type": "array", "items": {"$ref": "#/$defs/fromTypeNode"}}
+					},
+					"required": ["value"]
+				}
+			}
+		}`, string(buf))
+
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, []byte(`{"root": {"value": 1, "children": [{"value": 2}]}}`))
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, []byte(`{"root": null}`))
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, []byte(`{"root": {"value": 1, "children": [{}]}}`))
+		require.Error(t, err)
+	})
+
+	t.Run("additional properties", func(t *testing.T) {
+		s, err := schema.FromValue(fromTypeAddress{}, schema.WithAdditionalProperties(false))
+		require.NoError(t, err)
+		require.Equal(t, schema.FalseSchema(), s.AdditionalProperties())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := schema.FromValue(struct {
+			C chan int `json:"c"`
+		}{})
+		require.ErrorContains(t, err, `unsupported type chan int`)
+
+		_, err = schema.FromValue(struct {
+			N int `json:"n" jsonschema:"minimum=low"`
+		}{})
+		require.ErrorContains(t, err, `invalid value for "minimum"`)
+
+		_, err = schema.FromValue(struct {
+			N int `json:"n" jsonschema:"minimun=1"`
+		}{})
+		require.ErrorContains(t, err, `unknown key "minimun"`)
+
+		_, err = schema.FromValue(nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,298 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jsref/v2"
+	"github.com/lestrrat-go/option/v3"
+)
+
+// HTTPResolverOption configures NewHTTPResolver.
+type HTTPResolverOption interface {
+	option.Interface
+	httpResolverOption()
+}
+
+type httpResolverOption struct{ option.Interface }
+
+func (httpResolverOption) httpResolverOption() {}
+
+type identHTTPClient struct{}
+type identHTTPTimeout struct{}
+type identAllowedHosts struct{}
+type identRemoteFetcher struct{}
+type identMaxBytes struct{}
+type identMaxRedirects struct{}
+
+// RemoteFetcher retrieves the document at uri, an absolute URI without
+// fragment, for NewHTTPResolver (see WithRemoteFetcher).
+type RemoteFetcher func(ctx context.Context, uri string) ([]byte, error)
+
+// WithHTTPClient sets the client used to fetch documents. The default is
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) HTTPResolverOption {
+	return httpResolverOption{option.New(identHTTPClient{}, client)}
+}
+
+// WithHTTPTimeout bounds each fetch, including reading the response body.
+// A zero or negative duration means no limit beyond the client's own.
+func WithHTTPTimeout(d time.Duration) HTTPResolverOption {
+	return httpResolverOption{option.New(identHTTPTimeout{}, d)}
+}
+
+// WithAllowedHosts restricts fetching to the given hosts. Entries are matched
+// case-insensitively against either the host name or the host:port of the
+// reference. References to any other host fail to resolve without a request
+// being made. WithAllowedHosts may be supplied multiple times; the lists are
+// combined. When it is not supplied, every host is allowed.
+func WithAllowedHosts(hosts ...string) HTTPResolverOption {
+	return httpResolverOption{option.New(identAllowedHosts{}, hosts)}
+}
+
+// WithRemoteFetcher replaces the HTTP GET of the resolver with fetch, which
+// is then called for every absolute URI a reference names, whatever its
+// scheme. It lets documents be retrieved through a proxy, or from a fake in
+// tests. The documents fetch returns are parsed and cached as the resolver's
+// own fetches are, and WithAllowedHosts, WithHTTPTimeout and WithMaxBytes
+// still apply; WithHTTPClient and WithMaxRedirects do not.
+func WithRemoteFetcher(fetch RemoteFetcher) HTTPResolverOption {
+	return httpResolverOption{option.New(identRemoteFetcher{}, fetch)}
+}
+
+// WithMaxBytes makes a fetch fail when the document is larger than n bytes,
+// without reading more of it than that. A zero or negative n means no limit.
+func WithMaxBytes(n int64) HTTPResolverOption {
+	return httpResolverOption{option.New(identMaxBytes{}, n)}
+}
+
+// WithMaxRedirects makes a fetch fail when it is redirected more than n
+// times; zero refuses any redirect. Without it the client's own policy
+// applies, which for http.DefaultClient is to stop after 10.
+func WithMaxRedirects(n int) HTTPResolverOption {
+	return httpResolverOption{option.New(identMaxRedirects{}, n)}
+}
+
+// NewHTTPResolver returns a resolver that fetches http and https references
+// with GET requests. Each document is fetched at most once per resolver: the
+// parsed body is cached under its URI (without fragment), and later references
+// into the same document, including other JSON pointers within it, are served
+// from the cache. Failed fetches are not cached. A redirect to a host that
+// WithAllowedHosts does not allow fails the fetch.
+//
+// Like HTTPResolver, it is opt-in. Pass it to WithResolver to let a Resolver
+// reach the network:
+//
+//	r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(
+//		schema.WithHTTPTimeout(5*time.Second),
+//		schema.WithAllowedHosts("schemas.example.com"),
+//	)))
+func NewHTTPResolver(options ...HTTPResolverOption) jsref.Resolver {
+	r := &httpResolver{
+		client:       http.DefaultClient,
+		maxRedirects: -1,
+		cache:        make(map[string]any),
+	}
+	for _, o := range options {
+		switch o.Ident() {
+		case identHTTPClient{}:
+			if c := option.MustGet[*http.Client](o); c != nil {
+				r.client = c
+			}
+		case identHTTPTimeout{}:
+			r.timeout = option.MustGet[time.Duration](o)
+		case identAllowedHosts{}:
+			for _, h := range option.MustGet[[]string](o) {
+				if r.allowedHosts == nil {
+					r.allowedHosts = make(map[string]struct{})
+				}
+				r.allowedHosts[strings.ToLower(h)] = struct{}{}
+			}
+		case identRemoteFetcher{}:
+			r.fetcher = option.MustGet[RemoteFetcher](o)
+		case identMaxBytes{}:
+			r.maxBytes = option.MustGet[int64](o)
+		case identMaxRedirects{}:
+			r.maxRedirects = option.MustGet[int](o)
+		}
+	}
+	if r.fetcher == nil && (r.allowedHosts != nil || r.maxRedirects >= 0) {
+		// Check every hop of a redirect, not just the URI of the reference.
+		client := *r.client
+		next := client.CheckRedirect
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if r.maxRedirects >= 0 && len(via) > r.maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", r.maxRedirects)
+			}
+			if !r.allowed(req.URL) {
+				return fmt.Errorf("redirect to host %q, which is not in the allowed hosts", req.URL.Host)
+			}
+			if next != nil {
+				return next(req, via)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+		r.client = &client
+	}
+	return r
+}
+
+type httpResolver struct {
+	client       *http.Client
+	fetcher      RemoteFetcher // replaces the GET with client when set
+	timeout      time.Duration
+	allowedHosts map[string]struct{} // nil allows every host
+	maxBytes     int64               // largest document fetched; 0 for no limit
+	maxRedirects int                 // redirects followed; -1 for the client's policy
+
+	mu    sync.Mutex
+	cache map[string]any // document URI -> parsed document
+}
+
+func (r *httpResolver) CanResolve(resource any) bool {
+	s, ok := resource.(string)
+	if !ok {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	if r.fetcher != nil {
+		return u.IsAbs()
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+func (r *httpResolver) Resolve(dst any, resource any, localRef string) error {
+	s, ok := resource.(string)
+	if !ok {
+		return fmt.Errorf("httpResolver requires string resource, got %T", resource)
+	}
+	u, err := url.Parse(s)
+	if err != nil || !r.CanResolve(s) {
+		if r.fetcher != nil {
+			return fmt.Errorf("httpResolver requires an absolute URI, got %q", s)
+		}
+		return fmt.Errorf("httpResolver requires an http or https URL, got %q", s)
+	}
+	if !r.allowed(u) {
+		return fmt.Errorf("httpResolver: host %q is not in the allowed hosts", u.Host)
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	doc, err := r.document(u.String())
+	if err != nil {
+		return err
+	}
+
+	if localRef == "" {
+		localRef = "#"
+	}
+	return jsref.NewObjectResolver().Resolve(dst, doc, localRef)
+}
+
+func (r *httpResolver) allowed(u *url.URL) bool {
+	if r.allowedHosts == nil {
+		return true
+	}
+	if _, ok := r.allowedHosts[strings.ToLower(u.Hostname())]; ok {
+		return true
+	}
+	_, ok := r.allowedHosts[strings.ToLower(u.Host)]
+	return ok
+}
+
+// document returns the parsed document at uri, fetching it on first use.
+func (r *httpResolver) document(uri string) (any, error) {
+	r.mu.Lock()
+	doc, ok := r.cache[uri]
+	r.mu.Unlock()
+	if ok {
+		return doc, nil
+	}
+
+	data, err := r.fetch(uri)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", uri, err)
+	}
+
+	r.mu.Lock()
+	// Another goroutine may have fetched the same document meanwhile; keep the
+	// first copy so every caller sees the same value.
+	if cached, ok := r.cache[uri]; ok {
+		doc = cached
+	} else {
+		r.cache[uri] = doc
+	}
+	r.mu.Unlock()
+	return doc, nil
+}
+
+func (r *httpResolver) fetch(uri string) ([]byte, error) {
+	// jsref.Resolver carries no context, so the timeout is the only way to
+	// bound a fetch from here.
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	if r.fetcher != nil {
+		data, err := r.fetcher(ctx, uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", uri, err)
+		}
+		if r.maxBytes > 0 && int64(len(data)) > r.maxBytes {
+			return nil, fmt.Errorf("failed to fetch %s: document is larger than %d bytes", uri, r.maxBytes)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", uri, err)
+	}
+	req.Header.Set("Accept", "application/schema+json, application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", uri, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", uri, resp.Status)
+	}
+
+	body := io.Reader(resp.Body)
+	if r.maxBytes > 0 {
+		// One byte more than allowed tells a document of exactly maxBytes
+		// apart from a larger one.
+		body = io.LimitReader(resp.Body, r.maxBytes+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body of %s: %w", uri, err)
+	}
+	if r.maxBytes > 0 && int64(len(data)) > r.maxBytes {
+		return nil, fmt.Errorf("failed to fetch %s: document is larger than %d bytes", uri, r.maxBytes)
+	}
+	return data, nil
+}
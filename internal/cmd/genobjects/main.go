@@ -144,6 +144,11 @@ func genObject(obj *codegen.Object) error {
 		}
 		o.L("%s %s", field.Name(false), typ)
 	}
+	o.L("extensions map[string]json.RawMessage // unknown keywords, see extensions.go")
+	o.L("numbers map[string]json.Number // literals float64 does not hold exactly, see number.go")
+	o.L("compiled *compiledValidation // Validate's cache, see validate.go")
+	o.L("frozen bool // set by Freeze, see freeze.go")
+	o.L("boolean *bool // the boolean schema s was decoded from, see boolSchema in marshal.go")
 	o.L("}")
 
 	o.LL(`func New() *Schema {`)
@@ -179,6 +184,36 @@ func genObject(obj *codegen.Object) error {
 		o.L("}")
 	}
 
+	for _, field := range obj.Fields() {
+		if field.Type() != "float64" {
+			continue
+		}
+		o.LL("// %sNumber returns %q exactly, as it was written when the schema was", field.Name(true), field.JSON())
+		o.L("// decoded, even where %s rounds it to the nearest float64.", field.Name(true))
+		o.L("func (s *Schema) %sNumber() json.Number {", field.Name(true))
+		o.L("return s.number(keywords.%s, *(s.%s))", keywordConstName(field), field.Name(false))
+		o.L("}")
+	}
+
+	for _, field := range obj.Fields() {
+		if field.Type() != "SchemaOrBool" {
+			continue
+		}
+		// "ifSchema" and its siblings are named after the keyword, "if"
+		keyword := strings.TrimSuffix(field.Name(true), "Schema")
+		o.LL("// %sAsSchema returns %q when it holds a schema rather than a", keyword, field.JSON())
+		o.L("// boolean, and false otherwise.")
+		o.L("func (s *Schema) %sAsSchema() (*Schema, bool) {", keyword)
+		o.L("v, ok := s.%s.(*Schema)", field.Name(false))
+		o.L("return v, ok && v != nil")
+		o.L("}")
+		o.LL("// %sAsBool returns %q when it holds a boolean, and false otherwise.", keyword, field.JSON())
+		o.L("func (s *Schema) %sAsBool() (bool, bool) {", keyword)
+		o.L("v, ok := s.%s.(BoolSchema)", field.Name(false))
+		o.L("return bool(v), ok")
+		o.L("}")
+	}
+
 	o.LL("func (s *Schema) ContainsType(typ PrimitiveType) bool {")
 	o.L("if s.types == nil {")
 	o.L("return false")
@@ -195,47 +230,57 @@ func genObject(obj *codegen.Object) error {
 	o.L(`Name string`)
 	o.L(`Value any`)
 	o.L(`}`)
-	o.LL(`func (s *Schema) MarshalJSON() ([]byte, error) {`)
+	o.LL(`// marshalFields lists the populated keywords of s, in no particular order,`)
+	o.L(`// for the encoder in marshal.go.`)
+	o.L(`func (s *Schema) marshalFields() []pair {`)
 	o.L(`fields := make([]pair, 0, %d)`, len(obj.Fields()))
 	for _, field := range obj.Fields() {
 		o.L(`if s.Has%s() {`, field.Name(true))
-		constName := field.Name(true)
-		switch constName {
-		case "Types":
-			constName = "Type"
-		case "IfSchema", "ThenSchema", "ElseSchema":
-			constName = strings.TrimSuffix(constName, "Schema")
-		}
-		if !isNilZeroType(field) && !isInterfaceField(field) {
+		constName := keywordConstName(field)
+		if field.Type() == "float64" {
+			o.L(`fields = append(fields, pair{Name: keywords.%s, Value: s.numberValue(keywords.%[1]s, *(s.%s))})`, constName, field.Name(false))
+		} else if !isNilZeroType(field) && !isInterfaceField(field) {
 			o.L(`fields = append(fields, pair{Name: keywords.%s, Value: *(s.%s)})`, constName, field.Name(false))
 		} else {
 			o.L(`fields = append(fields, pair{Name: keywords.%s, Value: s.%s})`, constName, field.Name(false))
 		}
 		o.L(`}`)
 	}
-	o.L(`sort.Slice(fields, func(i, j int) bool {`)
-	o.L(`return compareFieldNames(fields[i].Name, fields[j].Name)`)
-	o.L(`})`)
-	o.L(`var buf bytes.Buffer`)
-	o.L(`enc := json.NewEncoder(&buf)`)
-	o.L(`buf.WriteByte('{')`)
-	o.L(`for i, field := range fields {`)
-	o.L(`if i > 0 {`)
-	o.L(`buf.WriteByte(',')`)
-	o.L(`}`)
-	o.L(`if err := enc.Encode(field.Name); err != nil {`)
-	o.L(`return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field name: %%w", err)`)
-	o.L(`}`)
-	o.L(`buf.WriteByte(':')`)
-	o.L(`if err := enc.Encode(field.Value); err != nil {`)
-	o.L(`return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field value: %%w", err)`)
-	o.L(`}`)
+	o.L(`return fields`)
 	o.L(`}`)
-	o.L(`buf.WriteByte('}')`)
-	o.L(`return buf.Bytes(), nil`)
+
+	o.LL(`// keywordShapes maps each keyword Schema models to the shape of its value,`)
+	o.L(`// for UnmarshalStrict to find the subschemas in a document.`)
+	o.L(`var keywordShapes = map[string]keywordShape{`)
+	for _, field := range obj.Fields() {
+		shape := "shapeValue"
+		switch field.Type() {
+		case "SchemaOrBool", "*Schema":
+			shape = "shapeSchema"
+		case "[]SchemaOrBool":
+			shape = "shapeSchemaList"
+		case "map[string]*Schema", "map[string]SchemaOrBool":
+			shape = "shapeSchemaMap"
+		case "map[string]any":
+			shape = "shapeDependencies"
+		}
+		o.L(`keywords.%s: %s,`, keywordConstName(field), shape)
+	}
 	o.L(`}`)
+
+	genDeepCopyFields(o, obj)
 	o.LL(`func (s *Schema) UnmarshalJSON(buf []byte) error {`)
+	o.L("if s.frozen {")
+	o.L("return errFrozen")
+	o.L("}")
 	o.L("dec := json.NewDecoder(bytes.NewReader(buf))")
+	// Numbers in const, enum, default and examples are decoded as json.Number
+	// first, so that preserveLargeIntegers can keep integers that float64
+	// would round.
+	o.L("dec.UseNumber()")
+	// Draft-04 boolean exclusiveMinimum/exclusiveMaximum are held until the
+	// whole object is read, as they modify minimum/maximum.
+	o.L("var draft04ExclusiveMinimum, draft04ExclusiveMaximum *bool")
 	o.L("LOOP:")
 	o.L("for {")
 	o.L("tok, err := dec.Token()")
@@ -257,14 +302,7 @@ func genObject(obj *codegen.Object) error {
 		_ = field
 		switch field.Type() {
 		default:
-			constName := field.Name(true)
-			switch constName {
-			case "Types":
-				constName = "Type"
-			case "IfSchema", "ThenSchema", "ElseSchema":
-				constName = strings.TrimSuffix(constName, "Schema")
-			}
-			o.L("case keywords.%s:", constName)
+			o.L("case keywords.%s:", keywordConstName(field))
 			if field.Type() == "SchemaOrBool" {
 				// Handle single SchemaOrBool fields
 				o.L("var rawData json.RawMessage")
@@ -275,6 +313,16 @@ func genObject(obj *codegen.Object) error {
 				o.L("var b bool")
 				o.L("if err := json.Unmarshal(rawData, &b); err == nil {")
 				o.L("s.%s = BoolSchema(b)", field.Name(false))
+				if field.Name(false) == "items" {
+					// Draft 2019-09 and earlier allow "items" to be an array of
+					// schemas (tuple validation, now spelled "prefixItems").
+					o.L("} else if len(rawData) > 0 && rawData[0] == '[' {")
+					o.L("v, err := unmarshalSchemaOrBoolSlice(json.NewDecoder(bytes.NewReader(rawData)))")
+					o.L("if err != nil {")
+					o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as TupleItems): %%w`, err)", field.JSON())
+					o.L("}")
+					o.L("s.%s = TupleItems(v)", field.Name(false))
+				}
 				o.L("} else {")
 				o.L("// Try to decode as Schema object")
 				o.L("var schema Schema")
@@ -301,6 +349,15 @@ func genObject(obj *codegen.Object) error {
 				o.L("}")
 				o.L("s.%s = v", field.Name(false))
 				o.L("s.populatedFields |= %sField", field.Name(true))
+			} else if field.Type() == "map[string]any" {
+				// The only map[string]any field is draft-07 "dependencies", whose
+				// values are either property name lists or schemas
+				o.L("v, err := unmarshalDependencies(dec)")
+				o.L("if err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as dependencies): %%w`, err)", field.JSON())
+				o.L("}")
+				o.L("s.%s = v", field.Name(false))
+				o.L("s.populatedFields |= %sField", field.Name(true))
 			} else if field.Type() == "SchemaOrBool" {
 				// Special handling for SchemaOrBool fields - decode as raw JSON values
 				o.L("var v %s", field.Type())
@@ -318,15 +375,7 @@ func genObject(obj *codegen.Object) error {
 				o.L("// Try to decode as boolean first")
 				o.L("var b bool")
 				o.L("if err := json.Unmarshal(rawData, &b); err == nil {")
-				o.L("// Convert boolean to Schema object")
-				o.L("if b {")
-				o.L("s.%s = &Schema{} // true schema - allow everything", field.Name(false))
-				o.L("} else {")
-				o.L("// false schema - deny everything using \"not\": {}")
-				o.L("falseSchema := &Schema{not: &Schema{}}")
-				o.L("falseSchema.populatedFields |= NotField")
-				o.L("s.%s = falseSchema", field.Name(false))
-				o.L("}")
+				o.L("s.%s = boolSchema(b)", field.Name(false))
 				o.L("} else {")
 				o.L("// Try to decode as Schema object")
 				o.L("var schema Schema")
@@ -354,15 +403,7 @@ func genObject(obj *codegen.Object) error {
 				o.L("// Try to decode as boolean first")
 				o.L("var b bool")
 				o.L("if err := json.Unmarshal(rawValue, &b); err == nil {")
-				o.L("// Convert boolean to Schema object")
-				o.L("if b {")
-				o.L("v[key] = &Schema{} // true schema - allow everything")
-				o.L("} else {")
-				o.L("// false schema - deny everything using \"not\": {}")
-				o.L("falseSchema := &Schema{not: &Schema{}}")
-				o.L("falseSchema.populatedFields |= NotField")
-				o.L("v[key] = falseSchema")
-				o.L("}")
+				o.L("v[key] = boolSchema(b)")
 				o.L("} else {")
 				o.L("// Try to decode as Schema object")
 				o.L("var schema Schema")
@@ -375,11 +416,65 @@ func genObject(obj *codegen.Object) error {
 				o.L("}")
 				o.L("s.%s = v", field.Name(false))
 				o.L("s.populatedFields |= %sField", field.Name(true))
+			} else if field.Type() == "int" || field.Type() == "uint" {
+				// The count keywords take an integer, which JSON may also
+				// write with a zero fractional part, as in 3.0
+				o.L("var n json.Number")
+				o.L("if err := dec.Decode(&n); err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("v, err := integerValue[%s](n)", field.Type())
+				o.L("if err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("s.%s = &v", field.Name(false))
+				o.L("s.populatedFields |= %sField", field.Name(true))
+			} else if field.Type() == "float64" && field.JSON() != "exclusiveMaximum" && field.JSON() != "exclusiveMinimum" {
+				// Kept as written where float64 would round it, see number.go
+				o.L("var n json.Number")
+				o.L("if err := dec.Decode(&n); err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("v, err := s.decodeNumber(keywords.%s, n)", keywordConstName(field))
+				o.L("if err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("s.%s = &v", field.Name(false))
+				o.L("s.populatedFields |= %sField", field.Name(true))
+			} else if field.JSON() == "exclusiveMaximum" || field.JSON() == "exclusiveMinimum" {
+				o.L("var rawData json.RawMessage")
+				o.L("if err := dec.Decode(&rawData); err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode raw data for field %q: %%w`, err)", field.JSON())
+				o.L("}")
+				o.L("// Draft-04 uses a boolean that makes %q exclusive", strings.TrimPrefix(strings.ToLower(field.JSON()), "exclusive"))
+				o.L("var b bool")
+				o.L("if err := json.Unmarshal(rawData, &b); err == nil {")
+				o.L("draft04%s = &b", field.Name(true))
+				o.L("} else {")
+				o.L("var n json.Number")
+				o.L("if err := json.Unmarshal(rawData, &n); err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s or bool): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("v, err := s.decodeNumber(keywords.%s, n)", keywordConstName(field))
+				o.L("if err != nil {")
+				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s or bool): %%w`, err)", field.JSON(), field.Type())
+				o.L("}")
+				o.L("s.%s = &v", field.Name(false))
+				o.L("s.populatedFields |= %sField", field.Name(true))
+				o.L("}")
 			} else {
 				o.L("var v %s", field.Type())
 				o.L("if err := dec.Decode(&v); err != nil {")
 				o.L("return fmt.Errorf(`json-schema: failed to decode value for field %q (attempting to unmarshal as %s): %%w`, err)", field.JSON(), field.Type())
 				o.L("}")
+				switch field.Type() {
+				case "any":
+					o.L("v = preserveLargeIntegers(v)")
+				case "[]any":
+					o.L("for i, elem := range v {")
+					o.L("v[i] = preserveLargeIntegers(elem)")
+					o.L("}")
+				}
 				if !isNilZeroType(field) {
 					o.L("s.%s = &v", field.Name(false))
 				} else {
@@ -391,15 +486,19 @@ func genObject(obj *codegen.Object) error {
 	}
 	// Add default case to handle unknown fields by consuming their values
 	o.L("default:")
-	o.L("// Skip unknown fields by consuming their values")
-	o.L("var discard json.RawMessage")
-	o.L("if err := dec.Decode(&discard); err != nil {")
+	o.L("// Keep unknown fields as they are, so they survive a round trip")
+	o.L("var raw json.RawMessage")
+	o.L("if err := dec.Decode(&raw); err != nil {")
 	o.L("return fmt.Errorf(`json-schema: failed to decode unknown field %%q: %%w`, tok, err)")
 	o.L("}")
+	o.L("if s.extensions == nil {")
+	o.L("s.extensions = make(map[string]json.RawMessage)")
 	o.L("}")
+	o.L("s.extensions[tok] = raw")
 	o.L("}")
 	o.L("}")
-	o.L("return nil")
+	o.L("}")
+	o.L("return s.applyDraft04ExclusiveBounds(draft04ExclusiveMinimum, draft04ExclusiveMaximum)")
 	o.L(`}`)
 
 	if err := o.WriteFile(fn, codegen.WithFormatCode(true)); err != nil {
@@ -411,6 +510,66 @@ func genObject(obj *codegen.Object) error {
 	return nil
 }
 
+// keywordConstName returns the name of the constant in the keywords package
+// that holds the JSON name of field.
+func keywordConstName(field codegen.Field) string {
+	constName := field.Name(true)
+	switch constName {
+	case "Types":
+		constName = "Type"
+	case "IfSchema", "ThenSchema", "ElseSchema":
+		constName = strings.TrimSuffix(constName, "Schema")
+	case "RecursiveReference":
+		constName = "RecursiveRef"
+	}
+	return constName
+}
+
+// genDeepCopyFields emits deepCopyFields, which backs Schema.DeepClone. The
+// clone helpers it calls live in clone.go.
+func genDeepCopyFields(o *codegen.Output, obj *codegen.Object) {
+	o.LL(`// deepCopyFields copies every keyword of s into c, duplicating the`)
+	o.L(`// subschemas, slices and maps they hold.`)
+	o.L(`func (s *Schema) deepCopyFields(c *Schema) {`)
+	o.L(`c.populatedFields = s.populatedFields`)
+	for _, field := range obj.Fields() {
+		name := field.Name(false)
+		switch typ := field.Type(); typ {
+		case "any":
+			o.L(`c.%s = cloneValuePtr(s.%s)`, name, name)
+		case "*Schema":
+			o.L(`c.%s = s.%s.DeepClone()`, name, name)
+		case "SchemaOrBool":
+			o.L(`c.%s = cloneSchemaOrBool(s.%s)`, name, name)
+		case "[]SchemaOrBool":
+			o.L(`c.%s = cloneSchemaOrBoolSlice(s.%s)`, name, name)
+		case "[]any":
+			o.L(`c.%s = cloneValueSlice(s.%s)`, name, name)
+		case "[]string", "PrimitiveTypes":
+			o.L(`c.%s = slices.Clone(s.%s)`, name, name)
+		case "map[string]*Schema":
+			o.L(`c.%s = cloneSchemaMap(s.%s)`, name, name)
+		case "map[string]SchemaOrBool":
+			o.L(`c.%s = cloneSchemaOrBoolMap(s.%s)`, name, name)
+		case "map[string][]string":
+			o.L(`c.%s = cloneStringSliceMap(s.%s)`, name, name)
+		case "map[string]any":
+			o.L(`c.%s = cloneValueMap(s.%s)`, name, name)
+		case "map[string]bool":
+			o.L(`c.%s = maps.Clone(s.%s)`, name, name)
+		default:
+			if isNilZeroType(field) || isInterfaceField(field) {
+				panic(fmt.Sprintf(`deepCopyFields: no clone rule for field %q of type %s`, name, typ))
+			}
+			o.L(`c.%s = clonePtr(s.%s)`, name, name)
+		}
+	}
+	o.L(`c.extensions = cloneExtensions(s.extensions)`)
+	o.L(`c.numbers = maps.Clone(s.numbers)`)
+	o.L(`c.boolean = clonePtr(s.boolean)`)
+	o.L(`}`)
+}
+
 func writeComment(o *codegen.Output, comment string) {
 	scanner := bufio.NewScanner(strings.NewReader(comment))
 	for scanner.Scan() {
@@ -432,7 +591,14 @@ func genBuilder(obj *codegen.Object) error {
 	o.L("")
 	o.L("package schema")
 	o.L("")
-	o.L("import \"fmt\"")
+	o.L("import (")
+	o.L("\"encoding/json\"")
+	o.L("\"fmt\"")
+	o.L("\"maps\"")
+	o.L("\"slices\"")
+	o.L("")
+	o.L("\"github.com/lestrrat-go/json-schema/keywords\"")
+	o.L(")")
 	o.L("")
 	o.L("type propPair struct {")
 	o.L("Name   string")
@@ -465,6 +631,8 @@ func genBuilder(obj *codegen.Object) error {
 			}
 		}
 	}
+	o.L("extensions map[string]json.RawMessage")
+	o.L("numbers map[string]json.Number")
 	o.L("}")
 
 	o.LL("func NewBuilder() *Builder {")
@@ -494,6 +662,27 @@ func genBuilder(obj *codegen.Object) error {
 			o.LL(`b.%[1]s = append(b.%[1]s, &propPair{Name: n, Schema: v})`, field.Name(false))
 			o.L("return b")
 			o.L("}")
+
+			// Bulk form, for callers that already hold the map. Entries are
+			// appended in key order so that Build reports duplicates
+			// deterministically. The singular methods of the properties
+			// fields free the plural name; the definitions fields already use
+			// theirs and get a Map suffix instead.
+			bulk := field.Name(true)
+			if bulk == name {
+				bulk += `Map`
+			}
+			o.LL("// %s adds every entry of v, as %s does, in key order.", bulk, name)
+			o.L("// Build reports an error for a name that was added more than once.")
+			o.L("func (b *Builder) %s(v map[string]*Schema) *Builder {", bulk)
+			o.L("if b.err != nil {")
+			o.L("return b")
+			o.L("}")
+			o.LL("for _, n := range slices.Sorted(maps.Keys(v)) {")
+			o.L("b.%[1]s = append(b.%[1]s, &propPair{Name: n, Schema: v[n]})", field.Name(false))
+			o.L("}")
+			o.L("return b")
+			o.L("}")
 		default:
 			if isVariadicSliceType(field) {
 				elementType := getVariadicElementType(field)
@@ -540,6 +729,9 @@ func genBuilder(obj *codegen.Object) error {
 				} else {
 					o.LL("b.%s = v", field.Name(false))
 				}
+				if field.Type() == "float64" {
+					o.L("delete(b.numbers, keywords.%s)", keywordConstName(field))
+				}
 				o.L("return b")
 				o.L("}")
 			}
@@ -570,6 +762,9 @@ func genBuilder(obj *codegen.Object) error {
 			if !isNilZeroType(field) && !isInterfaceField(field) {
 				// For pointer fields, can assign the pointer directly
 				o.L("b.%s = original.%s", field.Name(false), field.Name(false))
+				if field.Type() == "float64" {
+					o.L("b.setNumber(keywords.%s, original.numbers[keywords.%[1]s])", keywordConstName(field))
+				}
 			} else {
 				// For slice/map/interface fields, can assign directly
 				o.L("b.%s = original.%s", field.Name(false), field.Name(false))
@@ -578,6 +773,13 @@ func genBuilder(obj *codegen.Object) error {
 		}
 	}
 
+	o.LL("if original.extensions != nil {")
+	o.L("if b.extensions == nil {")
+	o.L("b.extensions = make(map[string]json.RawMessage)")
+	o.L("}")
+	o.L("maps.Copy(b.extensions, original.extensions)")
+	o.L("}")
+
 	o.L("return b")
 	o.L("}")
 
@@ -590,6 +792,9 @@ func genBuilder(obj *codegen.Object) error {
 		o.L("}")
 
 		o.L("b.%s = nil", field.Name(false))
+		if field.Type() == "float64" {
+			o.L("delete(b.numbers, keywords.%s)", keywordConstName(field))
+		}
 
 		o.L("return b")
 		o.L("}")
@@ -606,12 +811,18 @@ func genBuilder(obj *codegen.Object) error {
 	for _, field := range obj.Fields() {
 		o.L("if (flags & %sField) != 0 {", field.Name(true))
 		o.L("b.%s = nil", field.Name(false))
+		if field.Type() == "float64" {
+			o.L("delete(b.numbers, keywords.%s)", keywordConstName(field))
+		}
 		o.L("}")
 	}
 	o.L("return b")
 	o.L("}")
 
-	o.LL("func (b *Builder) Build() (*Schema, error) {")
+	o.LL("// Build returns the schema. It fails with the first error that a setter")
+	o.L("// recorded, and with an error for bounds that no value can satisfy (see")
+	o.L("// WithBoundsCheck).")
+	o.L("func (b *Builder) Build(options ...BuildOption) (*Schema, error) {")
 	o.L("if b.err != nil {")
 	o.L("return nil, b.err")
 	o.L("}")
@@ -636,11 +847,20 @@ func genBuilder(obj *codegen.Object) error {
 			o.L(`}`)
 		}
 	}
+	o.L("if len(b.extensions) > 0 {")
+	o.L("s.extensions = maps.Clone(b.extensions)")
+	o.L("}")
+	o.L("if len(b.numbers) > 0 {")
+	o.L("s.numbers = maps.Clone(b.numbers)")
+	o.L("}")
+	o.L("if err := checkBuild(s, options); err != nil {")
+	o.L("return nil, err")
+	o.L("}")
 	o.L("return s, nil")
 	o.L("}")
 
-	o.LL("func (b *Builder) MustBuild() *Schema {")
-	o.L("s, err := b.Build()")
+	o.LL("func (b *Builder) MustBuild(options ...BuildOption) *Schema {")
+	o.L("s, err := b.Build(options...)")
 	o.L("if err != nil {")
 	o.L("panic(fmt.Errorf(`failed to build schema: %%w`, err))")
 	o.L("}")
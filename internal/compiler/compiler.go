@@ -0,0 +1,12 @@
+// Package compiler connects Schema.Validate to the validator package. The
+// validator package imports the schema package, so the schema package cannot
+// call it directly; instead the validator package installs Compile when it is
+// initialized.
+package compiler
+
+import "context"
+
+// Compile compiles s, a *schema.Schema, with default options and returns a
+// function validating a value against it. It is nil until the validator
+// package is initialized.
+var Compile func(ctx context.Context, s any) (func(context.Context, any) error, error)
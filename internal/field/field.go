@@ -17,18 +17,23 @@ const (
 	ContentSchema
 	Default
 	Definitions
+	Deprecated
+	Dependencies
 	DependentRequired
 	DependentSchemas
+	Description
 	DynamicAnchor
 	DynamicReference
 	ElseSchema
 	Enum
+	Examples
 	ExclusiveMaximum
 	ExclusiveMinimum
 	Format
 	ID
 	IfSchema
 	Items
+	LegacyDefinitions
 	MaxContains
 	MaxItems
 	MaxLength
@@ -47,13 +52,18 @@ const (
 	PrefixItems
 	Properties
 	PropertyNames
+	ReadOnly
+	RecursiveAnchor
+	RecursiveReference
 	Reference
 	Required
 	Schema
 	ThenSchema
+	Title
 	Types
 	UnevaluatedItems
 	UnevaluatedProperties
 	UniqueItems
 	Vocabulary
+	WriteOnly
 )
@@ -69,14 +69,27 @@ const (
 	// Legacy keywords for backward compatibility.
 	// These keywords were deprecated in JSON Schema 2020-12 but may still appear in older schemas.
 
-	RecursiveAnchor = "$recursiveAnchor" // Deprecated: use $dynamicAnchor instead
-	RecursiveRef    = "$recursiveRef"    // Deprecated: use $dynamicRef instead
+	Dependencies      = "dependencies"     // Deprecated: use dependentRequired/dependentSchemas instead
+	LegacyDefinitions = "definitions"      // Deprecated: use $defs instead
+	RecursiveAnchor   = "$recursiveAnchor" // Deprecated: use $dynamicAnchor instead
+	RecursiveRef      = "$recursiveRef"    // Deprecated: use $dynamicRef instead
 
 	// Format constants for string validation
 
-	FormatEmail    = "email"
-	FormatDate     = "date"
-	FormatDateTime = "date-time"
-	FormatURI      = "uri"
-	FormatUUID     = "uuid"
+	FormatEmail               = "email"
+	FormatDate                = "date"
+	FormatDateTime            = "date-time"
+	FormatDuration            = "duration"
+	FormatHostname            = "hostname"
+	FormatIPv4                = "ipv4"
+	FormatIPv6                = "ipv6"
+	FormatIRI                 = "iri"
+	FormatIRIReference        = "iri-reference"
+	FormatJSONPointer         = "json-pointer"
+	FormatRegex               = "regex"
+	FormatRelativeJSONPointer = "relative-json-pointer"
+	FormatTime                = "time"
+	FormatURI                 = "uri"
+	FormatURIReference        = "uri-reference"
+	FormatUUID                = "uuid"
 )
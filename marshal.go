@@ -0,0 +1,299 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lestrrat-go/json-schema/keywords"
+	"github.com/lestrrat-go/option/v3"
+)
+
+// KeywordOrder selects how MarshalJSONWith orders the keywords of a schema.
+type KeywordOrder int
+
+const (
+	// KeywordOrderAlphabetical sorts keywords by name, with "$"-prefixed
+	// keywords such as "$id" and "$schema" first. This is what MarshalJSON
+	// produces.
+	KeywordOrderAlphabetical KeywordOrder = iota
+	// KeywordOrderCanonical follows the layout commonly used for hand-written
+	// schemas: identification ($schema, $id, anchors), then annotations
+	// (title, description, default, ...), references, type and value
+	// constraints, the applicators, and finally $defs.
+	KeywordOrderCanonical
+)
+
+// canonicalKeywordOrder is the keyword sequence of KeywordOrderCanonical.
+// Keywords not listed here follow, alphabetically.
+var canonicalKeywordOrder = []string{
+	keywords.Schema,
+	keywords.ID,
+	keywords.Vocabulary,
+	keywords.Anchor,
+	keywords.DynamicAnchor,
+	keywords.RecursiveAnchor,
+	keywords.Comment,
+	keywords.Title,
+	keywords.Description,
+	keywords.Default,
+	keywords.Examples,
+	keywords.Deprecated,
+	keywords.ReadOnly,
+	keywords.WriteOnly,
+	keywords.Reference,
+	keywords.DynamicReference,
+	keywords.RecursiveRef,
+	keywords.Type,
+	keywords.Enum,
+	keywords.Const,
+	keywords.Format,
+	keywords.MinLength,
+	keywords.MaxLength,
+	keywords.Pattern,
+	keywords.ContentEncoding,
+	keywords.ContentMediaType,
+	keywords.ContentSchema,
+	keywords.MultipleOf,
+	keywords.Minimum,
+	keywords.ExclusiveMinimum,
+	keywords.Maximum,
+	keywords.ExclusiveMaximum,
+	keywords.Required,
+	keywords.MinProperties,
+	keywords.MaxProperties,
+	keywords.DependentRequired,
+	keywords.Properties,
+	keywords.PatternProperties,
+	keywords.AdditionalProperties,
+	keywords.PropertyNames,
+	keywords.DependentSchemas,
+	keywords.Dependencies,
+	keywords.UnevaluatedProperties,
+	keywords.MinItems,
+	keywords.MaxItems,
+	keywords.UniqueItems,
+	keywords.PrefixItems,
+	keywords.Items,
+	keywords.AdditionalItems,
+	keywords.Contains,
+	keywords.MinContains,
+	keywords.MaxContains,
+	keywords.UnevaluatedItems,
+	keywords.AllOf,
+	keywords.AnyOf,
+	keywords.OneOf,
+	keywords.Not,
+	keywords.If,
+	keywords.Then,
+	keywords.Else,
+	keywords.Definitions,
+	keywords.LegacyDefinitions,
+}
+
+// MarshalOption configures MarshalJSONWith.
+type MarshalOption interface {
+	option.Interface
+	marshalOption()
+}
+
+type marshalOption struct{ option.Interface }
+
+func (marshalOption) marshalOption() {}
+
+type identKeyOrder struct{}
+type identCustomKeyOrder struct{}
+
+// WithKeyOrder selects one of the predefined keyword orders. The default is
+// KeywordOrderAlphabetical.
+func WithKeyOrder(o KeywordOrder) MarshalOption {
+	return marshalOption{option.New(identKeyOrder{}, o)}
+}
+
+// WithCustomKeyOrder emits the given keywords first, in the given order, and
+// every other keyword after them, alphabetically. It overrides WithKeyOrder.
+func WithCustomKeyOrder(names ...string) MarshalOption {
+	return marshalOption{option.New(identCustomKeyOrder{}, slices.Clone(names))}
+}
+
+// MarshalJSON encodes s with its keywords in KeywordOrderAlphabetical order.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return s.marshalJSON(compareFieldNames)
+}
+
+// MarshalJSONIndent encodes s like MarshalJSON, keeping its keyword order,
+// with each element on a new line that begins with prefix followed by one or
+// more copies of indent, as json.MarshalIndent does.
+func (s *Schema) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	buf, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf, prefix, indent); err != nil {
+		return nil, fmt.Errorf("json-schema: Schema.MarshalJSONIndent: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// MarshalJSONWith encodes s like MarshalJSON, with the keyword order chosen by
+// options. The order applies to s and to every subschema nested in it. Keys
+// that are not keywords, such as property names under "properties", are
+// always sorted.
+func (s *Schema) MarshalJSONWith(options ...MarshalOption) ([]byte, error) {
+	order := KeywordOrderAlphabetical
+	var custom []string
+	for _, o := range options {
+		switch o.Ident() {
+		case identKeyOrder{}:
+			order = option.MustGet[KeywordOrder](o)
+		case identCustomKeyOrder{}:
+			custom = option.MustGet[[]string](o)
+		}
+	}
+
+	switch {
+	case custom != nil:
+		return s.marshalJSON(rankedFieldNames(custom))
+	case order == KeywordOrderCanonical:
+		return s.marshalJSON(rankedFieldNames(canonicalKeywordOrder))
+	default:
+		return s.marshalJSON(compareFieldNames)
+	}
+}
+
+// rankedFieldNames returns a comparator that puts the names in ranking first,
+// in that order, and sorts the remaining names with compareFieldNames.
+func rankedFieldNames(ranking []string) func(a, b string) bool {
+	ranks := make(map[string]int, len(ranking))
+	for i, name := range ranking {
+		if _, ok := ranks[name]; !ok {
+			ranks[name] = i
+		}
+	}
+	return func(a, b string) bool {
+		ra, okA := ranks[a]
+		rb, okB := ranks[b]
+		switch {
+		case okA && okB:
+			return ra < rb
+		case okA != okB:
+			return okA
+		default:
+			return compareFieldNames(a, b)
+		}
+	}
+}
+
+// boolSchema returns the Schema that a boolean subschema decodes to where a
+// *Schema is expected, as under "properties": an empty schema for true, and
+// {"not": {}} for false. The schema remembers b, so that it is written back
+// as the boolean it came from for as long as it keeps that shape.
+func boolSchema(b bool) *Schema {
+	s := &Schema{boolean: &b}
+	if !b {
+		s.not = &Schema{}
+		s.populatedFields |= NotField
+	}
+	return s
+}
+
+// booleanForm reports the boolean s was decoded from, if s still means just
+// that boolean.
+func (s *Schema) booleanForm() (bool, bool) {
+	if s.boolean == nil || len(s.extensions) > 0 {
+		return false, false
+	}
+	if *s.boolean {
+		return true, s.populatedFields == 0
+	}
+	return false, s.populatedFields == NotField && s.not.populatedFields == 0 && len(s.not.extensions) == 0
+}
+
+func (s *Schema) marshalJSON(less func(a, b string) bool) ([]byte, error) {
+	if b, ok := s.booleanForm(); ok {
+		return json.Marshal(b)
+	}
+	fields := s.extensionFields(s.marshalFields())
+	slices.SortFunc(fields, func(a, b pair) int {
+		switch {
+		case less(a.Name, b.Name):
+			return -1
+		case less(b.Name, a.Name):
+			return 1
+		default:
+			return strings.Compare(a.Name, b.Name)
+		}
+	})
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(field.Name); err != nil {
+			return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field name: %w", err)
+		}
+		buf.WriteByte(':')
+		if err := enc.Encode(orderedValue(field.Value, less)); err != nil {
+			return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field value: %w", err)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// orderedSchema marshals a nested schema with the keyword order of its parent.
+type orderedSchema struct {
+	schema *Schema
+	less   func(a, b string) bool
+}
+
+func (o orderedSchema) MarshalJSON() ([]byte, error) {
+	return o.schema.marshalJSON(o.less)
+}
+
+// orderedValue wraps the subschemas held by a keyword value so they are
+// encoded with less as well. Values that hold no subschema are returned as-is.
+func orderedValue(v any, less func(a, b string) bool) any {
+	switch val := v.(type) {
+	case *Schema:
+		if val == nil {
+			return val
+		}
+		return orderedSchema{schema: val, less: less}
+	case []SchemaOrBool:
+		return orderedSlice(val, less)
+	case TupleItems:
+		return orderedSlice(val, less)
+	case map[string]*Schema:
+		return orderedMap(val, less)
+	case map[string]SchemaOrBool:
+		return orderedMap(val, less)
+	case map[string]any:
+		// dependencies: schema values among property-name arrays
+		return orderedMap(val, less)
+	default:
+		return v
+	}
+}
+
+func orderedSlice[T any](list []T, less func(a, b string) bool) []any {
+	out := make([]any, len(list))
+	for i, elem := range list {
+		out[i] = orderedValue(elem, less)
+	}
+	return out
+}
+
+func orderedMap[T any](m map[string]T, less func(a, b string) bool) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, elem := range m {
+		out[k] = orderedValue(elem, less)
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSONWith(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"properties": {"b": {"type": "string", "minLength": 1, "$comment": "x"}, "a": {}},
+		"type": "object",
+		"default": {},
+		"$id": "https://example.com/thing.json",
+		"$defs": {"n": {"type": "number", "minimum": 0}},
+		"$schema": "https://json-schema.org/draft/2020-12/schema"
+	}`)
+
+	testCases := []struct {
+		name    string
+		options []schema.MarshalOption
+		want    string
+	}{
+		{
+			name: "default is alphabetical",
+			want: `{"$defs":{"n":{"minimum":0,"type":"number"}},"$id":"https://example.com/thing.json","$schema":"https://json-schema.org/draft/2020-12/schema","default":{},"properties":{"a":{},"b":{"$comment":"x","minLength":1,"type":"string"}},"type":"object"}`,
+		},
+		{
+			name:    "canonical",
+			options: []schema.MarshalOption{schema.WithKeyOrder(schema.KeywordOrderCanonical)},
+			want:    `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"https://example.com/thing.json","default":{},"type":"object","properties":{"a":{},"b":{"$comment":"x","type":"string","minLength":1}},"$defs":{"n":{"type":"number","minimum":0}}}`,
+		},
+		{
+			name:    "custom",
+			options: []schema.MarshalOption{schema.WithCustomKeyOrder("type", "default")},
+			want:    `{"type":"object","default":{},"$defs":{"n":{"type":"number","minimum":0}},"$id":"https://example.com/thing.json","$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"a":{},"b":{"type":"string","$comment":"x","minLength":1}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf, err := s.MarshalJSONWith(tc.options...)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, compact(t, buf))
+		})
+	}
+
+	t.Run("MarshalJSON matches the default", func(t *testing.T) {
+		a, err := json.Marshal(s)
+		require.NoError(t, err)
+		b, err := s.MarshalJSONWith()
+		require.NoError(t, err)
+		require.Equal(t, compact(t, a), compact(t, b))
+	})
+}
+
+func TestMarshalJSONIndent(t *testing.T) {
+	s := mustParseSchema(t, `{"type": "object", "properties": {"b": {"type": "string"}, "a": {"minimum": 0}}, "$id": "https://example.com/x"}`)
+	buf, err := s.MarshalJSONIndent("", "  ")
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "$id": "https://example.com/x",
+  "properties": {
+    "a": {
+      "minimum": 0
+    },
+    "b": {
+      "type": "string"
+    }
+  },
+  "type": "object"
+}`, string(buf))
+
+	plain, err := s.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, compact(t, plain), compact(t, buf))
+
+	buf, err = s.MarshalJSONIndent("// ", "\t")
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "\n// \t\"properties\": {\n// \t\t\"a\": {")
+}
+
+func compact(t *testing.T, buf []byte) string {
+	t.Helper()
+	var v json.RawMessage
+	require.NoError(t, json.Unmarshal(buf, &v))
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestUnmarshalLargeIntegers(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"const": 18446744073709551615,
+		"enum": [1.5, 9007199254740993, {"id": 9223372036854775808}],
+		"default": 42
+	}`)
+	require.Equal(t, json.Number("18446744073709551615"), s.Const())
+	require.Equal(t, []any{1.5, json.Number("9007199254740993"), map[string]any{"id": json.Number("9223372036854775808")}}, s.Enum())
+	require.Equal(t, float64(42), s.Default(), "numbers float64 holds exactly decode as usual")
+
+	buf, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `"const":18446744073709551615`)
+}
+
+func TestUnmarshalExactNumericKeywords(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"maximum": 18446744073709551615,
+		"minimum": 0.1,
+		"multipleOf": 0.30000000000000001
+	}`)
+	require.Equal(t, float64(1<<64), s.Maximum(), "the float64 accessor rounds")
+	require.Equal(t, json.Number("18446744073709551615"), s.MaximumNumber())
+	require.Equal(t, json.Number("0.1"), s.MinimumNumber(), "float64 stands for 0.1 exactly enough")
+	require.Equal(t, json.Number("0.30000000000000001"), s.MultipleOfNumber())
+
+	buf, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"maximum":18446744073709551615,"minimum":0.1,"multipleOf":0.30000000000000001}`, string(buf))
+	require.Equal(t, json.Number("18446744073709551615"), s.DeepClone().MaximumNumber())
+
+	t.Run("builder", func(t *testing.T) {
+		kept, err := schema.NewBuilder().Clone(s).Build()
+		require.NoError(t, err)
+		require.Equal(t, json.Number("18446744073709551615"), kept.MaximumNumber())
+
+		set, err := schema.NewBuilder().Clone(s).Maximum(float64(1 << 64)).Build()
+		require.NoError(t, err)
+		require.Equal(t, json.Number("1.8446744073709552e+19"), set.MaximumNumber(), "setting the keyword drops the literal")
+
+		reset, err := schema.NewBuilder().Clone(s).ResetMaximum().Maximum(5).Build()
+		require.NoError(t, err)
+		require.Equal(t, json.Number("5"), reset.MaximumNumber())
+	})
+
+	t.Run("draft-04 exclusive bounds", func(t *testing.T) {
+		s := mustParseSchema(t, `{"maximum": 18446744073709551615, "exclusiveMaximum": true}`)
+		require.False(t, s.HasMaximum())
+		require.Equal(t, json.Number("18446744073709551615"), s.ExclusiveMaximumNumber())
+	})
+}
@@ -0,0 +1,317 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Keyword groups whose members only mean something together: "additionalProperties"
+// depends on the names matched by "properties" and "patternProperties", "items"
+// on the length of "prefixItems", and so on. Merge flattens such a group only
+// when the two schemas agree on it, or when merging it cannot change which
+// values the group accepts.
+const (
+	mergeObjectGroup      = PropertiesField | PatternPropertiesField | AdditionalPropertiesField
+	mergeArrayGroup       = PrefixItemsField | ItemsField | AdditionalItemsField
+	mergeContainsGroup    = ContainsField | MinContainsField | MaxContainsField
+	mergeConditionalGroup = IfSchemaField | ThenSchemaField | ElseSchemaField
+)
+
+// Merge combines a and b into a single schema that accepts exactly the values
+// accepted by both, as {"allOf": [a, b]} would, but with the keywords of the
+// two schemas flattened into one where that is safe:
+//
+//   - "required" lists are united, as are "allOf" lists and "dependentRequired"
+//   - the tighter of two bounds is kept for "minimum", "maximum", "minLength",
+//     "maxItems" and the other numeric limits
+//   - "type" and "enum" are intersected
+//   - "properties", "patternProperties" and "dependentSchemas" are merged entry
+//     by entry, recursively
+//   - "$defs" and "definitions" are united
+//
+// Keywords that both schemas set to the same value are kept once. Where a
+// keyword cannot be combined without changing its meaning, for example two
+// different "pattern"s, or "additionalProperties" next to properties from the
+// other schema, each schema's version is moved into its own "allOf" entry of
+// the result. A schema using "unevaluatedProperties" or "unevaluatedItems" is
+// never flattened, as those keywords depend on every sibling keyword: the
+// result is then simply {"allOf": [a, b]}.
+//
+// An error is returned when the schemas genuinely contradict each other: "type"
+// or "enum" with no value in common, different "const" values, or two
+// different definitions under the same "$defs" name.
+//
+// Unknown keywords (see Schema.Extensions) of both schemas are kept on the
+// result; where both set the same one, b's value wins.
+//
+// Neither a nor b is modified. The result may share subschemas with them. If
+// either one is nil, the other is returned.
+func Merge(a, b *Schema) (*Schema, error) {
+	switch {
+	case a == nil:
+		return b, nil
+	case b == nil:
+		return a, nil
+	}
+	if a.HasAny(UnevaluatedPropertiesField|UnevaluatedItemsField) || b.HasAny(UnevaluatedPropertiesField|UnevaluatedItemsField) {
+		return NewBuilder().AllOf(a, b).Build()
+	}
+
+	shared := a.populatedFields & b.populatedFields
+	builder := NewBuilder().Clone(a).Clone(b).Reset(shared)
+
+	// leftovers are the shared keywords that stay with their own schema, in
+	// an allOf entry.
+	var leftovers FieldFlag
+
+	for _, group := range []FieldFlag{mergeObjectGroup, mergeArrayGroup, mergeContainsGroup, mergeConditionalGroup} {
+		if !a.HasAny(group) || !b.HasAny(group) {
+			continue
+		}
+		// From here on the group's keywords are handled as a unit; the ones
+		// set on only one side were copied above and must be reset too.
+		builder.Reset(group)
+		switch {
+		case sameFields(a, b, group):
+			builder.Clone(onlyFields(a, group))
+		case group == mergeObjectGroup && !a.HasAdditionalProperties() && !b.HasAdditionalProperties():
+			if err := mergeObjectKeywords(builder, a, b); err != nil {
+				return nil, err
+			}
+		default:
+			leftovers |= group
+		}
+		shared &^= group
+	}
+
+	for flag := FieldFlag(1); flag != 0 && flag <= shared; flag <<= 1 {
+		if shared&flag == 0 {
+			continue
+		}
+		if sameFields(a, b, flag) {
+			builder.Clone(onlyFields(a, flag))
+			continue
+		}
+		ok, err := mergeKeyword(builder, a, b, flag)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			leftovers |= flag
+		}
+	}
+
+	if leftovers != 0 {
+		allOf := slices.Concat(a.AllOf(), b.AllOf())
+		if shared&AllOfField != 0 {
+			if sameFields(a, b, AllOfField) {
+				allOf = slices.Clone(a.AllOf())
+			}
+		}
+		builder.AllOf(append(allOf, onlyFields(a, leftovers), onlyFields(b, leftovers))...)
+	}
+	return builder.Build(WithBoundsCheck(false))
+}
+
+// mergeKeyword combines the differing values a and b have for the keyword
+// flag into builder. It reports false when the keyword cannot be flattened.
+func mergeKeyword(builder *Builder, a, b *Schema, flag FieldFlag) (bool, error) {
+	switch flag {
+	case RequiredField:
+		required := slices.Clone(a.Required())
+		for _, name := range b.Required() {
+			if !slices.Contains(required, name) {
+				required = append(required, name)
+			}
+		}
+		builder.Required(required...)
+	case AllOfField:
+		builder.AllOf(slices.Concat(a.AllOf(), b.AllOf())...)
+	case TypesField:
+		types := intersectTypes(a.Types(), b.Types())
+		if len(types) == 0 {
+			return false, fmt.Errorf("json-schema: Merge: conflicting type: %v and %v have no type in common", a.Types(), b.Types())
+		}
+		builder.Types(types...)
+	case ConstField:
+		return false, fmt.Errorf("json-schema: Merge: conflicting const: %v and %v", a.Const(), b.Const())
+	case EnumField:
+		var values []any
+		for _, v := range a.Enum() {
+			if slices.ContainsFunc(b.Enum(), func(w any) bool { return reflect.DeepEqual(v, w) }) {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return false, fmt.Errorf("json-schema: Merge: conflicting enum: %v and %v have no value in common", a.Enum(), b.Enum())
+		}
+		builder.Enum(values...)
+	case MinimumField:
+		builder.Minimum(max(a.Minimum(), b.Minimum()))
+	case ExclusiveMinimumField:
+		builder.ExclusiveMinimum(max(a.ExclusiveMinimum(), b.ExclusiveMinimum()))
+	case MaximumField:
+		builder.Maximum(min(a.Maximum(), b.Maximum()))
+	case ExclusiveMaximumField:
+		builder.ExclusiveMaximum(min(a.ExclusiveMaximum(), b.ExclusiveMaximum()))
+	case MinLengthField:
+		builder.MinLength(max(a.MinLength(), b.MinLength()))
+	case MaxLengthField:
+		builder.MaxLength(min(a.MaxLength(), b.MaxLength()))
+	case MinItemsField:
+		builder.MinItems(max(a.MinItems(), b.MinItems()))
+	case MaxItemsField:
+		builder.MaxItems(min(a.MaxItems(), b.MaxItems()))
+	case MinPropertiesField:
+		builder.MinProperties(max(a.MinProperties(), b.MinProperties()))
+	case MaxPropertiesField:
+		builder.MaxProperties(min(a.MaxProperties(), b.MaxProperties()))
+	case UniqueItemsField:
+		builder.UniqueItems(a.UniqueItems() || b.UniqueItems())
+	case MultipleOfField:
+		// A multiple of the larger factor is a multiple of both only if the
+		// larger is itself a multiple of the smaller.
+		lo, hi := min(a.MultipleOf(), b.MultipleOf()), max(a.MultipleOf(), b.MultipleOf())
+		if ratio := hi / lo; ratio != math.Trunc(ratio) {
+			return false, nil
+		}
+		builder.MultipleOf(hi)
+	case DependentRequiredField:
+		merged := maps.Clone(a.DependentRequired())
+		for name, deps := range b.DependentRequired() {
+			for _, dep := range deps {
+				if !slices.Contains(merged[name], dep) {
+					merged[name] = append(slices.Clip(merged[name]), dep)
+				}
+			}
+		}
+		builder.DependentRequired(merged)
+	case DependentSchemasField:
+		merged := maps.Clone(a.DependentSchemas())
+		for name, sb := range b.DependentSchemas() {
+			sa, ok := merged[name]
+			if !ok {
+				merged[name] = sb
+				continue
+			}
+			subA, okA := sa.(*Schema)
+			subB, okB := sb.(*Schema)
+			if !okA || !okB {
+				return false, nil
+			}
+			sub, err := Merge(subA, subB)
+			if err != nil {
+				return false, fmt.Errorf("json-schema: Merge: dependentSchemas %q: %w", name, err)
+			}
+			merged[name] = sub
+		}
+		builder.DependentSchemas(merged)
+	case DefinitionsField, LegacyDefinitionsField:
+		return true, mergeDefinitions(builder, a, b, flag)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// mergeObjectKeywords merges "properties" and "patternProperties" entry by
+// entry. Without "additionalProperties" on either side, the names matched by
+// one schema do not affect the other, so this is lossless.
+func mergeObjectKeywords(builder *Builder, a, b *Schema) error {
+	for name, prop := range mergeSchemaMaps(a.Properties(), b.Properties()) {
+		if prop.err != nil {
+			return fmt.Errorf("json-schema: Merge: property %q: %w", name, prop.err)
+		}
+		builder.Property(name, prop.schema)
+	}
+	for pattern, prop := range mergeSchemaMaps(a.PatternProperties(), b.PatternProperties()) {
+		if prop.err != nil {
+			return fmt.Errorf("json-schema: Merge: pattern property %q: %w", pattern, prop.err)
+		}
+		builder.PatternProperty(pattern, prop.schema)
+	}
+	return nil
+}
+
+type mergedSchema struct {
+	schema *Schema
+	err    error
+}
+
+// mergeSchemaMaps unites two keyword maps, merging the entries present in
+// both.
+func mergeSchemaMaps(a, b map[string]*Schema) map[string]mergedSchema {
+	out := make(map[string]mergedSchema, len(a)+len(b))
+	for name, s := range a {
+		out[name] = mergedSchema{schema: s}
+	}
+	for name, s := range b {
+		if prev, ok := out[name]; ok {
+			s, err := Merge(prev.schema, s)
+			out[name] = mergedSchema{schema: s, err: err}
+			continue
+		}
+		out[name] = mergedSchema{schema: s}
+	}
+	return out
+}
+
+func mergeDefinitions(builder *Builder, a, b *Schema, flag FieldFlag) error {
+	defsA, defsB := a.Definitions(), b.Definitions()
+	add := builder.Definitions
+	if flag == LegacyDefinitionsField {
+		defsA, defsB = a.LegacyDefinitions(), b.LegacyDefinitions()
+		add = builder.LegacyDefinitions
+	}
+	for _, name := range slices.Sorted(maps.Keys(defsA)) {
+		add(name, defsA[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(defsB)) {
+		def := defsB[name]
+		if prev, ok := defsA[name]; ok {
+			if !prev.Equal(def) {
+				return fmt.Errorf("json-schema: Merge: conflicting definitions for %q", name)
+			}
+			continue
+		}
+		add(name, def)
+	}
+	return nil
+}
+
+// intersectTypes returns the types in both a and b, in the order of a. An
+// "integer" on one side satisfies a "number" on the other.
+func intersectTypes(a, b PrimitiveTypes) PrimitiveTypes {
+	var out PrimitiveTypes
+	add := func(t PrimitiveType) {
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	for _, t := range a {
+		switch {
+		case slices.Contains(b, t):
+			add(t)
+		case t == NumberType && slices.Contains(b, IntegerType):
+			add(IntegerType)
+		case t == IntegerType && slices.Contains(b, NumberType):
+			add(IntegerType)
+		}
+	}
+	return out
+}
+
+// onlyFields returns a copy of s restricted to the keywords in flags, without
+// its extensions.
+func onlyFields(s *Schema, flags FieldFlag) *Schema {
+	return NewBuilder().Clone(s).Reset(^flags).ResetExtensions().MustBuild(WithBoundsCheck(false))
+}
+
+// sameFields reports whether a and b set the keywords in flags to the same
+// values.
+func sameFields(a, b *Schema, flags FieldFlag) bool {
+	return onlyFields(a, flags).Equal(onlyFields(b, flags))
+}
@@ -0,0 +1,149 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "disjoint keywords are combined",
+			a:    `{"type": "string"}`,
+			b:    `{"minLength": 1}`,
+			want: `{"type": "string", "minLength": 1}`,
+		},
+		{
+			name: "required is united",
+			a:    `{"required": ["a", "b"]}`,
+			b:    `{"required": ["b", "c"]}`,
+			want: `{"required": ["a", "b", "c"]}`,
+		},
+		{
+			name: "tighter bounds win",
+			a:    `{"minimum": 0, "maximum": 100, "maxLength": 10}`,
+			b:    `{"minimum": 5, "maximum": 200, "maxLength": 3}`,
+			want: `{"minimum": 5, "maximum": 100, "maxLength": 3}`,
+		},
+		{
+			name: "type is intersected",
+			a:    `{"type": ["string", "number", "null"]}`,
+			b:    `{"type": ["integer", "string"]}`,
+			want: `{"type": ["string", "integer"]}`,
+		},
+		{
+			name: "enum is intersected",
+			a:    `{"enum": ["a", "b", 1]}`,
+			b:    `{"enum": [1, "b", "c"]}`,
+			want: `{"enum": ["b", 1]}`,
+		},
+		{
+			name: "properties are merged recursively",
+			a:    `{"properties": {"name": {"type": "string"}, "id": {"type": "integer"}}}`,
+			b:    `{"properties": {"name": {"minLength": 1}, "tags": {"type": "array"}}}`,
+			want: `{"properties": {"id": {"type": "integer"}, "name": {"minLength": 1, "type": "string"}, "tags": {"type": "array"}}}`,
+		},
+		{
+			name: "multipleOf keeps a common multiple",
+			a:    `{"multipleOf": 2}`,
+			b:    `{"multipleOf": 6}`,
+			want: `{"multipleOf": 6}`,
+		},
+		{
+			name: "identical keywords are kept once",
+			a:    `{"pattern": "^a", "format": "email"}`,
+			b:    `{"pattern": "^a"}`,
+			want: `{"pattern": "^a", "format": "email"}`,
+		},
+		{
+			name: "conflicting patterns fall back to allOf",
+			a:    `{"type": "string", "pattern": "^a"}`,
+			b:    `{"pattern": "z$"}`,
+			want: `{"type": "string", "allOf": [{"pattern": "^a"}, {"pattern": "z$"}]}`,
+		},
+		{
+			name: "additionalProperties keeps the object keywords apart",
+			a:    `{"properties": {"a": {}}, "additionalProperties": false}`,
+			b:    `{"properties": {"b": {}}}`,
+			want: `{"allOf": [{"properties": {"a": {}}, "additionalProperties": false}, {"properties": {"b": {}}}]}`,
+		},
+		{
+			name: "unevaluatedProperties is never flattened",
+			a:    `{"type": "object", "unevaluatedProperties": false}`,
+			b:    `{"required": ["a"]}`,
+			want: `{"allOf": [{"type": "object", "unevaluatedProperties": false}, {"required": ["a"]}]}`,
+		},
+		{
+			name: "$defs are united",
+			a:    `{"$defs": {"a": {"type": "string"}}}`,
+			b:    `{"$defs": {"a": {"type": "string"}, "b": {"type": "number"}}}`,
+			want: `{"$defs": {"a": {"type": "string"}, "b": {"type": "number"}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, err := schema.Merge(mustParseSchema(t, tc.a), mustParseSchema(t, tc.b))
+			require.NoError(t, err)
+			buf, err := json.Marshal(merged)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(buf))
+		})
+	}
+
+	t.Run("contradictions are errors", func(t *testing.T) {
+		for _, pair := range [][2]string{
+			{`{"type": "string"}`, `{"type": "number"}`},
+			{`{"const": 1}`, `{"const": 2}`},
+			{`{"enum": [1, 2]}`, `{"enum": [3]}`},
+			{`{"properties": {"a": {"type": "string"}}}`, `{"properties": {"a": {"type": "object"}}}`},
+			{`{"$defs": {"a": {"type": "string"}}}`, `{"$defs": {"a": {"type": "number"}}}`},
+		} {
+			_, err := schema.Merge(mustParseSchema(t, pair[0]), mustParseSchema(t, pair[1]))
+			require.Error(t, err, "%s + %s", pair[0], pair[1])
+		}
+	})
+
+	t.Run("nil operands", func(t *testing.T) {
+		s := mustParseSchema(t, `{"type": "string"}`)
+		merged, err := schema.Merge(nil, s)
+		require.NoError(t, err)
+		require.Same(t, s, merged)
+		merged, err = schema.Merge(s, nil)
+		require.NoError(t, err)
+		require.Same(t, s, merged)
+	})
+
+	t.Run("validates like allOf", func(t *testing.T) {
+		a := mustParseSchema(t, `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string", "pattern": "^[A-Z]"}}}`)
+		b := mustParseSchema(t, `{"required": ["age"], "properties": {"name": {"maxLength": 5, "pattern": "o"}, "age": {"type": "integer", "minimum": 0}}}`)
+		merged, err := schema.Merge(a, b)
+		require.NoError(t, err)
+
+		mergedValidator, err := validator.Compile(t.Context(), merged)
+		require.NoError(t, err)
+		allOfValidator, err := validator.Compile(t.Context(), schema.NewBuilder().AllOf(a, b).MustBuild())
+		require.NoError(t, err)
+
+		for _, v := range []any{
+			map[string]any{"name": "Bob", "age": 3},
+			map[string]any{"name": "bob", "age": 3},
+			map[string]any{"name": "Bobby Joe", "age": 3},
+			map[string]any{"name": "Bill", "age": 3},
+			map[string]any{"name": "Bob", "age": -1},
+			map[string]any{"name": "Bob"},
+			"not an object",
+		} {
+			_, wantErr := allOfValidator.Validate(t.Context(), v)
+			_, gotErr := mergedValidator.Validate(t.Context(), v)
+			require.Equal(t, wantErr == nil, gotErr == nil, "%v", v)
+		}
+	})
+}
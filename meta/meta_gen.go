@@ -470,7 +470,7 @@ func init() {
 								MustBuild(),
 						),
 						validator.PropPair(
-							keywords.Definitions,
+							keywords.LegacyDefinitions,
 							validator.Object().
 								AdditionalProperties(
 									validator.NewDynamicReferenceValidator("#meta"),
@@ -479,7 +479,7 @@ func init() {
 								MustBuild(),
 						),
 						validator.PropPair(
-							keywords.DependentSchemas,
+							keywords.Dependencies,
 							validator.Object().
 								AdditionalProperties(
 
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// preserveLargeIntegers converts the json.Number values in v, a value decoded
+// with UseNumber, to float64 as a plain decode would, except for integers
+// beyond ±2^53: float64 cannot hold those exactly, so they are kept as
+// json.Number. A const of 18446744073709551615 thus still compares equal to
+// that value only, and not to its float64 neighbors. Maps and slices are
+// converted in place.
+func preserveLargeIntegers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if !strings.ContainsAny(val.String(), ".eE") {
+			i, err := val.Int64()
+			if err != nil || i > 1<<53 || i < -1<<53 {
+				return val
+			}
+			return float64(i)
+		}
+		f, err := val.Float64()
+		if err != nil {
+			return val
+		}
+		return f
+	case map[string]any:
+		for k, elem := range val {
+			val[k] = preserveLargeIntegers(elem)
+		}
+	case []any:
+		for i, elem := range val {
+			val[i] = preserveLargeIntegers(elem)
+		}
+	}
+	return v
+}
+
+// integerValue converts n, the value of a keyword that takes an integer such
+// as "maxLength", to T. JSON does not tell 3 from 3.0, so any number without
+// a fractional part is accepted.
+func integerValue[T int | uint](n json.Number) (T, error) {
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("%s is not an integer that fits in %T", n, T(0))
+	}
+	i := r.Num().Int64()
+	v := T(i)
+	if int64(v) != i || (i < 0) != (v < 0) {
+		return 0, fmt.Errorf("%s is not an integer that fits in %T", n, v)
+	}
+	return v, nil
+}
+
+// The numeric keywords, such as "maximum", are held as float64, which rounds
+// 18446744073709551615 to 2^64. UnmarshalJSON records the literal of such a
+// keyword in numbers, so that MarshalJSON writes it back as it was written
+// and validators can compare with its exact value, from MaximumNumber and its
+// siblings. Setting the keyword with a Builder drops the literal.
+
+// maxLiteralExponent bounds the exponent of a literal that decodeNumber
+// compares exactly. Past it, float64 holds nothing but zero or an error.
+const maxLiteralExponent = 400
+
+// decodeNumber returns n, the value of the numeric keyword, as float64. If
+// float64 does not hold n exactly, n is recorded as the literal of keyword.
+func (s *Schema) decodeNumber(keyword string, n json.Number) (float64, error) {
+	f, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+	text := n.String()
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(text[i+1:])
+		if err != nil || exp > maxLiteralExponent || exp < -maxLiteralExponent {
+			return f, nil
+		}
+	}
+	exact, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return f, nil
+	}
+	// A float64 stands for the shortest decimal that round-trips to it, so
+	// "0.1" is held exactly and needs no literal.
+	held, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if exact.Cmp(held) == 0 {
+		delete(s.numbers, keyword)
+		return f, nil
+	}
+	if s.numbers == nil {
+		s.numbers = make(map[string]json.Number)
+	}
+	s.numbers[keyword] = n
+	return f, nil
+}
+
+// number returns the literal recorded for keyword, or else f.
+func (s *Schema) number(keyword string, f float64) json.Number {
+	if n, ok := s.numbers[keyword]; ok {
+		return n
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// numberValue returns what MarshalJSON writes for keyword: its literal, or
+// else f.
+func (s *Schema) numberValue(keyword string, f float64) any {
+	if n, ok := s.numbers[keyword]; ok {
+		return n
+	}
+	return f
+}
+
+// setNumber records n as the literal of keyword, or drops the literal if n
+// is empty.
+func (b *Builder) setNumber(keyword string, n json.Number) {
+	if n == "" {
+		delete(b.numbers, keyword)
+		return
+	}
+	if b.numbers == nil {
+		b.numbers = make(map[string]json.Number)
+	}
+	b.numbers[keyword] = n
+}
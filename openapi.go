@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// OpenAPI 3.0 keywords that JSON Schema does not have. UnmarshalJSON keeps
+// them among the extensions of the schema they appear in.
+const (
+	openAPINullable = "nullable"
+	openAPIExample  = "example"
+)
+
+// FromOpenAPI30 parses an OpenAPI 3.0 Schema Object, such as an entry of
+// "components/schemas", into a JSON Schema. The document is read with
+// UnmarshalJSON, then the OpenAPI-specific keywords of the schema and of every
+// subschema in it are translated:
+//
+//   - "nullable": true adds "null" to "type", and null to "enum" if there is
+//     one. As in OpenAPI, it has no effect on a schema without "type".
+//     "nullable": false is dropped.
+//   - "example" is added to "examples", after any values already there.
+//
+// Other OpenAPI keywords, such as "discriminator" or "xml", stay in the
+// Extensions of their schema. OpenAPI 3.1 Schema Objects are JSON Schema
+// 2020-12 documents and need no adapter: parse them with UnmarshalJSON.
+func FromOpenAPI30(data []byte) (*Schema, error) {
+	var s Schema
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	err := s.Walk(func(path string, sub *Schema) error {
+		if err := fromOpenAPI30(sub); err != nil {
+			if path == "" {
+				path = "the root schema"
+			}
+			return fmt.Errorf(`json-schema: failed to convert OpenAPI 3.0 schema in %s: %w`, path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// fromOpenAPI30 rewrites the OpenAPI keywords of s in place. The subschemas
+// of s are left for the caller's walk.
+func fromOpenAPI30(s *Schema) error {
+	nullableRaw, hasNullable := s.extensions[openAPINullable]
+	exampleRaw, hasExample := s.extensions[openAPIExample]
+	if !hasNullable && !hasExample {
+		return nil
+	}
+
+	b := NewBuilder().Clone(s)
+	delete(b.extensions, openAPINullable)
+	delete(b.extensions, openAPIExample)
+
+	if hasNullable {
+		var nullable bool
+		if err := json.Unmarshal(nullableRaw, &nullable); err != nil {
+			return fmt.Errorf(`"nullable" must be a boolean: %w`, err)
+		}
+		if nullable && s.HasTypes() && !slices.Contains(s.Types(), NullType) {
+			b.Types(append(slices.Clone(s.Types()), NullType)...)
+			if s.HasEnum() && !slices.Contains(s.Enum(), nil) {
+				b.Enum(append(slices.Clone(s.Enum()), nil)...)
+			}
+		}
+	}
+
+	if hasExample {
+		dec := json.NewDecoder(bytes.NewReader(exampleRaw))
+		dec.UseNumber()
+		var example any
+		if err := dec.Decode(&example); err != nil {
+			return fmt.Errorf(`failed to decode "example": %w`, err)
+		}
+		b.Examples(append(slices.Clone(s.Examples()), preserveLargeIntegers(example))...)
+	}
+
+	converted, err := b.Build(WithBoundsCheck(false))
+	if err != nil {
+		return err
+	}
+	*s = *converted
+	return nil
+}
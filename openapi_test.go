@@ -0,0 +1,55 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromOpenAPI30(t *testing.T) {
+	t.Run("conversion", func(t *testing.T) {
+		s, err := schema.FromOpenAPI30([]byte(`{
+			"type": "object",
+			"discriminator": {"propertyName": "kind"},
+			"properties": {
+				"name": {"type": "string", "nullable": true, "example": "Ada"},
+				"size": {"type": "string", "enum": ["S", "M"], "nullable": true},
+				"age": {"type": "integer", "nullable": false, "examples": [1], "example": 2},
+				"any": {"nullable": true}
+			}
+		}`))
+		require.NoError(t, err)
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "object",
+			"discriminator": {"propertyName": "kind"},
+			"properties": {
+				"name": {"type": ["string", "null"], "examples": ["Ada"]},
+				"size": {"type": ["string", "null"], "enum": ["S", "M", null]},
+				"age": {"type": "integer", "examples": [1, 2]},
+				"any": {}
+			}
+		}`, string(buf))
+		require.False(t, s.Properties()["any"].HasExtensions())
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		s, err := schema.FromOpenAPI30([]byte(`{"type": "string", "nullable": true}`))
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), nil)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 1)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid nullable", func(t *testing.T) {
+		_, err := schema.FromOpenAPI30([]byte(`{"properties": {"a": {"type": "string", "nullable": "yes"}}}`))
+		require.ErrorContains(t, err, `in /properties/a: "nullable" must be a boolean`)
+	})
+}
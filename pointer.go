@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lestrrat-go/json-schema/keywords"
+)
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ResolvePointer returns the subschema of s that the RFC 6901 JSON Pointer ptr
+// refers to, such as "/$defs/address/properties/zip". ptr may also be given in
+// URI fragment form, as it appears in a local "$ref": "#/$defs/address", in
+// which case it is percent-decoded first. The empty pointer, and "#", refer to
+// s itself.
+//
+// The pointer is followed through every keyword that holds subschemas:
+// $defs, properties, patternProperties, dependentSchemas and the other maps
+// take a name as the next segment; allOf, anyOf, oneOf, prefixItems and the
+// array form of items take an index; items, not, if/then/else and the other
+// single-schema keywords are followed directly. A boolean schema at the end of
+// the pointer is returned as its equivalent schema, {} for true and
+// {"not": {}} for false.
+//
+// An error is returned when a segment names something s does not have, or
+// when the pointer runs into a value that is not a schema, such as
+// "/required/0". Unlike Resolver.ResolveJSONReference, ResolvePointer works on
+// the schema tree directly and does not follow $ref.
+func (s *Schema) ResolvePointer(ptr string) (*Schema, error) {
+	if strings.HasPrefix(ptr, "#") {
+		ptr = unescapeFragment(ptr[1:])
+	}
+	if ptr == "" {
+		return s, nil
+	}
+	if ptr[0] != '/' {
+		return nil, fmt.Errorf("json-schema: ResolvePointer: invalid JSON pointer %q: must be empty or start with '/'", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = pointerUnescaper.Replace(tok)
+	}
+
+	var cur SchemaOrBool = s
+	for i := 0; i < len(tokens); i++ {
+		current, ok := cur.(*Schema)
+		if !ok || current == nil {
+			return nil, fmt.Errorf("json-schema: ResolvePointer: %q: %s is a boolean schema, which has no keywords", ptr, pointerSuffix(tokens[:i]))
+		}
+		keyword := tokens[i]
+		next, consumed, err := pointerStep(current, keyword, tokens[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("json-schema: ResolvePointer: %q: at %s: %w", ptr, pointerSuffix(tokens[:i+1]), err)
+		}
+		i += consumed
+		cur = next
+	}
+
+	switch v := cur.(type) {
+	case *Schema:
+		return v, nil
+	case BoolSchema:
+		if v {
+			return New(), nil
+		}
+		return NewBuilder().Not(New()).Build()
+	default:
+		return nil, fmt.Errorf("json-schema: ResolvePointer: %q does not refer to a schema", ptr)
+	}
+}
+
+// pointerStep follows keyword of s, taking a name or an index from rest when
+// the keyword holds several subschemas. It returns the subschema reached and
+// the number of tokens of rest it used.
+func pointerStep(s *Schema, keyword string, rest []string) (SchemaOrBool, int, error) {
+	switch field := pointerFieldFor(keyword); {
+	case field == 0:
+		return nil, 0, fmt.Errorf("%q is not a keyword that holds a schema", keyword)
+	case !s.Has(field):
+		return nil, 0, fmt.Errorf("keyword %q is not set", keyword)
+	}
+
+	switch keyword {
+	case keywords.Definitions:
+		return pointerMapEntry(keyword, s.Definitions(), rest)
+	case keywords.LegacyDefinitions:
+		return pointerMapEntry(keyword, s.LegacyDefinitions(), rest)
+	case keywords.Properties:
+		return pointerMapEntry(keyword, s.Properties(), rest)
+	case keywords.PatternProperties:
+		return pointerMapEntry(keyword, s.PatternProperties(), rest)
+	case keywords.DependentSchemas:
+		return pointerMapEntry(keyword, s.DependentSchemas(), rest)
+	case keywords.Dependencies:
+		dep, n, err := pointerMapEntry(keyword, s.Dependencies(), rest)
+		if err != nil {
+			return nil, 0, err
+		}
+		sub, ok := dep.(SchemaOrBool)
+		if !ok {
+			return nil, 0, fmt.Errorf("%q entry %q is a list of property names, not a schema", keyword, rest[0])
+		}
+		return sub, n, nil
+	case keywords.AllOf:
+		return pointerListEntry(keyword, s.AllOf(), rest)
+	case keywords.AnyOf:
+		return pointerListEntry(keyword, s.AnyOf(), rest)
+	case keywords.OneOf:
+		return pointerListEntry(keyword, s.OneOf(), rest)
+	case keywords.PrefixItems:
+		return pointerListEntry(keyword, s.PrefixItems(), rest)
+	case keywords.Items:
+		if tuple, ok := s.Items().(TupleItems); ok {
+			return pointerListEntry(keyword, tuple, rest)
+		}
+		return s.Items(), 0, nil
+	case keywords.AdditionalItems:
+		return s.AdditionalItems(), 0, nil
+	case keywords.Contains:
+		return s.Contains(), 0, nil
+	case keywords.UnevaluatedItems:
+		return s.UnevaluatedItems(), 0, nil
+	case keywords.AdditionalProperties:
+		return s.AdditionalProperties(), 0, nil
+	case keywords.UnevaluatedProperties:
+		return s.UnevaluatedProperties(), 0, nil
+	case keywords.PropertyNames:
+		return s.PropertyNames(), 0, nil
+	case keywords.Not:
+		return s.Not(), 0, nil
+	case keywords.If:
+		return s.IfSchema(), 0, nil
+	case keywords.Then:
+		return s.ThenSchema(), 0, nil
+	case keywords.Else:
+		return s.ElseSchema(), 0, nil
+	case keywords.ContentSchema:
+		return s.ContentSchema(), 0, nil
+	default:
+		return nil, 0, fmt.Errorf("%q is not a keyword that holds a schema", keyword)
+	}
+}
+
+// pointerFieldFor returns the field flag of a keyword that holds subschemas,
+// or 0 for any other name.
+func pointerFieldFor(keyword string) FieldFlag {
+	switch keyword {
+	case keywords.Definitions:
+		return DefinitionsField
+	case keywords.LegacyDefinitions:
+		return LegacyDefinitionsField
+	case keywords.Properties:
+		return PropertiesField
+	case keywords.PatternProperties:
+		return PatternPropertiesField
+	case keywords.DependentSchemas:
+		return DependentSchemasField
+	case keywords.Dependencies:
+		return DependenciesField
+	case keywords.AllOf:
+		return AllOfField
+	case keywords.AnyOf:
+		return AnyOfField
+	case keywords.OneOf:
+		return OneOfField
+	case keywords.PrefixItems:
+		return PrefixItemsField
+	case keywords.Items:
+		return ItemsField
+	case keywords.AdditionalItems:
+		return AdditionalItemsField
+	case keywords.Contains:
+		return ContainsField
+	case keywords.UnevaluatedItems:
+		return UnevaluatedItemsField
+	case keywords.AdditionalProperties:
+		return AdditionalPropertiesField
+	case keywords.UnevaluatedProperties:
+		return UnevaluatedPropertiesField
+	case keywords.PropertyNames:
+		return PropertyNamesField
+	case keywords.Not:
+		return NotField
+	case keywords.If:
+		return IfSchemaField
+	case keywords.Then:
+		return ThenSchemaField
+	case keywords.Else:
+		return ElseSchemaField
+	case keywords.ContentSchema:
+		return ContentSchemaField
+	default:
+		return 0
+	}
+}
+
+func pointerMapEntry[T any](keyword string, m map[string]T, rest []string) (T, int, error) {
+	var zero T
+	if len(rest) == 0 {
+		return zero, 0, fmt.Errorf("%q holds a map of schemas, not a schema", keyword)
+	}
+	sub, ok := m[rest[0]]
+	if !ok {
+		return zero, 0, fmt.Errorf("%q has no entry named %q", keyword, rest[0])
+	}
+	return sub, 1, nil
+}
+
+func pointerListEntry(keyword string, list []SchemaOrBool, rest []string) (SchemaOrBool, int, error) {
+	if len(rest) == 0 {
+		return nil, 0, fmt.Errorf("%q holds an array of schemas, not a schema", keyword)
+	}
+	idx, err := strconv.Atoi(rest[0])
+	if err != nil || idx < 0 || (len(rest[0]) > 1 && rest[0][0] == '0') {
+		return nil, 0, fmt.Errorf("%q is not a valid array index", rest[0])
+	}
+	if idx >= len(list) {
+		return nil, 0, fmt.Errorf("index %d is out of range for %q, which has %d elements", idx, keyword, len(list))
+	}
+	return list[idx], 1, nil
+}
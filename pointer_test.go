@@ -0,0 +1,69 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePointer(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"$defs": {
+			"address": {"properties": {"zip": {"type": "string", "pattern": "^[0-9]{5}$"}}},
+			"a/b~c": {"type": "integer"},
+			"100%": {"type": "number"}
+		},
+		"properties": {"tags": {"prefixItems": [{"type": "string"}, true, false], "items": {"minLength": 1}}},
+		"allOf": [{"if": {"required": ["a"]}, "then": {"required": ["b"]}}],
+		"dependencies": {"x": ["y"], "z": {"minProperties": 2}},
+		"required": ["tags"]
+	}`)
+
+	testCases := []struct {
+		ptr  string
+		want string
+	}{
+		{ptr: "", want: `{}`},
+		{ptr: "/$defs/address/properties/zip", want: `{"type": "string", "pattern": "^[0-9]{5}$"}`},
+		{ptr: "#/$defs/address/properties/zip", want: `{"type": "string", "pattern": "^[0-9]{5}$"}`},
+		{ptr: "/$defs/a~1b~0c", want: `{"type": "integer"}`},
+		{ptr: "#/$defs/100%25", want: `{"type": "number"}`},
+		{ptr: "/properties/tags/prefixItems/0", want: `{"type": "string"}`},
+		{ptr: "/properties/tags/prefixItems/1", want: `{}`},
+		{ptr: "/properties/tags/prefixItems/2", want: `{"not": {}}`},
+		{ptr: "/properties/tags/items", want: `{"minLength": 1}`},
+		{ptr: "/allOf/0/then", want: `{"required": ["b"]}`},
+		{ptr: "/dependencies/z", want: `{"minProperties": 2}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ptr, func(t *testing.T) {
+			sub, err := s.ResolvePointer(tc.ptr)
+			require.NoError(t, err)
+			if tc.ptr == "" {
+				require.Same(t, s, sub)
+				return
+			}
+			buf, err := json.Marshal(sub)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(buf))
+		})
+	}
+
+	for _, ptr := range []string{
+		"$defs/address",                    // not a pointer
+		"/$defs/missing",                   // no such entry
+		"/$defs",                           // a map, not a schema
+		"/not",                             // keyword not set
+		"/required/0",                      // not a schema keyword
+		"/properties/tags/prefixItems/3",   // out of range
+		"/properties/tags/prefixItems/01",  // leading zero
+		"/properties/tags/prefixItems/1/x", // into a boolean schema
+		"/dependencies/x",                  // property list
+	} {
+		t.Run("error "+ptr, func(t *testing.T) {
+			_, err := s.ResolvePointer(ptr)
+			require.Error(t, err)
+		})
+	}
+}
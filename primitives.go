@@ -59,7 +59,7 @@ func (t *PrimitiveType) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	x, err := NewPrimitiveType(s)
+	x, err := ParsePrimitiveType(s)
 	if err != nil {
 		return err
 	}
@@ -76,16 +76,34 @@ func IsScalarPrimitiveType(typ PrimitiveType) bool {
 	}
 }
 
-// NewPrimitiveType creates a PrimitiveType from its string representation.
-// It accepts standard JSON Schema type names such as "null", "integer", "string", "object", "array", "boolean", and "number".
-func NewPrimitiveType(s string) (PrimitiveType, error) {
+// ParsePrimitiveType returns the PrimitiveType named s, one of the JSON
+// Schema type names "null", "integer", "string", "object", "array", "boolean"
+// and "number". Names are case-sensitive, as in a schema's "type" keyword.
+func ParsePrimitiveType(s string) (PrimitiveType, error) {
 	if pt, ok := availablePrimitives[s]; ok {
 		return pt, nil
 	}
 	return InvalidType, fmt.Errorf(`unknown primitive type %q`, s)
 }
 
-// String returns the string representation of this primitive type
+// NewPrimitiveType creates a PrimitiveType from its string representation.
+// It is the same as ParsePrimitiveType.
+func NewPrimitiveType(s string) (PrimitiveType, error) {
+	return ParsePrimitiveType(s)
+}
+
+// AllPrimitiveTypes returns every valid PrimitiveType, excluding InvalidType.
+// The returned slice is newly allocated on each call.
+func AllPrimitiveTypes() PrimitiveTypes {
+	types := make(PrimitiveTypes, 0, int(maxPrimitiveType)-1)
+	for t := NullType; t < maxPrimitiveType; t++ {
+		types = append(types, t)
+	}
+	return types
+}
+
+// String returns the JSON Schema name of this primitive type, such as
+// "string", or "<invalid>" for InvalidType and values out of range.
 func (t PrimitiveType) String() string {
 	if t < NullType || t >= maxPrimitiveType {
 		return invalidTypeString
@@ -139,3 +157,21 @@ func (pt PrimitiveTypes) MarshalJSON() ([]byte, error) {
 func (pt PrimitiveTypes) Contains(p PrimitiveType) bool {
 	return slices.Contains(pt, p)
 }
+
+// IsNullable reports whether the "type" keyword of s permits null, as in
+// "type": ["string", "null"]. Only "type" is consulted: a schema without it,
+// or one that admits null through a subschema such as an anyOf branch, is not
+// reported as nullable.
+func (s *Schema) IsNullable() bool {
+	return s.ContainsType(NullType)
+}
+
+// IsSingleType returns the type s permits when its "type" keyword names
+// exactly one. The boolean is false when "type" is absent or lists several
+// types.
+func (s *Schema) IsSingleType() (PrimitiveType, bool) {
+	if len(s.types) != 1 {
+		return InvalidType, false
+	}
+	return s.types[0], true
+}
@@ -41,3 +41,48 @@ func TestPrimitiveTypesUnmarshalEmpty(t *testing.T) {
 		require.Error(t, pt.UnmarshalJSON([]byte{}))
 	})
 }
+
+func TestParsePrimitiveType(t *testing.T) {
+	all := schema.AllPrimitiveTypes()
+	require.Len(t, all, 7)
+	require.NotContains(t, all, schema.InvalidType)
+	for _, typ := range all {
+		parsed, err := schema.ParsePrimitiveType(typ.String())
+		require.NoError(t, err)
+		require.Equal(t, typ, parsed, "%s should round-trip through String", typ)
+	}
+
+	for _, name := range []string{"", "String", "int", "float"} {
+		typ, err := schema.ParsePrimitiveType(name)
+		require.ErrorContains(t, err, "unknown primitive type", "%q", name)
+		require.Equal(t, schema.InvalidType, typ)
+	}
+	require.Equal(t, "<invalid>", schema.InvalidType.String())
+
+	all[0] = schema.InvalidType
+	require.Equal(t, schema.NullType, schema.AllPrimitiveTypes()[0], "callers get their own slice")
+}
+
+func TestSchemaTypeHelpers(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Schema   *schema.Schema
+		Nullable bool
+		Single   schema.PrimitiveType
+	}{
+		{Name: "no type", Schema: schema.NewBuilder().MinLength(1).MustBuild()},
+		{Name: "single type", Schema: schema.NewBuilder().Types(schema.StringType).MustBuild(), Single: schema.StringType},
+		{Name: "null", Schema: schema.NewBuilder().Types(schema.NullType).MustBuild(), Nullable: true, Single: schema.NullType},
+		{Name: "nullable", Schema: schema.NewBuilder().Types(schema.IntegerType, schema.NullType).MustBuild(), Nullable: true},
+		{Name: "several types", Schema: schema.NewBuilder().Types(schema.IntegerType, schema.StringType).MustBuild()},
+		{Name: "null through anyOf", Schema: schema.Optional(schema.NewBuilder().Types(schema.StringType).MustBuild()).MustBuild()},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.Equal(t, tc.Nullable, tc.Schema.IsNullable())
+			typ, ok := tc.Schema.IsSingleType()
+			require.Equal(t, tc.Single != schema.InvalidType, ok)
+			require.Equal(t, tc.Single, typ)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package schema
+
+// RefInfo is a reference keyword found by References.
+type RefInfo struct {
+	// Location is the JSON Pointer of the keyword, relative to the schema
+	// References was called on, such as "/properties/home/$ref".
+	Location string
+	// Keyword is "$ref", "$dynamicRef" or "$recursiveRef".
+	Keyword string
+	// Reference is the value of the keyword, as written.
+	Reference string
+	// URI is Reference resolved against the base URI in effect where it
+	// appears, which the $id of the enclosing resources set. It stays
+	// relative, like "#/$defs/a" or "common.json", where no $id gives the
+	// reference an absolute base.
+	URI string
+}
+
+// Dynamic reports whether r is a $dynamicRef or $recursiveRef, whose target
+// depends on the dynamic scope at validation time rather than on URI alone.
+func (r RefInfo) Dynamic() bool {
+	return r.Keyword != "$ref"
+}
+
+// References returns every $ref, $dynamicRef and $recursiveRef in s and its
+// subschemas, in the order Walk visits them, for dependency analysis and
+// tooling. The references are listed, not resolved; one that is not a
+// fragment of its own document, such as "#/$defs/a", usually points into
+// another one:
+//
+//	for _, ref := range s.References() {
+//		if !strings.HasPrefix(ref.Reference, "#") {
+//			fmt.Println(ref.Location, "depends on", ref.URI)
+//		}
+//	}
+//
+// References returns nil if s is nil or has no references.
+func (s *Schema) References() []RefInfo {
+	if s == nil {
+		return nil
+	}
+	var out []RefInfo
+	collectReferences(s, "", "", &out)
+	return out
+}
+
+func collectReferences(s *Schema, path, base string, out *[]RefInfo) {
+	if s.HasID() && IsResourceID(s.ID()) {
+		base, _, _ = splitFragment(resolveURI(base, s.ID()))
+	}
+	for _, ref := range []struct {
+		keyword string
+		has     bool
+		get     func() string
+	}{
+		{"$ref", s.HasReference(), s.Reference},
+		{"$dynamicRef", s.HasDynamicReference(), s.DynamicReference},
+		{"$recursiveRef", s.HasRecursiveReference(), s.RecursiveReference},
+	} {
+		if !ref.has {
+			continue
+		}
+		value := ref.get()
+		*out = append(*out, RefInfo{
+			Location:  path + "/" + ref.keyword,
+			Keyword:   ref.keyword,
+			Reference: value,
+			URI:       resolveURI(base, value),
+		})
+	}
+	for _, sub := range subschemas(s) {
+		collectReferences(sub.schema, path+pointerSuffix(sub.tokens), base, out)
+	}
+}
@@ -0,0 +1,53 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferences(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"$id": "https://example.com/root.json",
+		"properties": {
+			"home": {"$ref": "address.json#/$defs/home"},
+			"a/b": {"$ref": "#/$defs/name"},
+			"node": {"$dynamicRef": "#node"}
+		},
+		"$defs": {
+			"name": {"type": "string"},
+			"nested": {
+				"$id": "sub/nested.json",
+				"items": {"$ref": "other.json"}
+			}
+		}
+	}`)
+
+	require.Equal(t, []schema.RefInfo{
+		{Location: "/$defs/nested/items/$ref", Keyword: "$ref", Reference: "other.json", URI: "https://example.com/sub/other.json"},
+		{Location: "/properties/a~1b/$ref", Keyword: "$ref", Reference: "#/$defs/name", URI: "https://example.com/root.json#/$defs/name"},
+		{Location: "/properties/home/$ref", Keyword: "$ref", Reference: "address.json#/$defs/home", URI: "https://example.com/address.json#/$defs/home"},
+		{Location: "/properties/node/$dynamicRef", Keyword: "$dynamicRef", Reference: "#node", URI: "https://example.com/root.json#node"},
+	}, s.References())
+
+	t.Run("dynamic", func(t *testing.T) {
+		refs := mustParseSchema(t, `{"$schema":"https://json-schema.org/draft/2019-09/schema","$ref":"a.json","items":{"$recursiveRef":"#"}}`).References()
+		require.Len(t, refs, 2)
+		require.False(t, refs[0].Dynamic())
+		require.Equal(t, "/items/$recursiveRef", refs[1].Location)
+		require.True(t, refs[1].Dynamic())
+	})
+
+	t.Run("without a base URI", func(t *testing.T) {
+		refs := mustParseSchema(t, `{"not":{"$ref":"common.json#/$defs/x"}}`).References()
+		require.Equal(t, []schema.RefInfo{
+			{Location: "/not/$ref", Keyword: "$ref", Reference: "common.json#/$defs/x", URI: "common.json#/$defs/x"},
+		}, refs)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		require.Nil(t, mustParseSchema(t, `{"type":"string"}`).References())
+		require.Nil(t, (*schema.Schema)(nil).References())
+	})
+}
@@ -3,8 +3,13 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/lestrrat-go/json-schema/keywords"
 	"github.com/lestrrat-go/jsref/v2"
 )
 
@@ -41,12 +46,17 @@ func (idx *resourceIndex) index(s *Schema, baseURI string, visited map[*Schema]s
 	visited[s] = struct{}{}
 
 	current := baseURI
-	if s.HasID() && s.ID() != "" {
+	if s.HasID() && IsResourceID(s.ID()) {
 		resolved := resolveURI(baseURI, s.ID())
 		base, _, _ := splitFragment(resolved)
 		idx.byURI[base] = s
 		current = base
 	}
+	if s.HasID() {
+		if anchor := idAnchor(s.ID()); anchor != "" {
+			idx.anchors[current+"#"+anchor] = s
+		}
+	}
 	if s.HasAnchor() && s.Anchor() != "" {
 		idx.anchors[current+"#"+s.Anchor()] = s
 	}
@@ -59,6 +69,91 @@ func (idx *resourceIndex) index(s *Schema, baseURI string, visited map[*Schema]s
 	}
 }
 
+// addDocument indexes root as a document retrieved from uri: it is addressable
+// by uri (fragment stripped) even without an $id of its own, and by its
+// canonical $id resolved against uri. Nested resources and anchors are indexed
+// beneath it.
+func (idx *resourceIndex) addDocument(uri string, root *Schema) {
+	retrieval, _, _ := splitFragment(uri)
+	idx.byURI[retrieval] = root
+	// index resolves the $id of root against retrieval itself.
+	idx.index(root, retrieval, make(map[*Schema]struct{}))
+}
+
+// Registry is a set of schema documents known ahead of time, addressed by
+// URI. It is the "bundle of schemas" a multi-file schema set is compiled
+// against: Add every document once, then build a Resolver from it with
+// NewRegistryResolver so that references between the documents resolve from
+// memory.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	docs  map[string]*Schema // documents as passed to Add, by retrieval URI
+	index *resourceIndex     // docs plus every embedded $id resource
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		docs:  make(map[string]*Schema),
+		index: newResourceIndex(),
+	}
+}
+
+// Add registers s under id. An empty id means the document's own $id. Besides
+// id (and the $id of s, resolved against id), every resource embedded in s
+// with its own $id becomes addressable too. Adding a document under an id that
+// is already registered replaces it. Add does nothing if s is nil or no id can
+// be determined.
+func (r *Registry) Add(id string, s *Schema) {
+	if s == nil {
+		return
+	}
+	if id == "" && s.HasID() {
+		id = s.ID()
+	}
+	id, _, _ = splitFragment(id)
+	if id == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.docs[id] = s
+	r.index.addDocument(id, s)
+}
+
+// Get returns the schema registered under id, which may name a document passed
+// to Add or a resource embedded in one. A fragment in id is ignored.
+func (r *Registry) Get(id string) (*Schema, bool) {
+	id, _, _ = splitFragment(id)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.index.byURI[id]
+	return s, ok
+}
+
+// NewRegistryResolver creates a Resolver that knows every document in reg.
+// References into those documents, including $dynamicRef and anchors, resolve
+// against them before any resolver supplied through options is consulted, so
+// options can add e.g. NewHTTPResolver as a fallback for everything else.
+//
+// The documents are registered when the Resolver is created; documents added
+// to reg afterwards are not seen by it.
+func NewRegistryResolver(reg *Registry, options ...ResolverOption) *Resolver {
+	r := NewResolver(options...)
+	if reg == nil {
+		return r
+	}
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	for _, id := range slices.Sorted(maps.Keys(reg.docs)) {
+		r.RegisterDocument(id, reg.docs[id])
+	}
+	return r
+}
+
 // FindDynamicAnchor searches a schema resource for a subschema declaring
 // $dynamicAnchor == name, without crossing into nested $id resources (which are
 // distinct resources with their own dynamic scope). It returns nil if not found.
@@ -70,7 +165,7 @@ func FindDynamicAnchor(resource *Schema, name string) *Schema {
 		return resource
 	}
 	for _, child := range childSchemas(resource) {
-		if child.HasID() && child.ID() != "" {
+		if child.HasID() && IsResourceID(child.ID()) {
 			continue // a nested $id starts a separate resource
 		}
 		if found := FindDynamicAnchor(child, name); found != nil {
@@ -80,85 +175,153 @@ func FindDynamicAnchor(resource *Schema, name string) *Schema {
 	return nil
 }
 
+// findAnchor searches a schema resource for a subschema declaring $anchor or
+// $dynamicAnchor == name, or an $id such as "#name". Nested $id resources are searched, in document
+// order, only when the resource itself has no such anchor. It returns nil if
+// not found.
+func findAnchor(resource *Schema, name string) *Schema {
+	var nested []*Schema
+	var search func(*Schema) *Schema
+	search = func(s *Schema) *Schema {
+		if (s.HasAnchor() && s.Anchor() == name) || (s.HasDynamicAnchor() && s.DynamicAnchor() == name) || (s.HasID() && idAnchor(s.ID()) == name) {
+			return s
+		}
+		for _, child := range childSchemas(s) {
+			if child.HasID() && IsResourceID(child.ID()) {
+				nested = append(nested, child)
+				continue
+			}
+			if found := search(child); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	if found := search(resource); found != nil {
+		return found
+	}
+	for _, child := range nested {
+		if found := findAnchor(child, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // childSchemas returns the immediate subschemas of s across every keyword that
-// can hold one. It mirrors the traversal in findSchemaByAnchor (plus
-// prefixItems and list-valued items), so the resource index sees the same nodes
-// anchor resolution does.
+// can hold one, so the resource index sees every node a reference can reach.
 func childSchemas(s *Schema) []*Schema {
-	var out []*Schema
-	add := func(v any) {
+	subs := subschemas(s)
+	out := make([]*Schema, len(subs))
+	for i, sub := range subs {
+		out[i] = sub.schema
+	}
+	return out
+}
+
+// subschema is an immediate child schema together with the JSON Pointer
+// tokens that lead to it from its parent, e.g. ["properties", "name"].
+type subschema struct {
+	tokens []string
+	schema *Schema
+}
+
+// subschemas enumerates the immediate object subschemas of s in a stable
+// order: keywords in a fixed sequence, map entries sorted by key. Boolean
+// schemas are not included.
+func subschemas(s *Schema) []subschema {
+	var out []subschema
+	add := func(v any, tokens ...string) {
 		if sub, ok := v.(*Schema); ok && sub != nil {
-			out = append(out, sub)
+			out = append(out, subschema{tokens: tokens, schema: sub})
+		}
+	}
+	addMap := func(keyword string, m map[string]*Schema) {
+		for _, name := range slices.Sorted(maps.Keys(m)) {
+			add(m[name], keyword, name)
+		}
+	}
+	addList := func(keyword string, list []SchemaOrBool) {
+		for i, sub := range list {
+			add(sub, keyword, strconv.Itoa(i))
 		}
 	}
 
 	if s.HasDefinitions() {
-		for _, def := range s.Definitions() {
-			out = append(out, def)
-		}
+		addMap(keywords.Definitions, s.Definitions())
+	}
+	if s.HasLegacyDefinitions() {
+		addMap(keywords.LegacyDefinitions, s.LegacyDefinitions())
 	}
 	if s.HasProperties() {
-		for _, p := range s.Properties() {
-			out = append(out, p)
-		}
+		addMap(keywords.Properties, s.Properties())
 	}
 	if s.HasPatternProperties() {
-		for _, p := range s.PatternProperties() {
-			out = append(out, p)
+		addMap(keywords.PatternProperties, s.PatternProperties())
+	}
+	if s.HasAdditionalProperties() {
+		add(s.AdditionalProperties(), keywords.AdditionalProperties)
+	}
+	if s.HasPropertyNames() {
+		add(s.PropertyNames(), keywords.PropertyNames)
+	}
+	if s.HasUnevaluatedProperties() {
+		add(s.UnevaluatedProperties(), keywords.UnevaluatedProperties)
+	}
+	if s.HasDependentSchemas() {
+		deps := s.DependentSchemas()
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			add(deps[name], keywords.DependentSchemas, name)
 		}
 	}
-	if s.HasPrefixItems() {
-		for _, it := range s.PrefixItems() {
-			add(it)
+	if s.HasDependencies() {
+		deps := s.Dependencies()
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			add(deps[name], keywords.Dependencies, name)
 		}
 	}
+	if s.HasPrefixItems() {
+		addList(keywords.PrefixItems, s.PrefixItems())
+	}
 	if s.HasItems() {
-		add(s.Items())
+		if tuple, ok := s.Items().(TupleItems); ok {
+			addList(keywords.Items, tuple)
+		} else {
+			add(s.Items(), keywords.Items)
+		}
 	}
-	if s.HasAdditionalProperties() {
-		add(s.AdditionalProperties())
+	if s.HasAdditionalItems() {
+		add(s.AdditionalItems(), keywords.AdditionalItems)
 	}
-	if s.HasUnevaluatedProperties() {
-		add(s.UnevaluatedProperties())
+	if s.HasContains() {
+		add(s.Contains(), keywords.Contains)
 	}
 	if s.HasUnevaluatedItems() {
-		add(s.UnevaluatedItems())
+		add(s.UnevaluatedItems(), keywords.UnevaluatedItems)
 	}
 	if s.HasAllOf() {
-		for _, sub := range s.AllOf() {
-			add(sub)
-		}
+		addList(keywords.AllOf, s.AllOf())
 	}
 	if s.HasAnyOf() {
-		for _, sub := range s.AnyOf() {
-			add(sub)
-		}
+		addList(keywords.AnyOf, s.AnyOf())
 	}
 	if s.HasOneOf() {
-		for _, sub := range s.OneOf() {
-			add(sub)
-		}
+		addList(keywords.OneOf, s.OneOf())
 	}
 	if s.HasNot() {
-		out = append(out, s.Not())
+		add(s.Not(), keywords.Not)
 	}
 	if s.HasIfSchema() {
-		add(s.IfSchema())
+		add(s.IfSchema(), keywords.If)
 	}
 	if s.HasThenSchema() {
-		add(s.ThenSchema())
+		add(s.ThenSchema(), keywords.Then)
 	}
 	if s.HasElseSchema() {
-		add(s.ElseSchema())
-	}
-	if s.HasContains() {
-		add(s.Contains())
-	}
-	if s.HasPropertyNames() {
-		out = append(out, s.PropertyNames())
+		add(s.ElseSchema(), keywords.Else)
 	}
 	if s.HasContentSchema() {
-		out = append(out, s.ContentSchema())
+		add(s.ContentSchema(), keywords.ContentSchema)
 	}
 	return out
 }
@@ -0,0 +1,90 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseSchema(t *testing.T, src string) *schema.Schema {
+	t.Helper()
+	var s schema.Schema
+	require.NoError(t, json.Unmarshal([]byte(src), &s))
+	return &s
+}
+
+func TestRegistry(t *testing.T) {
+	common := mustParseSchema(t, `{
+		"$id": "https://example.com/common.json",
+		"$defs": {
+			"name": {"type": "string", "minLength": 1},
+			"address": {
+				"$id": "https://example.com/address.json",
+				"type": "object",
+				"required": ["city"]
+			}
+		}
+	}`)
+	person := mustParseSchema(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"$ref": "https://example.com/common.json#/$defs/name"},
+			"home": {"$ref": "https://example.com/address.json"}
+		}
+	}`)
+
+	reg := schema.NewRegistry()
+	reg.Add("", common)
+	reg.Add("https://example.com/person.json", person)
+
+	t.Run("Get", func(t *testing.T) {
+		got, ok := reg.Get("https://example.com/common.json")
+		require.True(t, ok)
+		require.Same(t, common, got)
+
+		got, ok = reg.Get("https://example.com/person.json#")
+		require.True(t, ok)
+		require.Same(t, person, got)
+
+		got, ok = reg.Get("https://example.com/address.json")
+		require.True(t, ok, "embedded $id resources should be indexed")
+		require.True(t, got.ContainsType(schema.ObjectType))
+
+		_, ok = reg.Get("https://example.com/missing.json")
+		require.False(t, ok)
+	})
+
+	t.Run("relative $id", func(t *testing.T) {
+		reg := schema.NewRegistry()
+		reg.Add("https://example.com/doc.json", mustParseSchema(t, `{
+			"$id": "rel/",
+			"$defs": {"x": {"$id": "x.json", "type": "string"}}
+		}`))
+		_, ok := reg.Get("https://example.com/rel/")
+		require.True(t, ok)
+		_, ok = reg.Get("https://example.com/rel/x.json")
+		require.True(t, ok, "nested ids resolve against the document's, resolved once")
+	})
+
+	t.Run("NewRegistryResolver", func(t *testing.T) {
+		r := schema.NewRegistryResolver(reg)
+		v, err := validator.Compile(t.Context(), person, validator.WithResolver(r), validator.WithBaseURI("https://example.com/person.json"))
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"name": "Jo", "home": map[string]any{"city": "Tokyo"}})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"name": ""})
+		require.Error(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"home": map[string]any{}})
+		require.Error(t, err)
+	})
+
+	t.Run("unregistered references still fail", func(t *testing.T) {
+		s := mustParseSchema(t, `{"$ref": "https://example.com/missing.json"}`)
+		_, err := validator.Compile(t.Context(), s, validator.WithResolver(schema.NewRegistryResolver(reg)))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,53 @@
+package schema
+
+import "slices"
+
+// IsRequired reports whether name is listed in the "required" keyword of s.
+func (s *Schema) IsRequired(name string) bool {
+	return slices.Contains(s.required, name)
+}
+
+// RequiredSet returns the names listed in the "required" keyword of s as a
+// set. The map is a new one each time and may be modified.
+func (s *Schema) RequiredSet() map[string]struct{} {
+	set := make(map[string]struct{}, len(s.required))
+	for _, name := range s.required {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// AddRequired adds names to the "required" keyword of the schema being built,
+// after the names already there, such as those copied by Clone. A name that is
+// already required is not added again.
+func (b *Builder) AddRequired(names ...string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	// Build a new list: the current one may be shared with a cloned schema.
+	required := make([]string, 0, len(b.required)+len(names))
+	for _, name := range slices.Concat(b.required, names) {
+		if !slices.Contains(required, name) {
+			required = append(required, name)
+		}
+	}
+	b.required = required
+	return b
+}
+
+// RemoveRequired removes names from the "required" keyword of the schema
+// being built. When no name is left, the keyword is omitted, as after
+// ResetRequired.
+func (b *Builder) RemoveRequired(names ...string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	var required []string
+	for _, name := range b.required {
+		if !slices.Contains(names, name) && !slices.Contains(required, name) {
+			required = append(required, name)
+		}
+	}
+	b.required = required
+	return b
+}
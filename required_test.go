@@ -0,0 +1,38 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredHelpers(t *testing.T) {
+	request := mustParseSchema(t, `{
+		"type": "object",
+		"properties": {"id": {}, "name": {}, "email": {}},
+		"required": ["name", "email"]
+	}`)
+	require.True(t, request.IsRequired("name"))
+	require.False(t, request.IsRequired("id"))
+	require.Equal(t, map[string]struct{}{"name": {}, "email": {}}, request.RequiredSet())
+	require.Empty(t, schema.NewBuilder().MustBuild().RequiredSet())
+
+	t.Run("AddRequired keeps existing names and dedupes", func(t *testing.T) {
+		response := schema.NewBuilder().Clone(request).AddRequired("id", "name", "id").MustBuild()
+		require.Equal(t, []string{"name", "email", "id"}, response.Required())
+		require.Equal(t, []string{"name", "email"}, request.Required(), "the cloned schema is untouched")
+
+		s := schema.NewBuilder().AddRequired("a").AddRequired("b", "a").MustBuild()
+		require.Equal(t, []string{"a", "b"}, s.Required())
+	})
+
+	t.Run("RemoveRequired", func(t *testing.T) {
+		patch := schema.NewBuilder().Clone(request).RemoveRequired("email", "missing").MustBuild()
+		require.Equal(t, []string{"name"}, patch.Required())
+
+		none := schema.NewBuilder().Clone(request).RemoveRequired("name", "email").MustBuild()
+		require.False(t, none.HasRequired())
+		require.Equal(t, []string{"name", "email"}, request.Required())
+	})
+}
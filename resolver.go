@@ -80,11 +80,9 @@ func (r *Resolver) RegisterRoot(root *Schema) {
 		return
 	}
 	r.registered[root] = struct{}{}
-	base := ""
-	if root.HasID() && root.ID() != "" {
-		base, _, _ = splitFragment(root.ID())
-	}
-	r.index.index(root, base, make(map[*Schema]struct{}))
+	// index resolves the root's own $id against the empty base. Passing the
+	// $id as the base would resolve a relative one twice, "rel/" as "rel/rel/".
+	r.index.index(root, "", make(map[*Schema]struct{}))
 	r.mu.Unlock()
 }
 
@@ -103,15 +101,7 @@ func (r *Resolver) RegisterDocument(uri string, root *Schema) {
 		r.registered = make(map[*Schema]struct{})
 	}
 	r.registered[root] = struct{}{}
-
-	retrieval, _, _ := splitFragment(uri)
-	base := retrieval
-	if root.HasID() && root.ID() != "" {
-		base, _, _ = splitFragment(resolveURI(retrieval, root.ID()))
-	}
-	// Address the document by its retrieval URI even when it has no $id of its own.
-	r.index.byURI[retrieval] = root
-	r.index.index(root, base, make(map[*Schema]struct{}))
+	r.index.addDocument(uri, root)
 }
 
 // RegisterFS walks fsys and registers every ".json" file as a document via
@@ -209,16 +199,19 @@ func (r *Resolver) ResolveJSONReference(_ context.Context, dst *Schema, referenc
 }
 
 // ResolveAnchor resolves anchor references against the given base schema.
-// It searches for schemas with the specified $anchor value within the base schema.
+// It searches the schema resource rooted at the base schema for a subschema
+// whose $anchor or $dynamicAnchor has the specified value. Subschemas with an
+// $id of their own are separate resources, and are only searched if the base
+// resource declares no such anchor.
 // The anchorName parameter should not include the # prefix.
 func (r *Resolver) ResolveAnchor(_ context.Context, dst *Schema, anchorName string, baseSchema *Schema) error {
 	if baseSchema == nil {
 		return fmt.Errorf("no base schema provided for resolving anchor %s", anchorName)
 	}
 
-	anchorSchema, err := r.findSchemaByAnchor(baseSchema, anchorName)
-	if err != nil {
-		return fmt.Errorf("failed to find anchor %s: %w", anchorName, err)
+	anchorSchema := findAnchor(baseSchema, anchorName)
+	if anchorSchema == nil {
+		return fmt.Errorf("failed to find anchor %s: anchor %s not found", anchorName, anchorName)
 	}
 	*dst = *anchorSchema
 	return nil
@@ -318,179 +311,3 @@ func ValidateReference(reference string) error {
 
 	return nil
 }
-
-// findSchemaByAnchor recursively searches for a schema with the given anchor name
-func (r *Resolver) findSchemaByAnchor(schema *Schema, anchorName string) (*Schema, error) {
-	// Check if current schema has the anchor
-	if schema.HasAnchor() && schema.Anchor() == anchorName {
-		return schema, nil
-	}
-
-	// Check if current schema has the dynamic anchor
-	if schema.HasDynamicAnchor() && schema.DynamicAnchor() == anchorName {
-		return schema, nil
-	}
-
-	// Search in definitions, but be scope-aware
-	if schema.HasDefinitions() {
-		// First pass: search definitions that don't have their own $id (same scope)
-		for _, defSchema := range schema.Definitions() {
-			if !defSchema.HasID() {
-				if found, err := r.findSchemaByAnchor(defSchema, anchorName); err == nil {
-					return found, nil
-				}
-			}
-		}
-
-		// Second pass: search definitions that have their own $id (different scope)
-		for _, defSchema := range schema.Definitions() {
-			if defSchema.HasID() {
-				if found, err := r.findSchemaByAnchor(defSchema, anchorName); err == nil {
-					return found, nil
-				}
-			}
-		}
-	}
-
-	// Search in properties
-	if schema.HasProperties() {
-		for _, propSchema := range schema.Properties() {
-			if found, err := r.findSchemaByAnchor(propSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in pattern properties
-	if schema.HasPatternProperties() {
-		for _, propSchema := range schema.PatternProperties() {
-			if found, err := r.findSchemaByAnchor(propSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in items
-	if schema.HasItems() {
-		if itemSchema, ok := schema.Items().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(itemSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in additional properties
-	if schema.HasAdditionalProperties() {
-		if addlSchema, ok := schema.AdditionalProperties().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(addlSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in unevaluated properties
-	if schema.HasUnevaluatedProperties() {
-		if unevalSchema, ok := schema.UnevaluatedProperties().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(unevalSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in unevaluated items
-	if schema.HasUnevaluatedItems() {
-		if unevalSchema, ok := schema.UnevaluatedItems().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(unevalSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in composition schemas
-	if schema.HasAllOf() {
-		for _, subSchema := range schema.AllOf() {
-			if subSchema, ok := subSchema.(*Schema); ok {
-				if found, err := r.findSchemaByAnchor(subSchema, anchorName); err == nil {
-					return found, nil
-				}
-			}
-		}
-	}
-
-	if schema.HasAnyOf() {
-		for _, subSchema := range schema.AnyOf() {
-			if subSchema, ok := subSchema.(*Schema); ok {
-				if found, err := r.findSchemaByAnchor(subSchema, anchorName); err == nil {
-					return found, nil
-				}
-			}
-		}
-	}
-
-	if schema.HasOneOf() {
-		for _, subSchema := range schema.OneOf() {
-			if subSchema, ok := subSchema.(*Schema); ok {
-				if found, err := r.findSchemaByAnchor(subSchema, anchorName); err == nil {
-					return found, nil
-				}
-			}
-		}
-	}
-
-	// Search in not schema
-	if schema.HasNot() {
-		if found, err := r.findSchemaByAnchor(schema.Not(), anchorName); err == nil {
-			return found, nil
-		}
-	}
-
-	// Search in if/then/else schemas
-	if schema.HasIfSchema() {
-		if ifSchema, ok := schema.IfSchema().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(ifSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	if schema.HasThenSchema() {
-		if thenSchema, ok := schema.ThenSchema().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(thenSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	if schema.HasElseSchema() {
-		if elseSchema, ok := schema.ElseSchema().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(elseSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in contains schema
-	if schema.HasContains() {
-		if containsSchema, ok := schema.Contains().(*Schema); ok {
-			if found, err := r.findSchemaByAnchor(containsSchema, anchorName); err == nil {
-				return found, nil
-			}
-		}
-	}
-
-	// Search in property names schema
-	if schema.HasPropertyNames() {
-		if found, err := r.findSchemaByAnchor(schema.PropertyNames(), anchorName); err == nil {
-			return found, nil
-		}
-	}
-
-	// Search in content schema
-	if schema.HasContentSchema() {
-		if found, err := r.findSchemaByAnchor(schema.ContentSchema(), anchorName); err == nil {
-			return found, nil
-		}
-	}
-
-	return nil, fmt.Errorf("anchor %s not found", anchorName)
-}
@@ -45,8 +45,11 @@ func WithResolver(r jsref.Resolver) ResolverOption {
 // Pass it to WithResolver to enable network access:
 //
 //	r := schema.NewResolver(schema.WithResolver(schema.HTTPResolver()))
+//
+// It is NewHTTPResolver with no options; use that directly to set a client,
+// a timeout or a host allowlist.
 func HTTPResolver() jsref.Resolver {
-	return jsref.NewHTTPResolver()
+	return NewHTTPResolver()
 }
 
 // FSResolver returns a resolver that reads references from fsys. It works
@@ -58,8 +61,38 @@ func HTTPResolver() jsref.Resolver {
 // References are looked up as slash-separated paths relative to the root of fsys
 // (a leading "/" and a "file://" scheme are stripped). JSON and YAML documents
 // are supported.
+//
+// It is NewFSResolver with no base URI.
 func FSResolver(fsys fs.FS) jsref.Resolver {
-	return &fsResolver{fsys: fsys}
+	return NewFSResolver(fsys, "")
+}
+
+// NewFSResolver returns a resolver that reads references from fsys, which
+// holds the documents published under the base URI base. A reference to a URI
+// under base, such as "https://example.com/schemas/common.json" for the base
+// "https://example.com/schemas/", reads the file at the rest of the URI,
+// "common.json"; relative references such as "common.json#/$defs/x" are paths
+// from the root of fsys. References to other absolute URIs are left to the
+// resolvers that follow, so that a multi-file schema directory checked into a
+// repository, or embedded with embed.FS, resolves offline by the $id URIs its
+// documents use:
+//
+//	//go:embed schemas
+//	var embedded embed.FS
+//
+//	sub, _ := fs.Sub(embedded, "schemas")
+//	r := schema.NewResolver(schema.WithResolver(
+//		schema.NewFSResolver(sub, "https://example.com/schemas/"),
+//	))
+//
+// The base may be a "file://" URI, such as "file:///etc/schemas/" for
+// os.DirFS("/etc/schemas"). A base that does not end in "/" names a
+// directory all the same. With an empty base, NewFSResolver is FSResolver.
+func NewFSResolver(fsys fs.FS, base string) jsref.Resolver {
+	if base != "" && !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return &fsResolver{fsys: fsys, base: base}
 }
 
 // DirResolver is shorthand for FSResolver(os.DirFS(dir)). It reads references
@@ -77,34 +110,70 @@ func DirResolver(dir string) jsref.Resolver {
 // → resolve-fragment flow.
 type fsResolver struct {
 	fsys fs.FS
+	base string // the URI of the root of fsys, ending in "/"; "" for none
 }
 
 func (r *fsResolver) CanResolve(resource any) bool {
 	s, ok := resource.(string)
-	if !ok || strings.HasPrefix(s, "#") {
+	if !ok {
 		return false
 	}
-	// Decline URLs with a network scheme so HTTPResolver handles those instead.
-	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
-		return false
+	_, ok = r.filePath(s)
+	return ok
+}
+
+// filePath returns the path within fsys of the document at uri, and false if
+// uri is not one for r to read.
+func (r *fsResolver) filePath(uri string) (string, bool) {
+	if strings.HasPrefix(uri, "#") {
+		return "", false
+	}
+	if r.base != "" {
+		if rest, ok := strings.CutPrefix(uri, r.base); ok {
+			return fsPath(unescapePath(rest)), true
+		}
+	}
+	u, err := url.Parse(uri)
+	switch {
+	case err != nil:
+		return fsPath(uri), r.base == ""
+	case u.Scheme == "":
+		return fsPath(u.Path), true
+	case r.base != "":
+		return "", false
+	case u.Scheme == "file":
+		// Accept "file://" URLs by extracting their path component.
+		return fsPath(u.Path), true
+	case u.Scheme == "http" || u.Scheme == "https":
+		// Decline URLs with a network scheme so HTTPResolver handles those instead.
+		return "", false
+	default:
+		return fsPath(uri), true
 	}
-	return true
+}
+
+// fsPath turns p into the unrooted, slash-separated, cleaned form fs.FS uses.
+func fsPath(p string) string {
+	return path.Clean(strings.TrimPrefix(p, "/"))
+}
+
+func unescapePath(p string) string {
+	if unescaped, err := url.PathUnescape(p); err == nil {
+		return unescaped
+	}
+	return p
 }
 
 func (r *fsResolver) Resolve(dst any, resource any, localRef string) error {
-	p, ok := resource.(string)
+	uri, ok := resource.(string)
 	if !ok {
 		return fmt.Errorf("fsResolver requires string resource, got %T", resource)
 	}
-
-	// Accept "file://" URLs by extracting their path component.
-	if u, err := url.Parse(p); err == nil && u.Scheme == "file" {
-		p = u.Path
+	p, ok := r.filePath(uri)
+	if !ok {
+		return fmt.Errorf("fsResolver: %q is not under %q", uri, r.base)
 	}
 
-	// fs.FS uses unrooted, slash-separated, cleaned paths.
-	p = path.Clean(strings.TrimPrefix(p, "/"))
-
 	data, err := fs.ReadFile(r.fsys, p)
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", p, err)
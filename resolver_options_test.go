@@ -1,12 +1,18 @@
 package schema_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
 	"github.com/stretchr/testify/require"
 )
 
@@ -56,3 +62,221 @@ func TestResolverExternalAccessOptIn(t *testing.T) {
 		})
 	})
 }
+
+func TestNewFSResolver(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common.json":      &fstest.MapFile{Data: []byte(`{"$id":"https://example.com/schemas/common.json","$defs":{"name":{"type":"string"},"tags":{"$ref":"nested/tags.json"}}}`)},
+		"nested/tags.json": &fstest.MapFile{Data: []byte(`{"type":"array","items":{"type":"string"}}`)},
+	}
+
+	t.Run("relative references", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewFSResolver(fsys, "")))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "common.json#/$defs/name", nil, ""))
+		require.True(t, resolved.ContainsType(schema.StringType))
+	})
+
+	t.Run("URIs under the base", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewFSResolver(fsys, "https://example.com/schemas")))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "https://example.com/schemas/nested/tags.json", nil, ""))
+		require.True(t, resolved.ContainsType(schema.ArrayType))
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "common.json#/$defs/name", nil, ""))
+		require.True(t, resolved.ContainsType(schema.StringType))
+
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, "https://example.com/other/common.json", nil, ""), "URIs outside the base are not read from fsys")
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, "file:///common.json", nil, ""))
+	})
+
+	t.Run("file base", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewFSResolver(fsys, "file:///etc/schemas/")))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "file:///etc/schemas/common.json#/$defs/name", nil, ""))
+		require.True(t, resolved.ContainsType(schema.StringType))
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, "file:///etc/common.json", nil, ""))
+	})
+
+	t.Run("falls through to other resolvers", func(t *testing.T) {
+		fetch := func(context.Context, string) ([]byte, error) {
+			return []byte(`{"type":"integer"}`), nil
+		}
+		r := schema.NewResolver(
+			schema.WithResolver(schema.NewFSResolver(fsys, "https://example.com/schemas/")),
+			schema.WithResolver(schema.NewHTTPResolver(schema.WithRemoteFetcher(fetch))),
+		)
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "https://example.org/count.json", nil, ""))
+		require.True(t, resolved.ContainsType(schema.IntegerType))
+	})
+
+	t.Run("compiled references", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewFSResolver(fsys, "https://example.com/schemas/")))
+		for _, src := range []string{
+			`{"properties":{"name":{"$ref":"common.json#/$defs/name"},"tags":{"$ref":"common.json#/$defs/tags"}}}`,
+			`{"$id":"https://example.com/schemas/root.json","properties":{"name":{"$ref":"common.json#/$defs/name"},"tags":{"$ref":"common.json#/$defs/tags"}}}`,
+		} {
+			v, err := validator.Compile(t.Context(), mustParseSchema(t, src), validator.WithResolver(r))
+			require.NoError(t, err, src)
+			_, err = v.Validate(t.Context(), map[string]any{"name": "x", "tags": []any{"a"}})
+			require.NoError(t, err, src)
+			_, err = v.Validate(t.Context(), map[string]any{"name": 1})
+			require.Error(t, err, src)
+			_, err = v.Validate(t.Context(), map[string]any{"tags": []any{1}})
+			require.Error(t, err, src)
+		}
+	})
+}
+
+func TestNewHTTPResolver(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hits.Add(1)
+		switch req.URL.Path {
+		case "/defs.json":
+			w.Header().Set("Content-Type", "application/schema+json")
+			_, _ = w.Write([]byte(`{"$defs":{"name":{"type":"string"},"age":{"type":"integer"}}}`))
+		case "/slow.json":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{}`))
+		case "/moved.json":
+			http.Redirect(w, req, "/defs.json", http.StatusFound)
+		case "/moved-twice.json":
+			http.Redirect(w, req, "/moved.json", http.StatusFound)
+		case "/elsewhere.json":
+			http.Redirect(w, req, "http://schemas.example.com/defs.json", http.StatusFound)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("caches documents by URI", func(t *testing.T) {
+		hits.Store(0)
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithHTTPClient(server.Client()))))
+
+		var name, age schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &name, server.URL+"/defs.json#/$defs/name", nil, ""))
+		require.NoError(t, r.ResolveReference(t.Context(), &age, server.URL+"/defs.json#/$defs/age", nil, ""))
+		require.True(t, name.ContainsType(schema.StringType))
+		require.True(t, age.ContainsType(schema.IntegerType))
+		require.Equal(t, int32(1), hits.Load(), "the document should be fetched once")
+	})
+
+	t.Run("does not cache failures", func(t *testing.T) {
+		hits.Store(0)
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver()))
+		var resolved schema.Schema
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, server.URL+"/missing.json", nil, ""))
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, server.URL+"/missing.json", nil, ""))
+		require.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("allowed hosts", func(t *testing.T) {
+		hits.Store(0)
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		denied := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithAllowedHosts("schemas.example.com"))))
+		var resolved schema.Schema
+		require.Error(t, denied.ResolveReference(t.Context(), &resolved, server.URL+"/defs.json", nil, ""))
+		require.Equal(t, int32(0), hits.Load(), "a disallowed host must not be contacted")
+
+		allowed := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithAllowedHosts(u.Hostname()))))
+		require.NoError(t, allowed.ResolveReference(t.Context(), &resolved, server.URL+"/defs.json#/$defs/name", nil, ""))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithHTTPTimeout(20 * time.Millisecond))))
+		var resolved schema.Schema
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, server.URL+"/slow.json", nil, ""))
+	})
+
+	t.Run("max redirects", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithMaxRedirects(1))))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, server.URL+"/moved.json#/$defs/name", nil, ""))
+		err := r.ResolveReference(t.Context(), &resolved, server.URL+"/moved-twice.json", nil, "")
+		require.ErrorContains(t, err, "stopped after 1 redirects")
+	})
+
+	t.Run("redirects stay within the allowed hosts", func(t *testing.T) {
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithAllowedHosts(u.Hostname()))))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, server.URL+"/moved.json", nil, ""))
+		err = r.ResolveReference(t.Context(), &resolved, server.URL+"/elsewhere.json", nil, "")
+		require.ErrorContains(t, err, "not in the allowed hosts")
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		const size = len(`{"$defs":{"name":{"type":"string"},"age":{"type":"integer"}}}`)
+		var resolved schema.Schema
+		exact := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithMaxBytes(int64(size)))))
+		require.NoError(t, exact.ResolveReference(t.Context(), &resolved, server.URL+"/defs.json", nil, ""))
+		small := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithMaxBytes(int64(size - 1)))))
+		err := small.ResolveReference(t.Context(), &resolved, server.URL+"/defs.json", nil, "")
+		require.ErrorContains(t, err, "larger than")
+	})
+}
+
+func TestRemoteFetcher(t *testing.T) {
+	var fetched []string
+	fetch := func(_ context.Context, uri string) ([]byte, error) {
+		fetched = append(fetched, uri)
+		switch uri {
+		case "https://schemas.example.com/defs.json":
+			return []byte(`{"$defs":{"name":{"type":"string"}}}`), nil
+		case "urn:example:defs":
+			return []byte(`{"type":"integer"}`), nil
+		default:
+			return nil, errors.New("not found")
+		}
+	}
+
+	t.Run("fetches and caches", func(t *testing.T) {
+		fetched = nil
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithRemoteFetcher(fetch))))
+		var name, again schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &name, "https://schemas.example.com/defs.json#/$defs/name", nil, ""))
+		require.NoError(t, r.ResolveReference(t.Context(), &again, "https://schemas.example.com/defs.json", nil, ""))
+		require.True(t, name.ContainsType(schema.StringType))
+		require.Equal(t, []string{"https://schemas.example.com/defs.json"}, fetched)
+	})
+
+	t.Run("any absolute URI", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithRemoteFetcher(fetch))))
+		var resolved schema.Schema
+		require.NoError(t, r.ResolveReference(t.Context(), &resolved, "urn:example:defs", nil, ""))
+		require.True(t, resolved.ContainsType(schema.IntegerType))
+		require.Error(t, r.ResolveReference(t.Context(), &resolved, "https://schemas.example.com/missing.json", nil, ""))
+	})
+
+	t.Run("guards still apply", func(t *testing.T) {
+		fetched = nil
+		var resolved schema.Schema
+		denied := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(
+			schema.WithRemoteFetcher(fetch),
+			schema.WithAllowedHosts("other.example.com"),
+		)))
+		require.ErrorContains(t, denied.ResolveReference(t.Context(), &resolved, "https://schemas.example.com/defs.json", nil, ""), "not in the allowed hosts")
+		require.Empty(t, fetched, "a disallowed host must not be fetched")
+
+		small := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(
+			schema.WithRemoteFetcher(fetch),
+			schema.WithMaxBytes(10),
+		)))
+		require.ErrorContains(t, small.ResolveReference(t.Context(), &resolved, "https://schemas.example.com/defs.json", nil, ""), "larger than 10 bytes")
+	})
+
+	t.Run("compiled references", func(t *testing.T) {
+		r := schema.NewResolver(schema.WithResolver(schema.NewHTTPResolver(schema.WithRemoteFetcher(fetch))))
+		s := mustParseSchema(t, `{"properties":{"name":{"$ref":"https://schemas.example.com/defs.json#/$defs/name"}}}`)
+		v, err := validator.Compile(t.Context(), s, validator.WithResolver(r))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"name": "x"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"name": 1})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// CheckRoundTrip decodes data as a Schema, encodes it again, and reports the
+// first place where the result means something else than data: a keyword
+// that was dropped, added or changed, or a value that changed type, such as
+// a boolean subschema that came back as an object. The error names the spot
+// by JSON Pointer. Key order, whitespace and the spelling of a number (3 and
+// 3.0) do not count. It returns nil when the schema survives the round trip.
+//
+// It is meant for tests of code that reads and writes schemas. Two losses are
+// known and by design: the numeric bounds (minimum, multipleOf, ...) are held
+// as float64, so a bound float64 cannot represent exactly comes back rounded,
+// and the boolean exclusiveMaximum and exclusiveMinimum of draft-04 are
+// rewritten on decoding.
+func CheckRoundTrip(data []byte) error {
+	var s Schema
+	if err := s.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf(`json-schema: CheckRoundTrip: failed to decode schema: %w`, err)
+	}
+	encoded, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf(`json-schema: CheckRoundTrip: failed to encode schema: %w`, err)
+	}
+	original, err := decodeWithNumbers(data)
+	if err != nil {
+		return fmt.Errorf(`json-schema: CheckRoundTrip: failed to decode schema: %w`, err)
+	}
+	result, err := decodeWithNumbers(encoded)
+	if err != nil {
+		return fmt.Errorf(`json-schema: CheckRoundTrip: failed to decode encoded schema: %w`, err)
+	}
+	if d := firstDifference("", original, result); d != nil {
+		return fmt.Errorf(`json-schema: CheckRoundTrip: %s`, d)
+	}
+	return nil
+}
+
+// roundTripDifference is where CheckRoundTrip found the encoded schema to
+// differ from the original.
+type roundTripDifference struct {
+	path           string
+	before, after  any
+	dropped, added bool
+}
+
+func (d *roundTripDifference) String() string {
+	path := d.path
+	if path == "" {
+		path = "/"
+	}
+	switch {
+	case d.dropped:
+		return fmt.Sprintf("%s: %s was dropped", path, changeValue(d.before))
+	case d.added:
+		return fmt.Sprintf("%s: %s was added", path, changeValue(d.after))
+	default:
+		return fmt.Sprintf("%s: %s came back as %s", path, changeValue(d.before), changeValue(d.after))
+	}
+}
+
+func decodeWithNumbers(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// firstDifference compares a and b, which were decoded by decodeWithNumbers,
+// and returns the first place where they differ, taking object keys in
+// sorted order, or nil.
+func firstDifference(path string, a, b any) *roundTripDifference {
+	changed := &roundTripDifference{path: path, before: a, after: b}
+	switch a := a.(type) {
+	case map[string]any:
+		bm, ok := b.(map[string]any)
+		if !ok {
+			return changed
+		}
+		for _, name := range sortedUnion(a, bm) {
+			child := path + "/" + pointerEscaper.Replace(name)
+			av, inA := a[name]
+			bv, inB := bm[name]
+			switch {
+			case !inB:
+				return &roundTripDifference{path: child, before: av, dropped: true}
+			case !inA:
+				return &roundTripDifference{path: child, after: bv, added: true}
+			}
+			if d := firstDifference(child, av, bv); d != nil {
+				return d
+			}
+		}
+		return nil
+	case []any:
+		bl, ok := b.([]any)
+		if !ok || len(a) != len(bl) {
+			return changed
+		}
+		for i := range a {
+			if d := firstDifference(path+"/"+strconv.Itoa(i), a[i], bl[i]); d != nil {
+				return d
+			}
+		}
+		return nil
+	case json.Number:
+		bn, ok := b.(json.Number)
+		if !ok {
+			return changed
+		}
+		x, ok1 := new(big.Rat).SetString(a.String())
+		y, ok2 := new(big.Rat).SetString(bn.String())
+		if !ok1 || !ok2 || x.Cmp(y) != 0 {
+			return changed
+		}
+		return nil
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return changed
+		}
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+package schema_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRoundTrip(t *testing.T) {
+	t.Run("meta-schemas", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join("internal", "cmd", "genmeta", "schemas", "2020-12", "*.json"))
+		require.NoError(t, err)
+		meta, err := filepath.Glob(filepath.Join("internal", "cmd", "genmeta", "schemas", "2020-12", "meta", "*.json"))
+		require.NoError(t, err)
+		files = append(files, meta...)
+		require.NotEmpty(t, files)
+		for _, file := range files {
+			t.Run(filepath.Base(file), func(t *testing.T) {
+				data, err := os.ReadFile(file)
+				require.NoError(t, err)
+				require.NoError(t, schema.CheckRoundTrip(data))
+			})
+		}
+	})
+
+	testcases := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name: "identification and annotations",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"$id": "https://example.com/person.json",
+				"$anchor": "person",
+				"$dynamicAnchor": "node",
+				"$comment": "a person",
+				"title": "Person",
+				"description": "Someone",
+				"default": {"name": "Ada"},
+				"examples": [{"name": "Ada"}, null],
+				"deprecated": false,
+				"readOnly": true,
+				"writeOnly": false
+			}`,
+		},
+		{
+			name: "boolean subschemas",
+			schema: `{
+				"properties": {"open": true, "closed": false, "nested": {"properties": {"x": false}}},
+				"patternProperties": {"^x-": true},
+				"additionalProperties": false,
+				"unevaluatedProperties": true,
+				"propertyNames": true,
+				"dependentSchemas": {"a": false},
+				"$defs": {"never": false, "always": true},
+				"items": false,
+				"prefixItems": [true, false],
+				"contains": true,
+				"not": false,
+				"if": true,
+				"then": false,
+				"else": true,
+				"allOf": [true],
+				"anyOf": [false, true],
+				"oneOf": [true]
+			}`,
+		},
+		{
+			name: "counts written as floats",
+			schema: `{
+				"minLength": 1.0,
+				"maxLength": 3.0,
+				"minItems": 0,
+				"maxItems": 1e1,
+				"minContains": 2.0,
+				"maxContains": 4,
+				"minProperties": 1.0,
+				"maxProperties": 10
+			}`,
+		},
+		{
+			name: "numbers",
+			schema: `{
+				"type": ["number", "null"],
+				"minimum": -1.5,
+				"maximum": 9007199254740992,
+				"exclusiveMinimum": 0,
+				"exclusiveMaximum": 100.25,
+				"multipleOf": 0.01,
+				"const": 3,
+				"enum": [1, 2.5, null, "x", [1], {"a": 1}]
+			}`,
+		},
+		{
+			name: "strings, arrays and objects",
+			schema: `{
+				"type": "object",
+				"required": ["a"],
+				"dependentRequired": {"a": ["b"]},
+				"properties": {
+					"a": {"type": "string", "pattern": "^a", "format": "email", "contentEncoding": "base64", "contentMediaType": "application/json", "contentSchema": {"type": "object"}},
+					"b": {"type": "array", "uniqueItems": true, "items": {"$ref": "#/$defs/item"}}
+				},
+				"$defs": {"item": {"$dynamicRef": "#node"}},
+				"unevaluatedItems": false
+			}`,
+		},
+		{
+			name: "legacy keywords",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"definitions": {"a": true, "b": {"type": "integer"}},
+				"dependencies": {"a": ["b"], "c": false},
+				"items": [true, {"type": "string"}],
+				"additionalItems": false
+			}`,
+		},
+		{
+			name:   "unknown keywords",
+			schema: `{"x-vendor": {"nested": [1, 2.0]}, "type": "string"}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, schema.CheckRoundTrip([]byte(tc.schema)))
+		})
+	}
+
+	t.Run("reports what changed", func(t *testing.T) {
+		err := schema.CheckRoundTrip([]byte(`{"$schema": "http://json-schema.org/draft-04/schema#", "maximum": 5, "exclusiveMaximum": true}`))
+		require.ErrorContains(t, err, "/exclusiveMaximum: true came back as 5")
+	})
+
+	t.Run("fractional count", func(t *testing.T) {
+		var s schema.Schema
+		require.Error(t, s.UnmarshalJSON([]byte(`{"maxLength": 2.5}`)))
+		require.Error(t, s.UnmarshalJSON([]byte(`{"minItems": -1}`)))
+	})
+}
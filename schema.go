@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"unicode"
+
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 // SchemaOrBool is an interface for types that can be either a Schema or boolean
@@ -34,6 +36,15 @@ func (s BoolSchema) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bool(s))
 }
 
+// TupleItems is the array form of the "items" keyword used by draft 2019-09
+// and earlier, where each element validates the array item at the same
+// position. It is what Schema.Items returns when such a schema is unmarshaled;
+// JSON Schema 2020-12 spells the same thing "prefixItems".
+type TupleItems []SchemaOrBool
+
+// schemaOrBool implements the SchemaOrBool interface
+func (TupleItems) schemaOrBool() {}
+
 // Version is the schema that this implementation supports. We use the name
 // Version here because Schema is confusing with other types.
 const Version = `https://json-schema.org/draft/2020-12/schema`
@@ -55,6 +66,44 @@ func FalseSchema() BoolSchema {
 	return falseSchema
 }
 
+// AsSchema returns v as a *Schema. A *Schema is returned as is, and a
+// BoolSchema as the schema that behaves the same: {} for true and
+// {"not": {}} for false, which IsTrue and IsFalse recognize. The boolean is
+// false when v is nil or holds something other than a single schema, such
+// as TupleItems.
+func AsSchema(v SchemaOrBool) (*Schema, bool) {
+	switch v := v.(type) {
+	case *Schema:
+		return v, v != nil
+	case BoolSchema:
+		if v {
+			return &Schema{}, true
+		}
+		return &Schema{not: &Schema{}, populatedFields: NotField}, true
+	default:
+		return nil, false
+	}
+}
+
+// IsEmpty reports whether s has no keywords at all, not even unknown ones. An
+// empty schema, {}, accepts every instance.
+func (s *Schema) IsEmpty() bool {
+	return s.populatedFields == 0 && len(s.extensions) == 0
+}
+
+// IsTrue reports whether s is the canonical form of the boolean schema true,
+// the empty schema {}. A boolean true read into a *Schema field has this form.
+func (s *Schema) IsTrue() bool {
+	return s.IsEmpty()
+}
+
+// IsFalse reports whether s is the canonical form of the boolean schema
+// false, {"not": {}}, with no other keyword. A boolean false read into a
+// *Schema field has this form.
+func (s *Schema) IsFalse() bool {
+	return s.populatedFields == NotField && len(s.extensions) == 0 && s.not.IsEmpty()
+}
+
 // Predefined field groups for common bit flag checks
 
 // StringConstraintFields groups all string-related validation fields
@@ -149,6 +198,77 @@ func unmarshalSchemaOrBoolMap(dec *json.Decoder) (map[string]SchemaOrBool, error
 	return result, nil
 }
 
+// unmarshalDependencies parses the draft-07 "dependencies" keyword. An array
+// value becomes a []string of dependent property names; any other value is
+// decoded as a SchemaOrBool.
+func unmarshalDependencies(dec *json.Decoder) (map[string]any, error) {
+	var rawMap map[string]json.RawMessage
+	if err := dec.Decode(&rawMap); err != nil {
+		return nil, fmt.Errorf("failed to decode map: %w", err)
+	}
+
+	result := make(map[string]any, len(rawMap))
+	for key, rawValue := range rawMap {
+		if len(rawValue) > 0 && rawValue[0] == '[' {
+			var names []string
+			if err := json.Unmarshal(rawValue, &names); err != nil {
+				return nil, fmt.Errorf("value for key %q is not an array of property names: %w", key, err)
+			}
+			result[key] = names
+			continue
+		}
+
+		var b bool
+		if err := json.Unmarshal(rawValue, &b); err == nil {
+			result[key] = BoolSchema(b)
+			continue
+		}
+
+		var schema Schema
+		if err := json.Unmarshal(rawValue, &schema); err == nil {
+			result[key] = &schema
+			continue
+		}
+
+		return nil, fmt.Errorf("value for key %q is neither a property name array, boolean, nor valid schema object", key)
+	}
+	return result, nil
+}
+
+// applyDraft04ExclusiveBounds rewrites the draft-04 boolean forms of
+// exclusiveMinimum and exclusiveMaximum, which turn minimum and maximum into
+// exclusive bounds, as the numeric keywords of later drafts. A nil or false
+// flag leaves the inclusive bound as it is.
+func (s *Schema) applyDraft04ExclusiveBounds(exclusiveMinimum, exclusiveMaximum *bool) error {
+	if exclusiveMinimum != nil && *exclusiveMinimum {
+		if !s.HasMinimum() {
+			return fmt.Errorf(`json-schema: "exclusiveMinimum": true requires "minimum"`)
+		}
+		s.exclusiveMinimum = s.minimum
+		s.populatedFields |= ExclusiveMinimumField
+		if n, ok := s.numbers[keywords.Minimum]; ok {
+			s.numbers[keywords.ExclusiveMinimum] = n
+			delete(s.numbers, keywords.Minimum)
+		}
+		s.minimum = nil
+		s.populatedFields &^= MinimumField
+	}
+	if exclusiveMaximum != nil && *exclusiveMaximum {
+		if !s.HasMaximum() {
+			return fmt.Errorf(`json-schema: "exclusiveMaximum": true requires "maximum"`)
+		}
+		s.exclusiveMaximum = s.maximum
+		s.populatedFields |= ExclusiveMaximumField
+		if n, ok := s.numbers[keywords.Maximum]; ok {
+			s.numbers[keywords.ExclusiveMaximum] = n
+			delete(s.numbers, keywords.Maximum)
+		}
+		s.maximum = nil
+		s.populatedFields &^= MaximumField
+	}
+	return nil
+}
+
 // compareFieldNames compares two field names with custom sorting logic:
 // Character-by-character comparison where at each position:
 // 1. Non-alphanumeric characters sort before alphanumeric characters
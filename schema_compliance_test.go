@@ -3,9 +3,15 @@ package schema_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -356,53 +362,178 @@ func TestSchemaBasicReferences(t *testing.T) {
 	})
 }
 
-// TestSpecificationCompliance runs the official JSON Schema Test Suite tests
+// complianceDrafts are the drafts of the JSON Schema Test Suite that
+// TestSpecificationCompliance runs, by the name of their directory under
+// tests/tests. To enable another draft, add it here and run
+//
+//	go test -run TestSpecificationCompliance -conformance.report=report.txt .
+//
+// The report ends with the cases that fail, one per line, in the form the
+// known-failures file of the draft (testdata/conformance/<dir>.txt) takes.
+var complianceDrafts = []struct {
+	dir   string
+	draft schema.Draft
+}{
+	{"draft2020-12", schema.Draft202012},
+}
+
+var conformanceReport = flag.String("conformance.report", "", "write the pass/fail counts and the failing cases of TestSpecificationCompliance to this file")
+
+// TestSpecificationCompliance runs the official JSON Schema Test Suite tests.
+// A case that fails is a test failure unless the draft lists it as a known
+// failure, in which case it is skipped; a known failure that passes is a test
+// failure too, so that the list only ever shrinks.
 func TestSpecificationCompliance(t *testing.T) {
 	t.Parallel()
 	if testing.Short() {
 		t.Skip("Skipping specification compliance tests in short mode")
 	}
 
-	testDir := filepath.Join("tests", "tests", "latest")
+	var reports []*complianceReport
+	t.Cleanup(func() {
+		var sb strings.Builder
+		for _, report := range reports {
+			report.write(&sb)
+		}
+		t.Log("\n" + sb.String())
+		if *conformanceReport != "" {
+			if err := os.WriteFile(*conformanceReport, []byte(sb.String()), 0o644); err != nil {
+				t.Errorf("failed to write the conformance report: %v", err)
+			}
+		}
+	})
 
-	// Resolve the symlink to get the actual directory
-	resolvedDir, err := filepath.EvalSymlinks(testDir)
-	require.NoError(t, err, "Failed to resolve symlink %s", testDir)
-	testDir = resolvedDir
+	for _, d := range complianceDrafts {
+		testDir := filepath.Join("tests", "tests", d.dir)
+		_, err := os.Stat(testDir)
+		require.NoError(t, err, "Test directory %s does not exist. Run ./init-test-suite.sh first.", testDir)
+
+		known, err := loadKnownFailures(filepath.Join("testdata", "conformance", d.dir+".txt"))
+		require.NoError(t, err)
+		report := &complianceReport{draft: d.dir, draftValue: d.draft, known: known}
+		reports = append(reports, report)
+
+		// The inner Run returns once every parallel case of the draft is done.
+		t.Run(d.dir, func(t *testing.T) {
+			t.Run("suite", func(t *testing.T) {
+				err := filepath.Walk(testDir, func(path string, info os.FileInfo, err error) error {
+					if err != nil {
+						return err
+					}
+
+					if info.IsDir() {
+						return nil
+					}
+
+					if !strings.HasSuffix(path, ".json") || strings.Contains(path, "remotes") {
+						return nil
+					}
+
+					// Skip optional tests that we don't support yet
+					if strings.Contains(path, "optional") {
+						return nil
+					}
+
+					relPath, _ := filepath.Rel(testDir, path)
+					t.Run(relPath, func(t *testing.T) {
+						t.Parallel()
+						runTestFile(t, report, filepath.ToSlash(relPath), path)
+					})
+
+					return nil
+				})
+				require.NoError(t, err)
+			})
+			for name := range report.known {
+				if !report.seen[name] {
+					t.Errorf("known failure %q is not a case of the suite; remove it from testdata/conformance/%s.txt", name, d.dir)
+				}
+			}
+		})
+	}
+}
 
-	// Check if test directory exists
-	_, err = os.Stat(testDir)
-	require.False(t, os.IsNotExist(err), "Test directory %s does not exist. Make sure TestMain ran successfully.", testDir)
+// complianceReport counts the outcomes of the cases of one draft.
+type complianceReport struct {
+	draft      string
+	draftValue schema.Draft
+	known      map[string]bool // known failures, by case name
 
-	// Read all test files
-	err = filepath.Walk(testDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	mu       sync.Mutex
+	passed   int
+	failures []string        // case names, failing or known to fail
+	seen     map[string]bool // the known failures met while running
+}
 
-		if info.IsDir() {
-			return nil
-		}
+// caseName names a case of the suite as the known-failures files do.
+func caseName(file, suite, test string) string {
+	return file + "\t" + suite + "\t" + test
+}
 
-		if !strings.HasSuffix(path, ".json") || strings.Contains(path, "remotes") {
-			return nil
+// record notes the outcome of the case name, and reports whether the test
+// for it should fail.
+func (r *complianceReport) record(t *testing.T, name string, err error) {
+	t.Helper()
+	r.mu.Lock()
+	known := r.known[name]
+	if known {
+		if r.seen == nil {
+			r.seen = make(map[string]bool)
 		}
+		r.seen[name] = true
+	}
+	if err == nil {
+		r.passed++
+	} else {
+		r.failures = append(r.failures, name)
+	}
+	r.mu.Unlock()
+
+	switch {
+	case err == nil && known:
+		t.Errorf("listed as a known failure, but passes: remove it from testdata/conformance/%s.txt", r.draft)
+	case err != nil && known:
+		t.Skipf("known failure: %v", err)
+	case err != nil:
+		t.Error(err)
+	}
+}
 
-		// Skip optional tests that we don't support yet
-		if strings.Contains(path, "optional") {
-			return nil
+func (r *complianceReport) write(sb *strings.Builder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var known int
+	for _, name := range r.failures {
+		if r.known[name] {
+			known++
 		}
+	}
+	fmt.Fprintf(sb, "# %s: %d passed, %d failed (%d known)\n", r.draft, r.passed, len(r.failures), known)
+	for _, name := range slices.Sorted(slices.Values(r.failures)) {
+		sb.WriteString(name)
+		sb.WriteByte('\n')
+	}
+}
 
-		relPath, _ := filepath.Rel(testDir, path)
-		t.Run(relPath, func(t *testing.T) {
-			t.Parallel()
-			runTestFile(t, path)
-		})
-
-		return nil
-	})
-
-	require.NoError(t, err)
+// loadKnownFailures reads a known-failures file: one case per line, as
+// caseName spells it, with blank lines and lines starting with "#" ignored. A
+// missing file lists nothing.
+func loadKnownFailures(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		known[line] = true
+	}
+	return known, nil
 }
 
 // TestSuite represents a single test suite from the JSON Schema Test Suite
@@ -419,8 +550,9 @@ type TestCase struct {
 	Valid       bool   `json:"valid"`
 }
 
-// runTestFile runs all test suites in a single JSON file
-func runTestFile(t *testing.T, filePath string) {
+// runTestFile runs all test suites in a single JSON file, file being its path
+// in the suite.
+func runTestFile(t *testing.T, report *complianceReport, file, filePath string) {
 	t.Helper()
 	data, err := os.ReadFile(filePath)
 	require.NoError(t, err)
@@ -433,16 +565,14 @@ func runTestFile(t *testing.T, filePath string) {
 		t.Run(testSuite.Description, func(t *testing.T) {
 			t.Parallel()
 			t.Helper()
-			runTestSuite(t, testSuite)
+			runTestSuite(t, report, file, testSuite)
 		})
 	}
 }
 
 // runTestSuite runs a single test suite
-func runTestSuite(t *testing.T, testSuite TestSuite) {
+func runTestSuite(t *testing.T, report *complianceReport, file string, testSuite TestSuite) {
 	t.Helper()
-	var s *schema.Schema
-	var err error
 
 	// Log schema in verbose mode
 	if testing.Verbose() {
@@ -454,43 +584,8 @@ func runTestSuite(t *testing.T, testSuite TestSuite) {
 		}
 	}
 
-	// Handle boolean schemas (true/false) and object schemas
-	switch schemaValue := testSuite.Schema.(type) {
-	case bool:
-		// Boolean schema: true accepts everything, false rejects everything
-		if schemaValue {
-			s = schema.New() // Empty schema accepts all
-		} else {
-			// False schema should reject everything
-			s, err = schema.NewBuilder().Not(schema.New()).Build()
-			if err != nil {
-				t.Skipf("Failed to build false schema: %v", err)
-				return
-			}
-		}
-	default:
-		// Object schema - convert to JSON and parse
-		schemaJSON, err := json.Marshal(testSuite.Schema)
-		if err != nil {
-			t.Skipf("Failed to marshal schema: %v", err)
-			return
-		}
-
-		// Try to parse the schema
-		err = json.Unmarshal(schemaJSON, &s)
-		if err != nil {
-			t.Skipf("Failed to parse schema: %v", err)
-			return
-		}
-	}
-
-	// Compile the schema to a validator, with the suite's remote documents
-	// preloaded so http://localhost:1234/... references resolve offline.
-	v, err := validator.Compile(context.Background(), s, validator.WithResolver(newSuiteResolver(t)))
-	if err != nil {
-		t.Skipf("Failed to compile schema: %v", err)
-		return
-	}
+	// A schema that does not parse or compile fails every case of the suite.
+	v, err := compileSuiteSchema(t, report.draftValue, testSuite.Schema)
 
 	// Run each test case
 	for _, testCase := range testSuite.Tests {
@@ -509,12 +604,53 @@ func runTestSuite(t *testing.T, testSuite TestSuite) {
 				t.Logf("Expected valid: %t", testCase.Valid)
 			}
 
-			_, err := v.Validate(context.Background(), testCase.Data)
-			if testCase.Valid {
-				require.NoError(t, err, "Expected validation to pass but got error: %v", err)
-			} else {
-				require.Error(t, err, "Expected validation to fail but it passed")
+			outcome := err
+			if outcome == nil {
+				_, verr := v.Validate(context.Background(), testCase.Data)
+				switch {
+				case testCase.Valid && verr != nil:
+					outcome = fmt.Errorf("expected validation to pass but got error: %w", verr)
+				case !testCase.Valid && verr == nil:
+					outcome = errors.New("expected validation to fail but it passed")
+				}
 			}
+			report.record(t, caseName(file, testSuite.Description, testCase.Description), outcome)
 		})
 	}
 }
+
+// compileSuiteSchema compiles the schema of a suite, a decoded JSON value, for
+// draft, with the suite's remote documents preloaded so
+// http://localhost:1234/... references resolve offline.
+func compileSuiteSchema(t *testing.T, draft schema.Draft, value any) (validator.Interface, error) {
+	t.Helper()
+	var s *schema.Schema
+	// Handle boolean schemas (true/false) and object schemas
+	switch schemaValue := value.(type) {
+	case bool:
+		// Boolean schema: true accepts everything, false rejects everything
+		if schemaValue {
+			s = schema.New() // Empty schema accepts all
+		} else {
+			var err error
+			if s, err = schema.NewBuilder().Not(schema.New()).Build(); err != nil {
+				return nil, fmt.Errorf("failed to build false schema: %w", err)
+			}
+		}
+	default:
+		// Object schema - convert to JSON and parse
+		schemaJSON, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal schema: %w", err)
+		}
+		if err := json.Unmarshal(schemaJSON, &s); err != nil {
+			return nil, fmt.Errorf("failed to parse schema: %w", err)
+		}
+	}
+
+	v, err := validator.Compile(context.Background(), s, validator.WithResolver(newSuiteResolver(t)), validator.WithDefaultDraft(draft))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+	return v, nil
+}
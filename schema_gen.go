@@ -6,7 +6,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"sort"
+	"maps"
+	"slices"
 
 	"github.com/lestrrat-go/json-schema/internal/field"
 	"github.com/lestrrat-go/json-schema/keywords"
@@ -29,18 +30,23 @@ const (
 	ContentSchemaField         = field.ContentSchema
 	DefaultField               = field.Default
 	DefinitionsField           = field.Definitions
+	DependenciesField          = field.Dependencies
 	DependentRequiredField     = field.DependentRequired
 	DependentSchemasField      = field.DependentSchemas
+	DeprecatedField            = field.Deprecated
+	DescriptionField           = field.Description
 	DynamicAnchorField         = field.DynamicAnchor
 	DynamicReferenceField      = field.DynamicReference
 	ElseSchemaField            = field.ElseSchema
 	EnumField                  = field.Enum
+	ExamplesField              = field.Examples
 	ExclusiveMaximumField      = field.ExclusiveMaximum
 	ExclusiveMinimumField      = field.ExclusiveMinimum
 	FormatField                = field.Format
 	IDField                    = field.ID
 	IfSchemaField              = field.IfSchema
 	ItemsField                 = field.Items
+	LegacyDefinitionsField     = field.LegacyDefinitions
 	MaxContainsField           = field.MaxContains
 	MaxItemsField              = field.MaxItems
 	MaxLengthField             = field.MaxLength
@@ -59,15 +65,20 @@ const (
 	PrefixItemsField           = field.PrefixItems
 	PropertiesField            = field.Properties
 	PropertyNamesField         = field.PropertyNames
+	ReadOnlyField              = field.ReadOnly
+	RecursiveAnchorField       = field.RecursiveAnchor
+	RecursiveReferenceField    = field.RecursiveReference
 	ReferenceField             = field.Reference
 	RequiredField              = field.Required
 	SchemaField                = field.Schema
 	ThenSchemaField            = field.ThenSchema
+	TitleField                 = field.Title
 	TypesField                 = field.Types
 	UnevaluatedItemsField      = field.UnevaluatedItems
 	UnevaluatedPropertiesField = field.UnevaluatedProperties
 	UniqueItemsField           = field.UniqueItems
 	VocabularyField            = field.Vocabulary
+	WriteOnlyField             = field.WriteOnly
 )
 
 type Schema struct {
@@ -85,18 +96,23 @@ type Schema struct {
 	contentSchema         *Schema
 	defaultValue          *any
 	definitions           map[string]*Schema
+	dependencies          map[string]any
 	dependentRequired     map[string][]string
 	dependentSchemas      map[string]SchemaOrBool
+	deprecated            *bool
+	description           *string
 	dynamicAnchor         *string
 	dynamicReference      *string
 	elseSchema            SchemaOrBool
 	enum                  []any
+	examples              []any
 	exclusiveMaximum      *float64
 	exclusiveMinimum      *float64
 	format                *string
 	id                    *string
 	ifSchema              SchemaOrBool
 	items                 SchemaOrBool
+	legacyDefinitions     map[string]*Schema
 	maxContains           *uint
 	maxItems              *uint
 	maxLength             *int
@@ -115,15 +131,25 @@ type Schema struct {
 	prefixItems           []SchemaOrBool
 	properties            map[string]*Schema
 	propertyNames         *Schema
+	readOnly              *bool
+	recursiveAnchor       *bool
+	recursiveReference    *string
 	reference             *string
 	required              []string
 	schema                *string
 	thenSchema            SchemaOrBool
+	title                 *string
 	types                 PrimitiveTypes
 	unevaluatedItems      SchemaOrBool
 	unevaluatedProperties SchemaOrBool
 	uniqueItems           *bool
 	vocabulary            map[string]bool
+	writeOnly             *bool
+	extensions            map[string]json.RawMessage // unknown keywords, see extensions.go
+	numbers               map[string]json.Number     // literals float64 does not hold exactly, see number.go
+	compiled              *compiledValidation        // Validate's cache, see validate.go
+	frozen                bool                       // set by Freeze, see freeze.go
+	boolean               *bool                      // the boolean schema s was decoded from, see boolSchema in marshal.go
 }
 
 func New() *Schema {
@@ -246,6 +272,14 @@ func (s *Schema) Definitions() map[string]*Schema {
 	return s.definitions
 }
 
+func (s *Schema) HasDependencies() bool {
+	return s.populatedFields&DependenciesField != 0
+}
+
+func (s *Schema) Dependencies() map[string]any {
+	return s.dependencies
+}
+
 func (s *Schema) HasDependentRequired() bool {
 	return s.populatedFields&DependentRequiredField != 0
 }
@@ -262,6 +296,22 @@ func (s *Schema) DependentSchemas() map[string]SchemaOrBool {
 	return s.dependentSchemas
 }
 
+func (s *Schema) HasDeprecated() bool {
+	return s.populatedFields&DeprecatedField != 0
+}
+
+func (s *Schema) Deprecated() bool {
+	return *(s.deprecated)
+}
+
+func (s *Schema) HasDescription() bool {
+	return s.populatedFields&DescriptionField != 0
+}
+
+func (s *Schema) Description() string {
+	return *(s.description)
+}
+
 func (s *Schema) HasDynamicAnchor() bool {
 	return s.populatedFields&DynamicAnchorField != 0
 }
@@ -294,6 +344,14 @@ func (s *Schema) Enum() []any {
 	return s.enum
 }
 
+func (s *Schema) HasExamples() bool {
+	return s.populatedFields&ExamplesField != 0
+}
+
+func (s *Schema) Examples() []any {
+	return s.examples
+}
+
 func (s *Schema) HasExclusiveMaximum() bool {
 	return s.populatedFields&ExclusiveMaximumField != 0
 }
@@ -342,6 +400,14 @@ func (s *Schema) Items() SchemaOrBool {
 	return s.items
 }
 
+func (s *Schema) HasLegacyDefinitions() bool {
+	return s.populatedFields&LegacyDefinitionsField != 0
+}
+
+func (s *Schema) LegacyDefinitions() map[string]*Schema {
+	return s.legacyDefinitions
+}
+
 func (s *Schema) HasMaxContains() bool {
 	return s.populatedFields&MaxContainsField != 0
 }
@@ -486,6 +552,30 @@ func (s *Schema) PropertyNames() *Schema {
 	return s.propertyNames
 }
 
+func (s *Schema) HasReadOnly() bool {
+	return s.populatedFields&ReadOnlyField != 0
+}
+
+func (s *Schema) ReadOnly() bool {
+	return *(s.readOnly)
+}
+
+func (s *Schema) HasRecursiveAnchor() bool {
+	return s.populatedFields&RecursiveAnchorField != 0
+}
+
+func (s *Schema) RecursiveAnchor() bool {
+	return *(s.recursiveAnchor)
+}
+
+func (s *Schema) HasRecursiveReference() bool {
+	return s.populatedFields&RecursiveReferenceField != 0
+}
+
+func (s *Schema) RecursiveReference() string {
+	return *(s.recursiveReference)
+}
+
 func (s *Schema) HasReference() bool {
 	return s.populatedFields&ReferenceField != 0
 }
@@ -518,6 +608,14 @@ func (s *Schema) ThenSchema() SchemaOrBool {
 	return s.thenSchema
 }
 
+func (s *Schema) HasTitle() bool {
+	return s.populatedFields&TitleField != 0
+}
+
+func (s *Schema) Title() string {
+	return *(s.title)
+}
+
 func (s *Schema) HasTypes() bool {
 	return s.populatedFields&TypesField != 0
 }
@@ -558,6 +656,161 @@ func (s *Schema) Vocabulary() map[string]bool {
 	return s.vocabulary
 }
 
+func (s *Schema) HasWriteOnly() bool {
+	return s.populatedFields&WriteOnlyField != 0
+}
+
+func (s *Schema) WriteOnly() bool {
+	return *(s.writeOnly)
+}
+
+// ExclusiveMaximumNumber returns "exclusiveMaximum" exactly, as it was written when the schema was
+// decoded, even where ExclusiveMaximum rounds it to the nearest float64.
+func (s *Schema) ExclusiveMaximumNumber() json.Number {
+	return s.number(keywords.ExclusiveMaximum, *(s.exclusiveMaximum))
+}
+
+// ExclusiveMinimumNumber returns "exclusiveMinimum" exactly, as it was written when the schema was
+// decoded, even where ExclusiveMinimum rounds it to the nearest float64.
+func (s *Schema) ExclusiveMinimumNumber() json.Number {
+	return s.number(keywords.ExclusiveMinimum, *(s.exclusiveMinimum))
+}
+
+// MaximumNumber returns "maximum" exactly, as it was written when the schema was
+// decoded, even where Maximum rounds it to the nearest float64.
+func (s *Schema) MaximumNumber() json.Number {
+	return s.number(keywords.Maximum, *(s.maximum))
+}
+
+// MinimumNumber returns "minimum" exactly, as it was written when the schema was
+// decoded, even where Minimum rounds it to the nearest float64.
+func (s *Schema) MinimumNumber() json.Number {
+	return s.number(keywords.Minimum, *(s.minimum))
+}
+
+// MultipleOfNumber returns "multipleOf" exactly, as it was written when the schema was
+// decoded, even where MultipleOf rounds it to the nearest float64.
+func (s *Schema) MultipleOfNumber() json.Number {
+	return s.number(keywords.MultipleOf, *(s.multipleOf))
+}
+
+// AdditionalItemsAsSchema returns "additionalItems" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) AdditionalItemsAsSchema() (*Schema, bool) {
+	v, ok := s.additionalItems.(*Schema)
+	return v, ok && v != nil
+}
+
+// AdditionalItemsAsBool returns "additionalItems" when it holds a boolean, and false otherwise.
+func (s *Schema) AdditionalItemsAsBool() (bool, bool) {
+	v, ok := s.additionalItems.(BoolSchema)
+	return bool(v), ok
+}
+
+// AdditionalPropertiesAsSchema returns "additionalProperties" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) AdditionalPropertiesAsSchema() (*Schema, bool) {
+	v, ok := s.additionalProperties.(*Schema)
+	return v, ok && v != nil
+}
+
+// AdditionalPropertiesAsBool returns "additionalProperties" when it holds a boolean, and false otherwise.
+func (s *Schema) AdditionalPropertiesAsBool() (bool, bool) {
+	v, ok := s.additionalProperties.(BoolSchema)
+	return bool(v), ok
+}
+
+// ContainsAsSchema returns "contains" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) ContainsAsSchema() (*Schema, bool) {
+	v, ok := s.contains.(*Schema)
+	return v, ok && v != nil
+}
+
+// ContainsAsBool returns "contains" when it holds a boolean, and false otherwise.
+func (s *Schema) ContainsAsBool() (bool, bool) {
+	v, ok := s.contains.(BoolSchema)
+	return bool(v), ok
+}
+
+// ElseAsSchema returns "else" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) ElseAsSchema() (*Schema, bool) {
+	v, ok := s.elseSchema.(*Schema)
+	return v, ok && v != nil
+}
+
+// ElseAsBool returns "else" when it holds a boolean, and false otherwise.
+func (s *Schema) ElseAsBool() (bool, bool) {
+	v, ok := s.elseSchema.(BoolSchema)
+	return bool(v), ok
+}
+
+// IfAsSchema returns "if" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) IfAsSchema() (*Schema, bool) {
+	v, ok := s.ifSchema.(*Schema)
+	return v, ok && v != nil
+}
+
+// IfAsBool returns "if" when it holds a boolean, and false otherwise.
+func (s *Schema) IfAsBool() (bool, bool) {
+	v, ok := s.ifSchema.(BoolSchema)
+	return bool(v), ok
+}
+
+// ItemsAsSchema returns "items" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) ItemsAsSchema() (*Schema, bool) {
+	v, ok := s.items.(*Schema)
+	return v, ok && v != nil
+}
+
+// ItemsAsBool returns "items" when it holds a boolean, and false otherwise.
+func (s *Schema) ItemsAsBool() (bool, bool) {
+	v, ok := s.items.(BoolSchema)
+	return bool(v), ok
+}
+
+// ThenAsSchema returns "then" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) ThenAsSchema() (*Schema, bool) {
+	v, ok := s.thenSchema.(*Schema)
+	return v, ok && v != nil
+}
+
+// ThenAsBool returns "then" when it holds a boolean, and false otherwise.
+func (s *Schema) ThenAsBool() (bool, bool) {
+	v, ok := s.thenSchema.(BoolSchema)
+	return bool(v), ok
+}
+
+// UnevaluatedItemsAsSchema returns "unevaluatedItems" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) UnevaluatedItemsAsSchema() (*Schema, bool) {
+	v, ok := s.unevaluatedItems.(*Schema)
+	return v, ok && v != nil
+}
+
+// UnevaluatedItemsAsBool returns "unevaluatedItems" when it holds a boolean, and false otherwise.
+func (s *Schema) UnevaluatedItemsAsBool() (bool, bool) {
+	v, ok := s.unevaluatedItems.(BoolSchema)
+	return bool(v), ok
+}
+
+// UnevaluatedPropertiesAsSchema returns "unevaluatedProperties" when it holds a schema rather than a
+// boolean, and false otherwise.
+func (s *Schema) UnevaluatedPropertiesAsSchema() (*Schema, bool) {
+	v, ok := s.unevaluatedProperties.(*Schema)
+	return v, ok && v != nil
+}
+
+// UnevaluatedPropertiesAsBool returns "unevaluatedProperties" when it holds a boolean, and false otherwise.
+func (s *Schema) UnevaluatedPropertiesAsBool() (bool, bool) {
+	v, ok := s.unevaluatedProperties.(BoolSchema)
+	return bool(v), ok
+}
+
 func (s *Schema) ContainsType(typ PrimitiveType) bool {
 	if s.types == nil {
 		return false
@@ -575,8 +828,10 @@ type pair struct {
 	Value any
 }
 
-func (s *Schema) MarshalJSON() ([]byte, error) {
-	fields := make([]pair, 0, 52)
+// marshalFields lists the populated keywords of s, in no particular order,
+// for the encoder in marshal.go.
+func (s *Schema) marshalFields() []pair {
+	fields := make([]pair, 0, 62)
 	if s.HasAdditionalItems() {
 		fields = append(fields, pair{Name: keywords.AdditionalItems, Value: s.additionalItems})
 	}
@@ -616,12 +871,21 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasDefinitions() {
 		fields = append(fields, pair{Name: keywords.Definitions, Value: s.definitions})
 	}
+	if s.HasDependencies() {
+		fields = append(fields, pair{Name: keywords.Dependencies, Value: s.dependencies})
+	}
 	if s.HasDependentRequired() {
 		fields = append(fields, pair{Name: keywords.DependentRequired, Value: s.dependentRequired})
 	}
 	if s.HasDependentSchemas() {
 		fields = append(fields, pair{Name: keywords.DependentSchemas, Value: s.dependentSchemas})
 	}
+	if s.HasDeprecated() {
+		fields = append(fields, pair{Name: keywords.Deprecated, Value: *(s.deprecated)})
+	}
+	if s.HasDescription() {
+		fields = append(fields, pair{Name: keywords.Description, Value: *(s.description)})
+	}
 	if s.HasDynamicAnchor() {
 		fields = append(fields, pair{Name: keywords.DynamicAnchor, Value: *(s.dynamicAnchor)})
 	}
@@ -634,11 +898,14 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasEnum() {
 		fields = append(fields, pair{Name: keywords.Enum, Value: s.enum})
 	}
+	if s.HasExamples() {
+		fields = append(fields, pair{Name: keywords.Examples, Value: s.examples})
+	}
 	if s.HasExclusiveMaximum() {
-		fields = append(fields, pair{Name: keywords.ExclusiveMaximum, Value: *(s.exclusiveMaximum)})
+		fields = append(fields, pair{Name: keywords.ExclusiveMaximum, Value: s.numberValue(keywords.ExclusiveMaximum, *(s.exclusiveMaximum))})
 	}
 	if s.HasExclusiveMinimum() {
-		fields = append(fields, pair{Name: keywords.ExclusiveMinimum, Value: *(s.exclusiveMinimum)})
+		fields = append(fields, pair{Name: keywords.ExclusiveMinimum, Value: s.numberValue(keywords.ExclusiveMinimum, *(s.exclusiveMinimum))})
 	}
 	if s.HasFormat() {
 		fields = append(fields, pair{Name: keywords.Format, Value: *(s.format)})
@@ -652,6 +919,9 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasItems() {
 		fields = append(fields, pair{Name: keywords.Items, Value: s.items})
 	}
+	if s.HasLegacyDefinitions() {
+		fields = append(fields, pair{Name: keywords.LegacyDefinitions, Value: s.legacyDefinitions})
+	}
 	if s.HasMaxContains() {
 		fields = append(fields, pair{Name: keywords.MaxContains, Value: *(s.maxContains)})
 	}
@@ -665,7 +935,7 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 		fields = append(fields, pair{Name: keywords.MaxProperties, Value: *(s.maxProperties)})
 	}
 	if s.HasMaximum() {
-		fields = append(fields, pair{Name: keywords.Maximum, Value: *(s.maximum)})
+		fields = append(fields, pair{Name: keywords.Maximum, Value: s.numberValue(keywords.Maximum, *(s.maximum))})
 	}
 	if s.HasMinContains() {
 		fields = append(fields, pair{Name: keywords.MinContains, Value: *(s.minContains)})
@@ -680,10 +950,10 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 		fields = append(fields, pair{Name: keywords.MinProperties, Value: *(s.minProperties)})
 	}
 	if s.HasMinimum() {
-		fields = append(fields, pair{Name: keywords.Minimum, Value: *(s.minimum)})
+		fields = append(fields, pair{Name: keywords.Minimum, Value: s.numberValue(keywords.Minimum, *(s.minimum))})
 	}
 	if s.HasMultipleOf() {
-		fields = append(fields, pair{Name: keywords.MultipleOf, Value: *(s.multipleOf)})
+		fields = append(fields, pair{Name: keywords.MultipleOf, Value: s.numberValue(keywords.MultipleOf, *(s.multipleOf))})
 	}
 	if s.HasNot() {
 		fields = append(fields, pair{Name: keywords.Not, Value: s.not})
@@ -706,6 +976,15 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasPropertyNames() {
 		fields = append(fields, pair{Name: keywords.PropertyNames, Value: s.propertyNames})
 	}
+	if s.HasReadOnly() {
+		fields = append(fields, pair{Name: keywords.ReadOnly, Value: *(s.readOnly)})
+	}
+	if s.HasRecursiveAnchor() {
+		fields = append(fields, pair{Name: keywords.RecursiveAnchor, Value: *(s.recursiveAnchor)})
+	}
+	if s.HasRecursiveReference() {
+		fields = append(fields, pair{Name: keywords.RecursiveRef, Value: *(s.recursiveReference)})
+	}
 	if s.HasReference() {
 		fields = append(fields, pair{Name: keywords.Reference, Value: *(s.reference)})
 	}
@@ -718,6 +997,9 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasThenSchema() {
 		fields = append(fields, pair{Name: keywords.Then, Value: s.thenSchema})
 	}
+	if s.HasTitle() {
+		fields = append(fields, pair{Name: keywords.Title, Value: *(s.title)})
+	}
 	if s.HasTypes() {
 		fields = append(fields, pair{Name: keywords.Type, Value: s.types})
 	}
@@ -733,30 +1015,157 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	if s.HasVocabulary() {
 		fields = append(fields, pair{Name: keywords.Vocabulary, Value: s.vocabulary})
 	}
-	sort.Slice(fields, func(i, j int) bool {
-		return compareFieldNames(fields[i].Name, fields[j].Name)
-	})
-	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
-	buf.WriteByte('{')
-	for i, field := range fields {
-		if i > 0 {
-			buf.WriteByte(',')
-		}
-		if err := enc.Encode(field.Name); err != nil {
-			return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field name: %w", err)
-		}
-		buf.WriteByte(':')
-		if err := enc.Encode(field.Value); err != nil {
-			return nil, fmt.Errorf("json-schema: Schema.MarshalJSON: failed to encode field value: %w", err)
-		}
+	if s.HasWriteOnly() {
+		fields = append(fields, pair{Name: keywords.WriteOnly, Value: *(s.writeOnly)})
 	}
-	buf.WriteByte('}')
-	return buf.Bytes(), nil
+	return fields
+}
+
+// keywordShapes maps each keyword Schema models to the shape of its value,
+// for UnmarshalStrict to find the subschemas in a document.
+var keywordShapes = map[string]keywordShape{
+	keywords.AdditionalItems:       shapeSchema,
+	keywords.AdditionalProperties:  shapeSchema,
+	keywords.AllOf:                 shapeSchemaList,
+	keywords.Anchor:                shapeValue,
+	keywords.AnyOf:                 shapeSchemaList,
+	keywords.Comment:               shapeValue,
+	keywords.Const:                 shapeValue,
+	keywords.Contains:              shapeSchema,
+	keywords.ContentEncoding:       shapeValue,
+	keywords.ContentMediaType:      shapeValue,
+	keywords.ContentSchema:         shapeSchema,
+	keywords.Default:               shapeValue,
+	keywords.Definitions:           shapeSchemaMap,
+	keywords.Dependencies:          shapeDependencies,
+	keywords.DependentRequired:     shapeValue,
+	keywords.DependentSchemas:      shapeSchemaMap,
+	keywords.Deprecated:            shapeValue,
+	keywords.Description:           shapeValue,
+	keywords.DynamicAnchor:         shapeValue,
+	keywords.DynamicReference:      shapeValue,
+	keywords.Else:                  shapeSchema,
+	keywords.Enum:                  shapeValue,
+	keywords.Examples:              shapeValue,
+	keywords.ExclusiveMaximum:      shapeValue,
+	keywords.ExclusiveMinimum:      shapeValue,
+	keywords.Format:                shapeValue,
+	keywords.ID:                    shapeValue,
+	keywords.If:                    shapeSchema,
+	keywords.Items:                 shapeSchema,
+	keywords.LegacyDefinitions:     shapeSchemaMap,
+	keywords.MaxContains:           shapeValue,
+	keywords.MaxItems:              shapeValue,
+	keywords.MaxLength:             shapeValue,
+	keywords.MaxProperties:         shapeValue,
+	keywords.Maximum:               shapeValue,
+	keywords.MinContains:           shapeValue,
+	keywords.MinItems:              shapeValue,
+	keywords.MinLength:             shapeValue,
+	keywords.MinProperties:         shapeValue,
+	keywords.Minimum:               shapeValue,
+	keywords.MultipleOf:            shapeValue,
+	keywords.Not:                   shapeSchema,
+	keywords.OneOf:                 shapeSchemaList,
+	keywords.Pattern:               shapeValue,
+	keywords.PatternProperties:     shapeSchemaMap,
+	keywords.PrefixItems:           shapeSchemaList,
+	keywords.Properties:            shapeSchemaMap,
+	keywords.PropertyNames:         shapeSchema,
+	keywords.ReadOnly:              shapeValue,
+	keywords.RecursiveAnchor:       shapeValue,
+	keywords.RecursiveRef:          shapeValue,
+	keywords.Reference:             shapeValue,
+	keywords.Required:              shapeValue,
+	keywords.Schema:                shapeValue,
+	keywords.Then:                  shapeSchema,
+	keywords.Title:                 shapeValue,
+	keywords.Type:                  shapeValue,
+	keywords.UnevaluatedItems:      shapeSchema,
+	keywords.UnevaluatedProperties: shapeSchema,
+	keywords.UniqueItems:           shapeValue,
+	keywords.Vocabulary:            shapeValue,
+	keywords.WriteOnly:             shapeValue,
+}
+
+// deepCopyFields copies every keyword of s into c, duplicating the
+// subschemas, slices and maps they hold.
+func (s *Schema) deepCopyFields(c *Schema) {
+	c.populatedFields = s.populatedFields
+	c.additionalItems = cloneSchemaOrBool(s.additionalItems)
+	c.additionalProperties = cloneSchemaOrBool(s.additionalProperties)
+	c.allOf = cloneSchemaOrBoolSlice(s.allOf)
+	c.anchor = clonePtr(s.anchor)
+	c.anyOf = cloneSchemaOrBoolSlice(s.anyOf)
+	c.comment = clonePtr(s.comment)
+	c.constantValue = cloneValuePtr(s.constantValue)
+	c.contains = cloneSchemaOrBool(s.contains)
+	c.contentEncoding = clonePtr(s.contentEncoding)
+	c.contentMediaType = clonePtr(s.contentMediaType)
+	c.contentSchema = s.contentSchema.DeepClone()
+	c.defaultValue = cloneValuePtr(s.defaultValue)
+	c.definitions = cloneSchemaMap(s.definitions)
+	c.dependencies = cloneValueMap(s.dependencies)
+	c.dependentRequired = cloneStringSliceMap(s.dependentRequired)
+	c.dependentSchemas = cloneSchemaOrBoolMap(s.dependentSchemas)
+	c.deprecated = clonePtr(s.deprecated)
+	c.description = clonePtr(s.description)
+	c.dynamicAnchor = clonePtr(s.dynamicAnchor)
+	c.dynamicReference = clonePtr(s.dynamicReference)
+	c.elseSchema = cloneSchemaOrBool(s.elseSchema)
+	c.enum = cloneValueSlice(s.enum)
+	c.examples = cloneValueSlice(s.examples)
+	c.exclusiveMaximum = clonePtr(s.exclusiveMaximum)
+	c.exclusiveMinimum = clonePtr(s.exclusiveMinimum)
+	c.format = clonePtr(s.format)
+	c.id = clonePtr(s.id)
+	c.ifSchema = cloneSchemaOrBool(s.ifSchema)
+	c.items = cloneSchemaOrBool(s.items)
+	c.legacyDefinitions = cloneSchemaMap(s.legacyDefinitions)
+	c.maxContains = clonePtr(s.maxContains)
+	c.maxItems = clonePtr(s.maxItems)
+	c.maxLength = clonePtr(s.maxLength)
+	c.maxProperties = clonePtr(s.maxProperties)
+	c.maximum = clonePtr(s.maximum)
+	c.minContains = clonePtr(s.minContains)
+	c.minItems = clonePtr(s.minItems)
+	c.minLength = clonePtr(s.minLength)
+	c.minProperties = clonePtr(s.minProperties)
+	c.minimum = clonePtr(s.minimum)
+	c.multipleOf = clonePtr(s.multipleOf)
+	c.not = s.not.DeepClone()
+	c.oneOf = cloneSchemaOrBoolSlice(s.oneOf)
+	c.pattern = clonePtr(s.pattern)
+	c.patternProperties = cloneSchemaMap(s.patternProperties)
+	c.prefixItems = cloneSchemaOrBoolSlice(s.prefixItems)
+	c.properties = cloneSchemaMap(s.properties)
+	c.propertyNames = s.propertyNames.DeepClone()
+	c.readOnly = clonePtr(s.readOnly)
+	c.recursiveAnchor = clonePtr(s.recursiveAnchor)
+	c.recursiveReference = clonePtr(s.recursiveReference)
+	c.reference = clonePtr(s.reference)
+	c.required = slices.Clone(s.required)
+	c.schema = clonePtr(s.schema)
+	c.thenSchema = cloneSchemaOrBool(s.thenSchema)
+	c.title = clonePtr(s.title)
+	c.types = slices.Clone(s.types)
+	c.unevaluatedItems = cloneSchemaOrBool(s.unevaluatedItems)
+	c.unevaluatedProperties = cloneSchemaOrBool(s.unevaluatedProperties)
+	c.uniqueItems = clonePtr(s.uniqueItems)
+	c.vocabulary = maps.Clone(s.vocabulary)
+	c.writeOnly = clonePtr(s.writeOnly)
+	c.extensions = cloneExtensions(s.extensions)
+	c.numbers = maps.Clone(s.numbers)
+	c.boolean = clonePtr(s.boolean)
 }
 
 func (s *Schema) UnmarshalJSON(buf []byte) error {
+	if s.frozen {
+		return errFrozen
+	}
 	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var draft04ExclusiveMinimum, draft04ExclusiveMaximum *bool
 LOOP:
 	for {
 		tok, err := dec.Token()
@@ -845,6 +1254,7 @@ LOOP:
 				if err := dec.Decode(&v); err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "const" (attempting to unmarshal as any): %w`, err)
 				}
+				v = preserveLargeIntegers(v)
 				s.constantValue = &v
 				s.populatedFields |= ConstField
 			case keywords.Contains:
@@ -888,15 +1298,7 @@ LOOP:
 				// Try to decode as boolean first
 				var b bool
 				if err := json.Unmarshal(rawData, &b); err == nil {
-					// Convert boolean to Schema object
-					if b {
-						s.contentSchema = &Schema{} // true schema - allow everything
-					} else {
-						// false schema - deny everything using "not": {}
-						falseSchema := &Schema{not: &Schema{}}
-						falseSchema.populatedFields |= NotField
-						s.contentSchema = falseSchema
-					}
+					s.contentSchema = boolSchema(b)
 				} else {
 					// Try to decode as Schema object
 					var schema Schema
@@ -912,6 +1314,7 @@ LOOP:
 				if err := dec.Decode(&v); err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "default" (attempting to unmarshal as any): %w`, err)
 				}
+				v = preserveLargeIntegers(v)
 				s.defaultValue = &v
 				s.populatedFields |= DefaultField
 			case keywords.Definitions:
@@ -930,15 +1333,7 @@ LOOP:
 					// Try to decode as boolean first
 					var b bool
 					if err := json.Unmarshal(rawValue, &b); err == nil {
-						// Convert boolean to Schema object
-						if b {
-							v[key] = &Schema{} // true schema - allow everything
-						} else {
-							// false schema - deny everything using "not": {}
-							falseSchema := &Schema{not: &Schema{}}
-							falseSchema.populatedFields |= NotField
-							v[key] = falseSchema
-						}
+						v[key] = boolSchema(b)
 					} else {
 						// Try to decode as Schema object
 						var schema Schema
@@ -951,6 +1346,13 @@ LOOP:
 				}
 				s.definitions = v
 				s.populatedFields |= DefinitionsField
+			case keywords.Dependencies:
+				v, err := unmarshalDependencies(dec)
+				if err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "dependencies" (attempting to unmarshal as dependencies): %w`, err)
+				}
+				s.dependencies = v
+				s.populatedFields |= DependenciesField
 			case keywords.DependentRequired:
 				var v map[string][]string
 				if err := dec.Decode(&v); err != nil {
@@ -965,6 +1367,20 @@ LOOP:
 				}
 				s.dependentSchemas = v
 				s.populatedFields |= DependentSchemasField
+			case keywords.Deprecated:
+				var v bool
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "deprecated" (attempting to unmarshal as bool): %w`, err)
+				}
+				s.deprecated = &v
+				s.populatedFields |= DeprecatedField
+			case keywords.Description:
+				var v string
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "description" (attempting to unmarshal as string): %w`, err)
+				}
+				s.description = &v
+				s.populatedFields |= DescriptionField
 			case keywords.DynamicAnchor:
 				var v string
 				if err := dec.Decode(&v); err != nil {
@@ -1003,22 +1419,63 @@ LOOP:
 				if err := dec.Decode(&v); err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "enum" (attempting to unmarshal as []any): %w`, err)
 				}
+				for i, elem := range v {
+					v[i] = preserveLargeIntegers(elem)
+				}
 				s.enum = v
 				s.populatedFields |= EnumField
-			case keywords.ExclusiveMaximum:
-				var v float64
+			case keywords.Examples:
+				var v []any
 				if err := dec.Decode(&v); err != nil {
-					return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMaximum" (attempting to unmarshal as float64): %w`, err)
+					return fmt.Errorf(`json-schema: failed to decode value for field "examples" (attempting to unmarshal as []any): %w`, err)
+				}
+				for i, elem := range v {
+					v[i] = preserveLargeIntegers(elem)
+				}
+				s.examples = v
+				s.populatedFields |= ExamplesField
+			case keywords.ExclusiveMaximum:
+				var rawData json.RawMessage
+				if err := dec.Decode(&rawData); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode raw data for field "exclusiveMaximum": %w`, err)
+				}
+				// Draft-04 uses a boolean that makes "maximum" exclusive
+				var b bool
+				if err := json.Unmarshal(rawData, &b); err == nil {
+					draft04ExclusiveMaximum = &b
+				} else {
+					var n json.Number
+					if err := json.Unmarshal(rawData, &n); err != nil {
+						return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMaximum" (attempting to unmarshal as float64 or bool): %w`, err)
+					}
+					v, err := s.decodeNumber(keywords.ExclusiveMaximum, n)
+					if err != nil {
+						return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMaximum" (attempting to unmarshal as float64 or bool): %w`, err)
+					}
+					s.exclusiveMaximum = &v
+					s.populatedFields |= ExclusiveMaximumField
 				}
-				s.exclusiveMaximum = &v
-				s.populatedFields |= ExclusiveMaximumField
 			case keywords.ExclusiveMinimum:
-				var v float64
-				if err := dec.Decode(&v); err != nil {
-					return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMinimum" (attempting to unmarshal as float64): %w`, err)
+				var rawData json.RawMessage
+				if err := dec.Decode(&rawData); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode raw data for field "exclusiveMinimum": %w`, err)
+				}
+				// Draft-04 uses a boolean that makes "minimum" exclusive
+				var b bool
+				if err := json.Unmarshal(rawData, &b); err == nil {
+					draft04ExclusiveMinimum = &b
+				} else {
+					var n json.Number
+					if err := json.Unmarshal(rawData, &n); err != nil {
+						return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMinimum" (attempting to unmarshal as float64 or bool): %w`, err)
+					}
+					v, err := s.decodeNumber(keywords.ExclusiveMinimum, n)
+					if err != nil {
+						return fmt.Errorf(`json-schema: failed to decode value for field "exclusiveMinimum" (attempting to unmarshal as float64 or bool): %w`, err)
+					}
+					s.exclusiveMinimum = &v
+					s.populatedFields |= ExclusiveMinimumField
 				}
-				s.exclusiveMinimum = &v
-				s.populatedFields |= ExclusiveMinimumField
 			case keywords.Format:
 				var v string
 				if err := dec.Decode(&v); err != nil {
@@ -1061,6 +1518,12 @@ LOOP:
 				var b bool
 				if err := json.Unmarshal(rawData, &b); err == nil {
 					s.items = BoolSchema(b)
+				} else if len(rawData) > 0 && rawData[0] == '[' {
+					v, err := unmarshalSchemaOrBoolSlice(json.NewDecoder(bytes.NewReader(rawData)))
+					if err != nil {
+						return fmt.Errorf(`json-schema: failed to decode value for field "items" (attempting to unmarshal as TupleItems): %w`, err)
+					}
+					s.items = TupleItems(v)
 				} else {
 					// Try to decode as Schema object
 					var schema Schema
@@ -1071,79 +1534,152 @@ LOOP:
 					}
 				}
 				s.populatedFields |= ItemsField
+			case keywords.LegacyDefinitions:
+				var rawData json.RawMessage
+				if err := dec.Decode(&rawData); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode raw data for field "definitions": %w`, err)
+				}
+				// First unmarshal as map[string]json.RawMessage
+				var rawMap map[string]json.RawMessage
+				if err := json.Unmarshal(rawData, &rawMap); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "definitions" (attempting to unmarshal as map): %w`, err)
+				}
+				// Convert each value to *Schema
+				v := make(map[string]*Schema)
+				for key, rawValue := range rawMap {
+					// Try to decode as boolean first
+					var b bool
+					if err := json.Unmarshal(rawValue, &b); err == nil {
+						v[key] = boolSchema(b)
+					} else {
+						// Try to decode as Schema object
+						var schema Schema
+						if err := json.Unmarshal(rawValue, &schema); err == nil {
+							v[key] = &schema
+						} else {
+							return fmt.Errorf(`json-schema: failed to decode value for field "definitions" key %q (attempting to unmarshal as Schema after bool failed): %w`, key, err)
+						}
+					}
+				}
+				s.legacyDefinitions = v
+				s.populatedFields |= LegacyDefinitionsField
 			case keywords.MaxContains:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "maxContains" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "maxContains" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.maxContains = &v
 				s.populatedFields |= MaxContainsField
 			case keywords.MaxItems:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "maxItems" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "maxItems" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.maxItems = &v
 				s.populatedFields |= MaxItemsField
 			case keywords.MaxLength:
-				var v int
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "maxLength" (attempting to unmarshal as int): %w`, err)
+				}
+				v, err := integerValue[int](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "maxLength" (attempting to unmarshal as int): %w`, err)
 				}
 				s.maxLength = &v
 				s.populatedFields |= MaxLengthField
 			case keywords.MaxProperties:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "maxProperties" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "maxProperties" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.maxProperties = &v
 				s.populatedFields |= MaxPropertiesField
 			case keywords.Maximum:
-				var v float64
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "maximum" (attempting to unmarshal as float64): %w`, err)
+				}
+				v, err := s.decodeNumber(keywords.Maximum, n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "maximum" (attempting to unmarshal as float64): %w`, err)
 				}
 				s.maximum = &v
 				s.populatedFields |= MaximumField
 			case keywords.MinContains:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "minContains" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "minContains" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.minContains = &v
 				s.populatedFields |= MinContainsField
 			case keywords.MinItems:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "minItems" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "minItems" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.minItems = &v
 				s.populatedFields |= MinItemsField
 			case keywords.MinLength:
-				var v int
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "minLength" (attempting to unmarshal as int): %w`, err)
+				}
+				v, err := integerValue[int](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "minLength" (attempting to unmarshal as int): %w`, err)
 				}
 				s.minLength = &v
 				s.populatedFields |= MinLengthField
 			case keywords.MinProperties:
-				var v uint
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "minProperties" (attempting to unmarshal as uint): %w`, err)
+				}
+				v, err := integerValue[uint](n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "minProperties" (attempting to unmarshal as uint): %w`, err)
 				}
 				s.minProperties = &v
 				s.populatedFields |= MinPropertiesField
 			case keywords.Minimum:
-				var v float64
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "minimum" (attempting to unmarshal as float64): %w`, err)
+				}
+				v, err := s.decodeNumber(keywords.Minimum, n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "minimum" (attempting to unmarshal as float64): %w`, err)
 				}
 				s.minimum = &v
 				s.populatedFields |= MinimumField
 			case keywords.MultipleOf:
-				var v float64
-				if err := dec.Decode(&v); err != nil {
+				var n json.Number
+				if err := dec.Decode(&n); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "multipleOf" (attempting to unmarshal as float64): %w`, err)
+				}
+				v, err := s.decodeNumber(keywords.MultipleOf, n)
+				if err != nil {
 					return fmt.Errorf(`json-schema: failed to decode value for field "multipleOf" (attempting to unmarshal as float64): %w`, err)
 				}
 				s.multipleOf = &v
@@ -1156,15 +1692,7 @@ LOOP:
 				// Try to decode as boolean first
 				var b bool
 				if err := json.Unmarshal(rawData, &b); err == nil {
-					// Convert boolean to Schema object
-					if b {
-						s.not = &Schema{} // true schema - allow everything
-					} else {
-						// false schema - deny everything using "not": {}
-						falseSchema := &Schema{not: &Schema{}}
-						falseSchema.populatedFields |= NotField
-						s.not = falseSchema
-					}
+					s.not = boolSchema(b)
 				} else {
 					// Try to decode as Schema object
 					var schema Schema
@@ -1205,15 +1733,7 @@ LOOP:
 					// Try to decode as boolean first
 					var b bool
 					if err := json.Unmarshal(rawValue, &b); err == nil {
-						// Convert boolean to Schema object
-						if b {
-							v[key] = &Schema{} // true schema - allow everything
-						} else {
-							// false schema - deny everything using "not": {}
-							falseSchema := &Schema{not: &Schema{}}
-							falseSchema.populatedFields |= NotField
-							v[key] = falseSchema
-						}
+						v[key] = boolSchema(b)
 					} else {
 						// Try to decode as Schema object
 						var schema Schema
@@ -1249,15 +1769,7 @@ LOOP:
 					// Try to decode as boolean first
 					var b bool
 					if err := json.Unmarshal(rawValue, &b); err == nil {
-						// Convert boolean to Schema object
-						if b {
-							v[key] = &Schema{} // true schema - allow everything
-						} else {
-							// false schema - deny everything using "not": {}
-							falseSchema := &Schema{not: &Schema{}}
-							falseSchema.populatedFields |= NotField
-							v[key] = falseSchema
-						}
+						v[key] = boolSchema(b)
 					} else {
 						// Try to decode as Schema object
 						var schema Schema
@@ -1278,15 +1790,7 @@ LOOP:
 				// Try to decode as boolean first
 				var b bool
 				if err := json.Unmarshal(rawData, &b); err == nil {
-					// Convert boolean to Schema object
-					if b {
-						s.propertyNames = &Schema{} // true schema - allow everything
-					} else {
-						// false schema - deny everything using "not": {}
-						falseSchema := &Schema{not: &Schema{}}
-						falseSchema.populatedFields |= NotField
-						s.propertyNames = falseSchema
-					}
+					s.propertyNames = boolSchema(b)
 				} else {
 					// Try to decode as Schema object
 					var schema Schema
@@ -1297,6 +1801,27 @@ LOOP:
 					}
 				}
 				s.populatedFields |= PropertyNamesField
+			case keywords.ReadOnly:
+				var v bool
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "readOnly" (attempting to unmarshal as bool): %w`, err)
+				}
+				s.readOnly = &v
+				s.populatedFields |= ReadOnlyField
+			case keywords.RecursiveAnchor:
+				var v bool
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "$recursiveAnchor" (attempting to unmarshal as bool): %w`, err)
+				}
+				s.recursiveAnchor = &v
+				s.populatedFields |= RecursiveAnchorField
+			case keywords.RecursiveRef:
+				var v string
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "$recursiveRef" (attempting to unmarshal as string): %w`, err)
+				}
+				s.recursiveReference = &v
+				s.populatedFields |= RecursiveReferenceField
 			case keywords.Reference:
 				var v string
 				if err := dec.Decode(&v); err != nil {
@@ -1337,6 +1862,13 @@ LOOP:
 					}
 				}
 				s.populatedFields |= ThenSchemaField
+			case keywords.Title:
+				var v string
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "title" (attempting to unmarshal as string): %w`, err)
+				}
+				s.title = &v
+				s.populatedFields |= TitleField
 			case keywords.Type:
 				var v PrimitiveTypes
 				if err := dec.Decode(&v); err != nil {
@@ -1396,14 +1928,25 @@ LOOP:
 				}
 				s.vocabulary = v
 				s.populatedFields |= VocabularyField
+			case keywords.WriteOnly:
+				var v bool
+				if err := dec.Decode(&v); err != nil {
+					return fmt.Errorf(`json-schema: failed to decode value for field "writeOnly" (attempting to unmarshal as bool): %w`, err)
+				}
+				s.writeOnly = &v
+				s.populatedFields |= WriteOnlyField
 			default:
-				// Skip unknown fields by consuming their values
-				var discard json.RawMessage
-				if err := dec.Decode(&discard); err != nil {
+				// Keep unknown fields as they are, so they survive a round trip
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
 					return fmt.Errorf(`json-schema: failed to decode unknown field %q: %w`, tok, err)
 				}
+				if s.extensions == nil {
+					s.extensions = make(map[string]json.RawMessage)
+				}
+				s.extensions[tok] = raw
 			}
 		}
 	}
-	return nil
+	return s.applyDraft04ExclusiveBounds(draft04ExclusiveMinimum, draft04ExclusiveMaximum)
 }
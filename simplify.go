@@ -0,0 +1,301 @@
+package schema
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// simplifyIdentity are the keywords that make a schema a resource or a
+	// reference target of its own, that declare its draft, or that hold
+	// reference targets.
+	simplifyIdentity = IDField | AnchorField | DynamicAnchorField | RecursiveAnchorField | SchemaField | VocabularyField | DefinitionsField | LegacyDefinitionsField
+	// simplifyReferences are the reference keywords, which draft-07 and
+	// earlier apply in place of their siblings.
+	simplifyReferences = ReferenceField | DynamicReferenceField | RecursiveReferenceField
+	// simplifyUnevaluated depend on every keyword next to them.
+	simplifyUnevaluated = UnevaluatedPropertiesField | UnevaluatedItemsField
+	// simplifyContentGroup is, like the groups of Merge, a set of keywords
+	// that only mean something together.
+	simplifyContentGroup = ContentEncodingField | ContentMediaTypeField | ContentSchemaField
+)
+
+// Simplify returns a copy of s with redundant structure removed, which
+// accepts and rejects exactly the values s does, and reports the same
+// annotations for them. Recursively, in every subschema:
+//
+//   - "true" and duplicate entries are dropped from allOf, and "false" and
+//     duplicate entries from anyOf
+//   - an allOf entry that holds nothing but an allOf is spliced into its
+//     parent, and an allOf containing "false" is reduced to [false]
+//   - an anyOf or oneOf with a single entry becomes an allOf entry
+//   - "not": false is removed
+//   - an allOf entry whose keywords do not overlap with those of its parent is
+//     merged into the parent, and a schema holding nothing but an allOf of one
+//     entry is replaced by that entry
+//
+// When no schema in s uses unevaluatedProperties or unevaluatedItems, which
+// depend on the annotations of every subschema, Simplify also drops an anyOf
+// that has a "true" entry, and unwraps "not": {"not": X} to X.
+//
+// Entries that are resources of their own ($id, $anchor, ...) or references
+// are never merged into their parent. Simplify moves subschemas, so a
+// "$ref" with a JSON Pointer such as "#/properties/a/allOf/0" could stop
+// resolving: s is returned unchanged, as a copy, if it contains a local
+// reference by JSON Pointer to anything but an entry of "$defs" or
+// "definitions". References from other documents into s are not known to
+// Simplify, and are the caller's concern.
+//
+// s is not modified. Simplify returns nil if s is nil.
+func Simplify(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	out := s.DeepClone()
+	var unsafe, unevaluated bool
+	_ = out.Walk(func(_ string, sub *Schema) error {
+		if sub.HasAny(simplifyUnevaluated) {
+			unevaluated = true
+		}
+		for _, ref := range []struct {
+			has bool
+			get func() string
+		}{
+			{sub.HasReference(), sub.Reference},
+			{sub.HasDynamicReference(), sub.DynamicReference},
+			{sub.HasRecursiveReference(), sub.RecursiveReference},
+		} {
+			if ref.has && !simplifiableReference(ref.get()) {
+				unsafe = true
+				return SkipAll
+			}
+		}
+		return nil
+	})
+	if unsafe {
+		return out
+	}
+	simplifier{unevaluated: unevaluated}.simplify(out)
+	return out
+}
+
+// simplifiableReference reports whether ref, a $ref value, keeps resolving
+// when subschemas move: it points to another document, to an anchor, to the
+// root, or to an entry of $defs or definitions.
+func simplifiableReference(ref string) bool {
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		return true
+	}
+	if i > 0 {
+		return true // the fragment applies to the document the URI names
+	}
+	fragment, err := url.PathUnescape(ref[i+1:])
+	if err != nil {
+		return false
+	}
+	if fragment == "" || !strings.HasPrefix(fragment, "/") {
+		return true
+	}
+	tokens := strings.Split(fragment[1:], "/")
+	return len(tokens) == 2 && (tokens[0] == "$defs" || tokens[0] == "definitions")
+}
+
+type simplifier struct {
+	// unevaluated is set when the document uses unevaluatedProperties or
+	// unevaluatedItems somewhere, which see the annotations of subschemas, so
+	// that a subschema that always passes cannot simply be dropped.
+	unevaluated bool
+}
+
+// simplify rewrites s in place, its subschemas first.
+func (sp simplifier) simplify(s *Schema) {
+	for _, sub := range subschemas(s) {
+		sp.simplify(sub.schema)
+	}
+
+	if s.HasNot() {
+		switch {
+		case isFalseSchema(s.not):
+			s.not = nil
+			s.populatedFields &^= NotField
+		case !sp.unevaluated && s.not.populatedFields == NotField && len(s.not.extensions) == 0:
+			inner := s.not.not
+			s.not = nil
+			s.populatedFields &^= NotField
+			s.appendAllOf(inner)
+		}
+	}
+
+	if s.HasAnyOf() {
+		var entries []SchemaOrBool
+		trivial := false
+		for _, entry := range s.anyOf {
+			switch {
+			case isFalseSchema(entry):
+				continue
+			case isTrueSchema(entry):
+				trivial = true
+			}
+			entries = appendUnique(entries, entry)
+		}
+		switch {
+		case trivial && !sp.unevaluated:
+			s.anyOf = nil
+			s.populatedFields &^= AnyOfField
+		case len(entries) == 0:
+			s.anyOf = []SchemaOrBool{BoolSchema(false)}
+		case len(entries) == 1:
+			s.anyOf = nil
+			s.populatedFields &^= AnyOfField
+			s.appendAllOf(entries[0])
+		default:
+			s.anyOf = entries
+		}
+	}
+
+	if s.HasOneOf() && len(s.oneOf) == 1 {
+		entry := s.oneOf[0]
+		s.oneOf = nil
+		s.populatedFields &^= OneOfField
+		s.appendAllOf(entry)
+	}
+
+	if s.HasAllOf() {
+		sp.simplifyAllOf(s)
+	}
+}
+
+func (sp simplifier) simplifyAllOf(s *Schema) {
+	var entries []SchemaOrBool
+	var flatten func(list []SchemaOrBool) bool
+	flatten = func(list []SchemaOrBool) bool {
+		for _, entry := range list {
+			switch {
+			case isTrueSchema(entry):
+				continue
+			case isFalseSchema(entry):
+				return false
+			}
+			if sub, ok := entry.(*Schema); ok && sub.populatedFields == AllOfField && len(sub.extensions) == 0 {
+				if !flatten(sub.allOf) {
+					return false
+				}
+				continue
+			}
+			entries = appendUnique(entries, entry)
+		}
+		return true
+	}
+	if !flatten(s.allOf) {
+		s.allOf = []SchemaOrBool{BoolSchema(false)}
+		return
+	}
+
+	var kept []SchemaOrBool
+	for _, entry := range entries {
+		sub, ok := entry.(*Schema)
+		if !ok || !mergeableInto(s, sub) {
+			kept = append(kept, entry)
+			continue
+		}
+		// The keywords of sub are disjoint from those of s, so cloning both
+		// yields their union. An allOf of sub joins the entries still to come.
+		b := NewBuilder().Clone(s).Clone(sub).ResetAllOf()
+		merged, err := b.Build(WithBoundsCheck(false))
+		if err != nil {
+			kept = append(kept, entry)
+			continue
+		}
+		*s = *merged
+		kept = append(kept, sub.allOf...)
+	}
+
+	switch {
+	case len(kept) == 0:
+		s.allOf = nil
+		s.populatedFields &^= AllOfField
+	case len(kept) == 1 && s.populatedFields&^AllOfField == 0 && len(s.extensions) == 0:
+		if sub, ok := kept[0].(*Schema); ok && !sub.HasAny(DefinitionsField|LegacyDefinitionsField) {
+			*s = *sub
+			return
+		}
+		s.allOf = kept
+	default:
+		s.allOf = kept
+		s.populatedFields |= AllOfField
+	}
+}
+
+// mergeableInto reports whether the keywords of sub, an allOf entry of s, can
+// move into s without changing what either means.
+func mergeableInto(s, sub *Schema) bool {
+	own := s.populatedFields &^ AllOfField
+	switch {
+	case len(sub.extensions) > 0,
+		sub.HasAny(simplifyIdentity | simplifyReferences | simplifyUnevaluated),
+		s.HasAny(simplifyReferences | simplifyUnevaluated),
+		own&sub.populatedFields != 0:
+		return false
+	}
+	for _, group := range []FieldFlag{mergeObjectGroup, mergeArrayGroup, mergeContainsGroup, mergeConditionalGroup, simplifyContentGroup} {
+		if own&group != 0 && sub.HasAny(group) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendAllOf adds entry to the allOf of s.
+func (s *Schema) appendAllOf(entry SchemaOrBool) {
+	s.allOf = append(s.allOf, entry)
+	s.populatedFields |= AllOfField
+}
+
+func appendUnique(list []SchemaOrBool, entry SchemaOrBool) []SchemaOrBool {
+	for _, other := range list {
+		if sameSchemaOrBool(other, entry) {
+			return list
+		}
+	}
+	return append(list, entry)
+}
+
+func sameSchemaOrBool(a, b SchemaOrBool) bool {
+	switch a := a.(type) {
+	case BoolSchema:
+		other, ok := b.(BoolSchema)
+		return ok && a == other
+	case *Schema:
+		other, ok := b.(*Schema)
+		return ok && a.Equal(other)
+	default:
+		return false
+	}
+}
+
+// isTrueSchema reports whether v accepts every value without producing
+// annotations: true, or the empty schema.
+func isTrueSchema(v SchemaOrBool) bool {
+	switch v := v.(type) {
+	case BoolSchema:
+		return bool(v)
+	case *Schema:
+		return v != nil && v.populatedFields == 0 && len(v.extensions) == 0
+	default:
+		return false
+	}
+}
+
+// isFalseSchema reports whether v rejects every value: false, or
+// {"not": true}.
+func isFalseSchema(v SchemaOrBool) bool {
+	switch v := v.(type) {
+	case BoolSchema:
+		return !bool(v)
+	case *Schema:
+		return v != nil && v.populatedFields == NotField && len(v.extensions) == 0 && isTrueSchema(v.not)
+	default:
+		return false
+	}
+}
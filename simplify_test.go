@@ -0,0 +1,128 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplify(t *testing.T) {
+	testcases := []struct {
+		name     string
+		schema   string
+		expected string
+		values   []string
+	}{
+		{
+			name:     "single allOf entry",
+			schema:   `{"allOf": [{"type": "string", "minLength": 2}]}`,
+			expected: `{"minLength": 2, "type": "string"}`,
+			values:   []string{`"ab"`, `"a"`, `1`},
+		},
+		{
+			name:     "nested allOf",
+			schema:   `{"allOf": [{"allOf": [{"minimum": 1}, {"allOf": [{"maximum": 9}]}]}, true, {}]}`,
+			expected: `{"maximum": 9, "minimum": 1}`,
+			values:   []string{`0`, `1`, `9`, `10`, `"x"`},
+		},
+		{
+			name:     "overlapping keywords stay apart",
+			schema:   `{"type": "object", "properties": {"a": true}, "allOf": [{"additionalProperties": false}, {"required": ["a"]}]}`,
+			expected: `{"allOf": [{"additionalProperties": false}], "properties": {"a": true}, "required": ["a"], "type": "object"}`,
+			values:   []string{`{"a": 1}`, `{"a": 1, "b": 2}`, `{}`},
+		},
+		{
+			name:     "duplicates",
+			schema:   `{"anyOf": [{"type": "string"}, {"type": "string"}, {"type": "integer"}, false], "allOf": [{"pattern": "^a"}, {"pattern": "^a"}, {"pattern": "b$"}]}`,
+			expected: `{"allOf": [{"pattern": "b$"}], "anyOf": [{"type": "string"}, {"type": "integer"}], "pattern": "^a"}`,
+			values:   []string{`"ab"`, `"a"`, `"b"`, `1`, `1.5`},
+		},
+		{
+			name:     "trivial anyOf",
+			schema:   `{"type": "array", "anyOf": [{"minItems": 3}, true]}`,
+			expected: `{"type": "array"}`,
+			values:   []string{`[]`, `[1, 2, 3]`, `{}`},
+		},
+		{
+			name:     "single oneOf and anyOf entries",
+			schema:   `{"oneOf": [{"enum": [1, 2, 3]}], "anyOf": [{"not": {"const": 2}}]}`,
+			expected: `{"enum": [1, 2, 3], "not": {"const": 2}}`,
+			values:   []string{`1`, `2`, `4`},
+		},
+		{
+			name:     "not patterns",
+			schema:   `{"properties": {"a": {"not": false}, "b": {"not": {"not": {"type": "null"}}}, "c": false}}`,
+			expected: `{"properties": {"a": {}, "b": {"type": "null"}, "c": false}}`,
+			values:   []string{`{"a": 1}`, `{"b": null}`, `{"b": 1}`, `{"c": 1}`},
+		},
+		{
+			name:     "false in allOf",
+			schema:   `{"type": "string", "allOf": [{"minLength": 1}, {"not": {}}]}`,
+			expected: `{"allOf": [false], "type": "string"}`,
+			values:   []string{`"a"`, `""`, `1`},
+		},
+		{
+			name:     "unevaluatedProperties keeps trivial branches",
+			schema:   `{"anyOf": [true, {"properties": {"a": true}}], "unevaluatedProperties": false}`,
+			expected: `{"anyOf": [true, {"properties": {"a": true}}], "unevaluatedProperties": false}`,
+			values:   []string{`{"a": 1}`, `{"b": 1}`, `{}`},
+		},
+		{
+			name:     "references stay in place",
+			schema:   `{"$defs": {"n": {"allOf": [{"type": "number"}]}}, "allOf": [{"$ref": "#/$defs/n"}], "minimum": 0}`,
+			expected: `{"$defs": {"n": {"type": "number"}}, "allOf": [{"$ref": "#/$defs/n"}], "minimum": 0}`,
+			values:   []string{`1`, `-1`, `"x"`},
+		},
+		{
+			name:     "pointers into the document",
+			schema:   `{"properties": {"a": {"allOf": [{"type": "string"}]}, "b": {"$ref": "#/properties/a/allOf/0"}}}`,
+			expected: `{"properties": {"a": {"allOf": [{"type": "string"}]}, "b": {"$ref": "#/properties/a/allOf/0"}}}`,
+			values:   []string{`{"a": "x", "b": "y"}`, `{"b": 1}`},
+		},
+		{
+			name:     "draft-07 reference siblings",
+			schema:   `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"s": {"type": "string"}}, "properties": {"a": {"$ref": "#/definitions/s", "allOf": [{"maxLength": 1}]}}}`,
+			expected: `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"s": {"type": "string"}}, "properties": {"a": {"$ref": "#/definitions/s", "allOf": [{"maxLength": 1}]}}}`,
+			values:   []string{`{"a": "x"}`, `{"a": "xy"}`, `{"a": 1}`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := mustParseSchema(t, tc.schema)
+			before, err := s.MarshalJSON()
+			require.NoError(t, err)
+
+			simplified := schema.Simplify(s)
+			got, err := simplified.MarshalJSON()
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(got))
+
+			after, err := s.MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, string(before), string(after), "Simplify must not modify its argument")
+
+			requireSameResults(t, s, simplified, tc.values)
+		})
+	}
+
+	require.Nil(t, schema.Simplify(nil))
+}
+
+// requireSameResults checks that a and b accept and reject the same values.
+func requireSameResults(t *testing.T, a, b *schema.Schema, values []string) {
+	t.Helper()
+	va, err := validator.Compile(t.Context(), a)
+	require.NoError(t, err)
+	vb, err := validator.Compile(t.Context(), b)
+	require.NoError(t, err)
+	for _, value := range values {
+		var v any
+		require.NoError(t, json.Unmarshal([]byte(value), &v))
+		_, errA := va.Validate(t.Context(), v)
+		_, errB := vb.Validate(t.Context(), v)
+		require.Equal(t, errA == nil, errB == nil, "validation of %s differs: %v / %v", value, errA, errB)
+	}
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// keywordShape is the shape of the value of a keyword, as far as UnmarshalStrict
+// needs to know it: whether, and where, the value holds subschemas.
+type keywordShape int
+
+const (
+	shapeValue        keywordShape = iota // no subschemas
+	shapeSchema                           // a schema; also a list of them, for legacy "items"
+	shapeSchemaList                       // a list of schemas
+	shapeSchemaMap                        // an object whose values are schemas
+	shapeDependencies                     // an object whose values are schemas or property name lists
+)
+
+// UnknownKeyword is a keyword reported by UnmarshalStrict.
+type UnknownKeyword struct {
+	// Name is the keyword, such as "minLenght".
+	Name string
+	// Location is the JSON Pointer to the schema object that holds it, empty
+	// for the document root.
+	Location string
+	// Offset is the byte offset of the keyword in the input. Line and Column
+	// give the same position counted from 1, with Column in bytes.
+	Offset int64
+	Line   int
+	Column int
+}
+
+// UnknownKeywordsError is the error UnmarshalStrict returns when the document
+// uses keywords it does not know.
+type UnknownKeywordsError struct {
+	Keywords []UnknownKeyword
+}
+
+func (e *UnknownKeywordsError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("json-schema: unknown keywords: ")
+	for i, kw := range e.Keywords {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		location := kw.Location
+		if location == "" {
+			location = "the root schema"
+		}
+		fmt.Fprintf(&sb, "%q in %s (line %d, column %d)", kw.Name, location, kw.Line, kw.Column)
+	}
+	return sb.String()
+}
+
+// UnmarshalStrict unmarshals the JSON document data into s, like
+// s.UnmarshalJSON, but fails if the document, or any schema in it, uses a
+// keyword that this package does not know. UnmarshalJSON ignores such
+// keywords, so a misspelled "minLenght" silently has no effect; UnmarshalStrict
+// returns an *UnknownKeywordsError listing every one of them with its
+// position instead. s is filled in either way, with the unknown keywords in
+// its Extensions.
+//
+// The keywords known are those of every draft this package reads, not only
+// those of the draft the document declares. Keywords inside values that are
+// not schemas, such as the members of a "const" object, are never reported.
+func UnmarshalStrict(data []byte, s *Schema) error {
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	c := strictChecker{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	if err := c.schema(""); err != nil {
+		return fmt.Errorf(`json-schema: failed to check keywords: %w`, err)
+	}
+	if len(c.unknown) > 0 {
+		return &UnknownKeywordsError{Keywords: c.unknown}
+	}
+	return nil
+}
+
+// strictChecker walks a schema document token by token, recording the
+// keywords that are not in keywordShapes.
+type strictChecker struct {
+	data    []byte
+	dec     *json.Decoder
+	unknown []UnknownKeyword
+}
+
+// schema checks the schema value starting at the next token. ptr is its JSON
+// Pointer.
+func (c *strictChecker) schema(ptr string) error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('['):
+		return c.schemaListElements(ptr)
+	case json.Delim('{'):
+	default:
+		return nil // a boolean schema, or a value UnmarshalJSON has accepted
+	}
+
+	for c.dec.More() {
+		offset := c.next()
+		tok, err := c.dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		kwptr := ptr + "/" + pointerEscaper.Replace(name)
+		shape, known := keywordShapes[name]
+		if !known {
+			c.record(name, ptr, offset)
+		}
+		switch shape {
+		case shapeSchema:
+			err = c.schema(kwptr)
+		case shapeSchemaList:
+			err = c.schemaList(kwptr)
+		case shapeSchemaMap:
+			err = c.schemaMap(kwptr, false)
+		case shapeDependencies:
+			err = c.schemaMap(kwptr, true)
+		default:
+			err = c.skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.dec.Token() // '}'
+	return err
+}
+
+func (c *strictChecker) schemaList(ptr string) error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return nil
+	}
+	return c.schemaListElements(ptr)
+}
+
+// schemaListElements checks the elements of a list of schemas whose '[' has
+// been read.
+func (c *strictChecker) schemaListElements(ptr string) error {
+	for i := 0; c.dec.More(); i++ {
+		if err := c.schema(fmt.Sprintf("%s/%d", ptr, i)); err != nil {
+			return err
+		}
+	}
+	_, err := c.dec.Token() // ']'
+	return err
+}
+
+// schemaMap checks the members of an object whose values are schemas. With
+// dependencies, a member whose value is a list holds property names instead.
+func (c *strictChecker) schemaMap(ptr string, dependencies bool) error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return nil
+	}
+	for c.dec.More() {
+		tok, err := c.dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		memptr := ptr + "/" + pointerEscaper.Replace(name)
+		if dependencies && c.peek() == '[' {
+			err = c.skip()
+		} else {
+			err = c.schema(memptr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.dec.Token() // '}'
+	return err
+}
+
+func (c *strictChecker) skip() error {
+	var discard json.RawMessage
+	return c.dec.Decode(&discard)
+}
+
+// peek returns the first byte of the next value.
+func (c *strictChecker) peek() byte {
+	if i := c.next(); i < int64(len(c.data)) {
+		return c.data[i]
+	}
+	return 0
+}
+
+// next returns the offset of the next token. The decoder's offset is that of
+// the end of the previous token, so the whitespace and separators in between
+// are skipped.
+func (c *strictChecker) next() int64 {
+	i := c.dec.InputOffset()
+	for i < int64(len(c.data)) && strings.IndexByte(" \t\r\n:,", c.data[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+func (c *strictChecker) record(name, ptr string, offset int64) {
+	before := c.data[:offset]
+	line := bytes.Count(before, []byte{'\n'}) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	c.unknown = append(c.unknown, UnknownKeyword{
+		Name:     name,
+		Location: ptr,
+		Offset:   offset,
+		Line:     line,
+		Column:   column,
+	})
+}
@@ -0,0 +1,69 @@
+package schema_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalStrict(t *testing.T) {
+	t.Run("known keywords", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, schema.UnmarshalStrict([]byte(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"description": "a person",
+			"type": "object",
+			"properties": {"name": {"type": "string", "minLength": 1}},
+			"items": [{"type": "string"}],
+			"dependencies": {"a": ["b"], "c": {"required": ["d"]}},
+			"const": {"notAKeyword": true}
+		}`), &s))
+		require.True(t, s.HasProperties())
+	})
+
+	t.Run("2019-09 recursive keywords", func(t *testing.T) {
+		src := `{"$recursiveAnchor":true,"properties":{"next":{"$recursiveRef":"#"}}}`
+		var s schema.Schema
+		require.NoError(t, schema.UnmarshalStrict([]byte(src), &s))
+		require.True(t, s.RecursiveAnchor())
+		require.Equal(t, "#", s.Properties()["next"].RecursiveReference())
+		require.False(t, s.HasExtensions())
+
+		buf, err := s.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, src, string(buf))
+	})
+
+	t.Run("unknown keywords", func(t *testing.T) {
+		src := `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "minLenght": 1},
+    "tags": {"items": {"x-kind": "tag"}}
+  },
+  "dependencies": {"a": {"requird": ["b"]}},
+  "allOf": [true, {"maximun": 3}],
+  "nullable": true
+}`
+		var s schema.Schema
+		err := schema.UnmarshalStrict([]byte(src), &s)
+		var uerr *schema.UnknownKeywordsError
+		require.ErrorAs(t, err, &uerr)
+		require.Equal(t, []schema.UnknownKeyword{
+			{Name: "minLenght", Location: "/properties/name", Offset: 71, Line: 4, Column: 32},
+			{Name: "x-kind", Location: "/properties/tags/items", Offset: 111, Line: 5, Column: 24},
+			{Name: "requird", Location: "/dependencies/a", Offset: 159, Line: 7, Column: 26},
+			{Name: "maximun", Location: "/allOf/1", Offset: 198, Line: 8, Column: 20},
+			{Name: "nullable", Location: "", Offset: 216, Line: 9, Column: 3},
+		}, uerr.Keywords)
+		require.Contains(t, err.Error(), `"minLenght" in /properties/name (line 4, column 32)`)
+		require.Contains(t, err.Error(), `"nullable" in the root schema`)
+		require.True(t, s.HasProperties(), "the schema is still unmarshaled")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		var s schema.Schema
+		require.Error(t, schema.UnmarshalStrict([]byte(`{"type":`), &s))
+	})
+}
@@ -27,9 +27,40 @@ func resolveURI(base, ref string) string {
 	if err != nil {
 		return ref
 	}
+	if b.Scheme == "" && b.Host == "" && !r.IsAbs() {
+		return resolveRelativeURI(b, r)
+	}
 	return b.ResolveReference(r).String()
 }
 
+// relativeRoot stands in for the missing scheme and authority of a relative
+// base URI, and is removed again from the result.
+const relativeRoot = "relative-base://root"
+
+// resolveRelativeURI resolves ref against base, itself a relative URI such as
+// "sub/" (an "$id" in a document without an absolute base URI). url.URL always
+// yields an absolute path there, turning "sub/" and "t.json" into
+// "/sub/t.json", where the relative "sub/t.json" is wanted.
+func resolveRelativeURI(base, ref *url.URL) string {
+	rooted := strings.HasPrefix(base.Path, "/")
+	path := base.Path
+	if !rooted {
+		path = "/" + path
+	}
+	b, err := url.Parse(relativeRoot + (&url.URL{Path: path, RawQuery: base.RawQuery, Fragment: base.Fragment}).String())
+	if err != nil {
+		return ref.String()
+	}
+	resolved := b.ResolveReference(ref).String()
+	if resolved, ok := strings.CutPrefix(resolved, relativeRoot); ok {
+		if !rooted && !strings.HasPrefix(ref.Path, "/") {
+			resolved = strings.TrimPrefix(resolved, "/")
+		}
+		return resolved
+	}
+	return resolved
+}
+
 // ResolveURI resolves a (possibly relative) reference against a base URI using
 // RFC 3986 reference resolution, returning the resulting absolute URI. It is the
 // exported entry point for callers outside this package (e.g. the validator's
@@ -61,3 +92,24 @@ func unescapeFragment(fragment string) string {
 	}
 	return decoded
 }
+
+// IsResourceID reports whether id, the value of an "$id", makes its schema a
+// resource of its own with a base URI for the subschemas beneath it. An empty
+// id does not, and neither does a fragment-only id such as "#foo": drafts 06
+// and 07 use that form to name a location in the enclosing resource, the way
+// "$anchor" does in later drafts.
+func IsResourceID(id string) bool {
+	return id != "" && !strings.HasPrefix(id, "#")
+}
+
+// idAnchor returns the plain-name fragment that id, the value of an "$id",
+// declares: "foo" for "#foo" or "item.json#foo". It is empty when id has no
+// fragment, or when the fragment is a JSON Pointer.
+func idAnchor(id string) string {
+	_, fragment, _ := splitFragment(id)
+	fragment = unescapeFragment(fragment)
+	if strings.HasPrefix(fragment, "/") {
+		return ""
+	}
+	return fragment
+}
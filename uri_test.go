@@ -21,6 +21,11 @@ func TestResolveURI(t *testing.T) {
 		{"absolute ref ignores base", "urn:uuid:deadbeef", "urn:uuid:deadbeef#/$defs/bar", "urn:uuid:deadbeef#/$defs/bar"},
 		{"empty ref returns base", "https://example.com/a", "", "https://example.com/a"},
 		{"empty base returns ref", "", "int.json", "int.json"},
+		{"relative base stays relative", "sub/", "t.json", "sub/t.json"},
+		{"parent of relative base", "a/b/", "../c.json", "a/c.json"},
+		{"rooted relative base", "/a/b.json", "c.json", "/a/c.json"},
+		{"absolute ref against relative base", "sub/", "https://example.com/x.json", "https://example.com/x.json"},
+		{"fragment against relative base", "sub/t.json", "#foo", "sub/t.json#foo"},
 	}
 
 	for _, tc := range testCases {
@@ -29,3 +34,10 @@ func TestResolveURI(t *testing.T) {
 		})
 	}
 }
+
+func TestIsResourceID(t *testing.T) {
+	require.True(t, schema.IsResourceID("https://example.com/root.json"))
+	require.True(t, schema.IsResourceID("other.json#bar"))
+	require.False(t, schema.IsResourceID("#foo"))
+	require.False(t, schema.IsResourceID(""))
+}
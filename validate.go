@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lestrrat-go/json-schema/internal/compiler"
+)
+
+// compiledValidation holds the validator Validate compiled for a schema.
+type compiledValidation struct {
+	mu       sync.Mutex
+	validate func(context.Context, any) error
+}
+
+// compiledMu guards the compiled field of every Schema. It is only held while
+// looking up or creating the cache entry, never while compiling.
+var compiledMu sync.Mutex
+
+// Validate validates data against s, for one-off checks that do not need the
+// options or the Result of the validator package:
+//
+//	if err := s.Validate(ctx, data); err != nil {
+//		...
+//	}
+//
+// The first call compiles s with validator.Compile and default options, and
+// keeps the compiled validator with s; later calls only validate. Validate is
+// safe for concurrent use. Because of the cache, s must not be modified after
+// the first call: build or parse a new Schema instead. A failure to compile is
+// not cached, and is reported again by the next call.
+//
+// The compiler lives in the validator package, which this package cannot
+// import. Validate therefore requires the validator package to be part of the
+// program; a blank import is enough:
+//
+//	import _ "github.com/lestrrat-go/json-schema/validator"
+func (s *Schema) Validate(ctx context.Context, data any) error {
+	compiledMu.Lock()
+	c := s.compiled
+	if c == nil {
+		c = &compiledValidation{}
+		s.compiled = c
+	}
+	compiledMu.Unlock()
+
+	c.mu.Lock()
+	validate := c.validate
+	if validate == nil {
+		if compiler.Compile == nil {
+			c.mu.Unlock()
+			return errors.New(`schema.Validate requires the validator package; import "github.com/lestrrat-go/json-schema/validator"`)
+		}
+		v, err := compiler.Compile(ctx, s)
+		if err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf(`failed to compile schema: %w`, err)
+		}
+		c.validate = v
+		validate = v
+	}
+	c.mu.Unlock()
+
+	return validate(ctx, data)
+}
@@ -0,0 +1,44 @@
+package schema_test
+
+import (
+	"sync"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	s := schema.NewBuilder().
+		Types(schema.ObjectType).
+		Property("name", schema.NewBuilder().Types(schema.StringType).MinLength(1).MustBuild()).
+		Required("name").
+		MustBuild()
+
+	require.NoError(t, s.Validate(t.Context(), map[string]any{"name": "alice"}))
+
+	err := s.Validate(t.Context(), map[string]any{"name": ""})
+	var verr *validator.Error
+	require.ErrorAs(t, err, &verr, "errors are those of validator.Validate")
+	require.Equal(t, "/name", verr.InstanceLocation())
+
+	t.Run("concurrent use", func(t *testing.T) {
+		s := schema.NewBuilder().Types(schema.IntegerType).Minimum(0).MustBuild()
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, s.Validate(t.Context(), i))
+				require.Error(t, s.Validate(t.Context(), -i-1))
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("compile failure", func(t *testing.T) {
+		s := schema.NewBuilder().Reference("#/$defs/missing").MustBuild()
+		require.ErrorContains(t, s.Validate(t.Context(), 1), "failed to compile schema")
+	})
+}
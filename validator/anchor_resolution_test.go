@@ -171,4 +171,52 @@ func TestAnchorResolution(t *testing.T) {
 		_, err = v.Validate(context.Background(), invalidData)
 		require.Error(t, err)
 	})
+
+	t.Run("anchors under every applicator", func(t *testing.T) {
+		jsonSchema := `{
+			"type": "object",
+			"prefixItems": [{"$anchor": "first", "type": "string"}],
+			"dependentSchemas": {"a": {"properties": {"b": {"$anchor": "count", "type": "integer"}}}},
+			"properties": {
+				"x": {"$ref": "#first"},
+				"y": {"$ref": "#count"}
+			}
+		}`
+
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(jsonSchema)))
+
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"x": "a", "y": 1})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"x": 1})
+		require.Error(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"y": "1"})
+		require.Error(t, err)
+	})
+
+	t.Run("anchors are scoped to their resource", func(t *testing.T) {
+		// The nested resource declares "item" first in document order, but a
+		// plain-name fragment refers to the anchor of the enclosing resource.
+		jsonSchema := `{
+			"$defs": {
+				"a": {"$id": "https://example.com/nested", "$defs": {"x": {"$anchor": "item", "type": "integer"}}},
+				"b": {"$anchor": "item", "type": "string"}
+			},
+			"$ref": "#item"
+		}`
+
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(jsonSchema)))
+
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), "text")
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 1)
+		require.Error(t, err)
+	})
 }
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"context"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/keywords"
+)
+
+// Annotation is the value an annotation keyword of a schema gave to the part
+// of a validated value that the schema applied to.
+type Annotation struct {
+	// Keyword is "title", "description", "default" or "examples".
+	Keyword string
+	// Value is the value of the keyword: a string for title and description,
+	// the default value as decoded, or the []any of examples.
+	Value any
+}
+
+// annotatedResult is the Result of a Validate call that succeeded and was
+// given WithAnnotationCollection, or was rooted at a validator compiled
+// WithCoercion.
+type annotatedResult struct {
+	result      Result
+	annotations map[string][]Annotation
+	instance    any            // the validated value, as validators saw it
+	coerced     map[string]any // coerced values by instance location
+}
+
+// Annotations returns the annotations collected by a successful Validate call
+// given WithAnnotationCollection, keyed by instance location: a JSON Pointer
+// into the validated value, "" for the value itself. The annotations at each
+// location are in evaluation order, those of an enclosing schema before those
+// of the subschemas it applies, so that a "title" next to a "$ref" comes
+// before the one in the referenced schema.
+//
+// Only schemas that the value passed contribute: the annotations of a failed
+// anyOf or oneOf branch, a failed "if", a "contains" item that did not match,
+// or anything under "not" are dropped. Annotations returns nil when r records
+// none.
+func Annotations(r Result) map[string][]Annotation {
+	res, ok := r.(*annotatedResult)
+	if !ok || res == nil {
+		return nil
+	}
+	return res.annotations
+}
+
+// unwrapResult returns the Result a validator produced, without the
+// annotations and coerced values Validate added around it.
+func unwrapResult(r Result) Result {
+	if res, ok := r.(*annotatedResult); ok && res != nil {
+		return res.result
+	}
+	return r
+}
+
+type annotationEntry struct {
+	location   string
+	annotation Annotation
+}
+
+func (is *instanceState) recordAnnotations(annotations []Annotation) {
+	for _, a := range annotations {
+		is.annotations = append(is.annotations, annotationEntry{location: is.location, annotation: a})
+	}
+}
+
+func (is *instanceState) annotationsByLocation() map[string][]Annotation {
+	if len(is.annotations) == 0 {
+		return nil
+	}
+	m := make(map[string][]Annotation)
+	for _, e := range is.annotations {
+		m[e.location] = append(m[e.location], e.annotation)
+	}
+	return m
+}
+
+// annotationValidator records the annotation keywords of a schema for
+// WithAnnotationCollection, then evaluates the validator compiled for the rest
+// of the schema.
+type annotationValidator struct {
+	annotations []Annotation
+	inner       Interface
+}
+
+func (a *annotationValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
+	return validateRoot(ctx, a, v, options)
+}
+
+func (a *annotationValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
+	if st.instance != nil && st.instance.annotate {
+		// Should the schema fail, evalChild in the caller drops these again.
+		st.instance.recordAnnotations(a.annotations)
+	}
+	return evalChild(ctx, a.inner, v, st)
+}
+
+// withAnnotations wraps v, compiled for s, so that it records the annotation
+// keywords of s. It returns v as is when s has none.
+func withAnnotations(s *schema.Schema, v Interface) Interface {
+	var annotations []Annotation
+	if s.HasTitle() {
+		annotations = append(annotations, Annotation{Keyword: keywords.Title, Value: s.Title()})
+	}
+	if s.HasDescription() {
+		annotations = append(annotations, Annotation{Keyword: keywords.Description, Value: s.Description()})
+	}
+	if s.HasDefault() {
+		annotations = append(annotations, Annotation{Keyword: keywords.Default, Value: s.Default()})
+	}
+	if s.HasExamples() {
+		annotations = append(annotations, Annotation{Keyword: keywords.Examples, Value: s.Examples()})
+	}
+	if len(annotations) == 0 {
+		return v
+	}
+	return &annotationValidator{annotations: annotations, inner: v}
+}
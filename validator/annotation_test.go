@@ -0,0 +1,78 @@
+package validator_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationCollection(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"title": "Signup",
+		"type": "object",
+		"properties": {
+			"name": {"title": "Name", "description": "Your full name", "type": "string"},
+			"country": {"$ref": "#/$defs/country", "title": "Country of residence", "minLength": 2},
+			"plan": {
+				"anyOf": [
+					{"title": "Free plan", "const": "free"},
+					{"title": "Paid plan", "type": "string", "pattern": "^paid-"}
+				]
+			},
+			"tags": {"type": "array", "items": {"examples": ["a", "b"]}},
+			"age": {
+				"if": {"title": "Adult", "minimum": 18},
+				"then": {"description": "May sign up alone"},
+				"else": {"description": "Needs a guardian"},
+				"not": {"title": "Never recorded", "type": "string"}
+			}
+		},
+		"$defs": {"country": {"title": "Country", "default": "JP", "type": "string"}}
+	}`)))
+	v, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	value := map[string]any{
+		"name":    "Ada",
+		"country": "GB",
+		"plan":    "paid-monthly",
+		"tags":    []any{"x"},
+		"age":     12,
+	}
+
+	t.Run("by instance location", func(t *testing.T) {
+		res, err := v.Validate(t.Context(), value, validator.WithAnnotationCollection())
+		require.NoError(t, err)
+		require.Equal(t, map[string][]validator.Annotation{
+			"": {{Keyword: "title", Value: "Signup"}},
+			"/name": {
+				{Keyword: "title", Value: "Name"},
+				{Keyword: "description", Value: "Your full name"},
+			},
+			"/country": {
+				{Keyword: "title", Value: "Country of residence"},
+				{Keyword: "title", Value: "Country"},
+				{Keyword: "default", Value: "JP"},
+			},
+			"/plan":   {{Keyword: "title", Value: "Paid plan"}},
+			"/tags/0": {{Keyword: "examples", Value: []any{"a", "b"}}},
+			"/age":    {{Keyword: "description", Value: "Needs a guardian"}},
+		}, validator.Annotations(res))
+		require.ElementsMatch(t, []string{"age", "country", "name", "plan", "tags"}, validator.EvaluatedProperties(res))
+	})
+
+	t.Run("not collected by default", func(t *testing.T) {
+		res, err := v.Validate(t.Context(), value)
+		require.NoError(t, err)
+		require.Nil(t, validator.Annotations(res))
+	})
+
+	t.Run("failed validation", func(t *testing.T) {
+		res, err := v.Validate(t.Context(), map[string]any{"name": 1}, validator.WithAnnotationCollection())
+		require.Error(t, err)
+		require.Nil(t, validator.Annotations(res))
+	})
+}
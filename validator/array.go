@@ -2,12 +2,13 @@ package validator
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/internal/schemactx"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 var _ Builder = (*ArrayValidatorBuilder)(nil)
@@ -96,25 +97,14 @@ func compileArrayValidator(ctx context.Context, s *schema.Schema, cs compileStat
 	if s.HasMaxContains() {
 		v.MaxContains(s.MaxContains())
 	}
-	if s.HasUnevaluatedItems() {
-		unevaluatedItems := s.UnevaluatedItems()
-		if unevaluatedItems != nil {
-			// Handle SchemaOrBool types
-			switch val := unevaluatedItems.(type) {
-			case schema.BoolSchema:
-				// This is a boolean value
-				v.UnevaluatedItemsBool(bool(val))
-			case *schema.Schema:
-				// This is a regular schema - validate unevaluated items with this schema
-				itemValidator, err := compile(ctx, val, cs)
-				if err != nil {
-					return nil, fmt.Errorf("failed to compile unevaluated items validator: %w", err)
-				}
-				v.UnevaluatedItemsSchema(itemValidator)
-			default:
-				return nil, fmt.Errorf("unexpected unevaluatedItems type: %T", unevaluatedItems)
-			}
+	if b, ok := s.UnevaluatedItemsAsBool(); ok {
+		v.UnevaluatedItemsBool(b)
+	} else if sub, ok := s.UnevaluatedItemsAsSchema(); ok {
+		itemValidator, err := compile(ctx, sub, cs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile unevaluated items validator: %w", err)
 		}
+		v.UnevaluatedItemsSchema(itemValidator)
 	}
 
 	v.StrictArrayType(strictType)
@@ -298,7 +288,7 @@ func newArrayAccessor(v any) (arrayAccessor, bool) {
 }
 
 func (c *arrayValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return c.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, c, v, options)
 }
 
 func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
@@ -312,7 +302,7 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 		// Handle non-array values based on whether this is strict array type validation
 		if c.strictArrayType {
 			// When schema explicitly declares type: array, non-array values should fail
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: expected array or slice, got %T`, v)
+			return nil, codeErrorf(ErrCodeType, `invalid value passed to ArrayValidator: expected array or slice, got %T`, v)
 		}
 		// For non-array values with inferred array type, array constraints don't apply
 		// According to JSON Schema spec, array constraints should be ignored for non-arrays
@@ -321,35 +311,37 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 	}
 
 	length := uint(acc.length)
+	failures := newFailureCollector(st)
 
 	// Check minItems constraint
 	if c.minItems != nil && length < *c.minItems {
-		return nil, fmt.Errorf(`invalid value passed to ArrayValidator: array length %d is below minimum items %d`, length, *c.minItems)
+		if failures.add(atLocation(codeErrorf(ErrCodeMinItems, `invalid value passed to ArrayValidator: array length %d is below minimum items %d`, length, *c.minItems), jsonPointer(keywords.MinItems), "")) {
+			return nil, failures.err()
+		}
 	}
 
 	// Check maxItems constraint
 	if c.maxItems != nil && length > *c.maxItems {
-		return nil, fmt.Errorf(`invalid value passed to ArrayValidator: array length %d exceeds maximum items %d`, length, *c.maxItems)
+		if failures.add(atLocation(codeErrorf(ErrCodeMaxItems, `invalid value passed to ArrayValidator: array length %d exceeds maximum items %d`, length, *c.maxItems), jsonPointer(keywords.MaxItems), "")) {
+			return nil, failures.err()
+		}
 	}
 
 	// Check uniqueItems constraint.
 	//
 	// Rather than the naive O(n^2) pairwise comparison, bucket items by their
-	// canonical JSON encoding and only compare within a bucket. reflect.DeepEqual
-	// implies identical json.Marshal output, so the key never yields a false
-	// negative (no missed duplicate); it may collide for DeepEqual-unequal values
-	// (e.g. native int(1) vs float64(1.0), both encode as "1"), which is why a real
-	// duplicate is still confirmed with reflect.DeepEqual to preserve existing
-	// semantics. For JSON-decoded data (the untrusted path) a shared key implies
-	// equality, so the first within-bucket comparison returns immediately and the
-	// scan stays linear.
+	// canonical key (see unique.go) and only compare within a bucket. Equal JSON
+	// values always share a key, so no duplicate is missed; items sharing a key
+	// are confirmed with an exact comparison.
 	if c.uniqueItems && acc.length > 1 {
-		// json.Marshal never returns empty bytes for a valid value, so this
-		// sentinel cannot collide with a real key. Items that fail to marshal
-		// (exotic non-JSON values from reflection or a custom ArrayIndexResolver)
-		// land here and are compared among themselves.
-		const unmarshalableKey = "\x00unmarshalable"
+		// Keys are never empty, so this sentinel cannot collide with a real
+		// one. Items that cannot be represented as JSON (exotic values from
+		// reflection or a custom ArrayIndexResolver) land here and are compared
+		// among themselves.
+		const unmarshalableKey = ""
+		mark := st.beginKeyword(keywords.UniqueItems)
 		seen := make(map[string][]any, acc.length)
+	unique:
 		for i := range acc.length {
 			if err := ctx.Err(); err != nil {
 				return nil, err
@@ -358,21 +350,25 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 			if err != nil {
 				return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
 			}
-			key := unmarshalableKey
-			if b, err := json.Marshal(item); err == nil {
-				key = string(b)
+			key, ok := uniqueItemKey(item)
+			if !ok {
+				key = unmarshalableKey
 			}
 			for _, prev := range seen[key] {
-				if reflect.DeepEqual(prev, item) {
-					return nil, fmt.Errorf(`invalid value passed to ArrayValidator: duplicate items found, uniqueItems violation`)
+				if jsonValueEqual(prev, item) {
+					if failures.add(atLocation(codeErrorf(ErrCodeUniqueItems, `invalid value passed to ArrayValidator: duplicate items found, uniqueItems violation`), jsonPointer(keywords.UniqueItems), "")) {
+						return nil, failures.err()
+					}
+					break unique
 				}
 			}
 			seen[key] = append(seen[key], item)
 		}
+		st.endKeyword(mark)
 	}
 
 	// Initialize result for tracking evaluated items
-	result := NewArrayResult()
+	result := newArrayResult(acc.length)
 
 	// Merge evaluated items from previous validators
 	var evaluatedItems schemactx.EvaluatedItems
@@ -383,33 +379,43 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 	prefixItemsCount := len(c.prefixItems)
 
 	// First, validate items covered by prefixItems
-	for i := 0; i < arrayLength && i < prefixItemsCount; i++ {
-		item, err := acc.at(i)
-		if err != nil {
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
-		}
-		_, err = evalChild(ctx, c.prefixItems[i], item, st)
-		if err != nil {
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: prefixItems[%d] validation failed: %w`, i, err)
+	if prefixItemsCount > 0 {
+		mark := st.beginKeyword(keywords.PrefixItems)
+		for i := 0; i < arrayLength && i < prefixItemsCount; i++ {
+			item, err := acc.at(i)
+			if err != nil {
+				return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
+			}
+			_, err = evalItem(ctx, c.prefixItems[i], i, item, st)
+			if err != nil {
+				if failures.add(fmt.Errorf(`invalid value passed to ArrayValidator: prefixItems[%d] validation failed: %w`, i, atLocation(err, jsonPointer(keywords.PrefixItems, strconv.Itoa(i)), jsonPointer(strconv.Itoa(i))))) {
+					return nil, failures.err()
+				}
+			}
+			// Mark this item as evaluated by prefixItems
+			result.SetEvaluatedItem(i)
 		}
-		// Mark this item as evaluated by prefixItems
-		result.SetEvaluatedItem(i)
+		st.endKeyword(mark)
 	}
 
 	// Then, validate remaining items with the items schema (if present)
 	if c.items != nil {
+		mark := st.beginKeyword(keywords.Items)
 		for i := prefixItemsCount; i < arrayLength; i++ {
 			item, err := acc.at(i)
 			if err != nil {
 				return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
 			}
-			_, err = evalChild(ctx, c.items, item, st)
+			_, err = evalItem(ctx, c.items, i, item, st)
 			if err != nil {
-				return nil, fmt.Errorf(`invalid value passed to ArrayValidator: item validation failed: %w`, err)
+				if failures.add(fmt.Errorf(`invalid value passed to ArrayValidator: item validation failed: %w`, atLocation(err, jsonPointer(keywords.Items), jsonPointer(strconv.Itoa(i))))) {
+					return nil, failures.err()
+				}
 			}
 			// Mark this item as evaluated by items
 			result.SetEvaluatedItem(i)
 		}
+		st.endKeyword(mark)
 	}
 	// Note: Items beyond prefixItems that are not validated by items remain unevaluated
 
@@ -417,13 +423,17 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 	// According to JSON Schema spec, minContains and maxContains are ignored when contains is not present
 	// No validation needed when contains schema is absent
 	if c.contains != nil {
+		mark := st.beginKeyword(keywords.Contains)
 		containsCount := uint(0)
 		for i := range acc.length {
 			item, err := acc.at(i)
 			if err != nil {
 				return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
 			}
-			_, err = evalChild(ctx, c.contains, item, st)
+			_, err = evalItem(ctx, c.contains, i, item, st)
+			if interrupted(err) {
+				return nil, err
+			}
 			if err == nil {
 				containsCount++
 				// Mark this item as evaluated by contains
@@ -431,24 +441,32 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 			}
 		}
 
-		// Check minContains constraint first
-		if c.minContains != nil && containsCount < *c.minContains {
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: minimum contains constraint failed: found %d, expected at least %d`, containsCount, *c.minContains)
-		}
-
-		// Check if any item matches the contains schema (only if minContains is not explicitly set to 0)
-		if containsCount == 0 && (c.minContains == nil || *c.minContains > 0) {
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: does not contain required item`)
+		// Check minContains constraint first; the generic "does not contain"
+		// failure below would only repeat it.
+		switch {
+		case c.minContains != nil && containsCount < *c.minContains:
+			if failures.add(atLocation(codeErrorf(ErrCodeMinContains, `invalid value passed to ArrayValidator: minimum contains constraint failed: found %d, expected at least %d`, containsCount, *c.minContains), jsonPointer(keywords.MinContains), "")) {
+				return nil, failures.err()
+			}
+		case containsCount == 0 && (c.minContains == nil || *c.minContains > 0):
+			// Check if any item matches the contains schema (only if minContains is not explicitly set to 0)
+			if failures.add(atLocation(codeErrorf(ErrCodeContains, `invalid value passed to ArrayValidator: does not contain required item`), jsonPointer(keywords.Contains), "")) {
+				return nil, failures.err()
+			}
 		}
 
 		// Check maxContains constraint
 		if c.maxContains != nil && containsCount > *c.maxContains {
-			return nil, fmt.Errorf(`invalid value passed to ArrayValidator: maximum contains constraint failed: found %d, expected at most %d`, containsCount, *c.maxContains)
+			if failures.add(atLocation(codeErrorf(ErrCodeMaxContains, `invalid value passed to ArrayValidator: maximum contains constraint failed: found %d, expected at most %d`, containsCount, *c.maxContains), jsonPointer(keywords.MaxContains), "")) {
+				return nil, failures.err()
+			}
 		}
+		st.endKeyword(mark)
 	}
 
 	// Validate additionalItems for items beyond prefixItems
 	if c.additionalItems != nil {
+		mark := st.beginKeyword(keywords.AdditionalItems)
 		// additionalItems only applies to indices beyond prefixItems
 		for i := prefixItemsCount; i < arrayLength; i++ {
 			// Only apply additionalItems if this item wasn't handled by items schema
@@ -457,17 +475,21 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 				if err != nil {
 					return nil, fmt.Errorf(`invalid value passed to ArrayValidator: failed to resolve item %d: %w`, i, err)
 				}
-				_, err = evalChild(ctx, c.additionalItems, item, st)
+				_, err = evalItem(ctx, c.additionalItems, i, item, st)
 				if err != nil {
-					return nil, fmt.Errorf(`invalid value passed to ArrayValidator: additionalItems validation failed: %w`, err)
+					if failures.add(fmt.Errorf(`invalid value passed to ArrayValidator: additionalItems validation failed: %w`, atLocation(err, jsonPointer(keywords.AdditionalItems), jsonPointer(strconv.Itoa(i))))) {
+						return nil, failures.err()
+					}
 				}
 				result.SetEvaluatedItem(i)
 			}
 		}
+		st.endKeyword(mark)
 	}
 
 	// Handle unevaluatedItems validation
 	if c.unevaluatedItems != nil {
+		mark := st.beginKeyword(keywords.UnevaluatedItems)
 		// Merge any inherited evaluated-item annotations with this validator's.
 		contextEvaluated := evaluatedItems.Values() // inherited (empty unless seeded)
 		currentEvaluated := result.EvaluatedItems() // from this validator
@@ -503,7 +525,9 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 			if boolVal, ok := c.unevaluatedItems.(bool); ok {
 				if !boolVal {
 					// false means unevaluated items are not allowed
-					return nil, fmt.Errorf(`invalid value passed to ArrayValidator: unevaluated item at index %d not allowed`, i)
+					if failures.add(atLocation(codeErrorf(ErrCodeUnevaluatedItems, `invalid value passed to ArrayValidator: unevaluated item at index %d not allowed`, i), jsonPointer(keywords.UnevaluatedItems), jsonPointer(strconv.Itoa(i)))) {
+						return nil, failures.err()
+					}
 				}
 				// true means unevaluated items are allowed - mark as evaluated
 				result.SetEvaluatedItem(i)
@@ -512,15 +536,21 @@ func (c *arrayValidator) evaluate(ctx context.Context, v any, st *evalState) (Re
 
 			// Handle schema unevaluatedItems
 			if validator, ok := c.unevaluatedItems.(Interface); ok {
-				_, err := evalChild(ctx, validator, item, st)
+				_, err := evalItem(ctx, validator, i, item, st)
 				if err != nil {
-					return nil, fmt.Errorf(`invalid value passed to ArrayValidator: unevaluated item validation failed at index %d: %w`, i, err)
+					if failures.add(fmt.Errorf(`invalid value passed to ArrayValidator: unevaluated item validation failed at index %d: %w`, i, atLocation(err, jsonPointer(keywords.UnevaluatedItems), jsonPointer(strconv.Itoa(i))))) {
+						return nil, failures.err()
+					}
 				}
 				// Mark as evaluated when schema validation passes
 				result.SetEvaluatedItem(i)
 			}
 		}
+		st.endKeyword(mark)
 	}
 
+	if err := failures.err(); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
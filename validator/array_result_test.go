@@ -3,6 +3,7 @@ package validator_test
 import (
 	"testing"
 
+	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/validator"
 	"github.com/stretchr/testify/require"
 )
@@ -33,3 +34,46 @@ func TestArrayResultEvaluatedItems(t *testing.T) {
 		require.Equal(t, []bool{true, true}, r.EvaluatedItems(), "mutating the returned slice must not affect the result")
 	})
 }
+
+// EvaluatedProperties/EvaluatedItems expose the annotations a validation run
+// collected, so callers can see which parts of the instance were covered by a
+// keyword (and which would be left for an unevaluated* layer).
+func TestEvaluatedAnnotations(t *testing.T) {
+	t.Run("object annotations merged across allOf", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{
+			"type": "object",
+			"properties": {"a": true},
+			"patternProperties": {"^x-": true},
+			"allOf": [{"properties": {"b": {"type": "string"}}}]
+		}`)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+
+		res, err := v.Validate(t.Context(), map[string]any{"a": 1, "b": "s", "x-c": 2, "d": 3})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "x-c"}, validator.EvaluatedProperties(res))
+		require.Nil(t, validator.EvaluatedItems(res))
+	})
+
+	t.Run("array annotations", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{
+			"type": "array",
+			"prefixItems": [true],
+			"contains": {"type": "string"}
+		}`)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+
+		res, err := v.Validate(t.Context(), []any{1, 2, "s", 4})
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 2}, validator.EvaluatedItems(res))
+		require.Nil(t, validator.EvaluatedProperties(res))
+	})
+
+	t.Run("no annotations", func(t *testing.T) {
+		require.Nil(t, validator.EvaluatedProperties(nil))
+		require.Nil(t, validator.EvaluatedItems(nil))
+	})
+}
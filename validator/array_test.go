@@ -2,6 +2,7 @@ package validator_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -462,9 +463,45 @@ func TestArrayValidatorComprehensive(t *testing.T) {
 			},
 			{
 				name:        "unique items - mixed types no duplicates",
+				value:       []any{1, "1", true, []any{1}, map[string]any{"1": 1}},
+				uniqueItems: true,
+				wantErr:     false, // Different JSON types are considered different
+			},
+			{
+				name:        "unique items - equal numbers of different Go types",
 				value:       []any{1, "1", true, 1.0},
 				uniqueItems: true,
-				wantErr:     false, // Different types are considered different
+				wantErr:     true, // 1 and 1.0 are the same JSON number
+				errMsg:      "duplicate items",
+			},
+			{
+				name:        "unique items - json.Number spellings of one number",
+				value:       []any{json.Number("10"), json.Number("1e1"), json.Number("10.0")},
+				uniqueItems: true,
+				wantErr:     true,
+				errMsg:      "duplicate items",
+			},
+			{
+				name:        "unique items - large integers float64 cannot tell apart",
+				value:       []any{json.Number("9007199254740993"), json.Number("9007199254740992")},
+				uniqueItems: true,
+				wantErr:     false,
+			},
+			{
+				name: "unique items - objects compare by content",
+				value: []any{
+					map[string]any{"a": 1.0, "b": []any{"x", map[string]any{"c": nil}}},
+					map[string]any{"b": []any{"x", map[string]any{"c": nil}}, "a": json.Number("1")},
+				},
+				uniqueItems: true,
+				wantErr:     true,
+				errMsg:      "duplicate items",
+			},
+			{
+				name:        "unique items - array order matters",
+				value:       []any{[]any{1, 2}, []any{2, 1}},
+				uniqueItems: true,
+				wantErr:     false,
 			},
 			{
 				name: "unique items - object duplicates",
@@ -716,32 +753,90 @@ func TestArrayValidatorComprehensive(t *testing.T) {
 
 // BenchmarkUniqueItems measures uniqueItems validation across array sizes. The
 // worst case for a naive O(n^2) implementation is an all-unique array (no early
-// termination), so that is what we feed it. Elements are float64 to mirror
-// JSON-decoded numbers.
+// termination), so that is what we feed it. Elements mirror JSON-decoded data:
+// float64 numbers, and small objects.
 func BenchmarkUniqueItems(b *testing.B) {
-	for _, n := range []int{100, 1000, 5000} {
-		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
-			s, err := schema.NewBuilder().
-				Types(schema.ArrayType).
-				UniqueItems(true).
-				Build()
-			require.NoError(b, err)
-
-			v, err := validator.Compile(b.Context(), s)
-			require.NoError(b, err)
-
-			data := make([]any, n)
-			for i := range data {
-				data[i] = float64(i)
-			}
+	shapes := []struct {
+		name string
+		make func(i int) any
+	}{
+		{name: "numbers", make: func(i int) any { return float64(i) }},
+		{name: "objects", make: func(i int) any {
+			return map[string]any{"id": float64(i), "name": fmt.Sprintf("item-%d", i), "tags": []any{"a", "b"}}
+		}},
+	}
+	for _, shape := range shapes {
+		for _, n := range []int{100, 1000, 10000} {
+			b.Run(fmt.Sprintf("%s/n=%d", shape.name, n), func(b *testing.B) {
+				benchmarkUniqueItems(b, n, shape.make)
+			})
+		}
+	}
+}
 
-			ctx := b.Context()
-			b.ResetTimer()
-			for range b.N {
-				if _, err := v.Validate(ctx, data); err != nil {
-					b.Fatal(err)
-				}
+func benchmarkUniqueItems(b *testing.B, n int, makeItem func(int) any) {
+	s, err := schema.NewBuilder().
+		Types(schema.ArrayType).
+		UniqueItems(true).
+		Build()
+	require.NoError(b, err)
+
+	v, err := validator.Compile(b.Context(), s)
+	require.NoError(b, err)
+
+	data := make([]any, n)
+	for i := range data {
+		data[i] = makeItem(i)
+	}
+
+	ctx := b.Context()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := v.Validate(ctx, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTypedSliceElements(t *testing.T) {
+	items := schema.NewBuilder().Types(schema.ArrayType).Items(schema.NewBuilder().Types(schema.IntegerType).Maximum(2).MustBuild()).MustBuild()
+	tuple := schema.NewBuilder().
+		Types(schema.ArrayType).
+		PrefixItems(schema.NewBuilder().Types(schema.StringType).MustBuild()).
+		Items(schema.NewBuilder().MinLength(2).MustBuild()).
+		MustBuild()
+	contains := schema.NewBuilder().Types(schema.ArrayType).Contains(schema.NewBuilder().Const(3).MustBuild()).MustBuild()
+
+	for _, tc := range []struct {
+		name     string
+		schema   *schema.Schema
+		value    any
+		valid    bool
+		location string // of the failure
+	}{
+		{"[]int within items", items, []int{1, 2}, true, ""},
+		{"[]int failing items", items, []int{1, 2, 3}, false, "/2"},
+		{"[]float64 failing items", items, []float64{1.5}, false, "/0"},
+		{"array failing items", items, [2]int{0, 5}, false, "/1"},
+		{"pointer to a slice", items, &[]int{1, 9}, false, "/1"},
+		{"[]string within prefixItems and items", tuple, []string{"a", "bc"}, true, ""},
+		{"[]string failing items", tuple, []string{"a", "b"}, false, "/1"},
+		{"[]int failing prefixItems", tuple, []int{1}, false, "/0"},
+		{"[]int with contains", contains, []int{1, 3}, true, ""},
+		{"[]int without contains", contains, []int{1, 2}, false, ""},
+		{"[][]int failing nested items", schema.NewBuilder().Items(items).MustBuild(), [][]int{{1}, {2, 3}}, false, "/1/1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := validator.Compile(t.Context(), tc.schema)
+			require.NoError(t, err)
+			_, err = v.Validate(t.Context(), tc.value)
+			if tc.valid {
+				require.NoError(t, err)
+				return
 			}
+			var verr *validator.Error
+			require.ErrorAs(t, err, &verr)
+			require.Equal(t, tc.location, verr.InstanceLocation())
 		})
 	}
 }
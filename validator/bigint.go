@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Integers outside the int64 range, such as 64-bit unsigned IDs decoded as
+// json.Number, cannot go through the int64 comparisons of the generated
+// integer validator. Neither can a constraint that int64 does not hold
+// exactly: "maximum": 1.5, or a "const" beyond int64. Those constraints are
+// kept here as exact rationals, and any validator that has one, or that is
+// given a value beyond int64, compares value and constraints with checkWide.
+
+// wideIntegerConstraints holds the integer validator constraints that int64
+// cannot represent. A nil field falls back to the int64 field of the same name.
+type wideIntegerConstraints struct {
+	multipleOf       *big.Rat
+	maximum          *big.Rat
+	exclusiveMaximum *big.Rat
+	minimum          *big.Rat
+	exclusiveMinimum *big.Rat
+	constantValue    *big.Rat
+	enum             []*big.Rat
+}
+
+func (b *IntegerValidatorBuilder) wide() *wideIntegerConstraints {
+	if b.c.wide == nil {
+		b.c.wide = &wideIntegerConstraints{}
+	}
+	return b.c.wide
+}
+
+// integerConstraint converts the value of a numeric keyword of the schema to
+// int64. When int64 cannot hold it exactly, it is returned as exact instead.
+func integerConstraint(v any) (int64, *big.Rat, error) {
+	r, ok := exactNumeric(v)
+	if !ok {
+		if isNumeric(v) {
+			return 0, nil, fmt.Errorf(`%v cannot be compared exactly`, v)
+		}
+		return 0, nil, fmt.Errorf(`expected numeric type, got %T`, v)
+	}
+	if r.IsInt() && r.Num().IsInt64() {
+		return r.Num().Int64(), nil, nil
+	}
+	return 0, r, nil
+}
+
+// ratConstraint returns the constraint held in exact, or else in n.
+func ratConstraint(exact *big.Rat, n *int64) *big.Rat {
+	if exact != nil {
+		return exact
+	}
+	if n != nil {
+		return new(big.Rat).SetInt64(*n)
+	}
+	return nil
+}
+
+// checkWide validates the numeric value in against every constraint of v at
+// full precision.
+func (v *integerValidator) checkWide(in any) error {
+	n, ok := exactNumeric(in)
+	if !ok {
+		return codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: %v cannot be compared exactly`, in)
+	}
+	if !n.IsInt() {
+		return codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got non-integer value %v`, in)
+	}
+	w := v.wide
+	if w == nil {
+		w = &wideIntegerConstraints{}
+	}
+
+	if m := ratConstraint(w.maximum, v.maximum); m != nil && n.Cmp(m) > 0 {
+		return &NumericError{Keyword: "maximum", Limit: m, Value: n, class: "Integer"}
+	}
+	if em := ratConstraint(w.exclusiveMaximum, v.exclusiveMaximum); em != nil && n.Cmp(em) >= 0 {
+		return &NumericError{Keyword: "exclusiveMaximum", Limit: em, Value: n, class: "Integer"}
+	}
+	if m := ratConstraint(w.minimum, v.minimum); m != nil && n.Cmp(m) < 0 {
+		return &NumericError{Keyword: "minimum", Limit: m, Value: n, class: "Integer"}
+	}
+	if em := ratConstraint(w.exclusiveMinimum, v.exclusiveMinimum); em != nil && n.Cmp(em) <= 0 {
+		return &NumericError{Keyword: "exclusiveMinimum", Limit: em, Value: n, class: "Integer"}
+	}
+	if mo := ratConstraint(w.multipleOf, v.multipleOf); mo != nil {
+		if mo.Sign() == 0 {
+			return codeErrorf(ErrCodeMultipleOf, `invalid value passed to IntegerValidator: multipleOf cannot be zero`)
+		}
+		if !new(big.Rat).Quo(n, mo).IsInt() {
+			return &NumericError{Keyword: "multipleOf", Limit: mo, Value: n, class: "Integer"}
+		}
+	}
+	if c := ratConstraint(w.constantValue, v.constantValue); c != nil && n.Cmp(c) != 0 {
+		return codeErrorf(ErrCodeConst, `invalid value passed to IntegerValidator: value must be const value %s`, c.RatString())
+	}
+	if len(v.enum) > 0 || len(w.enum) > 0 {
+		var found bool
+		for _, e := range v.enum {
+			if n.Cmp(new(big.Rat).SetInt64(e)) == 0 {
+				found = true
+				break
+			}
+		}
+		for _, e := range w.enum {
+			if found {
+				break
+			}
+			if n.Cmp(e) == 0 {
+				found = true
+			}
+		}
+		if !found {
+			return codeErrorf(ErrCodeEnum, `invalid value passed to IntegerValidator: value not found in enum`)
+		}
+	}
+	return nil
+}
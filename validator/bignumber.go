@@ -0,0 +1,90 @@
+package validator
+
+import "math/big"
+
+// A bound of the number validator, such as "maximum": 18446744073709551615,
+// may be a literal that float64 rounds: to 2^64, which 18446744073709551616
+// would then satisfy. Such bounds are kept here as exact rationals, read from
+// the Number accessors of the schema, and checkWide compares values with them
+// at full precision. The generated check compares with the other bounds.
+
+// wideNumberConstraints holds the number validator bounds that float64 cannot
+// represent exactly. The float64 field of the same name is nil for each of
+// them.
+type wideNumberConstraints struct {
+	multipleOf       *big.Rat
+	maximum          *big.Rat
+	exclusiveMaximum *big.Rat
+	minimum          *big.Rat
+	exclusiveMinimum *big.Rat
+}
+
+func (b *NumberValidatorBuilder) wide() *wideNumberConstraints {
+	if b.c.wide == nil {
+		b.c.wide = &wideNumberConstraints{}
+	}
+	return b.c.wide
+}
+
+// numberBound converts the value of a numeric bound of the schema to
+// float64. When float64 cannot hold it exactly, it is returned as exact as
+// well.
+func numberBound(v any) (float64, *big.Rat, error) {
+	f, err := numberConstraint(v)
+	if err != nil {
+		return 0, nil, err
+	}
+	r, ok := exactNumeric(v)
+	if !ok {
+		return f, nil, nil
+	}
+	if held, ok := exactNumeric(f); ok && held.Cmp(r) == 0 {
+		return f, nil, nil
+	}
+	return f, r, nil
+}
+
+// checkWide validates the numeric value in, whose float64 value is f,
+// against the exact bounds of v.
+func (v *numberValidator) checkWide(in any, f float64) error {
+	w := v.wide
+	n, exact := exactNumeric(in)
+	value := any(f)
+	if exact {
+		value = n
+	}
+	// Only the infinities, and numbers no float64 holds either, have no exact
+	// value; they are compared as floats.
+	cmp := func(limit *big.Rat) int {
+		if exact {
+			return n.Cmp(limit)
+		}
+		l, _ := limit.Float64()
+		switch {
+		case f < l:
+			return -1
+		case f > l:
+			return 1
+		}
+		return 0
+	}
+
+	if m := w.maximum; m != nil && cmp(m) > 0 {
+		return &NumericError{Keyword: "maximum", Limit: m, Value: value, class: "Number"}
+	}
+	if em := w.exclusiveMaximum; em != nil && cmp(em) >= 0 {
+		return &NumericError{Keyword: "exclusiveMaximum", Limit: em, Value: value, class: "Number"}
+	}
+	if m := w.minimum; m != nil && cmp(m) < 0 {
+		return &NumericError{Keyword: "minimum", Limit: m, Value: value, class: "Number"}
+	}
+	if em := w.exclusiveMinimum; em != nil && cmp(em) <= 0 {
+		return &NumericError{Keyword: "exclusiveMinimum", Limit: em, Value: value, class: "Number"}
+	}
+	if mo := w.multipleOf; mo != nil {
+		if !exact || mo.Sign() == 0 || !new(big.Rat).Quo(n, mo).IsInt() {
+			return &NumericError{Keyword: "multipleOf", Limit: mo, Value: value, class: "Number"}
+		}
+	}
+	return nil
+}
@@ -12,8 +12,8 @@ import (
 var _ Builder = (*BooleanValidatorBuilder)(nil)
 var _ Interface = (*booleanValidator)(nil)
 
-func compileBooleanValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (Interface, error) {
-	v := Boolean()
+func compileBooleanValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, coerce bool) (Interface, error) {
+	v := Boolean().Coerce(coerce)
 	if s.HasConst() && vocab.IsKeywordEnabled("const") {
 		v.Const(s.Const())
 	}
@@ -26,6 +26,7 @@ func compileBooleanValidator(s *schema.Schema, vocab *vocabulary.VocabularySet)
 type booleanValidator struct {
 	enum          []any
 	constantValue any
+	coerce        bool
 }
 
 type BooleanValidatorBuilder struct {
@@ -54,6 +55,16 @@ func (b *BooleanValidatorBuilder) Enum(v ...any) *BooleanValidatorBuilder {
 	return b
 }
 
+// Coerce makes the validator accept the strings "true" and "false", as
+// WithCoercion does for compiled schemas.
+func (b *BooleanValidatorBuilder) Coerce(v bool) *BooleanValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.coerce = v
+	return b
+}
+
 func (b *BooleanValidatorBuilder) Build() (Interface, error) {
 	if b.err != nil {
 		return nil, b.err
@@ -74,10 +85,32 @@ func (b *BooleanValidatorBuilder) Reset() *BooleanValidatorBuilder {
 	return b
 }
 
-func (c *booleanValidator) Validate(ctx context.Context, v any, _ ...ValidateOption) (Result, error) {
+func (c *booleanValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
+	v, err := leafValue(v, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
+	res, err := c.check(ctx, v)
+	return res, leafError(err)
+}
+
+func (c *booleanValidator) check(ctx context.Context, v any) (Result, error) {
 	logger := TraceSlogFromContext(ctx)
 	logger.InfoContext(ctx, "boolean validator starting", "value", v, "type", fmt.Sprintf("%T", v))
 
+	var coerced bool
+	if c.coerce {
+		if s, ok := coercibleString(v); ok {
+			switch s {
+			case "true", "false":
+				v = s == "true"
+				coerced = true
+			default:
+				return nil, codeErrorf(ErrCodeType, `invalid value passed to BooleanValidator: cannot coerce %q to boolean`, s)
+			}
+		}
+	}
+
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Ptr, reflect.Interface:
@@ -103,10 +136,13 @@ func (c *booleanValidator) Validate(ctx context.Context, v any, _ ...ValidateOpt
 			}
 		}
 
+		if coerced {
+			return &coercedResult{value: boolVal}, nil
+		}
 		//nolint: nilnil
 		return nil, nil
 	default:
 		logger.InfoContext(ctx, "boolean validator rejecting non-boolean", "type", fmt.Sprintf("%T", v))
-		return nil, fmt.Errorf(`invalid value passed to BooleanValidator: expected boolean, got %T`, v)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to BooleanValidator: expected boolean, got %T`, v)
 	}
 }
@@ -0,0 +1,201 @@
+package validator
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/option/v3"
+)
+
+// defaultCacheSize is the number of validators a Cache keeps unless
+// WithCacheSize says otherwise.
+const defaultCacheSize = 256
+
+// CacheMetrics receives the events of a Cache, for export to a metrics
+// system. Its methods are called synchronously and must be safe for
+// concurrent use.
+type CacheMetrics interface {
+	// Hit is called when CompileCached finds the schema in the cache.
+	Hit()
+	// Miss is called when CompileCached has to compile the schema.
+	Miss()
+	// Evict is called when a validator is dropped to make room for another.
+	Evict()
+}
+
+// CacheStats is a snapshot of the counters of a Cache.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Len is the number of validators held when the snapshot was taken.
+	Len int
+}
+
+// CacheOption configures NewCache.
+type CacheOption interface {
+	option.Interface
+	cacheOption()
+}
+
+type cacheOption struct{ option.Interface }
+
+func (cacheOption) cacheOption() {}
+
+type identCacheSize struct{}
+type identCacheMetrics struct{}
+type identCacheCompileOptions struct{}
+
+// WithCacheSize bounds the number of validators a Cache holds. Once full, the
+// least recently used one is evicted. A size of zero or less removes the bound.
+func WithCacheSize(n int) CacheOption {
+	return cacheOption{option.New(identCacheSize{}, n)}
+}
+
+// WithCacheMetrics reports the hits, misses and evictions of a Cache to m.
+func WithCacheMetrics(m CacheMetrics) CacheOption {
+	return cacheOption{option.New(identCacheMetrics{}, m)}
+}
+
+// WithCacheCompileOptions sets the options the Cache compiles every schema
+// with. They are fixed for the lifetime of the Cache, as they are not part of
+// the key a schema is cached under.
+func WithCacheCompileOptions(options ...CompileOption) CacheOption {
+	return cacheOption{option.New(identCacheCompileOptions{}, options)}
+}
+
+// Cache holds compiled validators keyed by the content of their schema, so
+// that compiling a schema equal to one compiled before returns the existing
+// validator instead of compiling again. Two schemas are the same to the cache
+// when their MarshalJSON forms are identical, regardless of how they were
+// built or parsed.
+//
+// A Cache is safe for concurrent use. Concurrent calls for the same schema
+// compile it once; the others wait for the result. A failed compilation is
+// not cached.
+type Cache struct {
+	mu             sync.Mutex
+	size           int
+	entries        map[[sha256.Size]byte]*list.Element
+	lru            *list.List // of *cacheEntry, most recently used first
+	metrics        CacheMetrics
+	compileOptions []CompileOption
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+type cacheEntry struct {
+	key   [sha256.Size]byte
+	ready chan struct{} // closed once v and err are set
+	v     Interface
+	err   error
+}
+
+// NewCache creates an empty Cache holding up to 256 validators, or as many as
+// WithCacheSize says.
+func NewCache(options ...CacheOption) *Cache {
+	c := &Cache{
+		size:    defaultCacheSize,
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+	for _, o := range options {
+		switch o.Ident() {
+		case identCacheSize{}:
+			c.size = option.MustGet[int](o)
+		case identCacheMetrics{}:
+			c.metrics = option.MustGet[CacheMetrics](o)
+		case identCacheCompileOptions{}:
+			c.compileOptions = option.MustGet[[]CompileOption](o)
+		}
+	}
+	return c
+}
+
+// CompileCached returns the validator for s, compiling s with Compile only if
+// no schema with the same content has been compiled by c before.
+func (c *Cache) CompileCached(ctx context.Context, s *schema.Schema) (Interface, error) {
+	buf, err := s.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf(`failed to compute cache key: %w`, err)
+	}
+	key := sha256.Sum256(buf)
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		e := el.Value.(*cacheEntry)
+		c.mu.Unlock()
+		c.hits.Add(1)
+		if c.metrics != nil {
+			c.metrics.Hit()
+		}
+		select {
+		case <-e.ready:
+			return e.v, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	e := &cacheEntry{key: key, ready: make(chan struct{})}
+	c.entries[key] = c.lru.PushFront(e)
+	evicted := c.evictLocked()
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+	if c.metrics != nil {
+		c.metrics.Miss()
+		for range evicted {
+			c.metrics.Evict()
+		}
+	}
+
+	e.v, e.err = Compile(ctx, s, c.compileOptions...)
+	close(e.ready)
+	if e.err != nil {
+		c.mu.Lock()
+		if el, ok := c.entries[key]; ok && el.Value == e {
+			c.lru.Remove(el)
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return e.v, e.err
+}
+
+// evictLocked drops least recently used entries until c is within its size,
+// and returns how many it dropped. c.mu must be held.
+func (c *Cache) evictLocked() int {
+	if c.size <= 0 {
+		return 0
+	}
+	var n int
+	for c.lru.Len() > c.size {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+		n++
+	}
+	c.evictions.Add(uint64(n))
+	return n
+}
+
+// Stats returns the counters of c.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	n := c.lru.Len()
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Len:       n,
+	}
+}
@@ -0,0 +1,111 @@
+package validator_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+type countingMetrics struct {
+	hits, misses, evictions atomic.Int64
+}
+
+func (m *countingMetrics) Hit()   { m.hits.Add(1) }
+func (m *countingMetrics) Miss()  { m.misses.Add(1) }
+func (m *countingMetrics) Evict() { m.evictions.Add(1) }
+
+func TestCache(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+
+	t.Run("equivalent schemas share a validator", func(t *testing.T) {
+		var m countingMetrics
+		c := validator.NewCache(validator.WithCacheMetrics(&m))
+
+		a, err := c.CompileCached(t.Context(), parse(t, `{"type": "integer", "minimum": 1}`))
+		require.NoError(t, err)
+		b, err := c.CompileCached(t.Context(), schema.NewBuilder().Minimum(1).Types(schema.IntegerType).MustBuild())
+		require.NoError(t, err)
+		require.Same(t, a, b)
+
+		other, err := c.CompileCached(t.Context(), parse(t, `{"type": "integer", "minimum": 2}`))
+		require.NoError(t, err)
+		require.NotSame(t, a, other)
+
+		require.Equal(t, validator.CacheStats{Hits: 1, Misses: 2, Len: 2}, c.Stats())
+		require.Equal(t, int64(1), m.hits.Load())
+		require.Equal(t, int64(2), m.misses.Load())
+	})
+
+	t.Run("least recently used is evicted", func(t *testing.T) {
+		var m countingMetrics
+		c := validator.NewCache(validator.WithCacheSize(2), validator.WithCacheMetrics(&m))
+		first := parse(t, `{"minLength": 1}`)
+		_, err := c.CompileCached(t.Context(), first)
+		require.NoError(t, err)
+		_, err = c.CompileCached(t.Context(), parse(t, `{"minLength": 2}`))
+		require.NoError(t, err)
+		_, err = c.CompileCached(t.Context(), first) // first is now the most recent
+		require.NoError(t, err)
+		_, err = c.CompileCached(t.Context(), parse(t, `{"minLength": 3}`))
+		require.NoError(t, err)
+
+		stats := c.Stats()
+		require.Equal(t, 2, stats.Len)
+		require.Equal(t, uint64(1), stats.Evictions)
+		require.Equal(t, int64(1), m.evictions.Load())
+
+		_, err = c.CompileCached(t.Context(), first)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), c.Stats().Hits, "first survived the eviction")
+	})
+
+	t.Run("compile options", func(t *testing.T) {
+		c := validator.NewCache(validator.WithCacheCompileOptions(validator.WithCoercion(true)))
+		v, err := c.CompileCached(t.Context(), parse(t, `{"type": "integer"}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "42")
+		require.NoError(t, err)
+	})
+
+	t.Run("failures are not cached", func(t *testing.T) {
+		c := validator.NewCache()
+		s := parse(t, `{"$ref": "#/$defs/missing"}`)
+		_, err := c.CompileCached(t.Context(), s)
+		require.Error(t, err)
+		_, err = c.CompileCached(t.Context(), s)
+		require.Error(t, err)
+		stats := c.Stats()
+		require.Zero(t, stats.Len)
+		require.Equal(t, uint64(2), stats.Misses)
+	})
+
+	t.Run("concurrent compilation", func(t *testing.T) {
+		c := validator.NewCache()
+		s := parse(t, `{"type": "object", "required": ["id"]}`)
+		results := make([]validator.Interface, 16)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := c.CompileCached(t.Context(), s)
+				require.NoError(t, err)
+				results[i] = v
+			}()
+		}
+		wg.Wait()
+		for _, v := range results {
+			require.Same(t, results[0], v)
+		}
+		require.Equal(t, uint64(1), c.Stats().Misses)
+	})
+}
@@ -3,6 +3,7 @@ package validator_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/validator"
@@ -39,3 +40,57 @@ func TestCompileRespectsCancellation(t *testing.T) {
 	_, err := validator.Compile(ctx, &s)
 	require.ErrorIs(t, err, context.Canceled)
 }
+
+func TestCancellationIsNotAValidationOutcome(t *testing.T) {
+	// Each of these keywords acts on a child's failure. A cancelled child must
+	// not be mistaken for one: "not" would then pass, "anyOf" would report a
+	// failure, and so on.
+	testCases := []struct {
+		name  string
+		src   string
+		value any
+	}{
+		{name: "not", src: `{"not": {"type": "string"}}`, value: 1},
+		{name: "anyOf", src: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, value: 1},
+		{name: "oneOf", src: `{"oneOf": [{"type": "string"}, {"type": "integer"}]}`, value: 1},
+		{name: "if", src: `{"if": {"type": "string"}, "else": true}`, value: 1},
+		{name: "contains", src: `{"contains": {"type": "string"}, "minContains": 0}`, value: []any{1, 2}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tc.src)))
+			v, err := validator.Compile(t.Context(), &s)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = v.Validate(ctx, tc.value)
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestValidateRespectsDeadline(t *testing.T) {
+	const src = `{"type": "array", "uniqueItems": true, "items": {"type": "integer"}}`
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(src)))
+	v, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	items := make([]any, 100000)
+	for i := range items {
+		items[i] = float64(i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err = v.Validate(ctx, items)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	out, err := validator.ValidateWithOutput(ctx, v, items, validator.OutputBasic)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, out)
+}
@@ -39,10 +39,10 @@ var keywordConstantMap = map[string]string{
 	"minProperties":         "keywords.MinProperties",
 	"required":              "keywords.Required",
 	"additionalProperties":  "keywords.AdditionalProperties",
-	"definitions":           "keywords.Definitions",
+	"definitions":           "keywords.LegacyDefinitions",
 	"properties":            "keywords.Properties",
 	"patternProperties":     "keywords.PatternProperties",
-	"dependencies":          "keywords.DependentSchemas", // Note: "dependencies" maps to DependentSchemas in 2020-12
+	"dependencies":          "keywords.Dependencies",
 	"dependentSchemas":      "keywords.DependentSchemas",
 	"dependentRequired":     "keywords.DependentRequired",
 	"propertyNames":         "keywords.PropertyNames",
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/lestrrat-go/codegen"
+	schema "github.com/lestrrat-go/json-schema"
 )
 
 // CodeGenerator generates Go code that creates equivalent validators
@@ -14,7 +15,23 @@ type CodeGenerator interface {
 }
 
 // codeGenerator implements the CodeGenerator interface
-type codeGenerator struct{}
+type codeGenerator struct {
+	// refs holds the references whose targets are being generated, so that a
+	// recursive reference can refer back to its enclosing target. It is nil
+	// outside of a Generate call.
+	refs    map[referenceKey]*generatedReference
+	nextRef int
+}
+
+type referenceKey struct {
+	root *schema.Schema
+	uri  string
+}
+
+type generatedReference struct {
+	name      string // variable the target is assigned to
+	recursive bool   // the target refers to itself
+}
 
 // NewCodeGenerator creates a new code generator
 func NewCodeGenerator() CodeGenerator {
@@ -24,6 +41,11 @@ func NewCodeGenerator() CodeGenerator {
 // Generate writes Go code that constructs the given validator to the provided Writer
 // The output is just the builder chain, e.g.: validator.String().MinLength(5).MaxLength(100)
 func (g *codeGenerator) Generate(dst io.Writer, v Interface) error {
+	if g.refs == nil {
+		// Top-level call: track references in a per-call generator, so that
+		// a CodeGenerator can be shared between goroutines.
+		g = &codeGenerator{refs: make(map[referenceKey]*generatedReference)}
+	}
 	// Generate the code using the internal method
 	return g.generateInternal(dst, v)
 }
@@ -77,6 +99,23 @@ func (g *codeGenerator) generateInternal(dst io.Writer, v Interface) error {
 		// through the WithDynamicAnchorValidator validate option, so emit the
 		// wrapped validator directly and drop the wrapper.
 		return g.generateInternal(dst, validator.inner)
+	case *coercingValidator:
+		// There is no builder for the coercing wrapper, so the generated
+		// enum/const check compares the input as passed; the typed validators
+		// themselves are still emitted with Coerce(true).
+		return g.generateInternal(dst, validator.inner)
+	case *coercionTracker:
+		// A generated validator reports a coerced scalar through CoercedValue,
+		// but not the values coerced below it.
+		return g.generateInternal(dst, validator.inner)
+	case *annotationValidator:
+		// Annotations are only recorded for WithAnnotationCollection, which
+		// generated validators do not support.
+		return g.generateInternal(dst, validator.inner)
+	case *locationValidator:
+		// Keyword locations only feed structured output; the generated
+		// validator reports the same errors without them.
+		return g.generateInternal(dst, validator.inner)
 	default:
 		// Unsupported validator type, falling back to EmptyValidator
 		o.R("&validator.EmptyValidator{}")
@@ -2,6 +2,12 @@ package validator
 
 import (
 	"context"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"regexp"
 	"strings"
 	"testing"
@@ -207,13 +213,13 @@ func TestCodeGeneration(t *testing.T) {
 			name: "NotValidator",
 			createValidator: func(_ *testing.T) Interface {
 				child := String().MinLength(10).MustBuild()
-				return &NotValidator{validator: child}
+				return Not(child)
 			},
 			testValue:  "short",
 			shouldPass: true, // "short" should pass NOT minLength(10)
 			checkGenerated: func(t *testing.T, code string) {
 				require.Contains(t, code, "validator.String().")
-				require.Contains(t, code, "&validator.NotValidator{validator:")
+				require.Contains(t, code, "validator.Not(")
 				require.Contains(t, code, "MinLength(10).")
 			},
 		},
@@ -313,6 +319,87 @@ func TestCodeGenerationWithCompiledValidators(t *testing.T) {
 	}
 }
 
+// TestGeneratedCodeCompiles type-checks the generated code as it would be
+// compiled in a package other than validator.
+func TestGeneratedCodeCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type-checking against the package source is slow")
+	}
+
+	schemas := []string{
+		`{"not": {"type": "string", "minLength": 10}}`,
+		`{"type": "string", "contentEncoding": "base64", "contentMediaType": "application/json", "contentSchema": {"type": "object"}}`,
+		`{"dependentSchemas": {"credit card": {"required": ["billing_address"]}, "name": {"properties": {"age": {"type": "integer"}}}}}`,
+		`{"if": {"type": "string"}, "then": {"minLength": 2}}`,
+		`{"if": {"type": "string"}, "else": {"type": "integer"}}`,
+		`{"if": true, "then": false, "else": true}`,
+		`{"$defs": {"node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/node"}}}}, "$ref": "#/$defs/node"}`,
+		`{"type": "object", "properties": {"type": {"type": "array", "items": {"enum": ["a", "b"]}}}, "required": ["type"], "dependentRequired": {"a": ["b"]}, "unevaluatedProperties": false}`,
+	}
+
+	var src strings.Builder
+	// Property names that are keywords are emitted as constants of the
+	// keywords package, which callers import alongside validator.
+	src.WriteString("package gen\n\nimport (\n\t\"github.com/lestrrat-go/json-schema/keywords\"\n\t\"github.com/lestrrat-go/json-schema/validator\"\n)\n\nvar _ = keywords.Type\n\n")
+	for i, data := range schemas {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(data)))
+		v, err := Compile(t.Context(), &s, WithContentAssertion(true))
+		require.NoError(t, err)
+
+		fmt.Fprintf(&src, "var v%d = ", i)
+		require.NoError(t, NewCodeGenerator().Generate(&src, v), data)
+		src.WriteString("\n\n")
+	}
+	code := src.String()
+	require.NotRegexp(t, `validator\.[a-z]`, code, "generated code must only use exported identifiers")
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "gen.go", code, 0)
+	require.NoError(t, err, code)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("gen", fset, []*ast.File{f}, nil)
+	require.NoError(t, err, code)
+}
+
+func TestReferenceCodeGeneration(t *testing.T) {
+	t.Run("recursive reference", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"type": "object", "properties": {"child": {"$ref": "#"}, "n": {"type": "integer"}}}`)))
+		v, err := Compile(t.Context(), &s)
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, NewCodeGenerator().Generate(&buf, v))
+		code := buf.String()
+		require.NotContains(t, code, "EmptyValidator", "a recursive reference must not be generated as a permissive validator")
+		require.Contains(t, code, "var ref0 validator.Interface")
+		require.Contains(t, code, "validator.Deferred(func() validator.Interface { return ref0 })")
+	})
+
+	t.Run("unresolvable reference", func(t *testing.T) {
+		s := schema.NewBuilder().MustBuild()
+		v := &ReferenceValidator{reference: "#/$defs/missing", rootSchema: s}
+
+		var buf strings.Builder
+		err := NewCodeGenerator().Generate(&buf, AllOf(String().MustBuild(), v))
+		require.ErrorContains(t, err, "failed to resolve reference #/$defs/missing")
+	})
+
+	t.Run("deferred validator", func(t *testing.T) {
+		var node Interface
+		node = Object().
+			Properties(PropPair("next", Deferred(func() Interface { return node }))).
+			StrictObjectType(true).
+			MustBuild()
+
+		_, err := node.Validate(t.Context(), map[string]any{"next": map[string]any{"next": map[string]any{}}})
+		require.NoError(t, err)
+		_, err = node.Validate(t.Context(), map[string]any{"next": map[string]any{"next": "x"}})
+		require.Error(t, err)
+	})
+}
+
 // unsupportedValidator is a mock validator type for testing
 type unsupportedValidator struct{}
 
@@ -346,19 +433,15 @@ func TestComplexValidatorsCodeGeneration(t *testing.T) {
 				ifValidator := String().MinLength(1).MustBuild()
 				thenValidator := String().MaxLength(10).MustBuild()
 				elseValidator := String().MaxLength(5).MustBuild()
-				return &IfThenElseValidator{
-					ifValidator:   ifValidator,
-					thenValidator: thenValidator,
-					elseValidator: elseValidator,
-				}
+				return IfThenElse(ifValidator, thenValidator, elseValidator)
 			},
 			testValue:  "test",
 			shouldPass: true,
 			checkGenerated: func(t *testing.T, code string) {
-				require.Contains(t, code, "&validator.IfThenElseValidator{")
-				require.Contains(t, code, "ifValidator:")
-				require.Contains(t, code, "thenValidator:")
-				require.Contains(t, code, "elseValidator:")
+				require.Contains(t, code, "validator.IfThenElse(")
+				require.Contains(t, code, "MinLength(1).")
+				require.Contains(t, code, "MaxLength(10).")
+				require.Contains(t, code, "MaxLength(5).")
 			},
 		},
 	}
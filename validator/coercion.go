@@ -0,0 +1,171 @@
+package validator
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	schema "github.com/lestrrat-go/json-schema"
+)
+
+// coercingValidator feeds inner the value WithCoercion converts a string input
+// to under the declared types, so that keywords evaluated beside the type
+// validators (enum and const) see the same value those validators checked.
+type coercingValidator struct {
+	types schema.PrimitiveTypes
+	inner Interface
+}
+
+func (v *coercingValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
+	return validateRoot(ctx, v, in, options)
+}
+
+func (v *coercingValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
+	if converted, ok := coerceToTypes(in, v.types); ok {
+		in = converted
+	}
+	return evalChild(ctx, v.inner, in, st)
+}
+
+// coerceToTypes converts a string input to the first of types it spells. A
+// string is left alone when "string" is itself one of the declared types.
+func coerceToTypes(in any, types schema.PrimitiveTypes) (any, bool) {
+	s, ok := coercibleString(in)
+	if !ok || slices.Contains(types, schema.StringType) {
+		return nil, false
+	}
+	for _, typ := range types {
+		switch typ {
+		case schema.IntegerType, schema.NumberType:
+			if num, ok := coerceNumberString(s); ok {
+				return num, true
+			}
+		case schema.BooleanType:
+			if s == "true" || s == "false" {
+				return s == "true", true
+			}
+		}
+	}
+	return nil, false
+}
+
+// coercionTracker is the validator Compile returns under WithCoercion. As the
+// root of a Validate call, it has the values coerced anywhere in the instance
+// recorded by location, so that CoercedValue can report a coerced property or
+// item and not only a coerced scalar.
+type coercionTracker struct {
+	inner Interface
+}
+
+func (v *coercionTracker) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
+	return validateRoot(ctx, v, in, options)
+}
+
+func (v *coercionTracker) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
+	return evalChild(ctx, v.inner, in, st)
+}
+
+// coercionEntry is the value one coerced part of the instance was converted
+// to, for a Validate call rooted at a coercionTracker.
+type coercionEntry struct {
+	location string
+	value    any
+}
+
+func (is *instanceState) recordCoercion(value any) {
+	is.coercions = append(is.coercions, coercionEntry{location: is.location, value: value})
+}
+
+// coercionsByLocation returns the coerced values keyed by instance location.
+// The first value recorded for a location wins, as every validator that
+// coerces the same string under the same type converts it alike.
+func (is *instanceState) coercionsByLocation() map[string]any {
+	if len(is.coercions) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(is.coercions))
+	for _, e := range is.coercions {
+		if _, ok := m[e.location]; !ok {
+			m[e.location] = e.value
+		}
+	}
+	return m
+}
+
+// evalDetached is evalChild for a value that is derived from the instance
+// rather than part of it, such as a property name for propertyNames: what it
+// coerces is not recorded.
+func evalDetached(ctx context.Context, child Interface, v any, st *evalState) (Result, error) {
+	if st.instance == nil || !st.instance.coerce {
+		return evalChild(ctx, child, v, st)
+	}
+	mark := len(st.instance.coercions)
+	res, err := evalChild(ctx, child, v, st)
+	st.instance.coercions = st.instance.coercions[:mark]
+	return res, err
+}
+
+// withCoercions returns a copy of instance, a decoded JSON value, with the
+// coerced values set at their locations, and false if a location does not
+// lead through map[string]any and []any containers.
+func withCoercions(instance any, coerced map[string]any) (any, bool) {
+	if v, ok := coerced[""]; ok {
+		return v, true
+	}
+	out := cloneContainers(instance)
+	for _, location := range slices.Sorted(maps.Keys(coerced)) {
+		if !setAt(out, strings.Split(location[1:], "/"), coerced[location]) {
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+func setAt(container any, tokens []string, value any) bool {
+	token := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[0])
+	switch c := container.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return false
+		}
+		if len(tokens) == 1 {
+			c[token] = value
+			return true
+		}
+		return setAt(c[token], tokens[1:], value)
+	case []any:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(c) {
+			return false
+		}
+		if len(tokens) == 1 {
+			c[i] = value
+			return true
+		}
+		return setAt(c[i], tokens[1:], value)
+	default:
+		return false
+	}
+}
+
+// cloneContainers deep-copies the maps and slices of a decoded JSON value.
+func cloneContainers(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for k, elem := range c {
+			out[k] = cloneContainers(elem)
+		}
+		return out
+	case []any:
+		out := make([]any, len(c))
+		for i, elem := range c {
+			out[i] = cloneContainers(elem)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,159 @@
+package validator_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoercion(t *testing.T) {
+	compile := func(t *testing.T, src string, options ...validator.CompileOption) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, json.Unmarshal([]byte(src), &s))
+		v, err := validator.Compile(t.Context(), &s, options...)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		v := compile(t, `{"type": "integer"}`)
+		_, err := v.Validate(t.Context(), "42")
+		require.Error(t, err)
+	})
+
+	testCases := []struct {
+		name    string
+		schema  string
+		value   any
+		want    any
+		wantErr bool
+	}{
+		{name: "integer", schema: `{"type": "integer"}`, value: "42", want: int64(42)},
+		{name: "negative integer", schema: `{"type": "integer"}`, value: "-7", want: int64(-7)},
+		{name: "integral exponent", schema: `{"type": "integer"}`, value: "1e2", want: int64(100)},
+		{name: "fraction is not an integer", schema: `{"type": "integer"}`, value: "4.5", wantErr: true},
+		{name: "not a number", schema: `{"type": "integer"}`, value: "abc", wantErr: true},
+		{name: "surrounding whitespace", schema: `{"type": "integer"}`, value: " 42", wantErr: true},
+		{name: "leading plus", schema: `{"type": "number"}`, value: "+1", wantErr: true},
+		{name: "number", schema: `{"type": "number"}`, value: "2.5", want: 2.5},
+		{name: "boolean true", schema: `{"type": "boolean"}`, value: "true", want: true},
+		{name: "boolean false", schema: `{"type": "boolean"}`, value: "false", want: false},
+		{name: "boolean spelling", schema: `{"type": "boolean"}`, value: "True", wantErr: true},
+		{name: "minimum applies to coerced value", schema: `{"type": "integer", "minimum": 10}`, value: "9", wantErr: true},
+		{name: "enum applies to coerced value", schema: `{"type": "number", "enum": [1.5, 2.5]}`, value: "2.5", want: 2.5},
+		{name: "nested property", schema: `{"type": "object", "properties": {"n": {"type": "integer", "maximum": 5}}}`, value: map[string]any{"n": "6"}, wantErr: true},
+		{name: "multiple types", schema: `{"type": ["boolean", "null"]}`, value: "false", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := compile(t, tc.schema, validator.WithCoercion(true))
+			res, err := v.Validate(t.Context(), tc.value)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.want != nil {
+				got, ok := validator.CoercedValue(res)
+				require.True(t, ok)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+
+	t.Run("typed values are not reported as coerced", func(t *testing.T) {
+		v := compile(t, `{"type": "integer"}`, validator.WithCoercion(true))
+		for _, in := range []any{42, json.Number("42")} {
+			res, err := v.Validate(t.Context(), in)
+			require.NoError(t, err)
+			_, ok := validator.CoercedValue(res)
+			require.False(t, ok)
+		}
+	})
+
+	t.Run("strings still validate as strings", func(t *testing.T) {
+		v := compile(t, `{"type": ["string", "integer"]}`, validator.WithCoercion(true))
+		_, err := v.Validate(t.Context(), "abc")
+		require.NoError(t, err)
+	})
+
+	t.Run("nested values", func(t *testing.T) {
+		v := compile(t, `{
+			"type": "object",
+			"properties": {
+				"age": {"type": "integer"},
+				"name": {"type": "string"},
+				"flags": {"type": "array", "items": {"type": "boolean"}},
+				"a/b": {"type": "number"}
+			}
+		}`, validator.WithCoercion(true))
+		in := map[string]any{"age": "42", "name": "7", "flags": []any{"true", false}, "a/b": "2.5"}
+		res, err := v.Validate(t.Context(), in)
+		require.NoError(t, err)
+
+		got, ok := validator.CoercedValue(res)
+		require.True(t, ok)
+		require.Equal(t, map[string]any{"age": int64(42), "name": "7", "flags": []any{true, false}, "a/b": 2.5}, got)
+		require.Equal(t, map[string]any{"/age": int64(42), "/flags/0": true, "/a~1b": 2.5}, validator.CoercedValues(res))
+		require.Equal(t, "42", in["age"], "the input is not modified")
+	})
+
+	t.Run("failed branches and property names coerce nothing", func(t *testing.T) {
+		v := compile(t, `{
+			"properties": {
+				"n": {"anyOf": [{"type": "integer", "minimum": 10}, {"type": "string"}]},
+				"id": {"not": {"type": "integer", "minimum": 10}},
+				"counts": {"propertyNames": {"type": "integer"}}
+			}
+		}`, validator.WithCoercion(true))
+		res, err := v.Validate(t.Context(), map[string]any{"n": "5", "id": "5", "counts": map[string]any{"1": true}})
+		require.NoError(t, err)
+		_, ok := validator.CoercedValue(res)
+		require.False(t, ok)
+		require.Nil(t, validator.CoercedValues(res))
+	})
+
+	t.Run("values in structs", func(t *testing.T) {
+		type form struct {
+			Age string `json:"age"`
+		}
+		v := compile(t, `{"properties": {"age": {"type": "integer"}}}`, validator.WithCoercion(true))
+		res, err := v.Validate(t.Context(), form{Age: "42"})
+		require.NoError(t, err)
+		_, ok := validator.CoercedValue(res)
+		require.False(t, ok, "a struct cannot hold the coerced value")
+		require.Equal(t, map[string]any{"/age": int64(42)}, validator.CoercedValues(res))
+	})
+
+	t.Run("together with annotations", func(t *testing.T) {
+		v := compile(t, `{"properties": {"n": {"title": "N", "type": "integer"}}}`, validator.WithCoercion(true))
+		res, err := v.Validate(t.Context(), map[string]any{"n": "1"}, validator.WithAnnotationCollection())
+		require.NoError(t, err)
+		got, ok := validator.CoercedValue(res)
+		require.True(t, ok)
+		require.Equal(t, map[string]any{"n": int64(1)}, got)
+		require.Equal(t, []validator.Annotation{{Keyword: "title", Value: "N"}}, validator.Annotations(res)["/n"])
+	})
+
+	t.Run("built validator together with annotations", func(t *testing.T) {
+		v := validator.AllOf(validator.Integer().Coerce(true).MustBuild())
+		res, err := v.Validate(t.Context(), "42", validator.WithAnnotationCollection())
+		require.NoError(t, err)
+		got, ok := validator.CoercedValue(res)
+		require.True(t, ok)
+		require.Equal(t, int64(42), got)
+		require.Equal(t, map[string]any{"": int64(42)}, validator.CoercedValues(res))
+	})
+
+	t.Run("code generation", func(t *testing.T) {
+		v := compile(t, `{"type": "integer"}`, validator.WithCoercion(true))
+		var buf bytes.Buffer
+		require.NoError(t, validator.NewCodeGenerator().Generate(&buf, v))
+		require.Contains(t, buf.String(), "Coerce(true)")
+	})
+}
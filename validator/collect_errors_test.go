@@ -0,0 +1,82 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectAllErrors(t *testing.T) {
+	v := compileOutputSchema(t, `{
+		"type": "object",
+		"required": ["email"],
+		"properties": {
+			"email": {"type": "string"},
+			"name": {"type": "string", "minLength": 2},
+			"age": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true}
+		},
+		"additionalProperties": false
+	}`)
+	instance := map[string]any{
+		"name":  "J",
+		"age":   -1,
+		"tags":  []any{"a", 1, "a", 2},
+		"extra": true,
+	}
+
+	t.Run("first failure by default", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), instance)
+		require.Error(t, err)
+		var multi interface{ Unwrap() []error }
+		require.NotErrorAs(t, err, &multi)
+	})
+
+	t.Run("every failure when collecting", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), instance, validator.WithCollectAllErrors(true))
+		require.Error(t, err)
+		var multi interface{ Unwrap() []error }
+		require.ErrorAs(t, err, &multi)
+
+		out, err := validator.ValidateWithOutput(t.Context(), v, instance, validator.OutputBasic, validator.WithCollectAllErrors(true))
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+
+		type location struct{ keyword, instance string }
+		var got []location
+		for _, unit := range out.Errors {
+			got = append(got, location{unit.KeywordLocation, unit.InstanceLocation})
+		}
+		require.Equal(t, []location{
+			{"/required", ""},
+			{"/properties/age", "/age"},
+			{"/additionalProperties", "/extra"},
+			{"/properties/name", "/name"},
+			{"/properties/tags/uniqueItems", "/tags"},
+			{"/properties/tags/items", "/tags/1"},
+			{"/properties/tags/items", "/tags/3"},
+		}, got)
+	})
+
+	t.Run("single failure is not joined", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), map[string]any{"email": "x", "age": -1}, validator.WithCollectAllErrors(true))
+		require.Error(t, err)
+		var multi interface{ Unwrap() []error }
+		require.NotErrorAs(t, err, &multi)
+	})
+
+	t.Run("anyOf reports every branch", func(t *testing.T) {
+		v := compileOutputSchema(t, `{"anyOf": [{"type": "string"}, {"type": "integer", "minimum": 10}]}`)
+		out, err := validator.ValidateWithOutput(t.Context(), v, 5, validator.OutputBasic, validator.WithCollectAllErrors(true))
+		require.NoError(t, err)
+		require.Len(t, out.Errors, 2)
+		require.Equal(t, "/anyOf/0", out.Errors[0].KeywordLocation)
+		require.Equal(t, "/anyOf/1", out.Errors[1].KeywordLocation)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), map[string]any{"email": "x", "name": "Jo"}, validator.WithCollectAllErrors(true))
+		require.NoError(t, err)
+	})
+}
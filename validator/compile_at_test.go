@@ -0,0 +1,95 @@
+package validator_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileAt(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"$id": "https://example.com/root.json",
+		"type": "object",
+		"required": ["address"],
+		"definitions": {
+			"address": {
+				"type": "object",
+				"properties": {"zip": {"$ref": "#/definitions/zip"}},
+				"dependencies": {"zip": ["country"]}
+			},
+			"zip": {"type": "string", "pattern": "^[0-9]{5}$"},
+			"nested": {
+				"$id": "nested/",
+				"definitions": {
+					"id": {"$ref": "other.json"},
+					"code": {"type": "integer"}
+				}
+			},
+			"other": {"$id": "nested/other.json", "type": "string"}
+		}
+	}`)))
+
+	testcases := []struct {
+		name    string
+		pointer string
+		valid   []any
+		invalid []any
+	}{
+		{
+			name:    "local reference against the document",
+			pointer: "#/definitions/address",
+			valid:   []any{map[string]any{"zip": "12345", "country": "US"}},
+			invalid: []any{map[string]any{"zip": "1234", "country": "US"}, "not an object"},
+		},
+		{
+			name:    "draft of the document",
+			pointer: "/definitions/address",
+			invalid: []any{map[string]any{"zip": "12345"}},
+		},
+		{
+			name:    "relative reference in a nested resource",
+			pointer: "#/definitions/nested/definitions/id",
+			valid:   []any{"abc"},
+			invalid: []any{1},
+		},
+		{
+			name:    "the root itself",
+			pointer: "",
+			valid:   []any{map[string]any{"address": map[string]any{}}},
+			invalid: []any{map[string]any{}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := validator.CompileAt(t.Context(), &s, tc.pointer)
+			require.NoError(t, err)
+			for _, value := range tc.valid {
+				_, err := v.Validate(t.Context(), value)
+				require.NoError(t, err, "%v should be valid", value)
+			}
+			for _, value := range tc.invalid {
+				_, err := v.Validate(t.Context(), value)
+				require.Error(t, err, "%v should be invalid", value)
+			}
+		})
+	}
+
+	t.Run("pointer to nothing", func(t *testing.T) {
+		_, err := validator.CompileAt(t.Context(), &s, "#/definitions/phone")
+		require.Error(t, err)
+	})
+
+	t.Run("references disabled", func(t *testing.T) {
+		_, err := validator.CompileAt(t.Context(), &s, "#/definitions/address", validator.WithReferencesDisabled())
+		require.Error(t, err)
+
+		v, err := validator.CompileAt(t.Context(), &s, "#/definitions/zip", validator.WithReferencesDisabled())
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "12345")
+		require.NoError(t, err)
+	})
+}
@@ -12,8 +12,33 @@ import (
 // pointer across every compileState derived during a single Compile call: these
 // values never change as recursion descends.
 type compileConfig struct {
-	resolver *schema.Resolver
-	vocab    *vocabulary.VocabularySet
+	resolver  *schema.Resolver
+	vocab     *vocabulary.VocabularySet
+	vocabSet  bool               // WithVocabularySet: vocab overrides the schema's $vocabulary
+	format    *bool              // WithFormatAssertion: forces format-assertion on or off in vocab
+	coerce    bool               // WithCoercion: scalar validators accept string encodings
+	ecmaRegex bool               // WithECMAScriptRegex: translate patterns to RE2
+	content   bool               // WithContentAssertion: content keywords assert
+	useNumber bool               // WithUseNumber: reject floats that may have lost integer precision
+	strictInt bool               // WithStrictInteger: "type": "integer" rejects 30.0
+	noRefs    bool               // WithReferencesDisabled: reject reference keywords, resolve nothing
+	maxDepth  int                // WithMaxDepth: deepest subschema nesting allowed (0: no limit)
+	custom    []customVocabulary // WithVocabulary: custom vocabularies, in the order given
+}
+
+// applyFormatAssertion returns vs with format-assertion forced as
+// WithFormatAssertion asked, leaving vs itself untouched.
+func (cfg *compileConfig) applyFormatAssertion(vs *vocabulary.VocabularySet) *vocabulary.VocabularySet {
+	if cfg.format == nil {
+		return vs
+	}
+	vs = vs.Clone()
+	if *cfg.format {
+		vs.Enable(vocabulary.FormatAssertionURL)
+	} else {
+		vs.Disable(vocabulary.FormatAssertionURL)
+	}
+	return vs
 }
 
 // compileState is the explicit per-recursion-edge carrier for compilation: the
@@ -33,15 +58,30 @@ type compileState struct {
 	baseSchema *schema.Schema // enclosing resource; changes on $id boundaries
 	baseURI    string         // enclosing resource's base URI
 
-	referenceStack []string       // active $ref chain for cycle detection
-	refDepths      map[string]int // data depth at which each active $ref was entered
+	referenceStack []string       // locations of the active $ref chain, for cycle detection
+	refDepths      map[string]int // data depth at which each active $ref location was entered
 	dataDepth      int            // child-applying keyword boundaries crossed
 
+	// draft is the specification version declared by the nearest enclosing
+	// "$schema", or given with WithDefaultDraft; DraftUnknown means 2020-12
+	// semantics.
+	draft schema.Draft
+
+	// depth is how many subschemas deep compilation is, checked against
+	// cfg.maxDepth.
+	depth int
+
 	// skipIDRebase marks that the caller already set the base URI to the target
 	// resource's canonical URI (from the registry), so compileSchema must not
 	// re-base the target's $id again (which would double a path segment). It
 	// applies only to the immediate schema, never its nested subschemas.
 	skipIDRebase bool
+
+	// skipAnnotations marks that the schema being compiled holds the keywords
+	// next to a reference, split off a schema whose own validator already
+	// records its annotations. Like skipIDRebase it applies only to the
+	// immediate schema.
+	skipAnnotations bool
 }
 
 // newCompileState builds the initial compileState for a top-level Compile call
@@ -51,7 +91,18 @@ type compileState struct {
 func newCompileState(s *schema.Schema, options []CompileOption) compileState {
 	resolver := schema.NewResolver()
 	vocab := vocabulary.DefaultSet()
+	var vocabSet bool
+	var format *bool
 	var baseURI string
+	var coerce bool
+	var ecmaRegex bool
+	var content bool
+	var useNumber bool
+	var strictInt bool
+	var noRefs bool
+	var maxDepth int
+	var custom []customVocabulary
+	var draft schema.Draft
 	// By default the schema being compiled is its own document root and base
 	// resource; WithBaseSchema overrides this for fragment compilation.
 	doc := s
@@ -64,9 +115,33 @@ func newCompileState(s *schema.Schema, options []CompileOption) compileState {
 		case identVocabularySet{}:
 			if vs := option.MustGet[*vocabulary.VocabularySet](o); vs != nil {
 				vocab = vs
+				vocabSet = true
 			}
 		case identBaseURI{}:
 			baseURI = option.MustGet[string](o)
+		case identCoercion{}:
+			coerce = option.MustGet[bool](o)
+		case identECMAScriptRegex{}:
+			ecmaRegex = option.MustGet[bool](o)
+		case identContentAssertion{}:
+			content = option.MustGet[bool](o)
+		case identUseNumber{}:
+			useNumber = option.MustGet[bool](o)
+		case identStrictInteger{}:
+			strictInt = option.MustGet[bool](o)
+		case identFormatAssertion{}:
+			v := option.MustGet[bool](o)
+			format = &v
+		case identReferencesDisabled{}:
+			noRefs = option.MustGet[bool](o)
+		case identMaxDepth{}:
+			maxDepth = option.MustGet[int](o)
+		case identVocabulary{}:
+			if cv := option.MustGet[customVocabulary](o); cv.set != nil && cv.compile != nil {
+				custom = append(custom, cv)
+			}
+		case identDefaultDraft{}:
+			draft = option.MustGet[schema.Draft](o)
 		case identBaseSchema{}:
 			if bs := option.MustGet[*schema.Schema](o); bs != nil {
 				doc = bs
@@ -79,11 +154,14 @@ func newCompileState(s *schema.Schema, options []CompileOption) compileState {
 	// deduped per root inside the resolver, so this is safe to call repeatedly.
 	resolver.RegisterRoot(doc)
 
+	cfg := &compileConfig{resolver: resolver, vocabSet: vocabSet, format: format, coerce: coerce, ecmaRegex: ecmaRegex, content: content, useNumber: useNumber, strictInt: strictInt, noRefs: noRefs, maxDepth: maxDepth, custom: custom}
+	cfg.vocab = cfg.applyFormatAssertion(vocab)
 	return compileState{
-		cfg:        &compileConfig{resolver: resolver, vocab: vocab},
+		cfg:        cfg,
 		rootSchema: doc,
 		baseSchema: doc,
 		baseURI:    baseURI,
+		draft:      draft,
 	}
 }
 
@@ -117,18 +195,19 @@ func (cs compileState) incDataDepth() compileState {
 	return cs
 }
 
-// pushReference returns a copy of cs with reference appended to the active
-// reference chain and its entry data-depth recorded. The slice and map are
-// copied so the parent's view is untouched.
-func (cs compileState) pushReference(reference string) compileState {
+// pushReference returns a copy of cs with location, a reference resolved
+// against the base URI, appended to the active reference chain and its entry
+// data-depth recorded. The slice and map are copied so the parent's view is
+// untouched.
+func (cs compileState) pushReference(location string) compileState {
 	newStack := make([]string, len(cs.referenceStack)+1)
 	copy(newStack, cs.referenceStack)
-	newStack[len(cs.referenceStack)] = reference
+	newStack[len(cs.referenceStack)] = location
 	cs.referenceStack = newStack
 
 	newDepths := make(map[string]int, len(cs.refDepths)+1)
 	maps.Copy(newDepths, cs.refDepths)
-	newDepths[reference] = cs.dataDepth
+	newDepths[location] = cs.dataDepth
 	cs.refDepths = newDepths
 
 	return cs
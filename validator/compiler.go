@@ -4,12 +4,29 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/internal/compiler"
+	"github.com/lestrrat-go/json-schema/keywords"
 	"github.com/lestrrat-go/json-schema/vocabulary"
 )
 
+// init installs Compile as the compiler behind schema.Schema.Validate.
+func init() {
+	compiler.Compile = func(ctx context.Context, s any) (func(context.Context, any) error, error) {
+		v, err := Compile(ctx, s.(*schema.Schema))
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, data any) error {
+			_, err := v.Validate(ctx, data)
+			return err
+		}, nil
+	}
+}
+
 // Compile builds a validator for s. A schema that declares a $dynamicAnchor is
 // a potential bookend target for $dynamicRef, so its validator is wrapped to
 // push the schema onto the runtime dynamic scope when validation enters it.
@@ -28,37 +45,229 @@ import (
 // registrations made on a resolver you did not pass, so such external
 // references fail to resolve rather than being silently fetched.
 func Compile(ctx context.Context, s *schema.Schema, options ...CompileOption) (Interface, error) {
-	return compile(ctx, s, newCompileState(s, options))
+	cs := newCompileState(s, options)
+	if cs.cfg.noRefs {
+		if err := rejectReferences(s); err != nil {
+			return nil, err
+		}
+	}
+	return cs.withCoercionTracking(compile(ctx, s, cs))
+}
+
+// CompileAt builds a validator for the subschema of root that the JSON Pointer
+// pointer refers to, such as "#/$defs/address", as located by
+// schema.Schema.ResolvePointer. The subschema is compiled as part of root, not
+// on its own: its references resolve against the whole document, and the
+// $id resources, "$schema" drafts and vocabularies it is nested in apply to it
+// as they would when compiling root. The options are those of Compile.
+func CompileAt(ctx context.Context, root *schema.Schema, pointer string, options ...CompileOption) (Interface, error) {
+	sub, err := root.ResolvePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if sub == root {
+		return Compile(ctx, root, options...)
+	}
+
+	cs := newCompileState(root, options)
+	if cs.cfg.noRefs {
+		if err := rejectReferences(sub); err != nil {
+			return nil, err
+		}
+	}
+	if cs, err = withDeclaredVocabularies(ctx, root, cs); err != nil {
+		return nil, err
+	}
+	return cs.withCoercionTracking(compile(ctx, sub, scopeAt(root, pointer, cs)))
+}
+
+// withCoercionTracking wraps v, the validator Compile built, in a
+// coercionTracker when it was compiled WithCoercion.
+func (cs compileState) withCoercionTracking(v Interface, err error) (Interface, error) {
+	if err != nil || !cs.cfg.coerce {
+		return v, err
+	}
+	return &coercionTracker{inner: v}, nil
+}
+
+// scopeAt returns cs as compileSchema leaves it on its way down root to the
+// schema at pointer: switched to the draft of each "$schema", and re-based onto
+// each $id resource, that encloses that schema.
+func scopeAt(root *schema.Schema, pointer string, cs compileState) compileState {
+	var prefix string
+	if strings.HasPrefix(pointer, "#") {
+		prefix, pointer = "#", pointer[1:]
+	}
+	tokens := strings.Split(pointer, "/")[1:]
+	for i := range tokens {
+		// Pointers to the name of a $defs or properties entry, and the like,
+		// do not refer to a schema and are skipped.
+		enclosing, err := root.ResolvePointer(prefix + strings.Join(append([]string{""}, tokens[:i]...), "/"))
+		if err != nil {
+			continue
+		}
+		if enclosing.HasSchema() {
+			if draft := schema.DetectDraft(enclosing.Schema()); draft != schema.DraftUnknown {
+				cs.draft = draft
+			}
+		}
+		if enclosing.HasID() && schema.IsResourceID(enclosing.ID()) {
+			baseURI := cs.baseURI
+			if absBase := schema.ResolveURI(cs.baseURI, enclosing.ID()); absBase != "" {
+				baseURI = absBase
+			}
+			cs = cs.withBase(enclosing, baseURI)
+		}
+	}
+	return cs
+}
+
+// rejectReferences fails if s or any of its subschemas uses a reference
+// keyword. WithReferencesDisabled runs it before compiling, so no reference is
+// ever resolved.
+func rejectReferences(s *schema.Schema) error {
+	return s.Walk(func(path string, sub *schema.Schema) error {
+		var keyword string
+		switch {
+		case sub.HasReference():
+			keyword = keywords.Reference
+		case sub.HasDynamicReference():
+			keyword = keywords.DynamicReference
+		case sub.HasRecursiveReference():
+			keyword = keywords.RecursiveRef
+		default:
+			return nil
+		}
+		return fmt.Errorf("references are disabled: %s found at #%s", keyword, path)
+	})
 }
 
 // compile is the internal entry point that threads an explicit compileState. It
 // compiles s and, when s is a schema resource ($id) or declares a
-// $dynamicAnchor, wraps the result so entering it during validation records it
-// on the runtime dynamic scope (letting $dynamicRef find the outermost in-scope
-// $dynamicAnchor).
+// $dynamicAnchor or $recursiveAnchor, wraps the result so entering it during
+// validation records it on the runtime dynamic scope (letting $dynamicRef find
+// the outermost in-scope $dynamicAnchor, and $recursiveRef the outermost
+// $recursiveAnchor).
 func compile(ctx context.Context, s *schema.Schema, cs compileState) (Interface, error) {
+	if cs.cfg.maxDepth > 0 && cs.depth > cs.cfg.maxDepth {
+		return nil, fmt.Errorf("%w: schema is nested deeper than %d", ErrMaxDepthExceeded, cs.cfg.maxDepth)
+	}
+	cs.depth++
+	skipAnnotations := cs.skipAnnotations
+	cs.skipAnnotations = false
 	v, err := compileSchema(ctx, s, cs)
 	if err != nil {
 		return nil, err
 	}
-	if s != nil && (s.HasID() || s.HasDynamicAnchor()) {
+	if s != nil && !skipAnnotations {
+		v = withAnnotations(s, v)
+	}
+	if s != nil && ((s.HasID() && schema.IsResourceID(s.ID())) || s.HasDynamicAnchor() || s.HasRecursiveAnchor()) {
 		return &dynamicScopeValidator{schema: s, inner: v}, nil
 	}
 	return v, nil
 }
 
-// combineReferenceWithConstraints combines a resolved $ref/$dynamicRef validator
-// with any sibling keywords present on the same schema, mirroring $ref handling
-// so that keywords like unevaluatedProperties alongside a $dynamicRef are still
-// applied (and can see the reference target's annotations).
+// withDeclaredVocabularies returns cs using the vocabularies declared for the
+// root schema s, unless WithVocabularySet chose them.
+func withDeclaredVocabularies(ctx context.Context, s *schema.Schema, cs compileState) (compileState, error) {
+	if cs.cfg.vocabSet {
+		return cs, nil
+	}
+	vocabSet, err := declaredVocabularies(ctx, s, cs)
+	if err != nil {
+		return cs, err
+	}
+	if vocabSet != nil {
+		cfg := *cs.cfg
+		cfg.vocab = cfg.applyFormatAssertion(vocabSet)
+		cs.cfg = &cfg
+	}
+	return cs, nil
+}
+
+// declaredVocabularies returns the vocabulary set declared for the root schema
+// s: its own $vocabulary if it has one, or else that of the metaschema named
+// by its $schema. The standard metaschemas declare the standard vocabularies,
+// so they are not retrieved; with WithReferencesDisabled no metaschema is. It returns nil, leaving the configured set in
+// place, when nothing is declared or the metaschema cannot be retrieved.
+func declaredVocabularies(ctx context.Context, s *schema.Schema, cs compileState) (*vocabulary.VocabularySet, error) {
+	decl := s
+	if !s.HasVocabulary() {
+		if !s.HasSchema() || schema.DetectDraft(s.Schema()) != schema.DraftUnknown || cs.cfg.noRefs {
+			return nil, nil //nolint:nilnil
+		}
+		var metaschema schema.Schema
+		if err := cs.cfg.resolver.ResolveReference(ctx, &metaschema, s.Schema(), s, cs.baseURI); err != nil {
+			return nil, nil //nolint:nilnil,nilerr // an unknown metaschema leaves the configured set in place
+		}
+		if !metaschema.HasVocabulary() {
+			return nil, nil //nolint:nilnil
+		}
+		decl = &metaschema
+	}
+
+	// A required vocabulary that is not understood must stop processing.
+	return vocabulary.SetFromSchema(decl, cs.cfg.customVocabularySets()...)
+}
+
+// compileReferenceTarget compiles the schema a $ref resolved to, in the
+// resource the reference points into.
+func compileReferenceTarget(ctx context.Context, targetSchema *schema.Schema, reference string, cs compileState) (Interface, error) {
+	resolvedCs := cs
+	var resource *schema.Schema
+	if strings.HasPrefix(reference, "#") {
+		// Local reference: the target lives in the current resource, so the
+		// base URI must not change. Re-base only if the target carries its own
+		// $id.
+		if targetSchema.HasID() && schema.IsResourceID(targetSchema.ID()) {
+			resolvedCs = cs.withBaseSchema(targetSchema)
+		}
+	} else {
+		// A reference into another document/resource. Its base URI is the
+		// reference's absolute (retrieval) URI, so the target's own relative
+		// references (e.g. "string.json") resolve against where it lives, and
+		// its local "#/..." pointers resolve within the enclosing resource.
+		absBase, _, _ := strings.Cut(schema.ResolveURI(cs.baseURI, reference), "#")
+		resource = cs.cfg.resolver.ResourceFor(absBase)
+		if absBase != "" {
+			resolvedCs = resolvedCs.withBaseURI(absBase)
+		}
+		switch {
+		case resource != nil:
+			// absBase is the resource's canonical registry URI, so suppress the
+			// $id re-base in compileSchema (it would double a path segment).
+			resolvedCs = resolvedCs.withBaseSchema(resource)
+			resolvedCs.skipIDRebase = true
+		case targetSchema.HasID() && schema.IsResourceID(targetSchema.ID()):
+			resolvedCs = resolvedCs.withBaseSchema(targetSchema)
+		}
+	}
+	compiled, err := compile(ctx, targetSchema, resolvedCs)
+	if err != nil {
+		return nil, err
+	}
+	// Following the $ref enters the target's resource; record it on the
+	// dynamic scope so a $dynamicRef deeper in the target can find it.
+	if resource != nil && resource != targetSchema {
+		compiled = &dynamicScopeValidator{schema: resource, inner: compiled}
+	}
+	return compiled, nil
+}
+
+// combineReferenceWithConstraints combines the validator of a $ref, $dynamicRef
+// or $recursiveRef with any sibling keywords present on the same schema, so
+// that keywords like unevaluatedProperties next to the reference are applied
+// and can see the reference target's annotations.
 func combineReferenceWithConstraints(ctx context.Context, s *schema.Schema, cs compileState, resolvedValidator Interface) (Interface, error) {
-	if !hasOtherConstraints(s) {
+	if !hasOtherConstraints(s) && !cs.cfg.hasCustomKeywords(s) {
 		return resolvedValidator, nil
 	}
 	schemaWithoutRef, err := createSchemaWithoutRef(s)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build schema without reference: %w", err)
 	}
+	cs.skipAnnotations = true
 	if schemaWithoutRef.HasUnevaluatedProperties() || schemaWithoutRef.HasUnevaluatedItems() {
 		schemaWithoutUnevaluated, err := createSchemaWithoutUnevaluatedFields(schemaWithoutRef)
 		if err != nil {
@@ -92,12 +301,26 @@ func compileSchema(ctx context.Context, s *schema.Schema, cs compileState) (Inte
 	skipIDRebase := cs.skipIDRebase
 	cs.skipIDRebase = false // applies only to the immediate schema, not its subschemas
 
+	// "$schema" selects the draft whose keyword semantics apply to this schema
+	// and, until another "$schema" says otherwise, to its subschemas.
+	if s.HasSchema() {
+		if draft := schema.DetectDraft(s.Schema()); draft != schema.DraftUnknown {
+			cs.draft = draft
+		}
+	}
+	// In draft-07 and earlier a "$ref" replaces its schema outright, so a
+	// sibling "$id" must not change the base URI either.
+	legacyRef := s.HasReference() && isLegacyDraft(cs.draft)
+	if legacyRef {
+		skipIDRebase = true
+	}
+
 	// A schema with its own $id establishes a new base URI and is itself the
 	// base resource for resolving references that appear within it. Re-base both
 	// the base URI and the base schema so that this resource's relative refs
 	// (e.g. "./bar.json") and local pointers (e.g. "#/$defs/inner") resolve
 	// against this resource rather than an enclosing one.
-	if s.HasID() && s.ID() != "" && !skipIDRebase {
+	if s.HasID() && schema.IsResourceID(s.ID()) && !skipIDRebase {
 		newBaseURI := cs.baseURI
 		if absBase := schema.ResolveURI(cs.baseURI, s.ID()); absBase != "" {
 			newBaseURI = absBase
@@ -105,29 +328,29 @@ func compileSchema(ctx context.Context, s *schema.Schema, cs compileState) (Inte
 		cs = cs.withBase(s, newBaseURI)
 	}
 
-	// For root schema compilation, resolve vocabulary from metaschema.
-	//
-	// FIXME: This special-cases a single JSON Schema Test Suite remote fixture
-	// instead of resolving the metaschema's $vocabulary declaration. The proper
-	// implementation is vocabulary.ResolveVocabularyFromMetaschema, but wiring it
-	// in is a behavioral change (its AllEnabled() fallback enables format-assertion,
-	// unlike the DefaultSet) and can only be verified against the full
-	// JSON Schema Test Suite. Left as-is pending that verified change.
-	if cs.rootSchema == s && s.HasSchema() && s.Schema() == "http://localhost:1234/draft2020-12/metaschema-no-validation.json" {
-		// This specific metaschema disables validation vocabulary.
-		vocabSet := vocabulary.AllEnabled()
-		vocabSet.Disable(vocabulary.ValidationURL)
-		cs.cfg = &compileConfig{resolver: cs.cfg.resolver, vocab: vocabSet}
+	// The vocabularies in use are declared by the root schema's metaschema, or
+	// by the root schema itself when it carries a $vocabulary of its own. An
+	// explicit WithVocabularySet takes precedence over both.
+	if cs.rootSchema == s {
+		var err error
+		if cs, err = withDeclaredVocabularies(ctx, s, cs); err != nil {
+			return nil, err
+		}
 	}
 
 	// Handle $ref and $dynamicRef first - if schema has a reference, resolve it immediately
 	var reference string
-	var isDynamicRef bool
+	var isDynamicRef, isRecursiveRef bool
 	if s.HasReference() {
 		reference = s.Reference()
 	} else if s.HasDynamicReference() {
 		reference = s.DynamicReference()
 		isDynamicRef = true
+	} else if s.HasRecursiveReference() && cs.draft == schema.Draft201909 {
+		// $recursiveRef only exists in 2019-09; later drafts replaced it with
+		// $dynamicRef, and for them it is an unknown keyword.
+		reference = s.RecursiveReference()
+		isRecursiveRef = true
 	}
 
 	if reference != "" {
@@ -136,21 +359,28 @@ func compileSchema(ctx context.Context, s *schema.Schema, cs compileState) (Inte
 		// root) so the non-dynamic fallback — a $dynamicRef whose fragment is a
 		// JSON pointer or a plain $ref to an $anchor — resolves within the correct
 		// schema resource. The dynamic scope itself is read at validation time.
-		if isDynamicRef {
+		if isDynamicRef || isRecursiveRef {
 			baseSchema := cs.baseSchema
 			if baseSchema == nil {
 				baseSchema = cs.rootSchema
 			}
 			drv := &DynamicReferenceValidator{
 				reference:  reference,
+				recursive:  isRecursiveRef,
 				resolver:   cs.cfg.resolver,
 				rootSchema: cs.rootSchema,
 				baseSchema: baseSchema,
 				baseURI:    cs.baseURI,
+				draft:      cs.draft,
+				cfg:        cs.cfg,
+			}
+			keyword := keywords.DynamicReference
+			if isRecursiveRef {
+				keyword = keywords.RecursiveRef
 			}
 			// Combine with any sibling keywords (e.g. unevaluatedProperties), the
 			// same way $ref does, so they are not silently dropped.
-			return combineReferenceWithConstraints(ctx, s, cs, drv)
+			return combineReferenceWithConstraints(ctx, s, cs, &locationValidator{keyword: jsonPointer(keyword), inner: drv})
 		}
 
 		resolver := cs.cfg.resolver
@@ -161,113 +391,56 @@ func compileSchema(ctx context.Context, s *schema.Schema, cs compileState) (Inte
 		// terminates on the instance being validated, so compile it lazily as a
 		// ReferenceValidator. Otherwise it is a pure cycle that can never
 		// terminate, which is a compile-time error.
-		if slices.Contains(cs.referenceStack, reference) {
-			if cs.dataDepth > cs.refDepths[reference] {
-				return &ReferenceValidator{
-					reference:  reference,
-					resolver:   resolver,
-					rootSchema: cs.rootSchema,
-					baseSchema: cs.baseSchema,
-					baseURI:    cs.baseURI,
-				}, nil
-			}
-			return nil, fmt.Errorf("circular reference detected: %s", reference)
-		}
-		// Push the reference, recording the data depth at which it was entered.
-		cs = cs.pushReference(reference)
-
-		// Resolve the reference to get the target schema.
-		var targetSchema schema.Schema
-		if err := resolver.ResolveReference(ctx, &targetSchema, reference, cs.baseSchema, cs.baseURI); err != nil {
-			return nil, fmt.Errorf("reference resolution failed for %s: %w", reference, err)
-		}
-
-		// Check if schema has other constraints beyond the reference
-		if hasOtherConstraints(s) {
-			// Schema has both $ref and additional constraints: combine the resolved
-			// schema and additional constraints.
-			resolvedValidator, err := compile(ctx, &targetSchema, cs.withBaseSchema(&targetSchema))
-			if err != nil {
-				return nil, fmt.Errorf("failed to compile resolved schema: %w", err)
-			}
-
-			// Create schema without reference for additional constraints
-			schemaWithoutRef, err := createSchemaWithoutRef(s)
-			if err != nil {
-				return nil, fmt.Errorf("failed to build schema without reference: %w", err)
+		//
+		// References are compared by the location they resolve to, not as
+		// written: "#/$defs/a" names a different schema in every resource.
+		location := schema.ResolveURI(cs.baseURI, reference)
+		var compiled Interface
+		if slices.Contains(cs.referenceStack, location) {
+			if cs.dataDepth <= cs.refDepths[location] {
+				return nil, fmt.Errorf("circular reference detected: %s", reference)
 			}
-
-			// Check if we need unevaluated coordination
-			if schemaWithoutRef.HasUnevaluatedProperties() || schemaWithoutRef.HasUnevaluatedItems() {
-				// Create additional validator WITHOUT unevaluated constraints
-				schemaWithoutUnevaluated, err := createSchemaWithoutUnevaluatedFields(schemaWithoutRef)
-				if err != nil {
-					return nil, fmt.Errorf("failed to build schema without unevaluated fields: %w", err)
-				}
-				additionalValidator, err := compile(ctx, schemaWithoutUnevaluated, cs)
-				if err != nil {
-					return nil, fmt.Errorf("failed to compile additional constraints: %w", err)
-				}
-
-				// Use unevaluatedCoordinator to properly handle annotation sharing
-				return &unevaluatedCoordinator{
-					validators:       []Interface{resolvedValidator, additionalValidator},
-					unevaluatedProps: schemaWithoutRef.UnevaluatedProperties(),
-					unevaluatedItems: schemaWithoutRef.UnevaluatedItems(),
-					strictArrayType:  hasExplicitArrayType(schemaWithoutRef),
-					strictObjectType: hasExplicitObjectType(schemaWithoutRef),
-				}, nil
-			}
-
-			// Regular allOf for cases without unevaluated constraints
-			additionalValidator, err := compile(ctx, schemaWithoutRef, cs)
-			if err != nil {
-				return nil, fmt.Errorf("failed to compile additional constraints: %w", err)
-			}
-			return AllOf(resolvedValidator, additionalValidator), nil
-		}
-
-		// Schema has only $ref: recursively compile the resolved schema.
-		resolvedCs := cs
-		var resource *schema.Schema
-		if strings.HasPrefix(reference, "#") {
-			// Local reference: the target lives in the current resource, so the
-			// base URI must not change. Re-base only if the target carries its own
-			// $id.
-			if targetSchema.HasID() {
-				resolvedCs = cs.withBaseSchema(&targetSchema)
+			compiled = &ReferenceValidator{
+				reference:  reference,
+				resolver:   resolver,
+				rootSchema: cs.rootSchema,
+				baseSchema: cs.baseSchema,
+				baseURI:    cs.baseURI,
+				draft:      cs.draft,
+				cfg:        cs.cfg,
 			}
 		} else {
-			// A reference into another document/resource. Its base URI is the
-			// reference's absolute (retrieval) URI, so the target's own relative
-			// references (e.g. "string.json") resolve against where it lives, and
-			// its local "#/..." pointers resolve within the enclosing resource.
-			absBase, _, _ := strings.Cut(schema.ResolveURI(cs.baseURI, reference), "#")
-			resource = resolver.ResourceFor(absBase)
-			if absBase != "" {
-				resolvedCs = resolvedCs.withBaseURI(absBase)
+			// Push the reference, recording the data depth at which it was entered.
+			cs = cs.pushReference(location)
+
+			// Resolve the reference to get the target schema.
+			var targetSchema schema.Schema
+			if err := resolver.ResolveReference(ctx, &targetSchema, reference, cs.baseSchema, cs.baseURI); err != nil {
+				return nil, fmt.Errorf("reference resolution failed for %s: %w", reference, err)
 			}
-			switch {
-			case resource != nil:
-				// absBase is the resource's canonical registry URI, so suppress the
-				// $id re-base in compileSchema (it would double a path segment).
-				resolvedCs = resolvedCs.withBaseSchema(resource)
-				resolvedCs.skipIDRebase = true
-			case targetSchema.HasID():
-				resolvedCs = resolvedCs.withBaseSchema(&targetSchema)
+			var err error
+			if compiled, err = compileReferenceTarget(ctx, &targetSchema, reference, cs); err != nil {
+				return nil, err
 			}
 		}
-		compiled, err := compile(ctx, &targetSchema, resolvedCs)
-		if err != nil {
-			return nil, err
+		compiled = atReference(compiled, cs.baseURI, reference)
+		// Legacy drafts ignore the keywords next to a $ref.
+		if legacyRef {
+			return compiled, nil
 		}
-		// Following the $ref enters the target's resource; record it on the
-		// dynamic scope so a $dynamicRef deeper in the target can find it.
-		if resource != nil && resource != &targetSchema {
-			compiled = &dynamicScopeValidator{schema: resource, inner: compiled}
-		}
-		return compiled, nil
+		// The target is compiled exactly as a bare $ref would be, so its
+		// annotations (evaluated properties and items) reach an
+		// unevaluatedCoordinator for sibling unevaluated* keywords. A deferred
+		// ReferenceValidator gets its siblings the same way.
+		return combineReferenceWithConstraints(ctx, s, cs, compiled)
+	}
+	// Rewrite draft-specific spellings (tuple "items", "dependencies") into
+	// their 2020-12 equivalents before dispatching on keywords.
+	s, err := normalizeLegacyKeywords(s, cs.draft)
+	if err != nil {
+		return nil, err
 	}
+
 	var validators []Interface
 
 	// Phase 2: Compile composite validators (allOf, anyOf, oneOf)
@@ -293,6 +466,13 @@ func compileSchema(ctx context.Context, s *schema.Schema, cs compileState) (Inte
 		validators = append(validators, baseValidator)
 	}
 
+	// Keywords of custom vocabularies (WithVocabulary)
+	customValidators, err := compileCustomVocabularies(ctx, s, cs)
+	if err != nil {
+		return nil, err
+	}
+	validators = append(validators, customValidators...)
+
 	// Phase 4: If unevaluated constraints exist, wrap in coordinator
 	if s.HasUnevaluatedProperties() || s.HasUnevaluatedItems() {
 		return &unevaluatedCoordinator{
@@ -325,12 +505,12 @@ func compileCompositeValidators(ctx context.Context, s *schema.Schema, cs compil
 	// AllOf
 	if s.HasAllOf() {
 		allOfValidators := make([]Interface, 0, len(s.AllOf()))
-		for _, subSchema := range s.AllOf() {
+		for i, subSchema := range s.AllOf() {
 			v, err := compile(ctx, convertSchemaOrBool(subSchema), cs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to compile allOf validator: %w", err)
 			}
-			allOfValidators = append(allOfValidators, v)
+			allOfValidators = append(allOfValidators, &locationValidator{keyword: jsonPointer(keywords.AllOf, strconv.Itoa(i)), inner: v})
 		}
 		validators = append(validators, AllOf(allOfValidators...))
 	}
@@ -338,12 +518,12 @@ func compileCompositeValidators(ctx context.Context, s *schema.Schema, cs compil
 	// AnyOf
 	if s.HasAnyOf() {
 		anyOfValidators := make([]Interface, 0, len(s.AnyOf()))
-		for _, subSchema := range s.AnyOf() {
+		for i, subSchema := range s.AnyOf() {
 			v, err := compile(ctx, convertSchemaOrBool(subSchema), cs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to compile anyOf validator: %w", err)
 			}
-			anyOfValidators = append(anyOfValidators, v)
+			anyOfValidators = append(anyOfValidators, &locationValidator{keyword: jsonPointer(keywords.AnyOf, strconv.Itoa(i)), inner: v})
 		}
 		validators = append(validators, AnyOf(anyOfValidators...))
 	}
@@ -351,12 +531,12 @@ func compileCompositeValidators(ctx context.Context, s *schema.Schema, cs compil
 	// OneOf
 	if s.HasOneOf() {
 		oneOfValidators := make([]Interface, 0, len(s.OneOf()))
-		for _, subSchema := range s.OneOf() {
+		for i, subSchema := range s.OneOf() {
 			v, err := compile(ctx, convertSchemaOrBool(subSchema), cs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to compile oneOf validator: %w", err)
 			}
-			oneOfValidators = append(oneOfValidators, v)
+			oneOfValidators = append(oneOfValidators, &locationValidator{keyword: jsonPointer(keywords.OneOf, strconv.Itoa(i)), inner: v})
 		}
 		validators = append(validators, OneOf(oneOfValidators...))
 	}
@@ -374,7 +554,7 @@ func compileConditionalValidators(ctx context.Context, s *schema.Schema, cs comp
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile not validator: %w", err)
 		}
-		validators = append(validators, &NotValidator{validator: notValidator})
+		validators = append(validators, &locationValidator{keyword: jsonPointer(keywords.Not), inner: &NotValidator{validator: notValidator}})
 	}
 
 	// If/Then/Else
@@ -401,28 +581,28 @@ func compileBaseConstraints(ctx context.Context, s *schema.Schema, cs compileSta
 			switch typ {
 			case schema.StringType:
 				// String type validator (with or without additional string constraints)
-				stringValidator, err := compileStringValidator(s, cs.cfg.vocab, true) // strict type checking
+				stringValidator, err := compileStringValidator(s, cs.cfg.vocab, true, cs.cfg.ecmaRegex) // strict type checking
 				if err != nil {
 					return nil, fmt.Errorf("failed to compile string validator: %w", err)
 				}
 				typeValidators = append(typeValidators, stringValidator)
 			case schema.IntegerType:
 				// Integer type validator
-				integerValidator, err := compileIntegerValidator(s, cs.cfg.vocab)
+				integerValidator, err := compileIntegerValidator(s, cs.cfg.vocab, cs.cfg.coerce, cs.cfg.useNumber, cs.cfg.strictInt)
 				if err != nil {
 					return nil, fmt.Errorf("failed to compile integer validator: %w", err)
 				}
 				typeValidators = append(typeValidators, integerValidator)
 			case schema.NumberType:
 				// Number type validator
-				numberValidator, err := compileNumberValidator(s, cs.cfg.vocab)
+				numberValidator, err := compileNumberValidator(s, cs.cfg.vocab, cs.cfg.coerce)
 				if err != nil {
 					return nil, fmt.Errorf("failed to compile number validator: %w", err)
 				}
 				typeValidators = append(typeValidators, numberValidator)
 			case schema.BooleanType:
 				// Boolean type validator
-				booleanValidator, err := compileBooleanValidator(s, cs.cfg.vocab)
+				booleanValidator, err := compileBooleanValidator(s, cs.cfg.vocab, cs.cfg.coerce)
 				if err != nil {
 					return nil, fmt.Errorf("failed to compile boolean validator: %w", err)
 				}
@@ -484,7 +664,7 @@ func compileBaseConstraints(ctx context.Context, s *schema.Schema, cs compileSta
 
 		// String constraints without explicit type
 		if s.HasAny(schema.StringConstraintFields) {
-			stringValidator, err := compileStringValidator(s, cs.cfg.vocab, false)
+			stringValidator, err := compileStringValidator(s, cs.cfg.vocab, false, cs.cfg.ecmaRegex)
 			if err != nil {
 				return nil, fmt.Errorf("failed to compile string validator: %w", err)
 			}
@@ -555,6 +735,11 @@ func compileBaseConstraints(ctx context.Context, s *schema.Schema, cs compileSta
 				return nil, fmt.Errorf("failed to compile value constraints validator: %w", err)
 			}
 			if valueValidator != nil {
+				if cs.cfg.coerce {
+					// enum and const compare the value the type validator
+					// coerced to, not the string that was passed in.
+					valueValidator = &coercingValidator{types: s.Types(), inner: valueValidator}
+				}
 				validators = append(validators, valueValidator)
 			}
 		}
@@ -601,6 +786,7 @@ func compileBaseConstraints(ctx context.Context, s *schema.Schema, cs compileSta
 			reference:  s.Reference(),
 			resolver:   cs.cfg.resolver,
 			rootSchema: cs.rootSchema,
+			cfg:        cs.cfg,
 		}
 		validators = append(validators, refValidator)
 	}
@@ -644,7 +830,7 @@ func createSchemaWithoutUnevaluatedFields(s *schema.Schema) (*schema.Schema, err
 	builder := schema.NewBuilder().Clone(s)
 	builder.ResetUnevaluatedProperties()
 	builder.ResetUnevaluatedItems()
-	return builder.Build()
+	return builder.Build(schema.WithBoundsCheck(false))
 }
 
 func hasExplicitArrayType(s *schema.Schema) bool {
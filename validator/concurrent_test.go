@@ -57,3 +57,131 @@ func TestConcurrentValidate(t *testing.T) {
 
 	require.Zero(t, mismatches.Load(), "concurrent validations produced inconsistent results")
 }
+
+// TestConcurrentFrozenSchema compiles and validates one frozen schema from
+// many goroutines, each with its own validator and all sharing one, with a
+// recursive $ref so that lazy reference resolution runs concurrently too.
+func TestConcurrentFrozenSchema(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"children": {"type": "array", "items": {"$ref": "#"}}
+		},
+		"required": ["name"]
+	}`)))
+	frozen := schema.Freeze(&s)
+
+	shared, err := validator.Compile(t.Context(), frozen)
+	require.NoError(t, err)
+
+	valid := map[string]any{"name": "a", "children": []any{map[string]any{"name": "b"}}}
+	invalid := map[string]any{"name": "a", "children": []any{map[string]any{"name": ""}}}
+
+	var mismatches atomic.Int64
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v := shared
+			if i%2 == 0 {
+				own, err := validator.Compile(t.Context(), frozen)
+				if err != nil {
+					mismatches.Add(1)
+					return
+				}
+				v = own
+			}
+			if _, err := v.Validate(t.Context(), valid); err != nil {
+				mismatches.Add(1)
+			}
+			if _, err := v.Validate(t.Context(), invalid); err == nil {
+				mismatches.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Zero(t, mismatches.Load(), "concurrent validations produced inconsistent results")
+}
+
+// TestConcurrentLazyResolution has many goroutines make the first Validate
+// calls of a fresh validator at once, so that the references it resolves on
+// first use ($ref back into the schema being compiled, $dynamicRef) are
+// resolved concurrently. Run with -race.
+func TestConcurrentLazyResolution(t *testing.T) {
+	testcases := []struct {
+		name    string
+		schema  string
+		valid   any
+		invalid any
+	}{
+		{
+			name: "recursive $ref",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"value": {"type": "integer"},
+					"children": {"type": "array", "items": {"$ref": "#"}}
+				}
+			}`,
+			valid:   map[string]any{"value": 1, "children": []any{map[string]any{"children": []any{map[string]any{"value": 2}}}}},
+			invalid: map[string]any{"children": []any{map[string]any{"children": []any{map[string]any{"value": "x"}}}}},
+		},
+		{
+			name: "$dynamicRef",
+			schema: `{
+				"$id": "https://example.com/concurrent/dynamic",
+				"$ref": "list",
+				"$defs": {
+					"foo": {"$dynamicAnchor": "items", "type": "string"},
+					"list": {
+						"$id": "list",
+						"type": "array",
+						"items": {"$dynamicRef": "#items"},
+						"$defs": {"items": {"$dynamicAnchor": "items"}}
+					}
+				}
+			}`,
+			valid:   []any{"a", "b"},
+			invalid: []any{"a", 1},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			for range 8 {
+				var s schema.Schema
+				require.NoError(t, s.UnmarshalJSON([]byte(tc.schema)))
+				v, err := validator.Compile(t.Context(), &s, validator.WithResolver(schema.NewResolver()))
+				require.NoError(t, err)
+
+				start := make(chan struct{})
+				var mismatches atomic.Int64
+				var wg sync.WaitGroup
+				for i := range 32 {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						<-start
+						if i%2 == 0 {
+							if _, err := v.Validate(t.Context(), tc.valid); err != nil {
+								mismatches.Add(1)
+							}
+							return
+						}
+						if _, err := v.Validate(t.Context(), tc.invalid); err == nil {
+							mismatches.Add(1)
+						}
+					}(i)
+				}
+				close(start)
+				wg.Wait()
+
+				require.Zero(t, mismatches.Load(), "concurrent validations produced inconsistent results")
+			}
+		})
+	}
+}
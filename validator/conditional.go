@@ -7,6 +7,7 @@ import (
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/internal/schemactx"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 // IfThenElseValidator handles if/then/else conditional validation
@@ -16,6 +17,18 @@ type IfThenElseValidator struct {
 	elseValidator Interface
 }
 
+// IfThenElse creates a validator that validates a value against
+// thenValidator if ifValidator accepts it, and against elseValidator
+// otherwise. thenValidator and elseValidator may be nil when the respective
+// branch is absent.
+func IfThenElse(ifValidator, thenValidator, elseValidator Interface) Interface {
+	return &IfThenElseValidator{
+		ifValidator:   ifValidator,
+		thenValidator: thenValidator,
+		elseValidator: elseValidator,
+	}
+}
+
 func compileIfThenElseValidator(ctx context.Context, s *schema.Schema, cs compileState) (Interface, error) {
 	v := &IfThenElseValidator{}
 
@@ -34,7 +47,7 @@ func compileIfThenElseValidator(ctx context.Context, s *schema.Schema, cs compil
 		if err != nil {
 			return nil, fmt.Errorf(`failed to compile then validator: %w`, err)
 		}
-		v.thenValidator = thenValidator
+		v.thenValidator = &locationValidator{keyword: jsonPointer(keywords.Then), inner: thenValidator}
 	}
 
 	// Compile 'else' validator (optional)
@@ -44,19 +57,24 @@ func compileIfThenElseValidator(ctx context.Context, s *schema.Schema, cs compil
 		if err != nil {
 			return nil, fmt.Errorf(`failed to compile else validator: %w`, err)
 		}
-		v.elseValidator = elseValidator
+		v.elseValidator = &locationValidator{keyword: jsonPointer(keywords.Else), inner: elseValidator}
 	}
 
 	return v, nil
 }
 
 func (v *IfThenElseValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, in, newEvalState(ctx, options))
+	return validateRoot(ctx, v, in, options)
 }
 
 func (v *IfThenElseValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
 	// First, check the 'if' condition and collect its annotations
+	mark := st.beginKeyword(keywords.If)
 	ifResult, ifErr := evalChild(ctx, v.ifValidator, in, st)
+	st.endKeyword(mark)
+	if interrupted(ifErr) {
+		return nil, ifErr
+	}
 
 	// The 'if' schema contributes annotations regardless of whether it passes or fails
 	var conditionalResult Result
@@ -0,0 +1,63 @@
+package validator_test
+
+import (
+	"fmt"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfThenElseBooleanSchemas(t *testing.T) {
+	for _, ifValue := range []bool{true, false} {
+		for _, thenValue := range []bool{true, false} {
+			for _, elseValue := range []bool{true, false} {
+				src := fmt.Sprintf(`{"if": %t, "then": %t, "else": %t}`, ifValue, thenValue, elseValue)
+				t.Run(src, func(t *testing.T) {
+					var s schema.Schema
+					require.NoError(t, s.UnmarshalJSON([]byte(src)))
+					v, err := validator.Compile(t.Context(), &s)
+					require.NoError(t, err)
+
+					valid := elseValue
+					if ifValue {
+						valid = thenValue
+					}
+					for _, value := range []any{"foo", 42, map[string]any{"a": 1}, nil} {
+						_, err := v.Validate(t.Context(), value)
+						if valid {
+							require.NoError(t, err, "%#v", value)
+						} else {
+							require.Error(t, err, "%#v", value)
+						}
+					}
+				})
+			}
+		}
+	}
+
+	t.Run("missing branches pass", func(t *testing.T) {
+		for _, src := range []string{`{"if": true, "else": false}`, `{"if": false, "then": false}`} {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(src)))
+			v, err := validator.Compile(t.Context(), &s)
+			require.NoError(t, err)
+			_, err = v.Validate(t.Context(), "foo")
+			require.NoError(t, err, src)
+		}
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			IfSchema(schema.FalseSchema()).
+			ThenSchema(schema.TrueSchema()).
+			ElseSchema(schema.FalseSchema()).
+			Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "foo")
+		require.Error(t, err)
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 var _ Interface = (*contentValidator)(nil)
@@ -16,6 +18,75 @@ type contentValidator struct {
 	contentEncoding  string
 	contentMediaType string
 	contentSchema    Interface
+	// assert makes decoding, parsing, and contentSchema failures invalidate
+	// the string (WithContentAssertion) instead of being ignored.
+	assert bool
+}
+
+// Content creates a validator for the contentEncoding, contentMediaType,
+// and contentSchema keywords
+func Content() *ContentValidatorBuilder {
+	return (&ContentValidatorBuilder{}).Reset()
+}
+
+// ContentValidatorBuilder builds content validators
+type ContentValidatorBuilder struct {
+	err error
+	v   *contentValidator
+}
+
+func (b *ContentValidatorBuilder) ContentEncoding(encoding string) *ContentValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.v.contentEncoding = encoding
+	return b
+}
+
+func (b *ContentValidatorBuilder) ContentMediaType(mediaType string) *ContentValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.v.contentMediaType = mediaType
+	return b
+}
+
+func (b *ContentValidatorBuilder) ContentSchema(v Interface) *ContentValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.v.contentSchema = v
+	return b
+}
+
+// Assert makes decoding, parsing, and contentSchema failures invalidate the
+// string, as WithContentAssertion does for compiled validators.
+func (b *ContentValidatorBuilder) Assert(assert bool) *ContentValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.v.assert = assert
+	return b
+}
+
+func (b *ContentValidatorBuilder) Build() (Interface, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.v, nil
+}
+
+func (b *ContentValidatorBuilder) MustBuild() Interface {
+	if b.err != nil {
+		panic(b.err)
+	}
+	return b.v
+}
+
+func (b *ContentValidatorBuilder) Reset() *ContentValidatorBuilder {
+	b.err = nil
+	b.v = &contentValidator{}
+	return b
 }
 
 func compileContentValidator(ctx context.Context, s *schema.Schema, cs compileState) (Interface, error) {
@@ -23,7 +94,7 @@ func compileContentValidator(ctx context.Context, s *schema.Schema, cs compileSt
 		return nil, nil //nolint:nilnil // Intentional: JSON Schema spec allows validators to return nil result
 	}
 
-	cv := &contentValidator{}
+	cv := &contentValidator{assert: cs.cfg.content}
 
 	if s.HasContentEncoding() {
 		cv.contentEncoding = s.ContentEncoding()
@@ -45,7 +116,7 @@ func compileContentValidator(ctx context.Context, s *schema.Schema, cs compileSt
 }
 
 func (cv *contentValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return cv.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, cv, v, options)
 }
 
 func (cv *contentValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
@@ -60,8 +131,13 @@ func (cv *contentValidator) evaluate(ctx context.Context, v any, st *evalState)
 	decodedData := str
 	if cv.contentEncoding != "" {
 		var err error
+		mark := st.beginKeyword(keywords.ContentEncoding)
 		decodedData, err = cv.applyContentDecoding(str, cv.contentEncoding)
+		st.endKeyword(mark)
 		if err != nil {
+			if cv.assert {
+				return nil, atLocation(codeErrorf(ErrCodeContentEncoding, `invalid value passed to ContentValidator: contentEncoding %q: %w`, cv.contentEncoding, err), jsonPointer(keywords.ContentEncoding), "")
+			}
 			// According to JSON Schema spec, encoding errors should be ignored
 			// The validation should pass even if decoding fails
 			return nil, nil //nolint:nilerr,nilnil // Intentional: spec requires passing on decode errors
@@ -72,8 +148,13 @@ func (cv *contentValidator) evaluate(ctx context.Context, v any, st *evalState)
 	var parsedData any = decodedData
 	if cv.contentMediaType != "" {
 		var err error
+		mark := st.beginKeyword(keywords.ContentMediaType)
 		parsedData, err = cv.applyContentMediaType(decodedData, cv.contentMediaType)
+		st.endKeyword(mark)
 		if err != nil {
+			if cv.assert {
+				return nil, atLocation(codeErrorf(ErrCodeContentMediaType, `invalid value passed to ContentValidator: contentMediaType %q: %w`, cv.contentMediaType, err), jsonPointer(keywords.ContentMediaType), "")
+			}
 			// According to JSON Schema spec, media type parsing errors should be ignored
 			// The validation should pass even if parsing fails
 			return nil, nil //nolint:nilerr,nilnil // Intentional: spec requires passing on parse errors
@@ -83,9 +164,19 @@ func (cv *contentValidator) evaluate(ctx context.Context, v any, st *evalState)
 	// Validate against content schema
 	// According to JSON Schema 2020-12 spec, content schema validation
 	// is for annotation purposes only and should not affect validation results
+	// unless assertion was requested. contentSchema describes the parsed
+	// content, so without contentMediaType it has nothing to assert on.
 	if cv.contentSchema != nil {
 		// We could store annotations here in the future, but for now just ignore the result
-		_, _ = evalChild(ctx, cv.contentSchema, parsedData, st)
+		mark := st.beginKeyword(keywords.ContentSchema)
+		_, err := evalDetached(ctx, cv.contentSchema, parsedData, st)
+		st.endKeyword(mark)
+		switch {
+		case interrupted(err):
+			return nil, err
+		case err != nil && cv.assert && cv.contentMediaType != "":
+			return nil, fmt.Errorf(`invalid value passed to ContentValidator: decoded content does not match contentSchema: %w`, atLocation(err, jsonPointer(keywords.ContentSchema), ""))
+		}
 	}
 
 	return nil, nil //nolint:nilnil // Intentional: JSON Schema spec allows validators to return nil result
@@ -114,10 +205,17 @@ func (cv *contentValidator) applyContentDecoding(data, encoding string) (string,
 func (cv *contentValidator) applyContentMediaType(data, mediaType string) (any, error) {
 	switch strings.ToLower(mediaType) {
 	case "application/json":
+		// Decode numbers as json.Number, as ValidateJSON does, so contentSchema
+		// sees large integers exactly.
+		dec := json.NewDecoder(strings.NewReader(data))
+		dec.UseNumber()
 		var result any
-		if err := json.Unmarshal([]byte(data), &result); err != nil {
+		if err := dec.Decode(&result); err != nil {
 			return nil, fmt.Errorf("JSON parsing failed: %w", err)
 		}
+		if _, err := dec.Token(); err != io.EOF {
+			return nil, fmt.Errorf("JSON parsing failed: unexpected data after top-level value")
+		}
 		return result, nil
 	default:
 		// Unknown media type - just return the string data
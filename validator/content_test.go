@@ -0,0 +1,105 @@
+package validator_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentAssertion(t *testing.T) {
+	const src = `{
+		"type": "string",
+		"contentEncoding": "base64",
+		"contentMediaType": "application/json",
+		"contentSchema": {
+			"type": "object",
+			"required": ["id"],
+			"properties": {"id": {"type": "integer"}}
+		}
+	}`
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(src)))
+
+	b64 := base64.StdEncoding.EncodeToString
+	testcases := []struct {
+		Name  string
+		Value string
+		Error string
+	}{
+		{Name: "valid payload", Value: b64([]byte(`{"id": 1}`))},
+		{Name: "not base64", Value: "not base64!", Error: `contentEncoding "base64"`},
+		{Name: "not JSON", Value: b64([]byte(`{"id":`)), Error: `contentMediaType "application/json"`},
+		{Name: "does not match contentSchema", Value: b64([]byte(`{"id": "x"}`)), Error: `contentSchema`},
+		{Name: "missing required member", Value: b64([]byte(`{}`)), Error: `contentSchema`},
+	}
+
+	annotating, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+	asserting, err := validator.Compile(t.Context(), &s, validator.WithContentAssertion(true))
+	require.NoError(t, err)
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := annotating.Validate(t.Context(), tc.Value)
+			require.NoError(t, err, "content keywords only annotate by default")
+
+			_, err = asserting.Validate(t.Context(), tc.Value)
+			if tc.Error == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.Error)
+		})
+	}
+
+	t.Run("errors carry the keyword location", func(t *testing.T) {
+		out, err := validator.ValidateWithOutput(t.Context(), asserting, b64([]byte(`{"id": "x"}`)), validator.OutputBasic)
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		var locations []string
+		for _, e := range out.Errors {
+			locations = append(locations, e.KeywordLocation)
+		}
+		require.Contains(t, locations, "/contentSchema/properties/id")
+	})
+
+	t.Run("unknown encodings and media types are not checked", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			ContentEncoding("quoted-printable").
+			ContentMediaType("text/csv").
+			Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s, validator.WithContentAssertion(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "=ZZ,\"")
+		require.NoError(t, err)
+	})
+
+	t.Run("contentSchema without contentMediaType is ignored", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			ContentSchema(schema.NewBuilder().Types(schema.ObjectType).MustBuild()).
+			Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s, validator.WithContentAssertion(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "plain text")
+		require.NoError(t, err)
+	})
+
+	t.Run("large integers in content are exact", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{
+			"contentMediaType": "application/json",
+			"contentSchema": {"properties": {"id": {"const": 9007199254740993}}}
+		}`)))
+		v, err := validator.Compile(t.Context(), &s, validator.WithContentAssertion(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `{"id": 9007199254740993}`)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `{"id": 9007199254740992}`)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/vocabulary"
+	"github.com/lestrrat-go/option/v3"
+)
+
+// KeywordCompiler compiles the keywords of a custom vocabulary that s holds
+// into a validator for them. The values of those keywords are among the
+// extensions of s (see schema.Schema.Extensions). It may return nil when s
+// holds nothing for the validator to check.
+type KeywordCompiler func(ctx context.Context, s *schema.Schema) (Interface, error)
+
+// customVocabulary is a vocabulary given to WithVocabulary.
+type customVocabulary struct {
+	set     *vocabulary.Set
+	compile KeywordCompiler
+}
+
+// WithVocabulary adds a custom vocabulary, the URI and keywords of set, whose
+// keywords compile calls validate. Wherever a schema holds any of the
+// keywords, compile is called with it, and the validator it returns applies
+// along with the rest of that schema.
+//
+// The vocabulary is gated like the standard ones: it takes part while the
+// vocabulary set in use enables its URI, which a set does unless told
+// otherwise. A $vocabulary declaration that does not list the URI disables
+// it, and one that lists it as required no longer fails as unsupported.
+func WithVocabulary(set *vocabulary.Set, compile KeywordCompiler) CompileOption {
+	return compileOption{option.New(identVocabulary{}, customVocabulary{set: set, compile: compile})}
+}
+
+// compileCustomVocabularies compiles the keywords of the custom vocabularies
+// in use that s holds.
+func compileCustomVocabularies(ctx context.Context, s *schema.Schema, cs compileState) ([]Interface, error) {
+	if len(cs.cfg.custom) == 0 || !s.HasExtensions() {
+		return nil, nil
+	}
+	extensions := s.Extensions()
+	var validators []Interface
+	for _, cv := range cs.cfg.custom {
+		if !cs.cfg.vocab.IsEnabled(cv.set.URI()) {
+			continue
+		}
+		present := cv.present(extensions)
+		if len(present) == 0 {
+			continue
+		}
+		v, err := cv.compile(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile vocabulary %s: %w", cv.set.URI(), err)
+		}
+		if v == nil {
+			continue
+		}
+		// Failures are located at the keyword when it is the only one of the
+		// vocabulary present; otherwise at the schema holding them.
+		if len(present) == 1 {
+			v = &locationValidator{keyword: jsonPointer(present[0]), inner: v}
+		}
+		validators = append(validators, v)
+	}
+	return validators, nil
+}
+
+// present lists the keywords of the vocabulary among extensions.
+func (cv customVocabulary) present(extensions map[string]json.RawMessage) []string {
+	var present []string
+	for _, keyword := range cv.set.Keywords() {
+		if _, ok := extensions[keyword]; ok {
+			present = append(present, keyword)
+		}
+	}
+	return present
+}
+
+// hasCustomKeywords reports whether s holds a keyword of a custom vocabulary
+// in use, which hasOtherConstraints does not know about.
+func (cfg *compileConfig) hasCustomKeywords(s *schema.Schema) bool {
+	if len(cfg.custom) == 0 || !s.HasExtensions() {
+		return false
+	}
+	extensions := s.Extensions()
+	for _, cv := range cfg.custom {
+		if cfg.vocab.IsEnabled(cv.set.URI()) && len(cv.present(extensions)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// customVocabularySets returns the sets of the custom vocabularies given to
+// the compilation.
+func (cfg *compileConfig) customVocabularySets() []*vocabulary.Set {
+	sets := make([]*vocabulary.Set, 0, len(cfg.custom))
+	for _, cv := range cfg.custom {
+		sets = append(sets, cv.set)
+	}
+	return sets
+}
@@ -0,0 +1,131 @@
+package validator_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/lestrrat-go/json-schema/vocabulary"
+	"github.com/stretchr/testify/require"
+)
+
+const unitsVocabularyURL = "https://example.com/vocab/units"
+
+// unitsValidator requires a string that ends in one of the units.
+type unitsValidator struct {
+	units []string
+}
+
+func (u unitsValidator) Validate(_ context.Context, v any, _ ...validator.ValidateOption) (validator.Result, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+	for _, unit := range u.units {
+		if strings.HasSuffix(s, unit) {
+			return nil, nil //nolint:nilnil
+		}
+	}
+	return nil, fmt.Errorf("%q is not in %s", s, strings.Join(u.units, ", "))
+}
+
+func compileUnits(_ context.Context, s *schema.Schema) (validator.Interface, error) {
+	var units []string
+	if err := json.Unmarshal(s.Extensions()["x-units"], &units); err != nil {
+		return nil, fmt.Errorf("x-units must be a list of units: %w", err)
+	}
+	return unitsValidator{units: units}, nil
+}
+
+func TestWithVocabulary(t *testing.T) {
+	units := vocabulary.NewSet(unitsVocabularyURL).Add("x-units")
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+
+	t.Run("keywords validate", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{
+			"type": "object",
+			"properties": {
+				"length": {"type": "string", "x-units": ["m", "km"]},
+				"height": {"$ref": "#/$defs/height", "x-units": ["cm"]}
+			},
+			"$defs": {"height": {"type": "string", "minLength": 2}}
+		}`), validator.WithVocabulary(units, compileUnits))
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"length": "12km", "height": "180cm"})
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"length": "12ft"})
+		require.ErrorContains(t, err, `"12ft" is not in m, km`)
+		var verr *validator.Error
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, "/properties/length/x-units", verr.KeywordLocation())
+		require.Equal(t, "/length", verr.InstanceLocation())
+
+		_, err = v.Validate(t.Context(), map[string]any{"height": "2m"})
+		require.Error(t, err, "next to $ref")
+	})
+
+	t.Run("without the vocabulary the keyword is an annotation", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"x-units": ["m"]}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "12ft")
+		require.NoError(t, err)
+	})
+
+	t.Run("compile errors", func(t *testing.T) {
+		_, err := validator.Compile(t.Context(), parse(t, `{"x-units": "m"}`), validator.WithVocabulary(units, compileUnits))
+		require.ErrorContains(t, err, "x-units must be a list of units")
+	})
+
+	t.Run("gated by the vocabulary set", func(t *testing.T) {
+		vs := vocabulary.DefaultSet()
+		vs.Disable(unitsVocabularyURL)
+		v, err := validator.Compile(t.Context(), parse(t, `{"x-units": ["m"]}`),
+			validator.WithVocabulary(units, compileUnits),
+			validator.WithVocabularySet(vs),
+		)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "12ft")
+		require.NoError(t, err)
+	})
+
+	t.Run("declared by $vocabulary", func(t *testing.T) {
+		const declared = `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://json-schema.org/draft/2020-12/vocab/applicator": true,
+				"https://example.com/vocab/units": true
+			},
+			"properties": {"length": {"x-units": ["m"]}}
+		}`
+		v, err := validator.Compile(t.Context(), parse(t, declared), validator.WithVocabulary(units, compileUnits))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"length": "12ft"})
+		require.Error(t, err)
+
+		_, err = validator.Compile(t.Context(), parse(t, declared))
+		require.ErrorContains(t, err, "unsupported vocabulary https://example.com/vocab/units is required")
+
+		v, err = validator.Compile(t.Context(), parse(t, `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://json-schema.org/draft/2020-12/vocab/applicator": true
+			},
+			"properties": {"length": {"x-units": ["m"]}}
+		}`), validator.WithVocabulary(units, compileUnits))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"length": "12ft"})
+		require.NoError(t, err, "a declaration that leaves the vocabulary out disables it")
+	})
+}
@@ -0,0 +1,84 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependentRequired(t *testing.T) {
+	compile := func(t *testing.T, jsonSchema string, options ...validator.CompileOption) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(jsonSchema)))
+		v, err := validator.Compile(t.Context(), &s, options...)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("dependency", func(t *testing.T) {
+		v := compile(t, `{"dependentRequired": {"credit_card": ["billing_address"]}}`)
+
+		_, err := v.Validate(t.Context(), map[string]any{"credit_card": "4111", "billing_address": "1 Main St"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"billing_address": "1 Main St"})
+		require.NoError(t, err, "the dependency only applies when credit_card is present")
+		_, err = v.Validate(t.Context(), map[string]any{})
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"credit_card": "4111"})
+		require.ErrorContains(t, err, "dependent required property billing_address is missing when credit_card is present")
+	})
+
+	t.Run("non-objects are ignored", func(t *testing.T) {
+		v := compile(t, `{"dependentRequired": {"credit_card": ["billing_address"]}}`)
+		for _, value := range []any{"credit_card", 12, []any{"credit_card"}, nil} {
+			_, err := v.Validate(t.Context(), value)
+			require.NoError(t, err, "%#v", value)
+		}
+	})
+
+	t.Run("every missing dependency is reported", func(t *testing.T) {
+		v := compile(t, `{"dependentRequired": {"quux": ["foo", "bar"]}}`)
+
+		_, err := v.Validate(t.Context(), map[string]any{"quux": 1, "foo": 1})
+		require.ErrorContains(t, err, "bar is missing")
+
+		_, err = v.Validate(t.Context(), map[string]any{"quux": 1}, validator.WithCollectAllErrors(true))
+		require.ErrorContains(t, err, "foo is missing")
+		require.ErrorContains(t, err, "bar is missing")
+
+		var verr *validator.Error
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, "/dependentRequired", verr.KeywordLocation())
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type payment struct {
+			CreditCard     string `json:"credit_card"`
+			BillingAddress string `json:"billing_address"`
+		}
+		type cardOnly struct {
+			CreditCard string `json:"credit_card"`
+		}
+		v := compile(t, `{"type": "object", "dependentRequired": {"credit_card": ["billing_address"]}}`)
+
+		_, err := v.Validate(t.Context(), payment{CreditCard: "4111", BillingAddress: "1 Main St"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), cardOnly{CreditCard: "4111"})
+		require.ErrorContains(t, err, "billing_address is missing")
+	})
+
+	t.Run("draft-07 dependencies", func(t *testing.T) {
+		v := compile(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"dependencies": {"credit_card": ["billing_address"]}
+		}`)
+
+		_, err := v.Validate(t.Context(), map[string]any{"credit_card": "4111"})
+		require.Error(t, err)
+	})
+}
@@ -3,8 +3,10 @@ package validator
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 type dependentSchemasValidator struct {
@@ -33,8 +35,15 @@ func DependentSchemasValidator(ctx context.Context, dependentSchemas map[string]
 	}, nil
 }
 
+// DependentSchemas creates a validator from already compiled dependent
+// schemas: when the object being validated has one of the property names in
+// validators, the object must also pass the corresponding validator.
+func DependentSchemas(validators map[string]Interface) Interface {
+	return &dependentSchemasValidator{dependentSchemas: maps.Clone(validators)}
+}
+
 func (v *dependentSchemasValidator) Validate(ctx context.Context, value any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, value, newEvalState(ctx, options))
+	return validateRoot(ctx, v, value, options)
 }
 
 func (v *dependentSchemasValidator) evaluate(ctx context.Context, value any, st *evalState) (Result, error) {
@@ -51,15 +60,20 @@ func (v *dependentSchemasValidator) evaluate(ctx context.Context, value any, st
 	}
 
 	// Check each dependent schema
-	for propertyName, depValidator := range v.dependentSchemas {
+	mark := st.beginKeyword(keywords.DependentSchemas)
+	defer st.endKeyword(mark)
+	failures := newFailureCollector(st)
+	for propertyName := range evaluationOrder(v.dependentSchemas, st) {
 		// If the property exists in the object, validate the entire object with the dependent schema
 		if _, exists := obj[propertyName]; exists {
-			if _, err := evalChild(ctx, depValidator, value, st); err != nil {
-				return nil, fmt.Errorf("dependent schema validation failed for property %s: %w", propertyName, err)
+			if _, err := evalChild(ctx, v.dependentSchemas[propertyName], value, st); err != nil {
+				if failures.add(fmt.Errorf("dependent schema validation failed for property %s: %w", propertyName, atLocation(err, jsonPointer(keywords.DependentSchemas, propertyName), ""))) {
+					return nil, failures.err()
+				}
 			}
 		}
 	}
 
 	//nolint: nilnil
-	return nil, nil
+	return nil, failures.err()
 }
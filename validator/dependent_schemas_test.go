@@ -168,9 +168,8 @@ func TestDependentSchemas(t *testing.T) {
 		// The standalone DependentSchemasValidator (public API, not used by the
 		// Compile path) must handle Go structs the same way the object validator
 		// does, rather than silently skipping anything that is not map[string]any.
-		// Struct fields are always present after extraction regardless of
-		// omitempty, so the trigger property is always present; this exercises
-		// the dependent schema's type constraint.
+		// The struct fields have no omitempty, so the trigger property is always
+		// present; this exercises the dependent schema's type constraint.
 		dependentSchemaJSON := `{
 			"properties": {
 				"bar": {"type": "string"}
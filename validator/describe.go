@@ -0,0 +1,356 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Description is what Describe reports about a compiled validator: its kind,
+// the constraints it checks itself, and the validators it applies in turn.
+type Description struct {
+	// Keyword is the JSON Pointer, relative to the parent validator, of the
+	// keyword that applies this one, such as "/properties/name", "/items" or
+	// "/anyOf/1". It is empty for the validator given to Describe, and for a
+	// validator its parent combines with others as is.
+	Keyword string
+	// Kind names the validator: "string", "integer", "number", "boolean",
+	// "null", "array", "object", "allOf", "anyOf", "oneOf", "not",
+	// "ifThenElse", "dependentSchemas", "content", "untyped" (const and enum
+	// on a value of any type), "unevaluated", "reference" (a "$ref" compiled
+	// in place), "lazyReference" (a recursive "$ref", compiled on first use),
+	// "dynamicReference", "deferred" or "empty". A validator this package did
+	// not build, such as one from WithVocabulary, is named by its Go type.
+	Kind string
+	// Params holds the constraints the validator checks itself, keyed by
+	// keyword, such as "minLength" and "pattern". The "type" param is only set
+	// when the schema declared the type; without it a string, array or object
+	// validator lets values of other types through.
+	Params map[string]any
+	// Children describes the validators applied to parts of the value, or
+	// combined with this one, in the order of their keywords.
+	Children []Description
+}
+
+// Describe reports the structure of v, a validator built by Compile or by the
+// builders of this package, without validating anything. It is meant for
+// debugging, and for seeing what a compiled schema actually checks:
+//
+//	v, _ := validator.Compile(ctx, s)
+//	fmt.Println(validator.Describe(v))
+//
+// Wrappers that only track state for validation, such as those for keyword
+// locations and annotations, are left out. Lazily compiled references are not
+// followed, so the description of a recursive schema is finite.
+func Describe(v Interface) Description {
+	switch v := v.(type) {
+	case *stringValidator:
+		d := newDescription("string")
+		d.setUint("minLength", v.minLength)
+		d.setUint("maxLength", v.maxLength)
+		if v.pattern != nil {
+			d.Params["pattern"] = v.pattern.String()
+		}
+		if v.format != nil {
+			d.Params["format"] = *v.format
+		}
+		if v.enum != nil {
+			d.Params["enum"] = v.enum
+		}
+		if v.constantValue != nil {
+			d.Params["const"] = v.constantValue
+		}
+		if v.strictStringType {
+			d.Params["type"] = "string"
+		}
+		return d
+	case *integerValidator:
+		d := newDescription("integer")
+		w := v.wide
+		if w == nil {
+			w = &wideIntegerConstraints{}
+		}
+		d.setInteger("multipleOf", v.multipleOf, w.multipleOf)
+		d.setInteger("maximum", v.maximum, w.maximum)
+		d.setInteger("exclusiveMaximum", v.exclusiveMaximum, w.exclusiveMaximum)
+		d.setInteger("minimum", v.minimum, w.minimum)
+		d.setInteger("exclusiveMinimum", v.exclusiveMinimum, w.exclusiveMinimum)
+		d.setInteger("const", v.constantValue, w.constantValue)
+		if len(v.enum) > 0 || len(w.enum) > 0 {
+			enum := make([]any, 0, len(v.enum)+len(w.enum))
+			for _, e := range v.enum {
+				enum = append(enum, e)
+			}
+			for _, e := range w.enum {
+				enum = append(enum, e)
+			}
+			d.Params["enum"] = enum
+		}
+		return d
+	case *numberValidator:
+		d := newDescription("number")
+		w := v.wide
+		if w == nil {
+			w = &wideNumberConstraints{}
+		}
+		for _, c := range []struct {
+			keyword string
+			value   *float64
+			exact   *big.Rat
+		}{
+			{"multipleOf", v.multipleOf, w.multipleOf},
+			{"maximum", v.maximum, w.maximum},
+			{"exclusiveMaximum", v.exclusiveMaximum, w.exclusiveMaximum},
+			{"minimum", v.minimum, w.minimum},
+			{"exclusiveMinimum", v.exclusiveMinimum, w.exclusiveMinimum},
+			{"const", v.constantValue, nil},
+		} {
+			switch {
+			case c.exact != nil:
+				d.Params[c.keyword] = c.exact
+			case c.value != nil:
+				d.Params[c.keyword] = *c.value
+			}
+		}
+		if len(v.enum) > 0 {
+			d.Params["enum"] = v.enum
+		}
+		return d
+	case *inferredNumberValidator:
+		return Describe(v.numberValidator)
+	case *booleanValidator:
+		d := newDescription("boolean")
+		if v.enum != nil {
+			d.Params["enum"] = v.enum
+		}
+		if v.constantValue != nil {
+			d.Params["const"] = v.constantValue
+		}
+		return d
+	case nullValidator, *nullValidator:
+		return newDescription("null")
+	case *untypedValidator:
+		d := newDescription("untyped")
+		if v.hasEnum {
+			d.Params["enum"] = v.enum
+		}
+		if v.constantValue != nil {
+			d.Params["const"] = *v.constantValue
+		}
+		return d
+	case *arrayValidator:
+		d := newDescription("array")
+		d.setUint("minItems", v.minItems)
+		d.setUint("maxItems", v.maxItems)
+		if v.uniqueItems {
+			d.Params["uniqueItems"] = true
+		}
+		d.setUint("minContains", v.minContains)
+		d.setUint("maxContains", v.maxContains)
+		if v.strictArrayType {
+			d.Params["type"] = "array"
+		}
+		for i, item := range v.prefixItems {
+			d.addChild(jsonPointer("prefixItems", strconv.Itoa(i)), item)
+		}
+		d.addChild("/items", v.items)
+		d.addChild("/additionalItems", v.additionalItems)
+		d.addChild("/contains", v.contains)
+		d.addSchemaOrBool("unevaluatedItems", v.unevaluatedItems)
+		return d
+	case *objectValidator:
+		d := newDescription("object")
+		d.setUint("minProperties", v.minProperties)
+		d.setUint("maxProperties", v.maxProperties)
+		if len(v.required) > 0 {
+			d.Params["required"] = v.required
+		}
+		if len(v.dependentRequired) > 0 {
+			d.Params["dependentRequired"] = v.dependentRequired
+		}
+		if v.strictObjectType {
+			d.Params["type"] = "object"
+		}
+		for _, name := range slices.Sorted(maps.Keys(v.properties)) {
+			d.addChild(jsonPointer("properties", name), v.properties[name])
+		}
+		patterns := slices.SortedFunc(maps.Keys(v.patternProperties), func(a, b *regexp.Regexp) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, pattern := range patterns {
+			d.addChild(jsonPointer("patternProperties", pattern.String()), v.patternProperties[pattern])
+		}
+		d.addSchemaOrBool("additionalProperties", v.additionalProperties)
+		d.addSchemaOrBool("unevaluatedProperties", v.unevaluatedProperties)
+		d.addChild("/propertyNames", v.propertyNames)
+		for _, name := range slices.Sorted(maps.Keys(v.dependentSchemas)) {
+			d.addChild(jsonPointer("dependentSchemas", name), v.dependentSchemas[name])
+		}
+		return d
+	case *allOfValidator:
+		return describeBranches("allOf", v.validators)
+	case *anyOfValidator:
+		return describeBranches("anyOf", v.validators)
+	case *oneOfValidator:
+		return describeBranches("oneOf", v.validators)
+	case *NotValidator:
+		d := newDescription("not")
+		d.addChild("/not", v.validator)
+		return d
+	case *IfThenElseValidator:
+		d := newDescription("ifThenElse")
+		d.addChild("/if", v.ifValidator)
+		d.addChild("/then", v.thenValidator)
+		d.addChild("/else", v.elseValidator)
+		return d
+	case *dependentSchemasValidator:
+		d := newDescription("dependentSchemas")
+		for _, name := range slices.Sorted(maps.Keys(v.dependentSchemas)) {
+			d.addChild(jsonPointer("dependentSchemas", name), v.dependentSchemas[name])
+		}
+		return d
+	case *contentValidator:
+		d := newDescription("content")
+		if v.contentEncoding != "" {
+			d.Params["contentEncoding"] = v.contentEncoding
+		}
+		if v.contentMediaType != "" {
+			d.Params["contentMediaType"] = v.contentMediaType
+		}
+		d.addChild("/contentSchema", v.contentSchema)
+		return d
+	case *unevaluatedCoordinator:
+		d := newDescription("unevaluated")
+		if v.unevaluatedProps != nil {
+			d.Params["unevaluatedProperties"] = v.unevaluatedProps
+		}
+		if v.unevaluatedItems != nil {
+			d.Params["unevaluatedItems"] = v.unevaluatedItems
+		}
+		for _, inner := range v.validators {
+			d.addChild("", inner)
+		}
+		return d
+	case *ReferenceValidator:
+		d := newDescription("lazyReference")
+		d.Params["$ref"] = v.reference
+		return d
+	case *DynamicReferenceValidator:
+		d := newDescription("dynamicReference")
+		if v.recursive {
+			d.Params["$recursiveRef"] = v.reference
+		} else {
+			d.Params["$dynamicRef"] = v.reference
+		}
+		return d
+	case *deferredValidator:
+		return newDescription("deferred")
+	case *EmptyValidator:
+		return newDescription("empty")
+	case *locationValidator:
+		if v.keyword != jsonPointer("$ref") {
+			return Describe(v.inner)
+		}
+		d := newDescription("reference")
+		if v.absolute != "" {
+			d.Params["$ref"] = v.absolute
+		}
+		d.addChild("/$ref", v.inner)
+		return d
+	case *dynamicScopeValidator:
+		return Describe(v.inner)
+	case *coercingValidator:
+		return Describe(v.inner)
+	case *coercionTracker:
+		return Describe(v.inner)
+	case *annotationValidator:
+		return Describe(v.inner)
+	default:
+		return newDescription(fmt.Sprintf("%T", v))
+	}
+}
+
+// String renders d as an indented tree, one validator per line:
+//
+//	object {required: [name] type: object}
+//	  /properties/name: string {minLength: 1 type: string}
+func (d Description) String() string {
+	var sb strings.Builder
+	d.write(&sb, 0)
+	return sb.String()
+}
+
+func (d Description) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	if d.Keyword != "" {
+		sb.WriteString(d.Keyword)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(d.Kind)
+	if len(d.Params) > 0 {
+		sb.WriteString(" {")
+		for i, keyword := range slices.Sorted(maps.Keys(d.Params)) {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(sb, "%s: %v", keyword, d.Params[keyword])
+		}
+		sb.WriteByte('}')
+	}
+	for _, child := range d.Children {
+		sb.WriteByte('\n')
+		child.write(sb, depth+1)
+	}
+}
+
+func newDescription(kind string) Description {
+	return Description{Kind: kind, Params: make(map[string]any)}
+}
+
+func describeBranches(keyword string, branches []Interface) Description {
+	d := newDescription(keyword)
+	for i, branch := range branches {
+		d.addChild(jsonPointer(keyword, strconv.Itoa(i)), branch)
+	}
+	return d
+}
+
+func (d *Description) setUint(keyword string, v *uint) {
+	if v != nil {
+		d.Params[keyword] = *v
+	}
+}
+
+// setInteger sets keyword to the constraint held in exact, or else in n.
+func (d *Description) setInteger(keyword string, n *int64, exact *big.Rat) {
+	switch {
+	case exact != nil:
+		d.Params[keyword] = exact
+	case n != nil:
+		d.Params[keyword] = *n
+	}
+}
+
+func (d *Description) addChild(keyword string, v Interface) {
+	if v == nil {
+		return
+	}
+	child := Describe(v)
+	child.Keyword = keyword
+	d.Children = append(d.Children, child)
+}
+
+// addSchemaOrBool records an additionalProperties-like constraint, which is a
+// bool or a validator.
+func (d *Description) addSchemaOrBool(keyword string, v any) {
+	switch v := v.(type) {
+	case bool:
+		d.Params[keyword] = v
+	case Interface:
+		d.addChild(jsonPointer(keyword), v)
+	}
+}
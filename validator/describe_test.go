@@ -0,0 +1,57 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("compiled schema", func(t *testing.T) {
+		v := compileOutputSchema(t, `{
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string", "minLength": 1, "pattern": "^[A-Z]"},
+				"tags": {"type": "array", "uniqueItems": true, "items": {"$ref": "#/$defs/tag"}},
+				"age": {"anyOf": [{"type": "integer", "maximum": 150}, {"type": "null"}]}
+			},
+			"additionalProperties": false,
+			"$defs": {"tag": {"enum": ["a", "b"]}}
+		}`)
+		require.Equal(t, `object {additionalProperties: false required: [name] type: object}
+  /properties/age: anyOf
+    /anyOf/0: integer {maximum: 150}
+    /anyOf/1: null
+  /properties/name: string {minLength: 1 pattern: ^[A-Z] type: string}
+  /properties/tags: array {type: array uniqueItems: true}
+    /items: reference
+      /$ref: untyped {enum: [a b]}`, validator.Describe(v).String())
+	})
+
+	t.Run("recursive schema", func(t *testing.T) {
+		v := compileOutputSchema(t, `{
+			"$defs": {"node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/node"}}}},
+			"$ref": "#/$defs/node"
+		}`)
+		d := validator.Describe(v)
+		require.Equal(t, "reference", d.Kind)
+		next := d.Children[0].Children[0]
+		require.Equal(t, "/properties/next", next.Keyword)
+		require.Equal(t, "reference", next.Kind)
+		require.Equal(t, validator.Description{
+			Keyword: "/$ref",
+			Kind:    "lazyReference",
+			Params:  map[string]any{"$ref": "#/$defs/node"},
+		}, next.Children[0])
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		d := validator.Describe(validator.Integer().Minimum(1).MultipleOf(2).MustBuild())
+		require.Equal(t, validator.Description{
+			Kind:   "integer",
+			Params: map[string]any{"minimum": int64(1), "multipleOf": int64(2)},
+		}, d)
+	})
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+
+	schema "github.com/lestrrat-go/json-schema"
+)
+
+// isLegacyDraft reports whether draft is draft-07 or earlier. Those drafts
+// spell dependencies as a single "dependencies" keyword, and give "$ref" the
+// meaning of replacing the whole schema it appears in: every sibling keyword is
+// ignored.
+func isLegacyDraft(draft schema.Draft) bool {
+	return draft != schema.DraftUnknown && draft <= schema.Draft07
+}
+
+// normalizeLegacyKeywords rewrites the keywords of s that older drafts spell
+// differently into their 2020-12 equivalents, so that the rest of the compiler
+// only ever deals with one vocabulary:
+//
+//   - an array-valued "items" (tuple validation, draft-07 and 2019-09) becomes
+//     "prefixItems"; "additionalItems" is left in place and keeps applying to
+//     the items past the tuple.
+//   - for draft-07 and earlier, "dependencies" is split into
+//     "dependentRequired" (array values) and "dependentSchemas" (schema
+//     values).
+//
+// s is returned unchanged when it uses none of these keywords.
+func normalizeLegacyKeywords(s *schema.Schema, draft schema.Draft) (*schema.Schema, error) {
+	tuple, isTuple := s.Items().(schema.TupleItems)
+	translateDependencies := s.HasDependencies() && isLegacyDraft(draft)
+	if !isTuple && !translateDependencies {
+		return s, nil
+	}
+
+	builder := schema.NewBuilder().Clone(s)
+	if isTuple {
+		builder = builder.ResetItems().PrefixItems(tuple...)
+	}
+	if translateDependencies {
+		required := make(map[string][]string)
+		maps.Copy(required, s.DependentRequired())
+		schemas := make(map[string]schema.SchemaOrBool)
+		maps.Copy(schemas, s.DependentSchemas())
+		for name, dep := range s.Dependencies() {
+			switch dep := dep.(type) {
+			case []string:
+				required[name] = dep
+			case schema.SchemaOrBool:
+				schemas[name] = dep
+			default:
+				return nil, fmt.Errorf("invalid value for dependencies %q: %T", name, dep)
+			}
+		}
+		builder = builder.ResetDependencies()
+		if len(required) > 0 {
+			builder = builder.DependentRequired(required)
+		}
+		if len(schemas) > 0 {
+			builder = builder.DependentSchemas(schemas)
+		}
+	}
+	return builder.Build(schema.WithBoundsCheck(false))
+}
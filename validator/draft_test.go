@@ -0,0 +1,146 @@
+package validator_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyDrafts(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Schema  string
+		Options []validator.CompileOption
+		Valid   []any
+		Invalid []any
+	}{
+		{
+			Name: "draft-07 tuple items with additionalItems",
+			Schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"items": [{"type": "string"}, {"type": "integer"}],
+				"additionalItems": false
+			}`,
+			Valid:   []any{[]any{"a", 1}, []any{"a"}},
+			Invalid: []any{[]any{1, "a"}, []any{"a", 1, true}},
+		},
+		{
+			Name: "2019-09 tuple items with additionalItems schema",
+			Schema: `{
+				"$schema": "https://json-schema.org/draft/2019-09/schema",
+				"items": [{"type": "string"}],
+				"additionalItems": {"type": "boolean"}
+			}`,
+			Valid:   []any{[]any{"a", true, false}},
+			Invalid: []any{[]any{"a", 1}},
+		},
+		{
+			Name: "draft-07 additionalItems is ignored next to a single items schema",
+			Schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"items": {"type": "integer"},
+				"additionalItems": false
+			}`,
+			Valid:   []any{[]any{1, 2, 3}},
+			Invalid: []any{[]any{"a"}},
+		},
+		{
+			Name: "draft-07 definitions",
+			Schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"properties": {"age": {"$ref": "#/definitions/positive"}},
+				"definitions": {"positive": {"type": "integer", "minimum": 1}}
+			}`,
+			Valid:   []any{map[string]any{"age": 3}},
+			Invalid: []any{map[string]any{"age": 0}},
+		},
+		{
+			Name: "draft-07 dependencies",
+			Schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"dependencies": {
+					"credit_card": ["billing_address"],
+					"name": {"required": ["age"]}
+				}
+			}`,
+			Valid: []any{
+				map[string]any{},
+				map[string]any{"credit_card": 1, "billing_address": "x"},
+				map[string]any{"name": "x", "age": 1},
+			},
+			Invalid: []any{
+				map[string]any{"credit_card": 1},
+				map[string]any{"name": "x"},
+			},
+		},
+		{
+			Name: "dependencies without $schema is ignored",
+			Schema: `{
+				"dependencies": {"credit_card": ["billing_address"]}
+			}`,
+			Valid: []any{map[string]any{"credit_card": 1}},
+		},
+		{
+			Name: "dependencies without $schema under WithDefaultDraft",
+			Schema: `{
+				"dependencies": {
+					"credit_card": ["billing_address"],
+					"name": {"required": ["age"]}
+				},
+				"properties": {
+					"sub": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"dependencies": {"a": ["b"]}
+					}
+				}
+			}`,
+			Options: []validator.CompileOption{validator.WithDefaultDraft(schema.Draft07)},
+			Valid: []any{
+				map[string]any{"credit_card": 1, "billing_address": "x"},
+				map[string]any{"sub": map[string]any{"a": 1}},
+			},
+			Invalid: []any{
+				map[string]any{"credit_card": 1},
+				map[string]any{"name": "x"},
+			},
+		},
+		{
+			Name: "draft-07 $ref ignores siblings",
+			Schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"definitions": {"any": {}},
+				"properties": {"a": {"$ref": "#/definitions/any", "type": "string"}}
+			}`,
+			Valid: []any{map[string]any{"a": 1}},
+		},
+		{
+			Name: "2020-12 $ref applies siblings",
+			Schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"$defs": {"any": {}},
+				"properties": {"a": {"$ref": "#/$defs/any", "type": "string"}}
+			}`,
+			Valid:   []any{map[string]any{"a": "x"}},
+			Invalid: []any{map[string]any{"a": 1}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tc.Schema)))
+			v, err := validator.Compile(t.Context(), &s, tc.Options...)
+			require.NoError(t, err)
+			for _, value := range tc.Valid {
+				_, err := v.Validate(t.Context(), value)
+				require.NoError(t, err, "expected %v to be valid", value)
+			}
+			for _, value := range tc.Invalid {
+				_, err := v.Validate(t.Context(), value)
+				require.Error(t, err, "expected %v to be invalid", value)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validator_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -758,3 +759,92 @@ func TestEmptyEnum(t *testing.T) {
 		require.Error(t, err, "empty enum must reject %#v", value)
 	}
 }
+
+func TestEnumConstNestedValues(t *testing.T) {
+	compile := func(t *testing.T, src string) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		return v
+	}
+
+	type point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	testCases := []struct {
+		name    string
+		schema  string
+		valid   []any
+		invalid []any
+	}{
+		{
+			name:   "nested object const",
+			schema: `{"const": {"a": 1, "b": [1, {"c": 2}]}}`,
+			valid: []any{
+				map[string]any{"b": []any{1, map[string]any{"c": 2}}, "a": 1},
+				map[string]any{"a": 1.0, "b": []any{json.Number("1"), map[string]any{"c": int64(2)}}},
+			},
+			invalid: []any{
+				map[string]any{"a": 1, "b": []any{map[string]any{"c": 2}, 1}},
+				map[string]any{"a": 1, "b": []any{1, map[string]any{"c": 2, "d": 3}}},
+				map[string]any{"a": true, "b": []any{1, map[string]any{"c": 2}}},
+				[]any{1, 2},
+			},
+		},
+		{
+			name:   "enum of arrays and objects",
+			schema: `{"enum": [[1, 2], {"x": [true]}]}`,
+			valid: []any{
+				[]any{1, 2},
+				[]int{1, 2},
+				map[string]any{"x": []any{true}},
+				map[string][]bool{"x": {true}},
+			},
+			invalid: []any{
+				[]any{2, 1},
+				[]any{1, 2, 3},
+				map[string]any{"x": []any{1}},
+				map[string]any{"x": []any{true}, "y": nil},
+				1,
+			},
+		},
+		{
+			name:   "structs compare by their JSON form",
+			schema: `{"enum": [{"x": 1, "y": 2}]}`,
+			valid:  []any{point{X: 1, Y: 2}, &point{X: 1, Y: 2}},
+			invalid: []any{
+				point{X: 2, Y: 1},
+			},
+		},
+		{
+			name:   "typed schema",
+			schema: `{"type": "object", "const": {"tags": ["a", "b"]}}`,
+			valid: []any{
+				map[string]any{"tags": []any{"a", "b"}},
+				map[string]any{"tags": []string{"a", "b"}},
+			},
+			invalid: []any{
+				map[string]any{"tags": []any{"b", "a"}},
+				[]any{"a", "b"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := compile(t, tc.schema)
+			for _, value := range tc.valid {
+				_, err := v.Validate(t.Context(), value)
+				require.NoError(t, err, "%#v", value)
+			}
+			for _, value := range tc.invalid {
+				_, err := v.Validate(t.Context(), value)
+				require.Error(t, err, "%#v", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"context"
+	"errors"
+)
+
+// Error is the error returned by Validate when a value is invalid. It reports
+// where the failure happened as JSON Pointers, alongside the message of the
+// keyword that failed:
+//
+//	var verr *validator.Error
+//	if errors.As(err, &verr) {
+//		fmt.Println(verr.InstanceLocation()) // "/user/roles/2"
+//		fmt.Println(verr.KeywordLocation())  // "/properties/user/properties/roles/items"
+//	}
+//
+// When several failures are reported (see WithCollectAllErrors), Validate
+// returns an error that implements Unwrap() []error, holding one *Error per
+// failure.
+type Error struct {
+	instanceLocation        string
+	keywordLocation         string
+	absoluteKeywordLocation string
+	message                 string
+	err                     error
+}
+
+// InstanceLocation returns the JSON Pointer to the part of the validated value
+// that failed, such as "/user/roles/2". It is empty when the value as a whole
+// failed.
+func (e *Error) InstanceLocation() string {
+	return e.instanceLocation
+}
+
+// KeywordLocation returns the JSON Pointer to the failing keyword, following the
+// path taken through the schema, including any "$ref" crossed.
+func (e *Error) KeywordLocation() string {
+	return e.keywordLocation
+}
+
+// AbsoluteKeywordLocation returns the absolute URI of the failing keyword. It is
+// only set once the path to the keyword has crossed a "$ref" that resolved to an
+// absolute URI.
+func (e *Error) AbsoluteKeywordLocation() string {
+	return e.absoluteKeywordLocation
+}
+
+// Message returns the message of the failing keyword, without the context that
+// Error adds about the schema branches that led to it.
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Error returns the full description of the failure, prefixed with the
+// instance location when it is not the whole value.
+func (e *Error) Error() string {
+	if e.instanceLocation == "" {
+		return e.err.Error()
+	}
+	return e.instanceLocation + ": " + e.err.Error()
+}
+
+// Code returns the ErrorCode of the failed check. When a check fails because
+// of another, as anyOf does when none of its branches pass, the code is that
+// of the outer check; errors.Is also matches the codes of the inner ones. Code
+// returns "" for a failure that has no code, such as an unresolvable
+// reference.
+func (e *Error) Code() ErrorCode {
+	var c coder
+	if errors.As(e.err, &c) {
+		return c.errorCode()
+	}
+	return ""
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// validateRoot runs e as the outermost validator of a Validate call and turns
+// a failure into the public error shape.
+func validateRoot(ctx context.Context, e evaluator, v any, options []ValidateOption) (Result, error) {
+	st := newEvalState(ctx, options)
+	if _, ok := e.(*coercionTracker); ok {
+		st.trackInstance().coerce = true
+	}
+	if !st.reflectionOnly {
+		var err error
+		if v, err = jsonValue(v); err != nil {
+			return nil, newValidationError(err)
+		}
+	}
+	if st.profile != nil {
+		st.profile.beginLocation("")
+	}
+	res, err := e.evaluate(ctx, v, st)
+	if st.profile != nil {
+		st.profile.finish()
+	}
+	if err != nil {
+		return res, newValidationError(err)
+	}
+	if st.instance == nil {
+		return res, nil
+	}
+	return &annotatedResult{
+		result:      res,
+		instance:    v,
+		annotations: st.instance.annotationsByLocation(),
+		coerced:     st.instance.coercionsByLocation(),
+	}, nil
+}
+
+// leafError wraps the failure of a validator that does not descend into the
+// value (string, number, boolean, ...). Its locations are empty: an enclosing
+// validator supplies them.
+func leafError(err error) error {
+	if err == nil || interrupted(err) {
+		return err
+	}
+	return &Error{message: err.Error(), err: err}
+}
+
+// newValidationError converts err, as produced by evaluate, into an *Error, or
+// into an errors.Join of one *Error per failure when err carries several. The
+// locations are those ValidateWithOutput reports for the same failures.
+func newValidationError(err error) error {
+	if interrupted(err) {
+		return err
+	}
+	leaves := flattenUnits(outputTree(err), nil)
+	if len(leaves) == 1 {
+		// Keep the whole chain so the message keeps its context.
+		return newErrorFromUnit(leaves[0], err)
+	}
+	errs := make([]error, len(leaves))
+	for i, leaf := range leaves {
+		errs[i] = newErrorFromUnit(leaf, leaf.cause)
+	}
+	return errors.Join(errs...)
+}
+
+func newErrorFromUnit(u *OutputUnit, err error) *Error {
+	return &Error{
+		instanceLocation:        u.InstanceLocation,
+		keywordLocation:         u.KeywordLocation,
+		absoluteKeywordLocation: u.AbsoluteKeywordLocation,
+		message:                 u.Error,
+		err:                     err,
+	}
+}
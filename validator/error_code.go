@@ -0,0 +1,88 @@
+package validator
+
+import "fmt"
+
+// ErrorCode identifies the check that a value failed. Unlike the text of an
+// error, which may be reworded, the codes are a stable contract: match on them
+// rather than on messages.
+//
+//	if errors.Is(err, validator.ErrCodeRequired) {
+//		// a required property is missing
+//	}
+//
+// Each code is the name of the keyword whose check failed. A value of the
+// wrong type, or one that cannot be coerced (WithCoercion), fails with
+// ErrCodeType, and a false schema with ErrCodeNot. ErrorCode implements error
+// so that it can be the target of errors.Is; see also Error.Code.
+type ErrorCode string
+
+const (
+	ErrCodeType                  ErrorCode = "type"
+	ErrCodeConst                 ErrorCode = "const"
+	ErrCodeEnum                  ErrorCode = "enum"
+	ErrCodeMinLength             ErrorCode = "minLength"
+	ErrCodeMaxLength             ErrorCode = "maxLength"
+	ErrCodePattern               ErrorCode = "pattern"
+	ErrCodeFormat                ErrorCode = "format"
+	ErrCodeMaximum               ErrorCode = "maximum"
+	ErrCodeExclusiveMaximum      ErrorCode = "exclusiveMaximum"
+	ErrCodeMinimum               ErrorCode = "minimum"
+	ErrCodeExclusiveMinimum      ErrorCode = "exclusiveMinimum"
+	ErrCodeMultipleOf            ErrorCode = "multipleOf"
+	ErrCodeMinProperties         ErrorCode = "minProperties"
+	ErrCodeMaxProperties         ErrorCode = "maxProperties"
+	ErrCodeRequired              ErrorCode = "required"
+	ErrCodeDependentRequired     ErrorCode = "dependentRequired"
+	ErrCodeAdditionalProperties  ErrorCode = "additionalProperties"
+	ErrCodeUnevaluatedProperties ErrorCode = "unevaluatedProperties"
+	ErrCodeMinItems              ErrorCode = "minItems"
+	ErrCodeMaxItems              ErrorCode = "maxItems"
+	ErrCodeUniqueItems           ErrorCode = "uniqueItems"
+	ErrCodeContains              ErrorCode = "contains"
+	ErrCodeMinContains           ErrorCode = "minContains"
+	ErrCodeMaxContains           ErrorCode = "maxContains"
+	ErrCodeUnevaluatedItems      ErrorCode = "unevaluatedItems"
+	ErrCodeContentEncoding       ErrorCode = "contentEncoding"
+	ErrCodeContentMediaType      ErrorCode = "contentMediaType"
+	ErrCodeAnyOf                 ErrorCode = "anyOf"
+	ErrCodeOneOf                 ErrorCode = "oneOf"
+	ErrCodeNot                   ErrorCode = "not"
+)
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// coder is implemented by the errors that carry an ErrorCode.
+type coder interface {
+	errorCode() ErrorCode
+}
+
+// codedError is a failure of the check identified by code. Its text is that
+// of err.
+type codedError struct {
+	code ErrorCode
+	err  error
+}
+
+// codeErrorf is fmt.Errorf for a failure of the check identified by code.
+func codeErrorf(code ErrorCode, format string, args ...any) error {
+	return &codedError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+func (e *codedError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.code
+}
+
+func (e *codedError) errorCode() ErrorCode {
+	return e.code
+}
@@ -0,0 +1,114 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	v := compileOutputSchema(t, `{
+		"$id": "https://example.com/user.json",
+		"type": "object",
+		"properties": {
+			"user": {
+				"type": "object",
+				"properties": {
+					"roles": {"type": "array", "items": {"$ref": "#/$defs/role"}}
+				}
+			},
+			"age": {"type": "integer", "minimum": 0}
+		},
+		"$defs": {"role": {"type": "string", "maxLength": 5}}
+	}`)
+
+	t.Run("nested failure", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), map[string]any{
+			"user": map[string]any{"roles": []any{"admin", "user", "superuser"}},
+		})
+		var verr *validator.Error
+		require.ErrorAs(t, err, &verr)
+		require.Equal(t, "/user/roles/2", verr.InstanceLocation())
+		require.Equal(t, "/properties/user/properties/roles/items/$ref", verr.KeywordLocation())
+		require.Equal(t, "https://example.com/user.json#/$defs/role", verr.AbsoluteKeywordLocation())
+		require.Contains(t, verr.Message(), "maxLength")
+		require.NotContains(t, verr.Message(), "ObjectValidator", "the message is the failing keyword's own")
+		require.Regexp(t, `^/user/roles/2: `, err.Error())
+	})
+
+	t.Run("failure of the whole value", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), "not an object")
+		var verr *validator.Error
+		require.ErrorAs(t, err, &verr)
+		require.Empty(t, verr.InstanceLocation())
+		require.Equal(t, verr.Message(), err.Error())
+	})
+
+	t.Run("scalar schema", func(t *testing.T) {
+		v := compileOutputSchema(t, `{"type": "integer", "minimum": 10}`)
+		_, err := v.Validate(t.Context(), 5)
+		var verr *validator.Error
+		require.ErrorAs(t, err, &verr)
+		require.Empty(t, verr.InstanceLocation())
+		require.Contains(t, verr.Message(), "minimum")
+	})
+
+	t.Run("one error per failure when collecting", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), map[string]any{
+			"user": map[string]any{"roles": []any{"superuser"}},
+			"age":  -1,
+		}, validator.WithCollectAllErrors(true))
+		var multi interface{ Unwrap() []error }
+		require.ErrorAs(t, err, &multi)
+
+		var locations []string
+		for _, e := range multi.Unwrap() {
+			var verr *validator.Error
+			require.True(t, errors.As(e, &verr))
+			locations = append(locations, verr.InstanceLocation())
+		}
+		require.Equal(t, []string{"/age", "/user/roles/0"}, locations)
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	testcases := []struct {
+		name   string
+		schema string
+		value  any
+		code   validator.ErrorCode
+		inner  validator.ErrorCode // also matched by errors.Is
+	}{
+		{name: "type", schema: `{"type": "string"}`, value: 1, code: validator.ErrCodeType},
+		{name: "minLength", schema: `{"minLength": 3}`, value: "ab", code: validator.ErrCodeMinLength},
+		{name: "maximum", schema: `{"type": "integer", "maximum": 10}`, value: 11, code: validator.ErrCodeMaximum},
+		{name: "number enum", schema: `{"type": "number", "enum": [1.5, 2.5]}`, value: 3.5, code: validator.ErrCodeEnum},
+		{name: "const", schema: `{"const": "a"}`, value: "b", code: validator.ErrCodeConst},
+		{name: "required", schema: `{"required": ["name"]}`, value: map[string]any{}, code: validator.ErrCodeRequired},
+		{name: "nested", schema: `{"properties": {"tags": {"uniqueItems": true}}}`, value: map[string]any{"tags": []any{1, 1}}, code: validator.ErrCodeUniqueItems},
+		{name: "additionalProperties", schema: `{"additionalProperties": false}`, value: map[string]any{"x": 1}, code: validator.ErrCodeAdditionalProperties},
+		{name: "false schema", schema: `{"properties": {"x": false}}`, value: map[string]any{"x": 1}, code: validator.ErrCodeNot},
+		{
+			name:   "anyOf",
+			schema: `{"anyOf": [{"type": "integer"}, {"type": "string", "maxLength": 1}]}`,
+			value:  "ab",
+			code:   validator.ErrCodeAnyOf,
+			inner:  validator.ErrCodeType,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := compileOutputSchema(t, tc.schema).Validate(t.Context(), tc.value)
+			var verr *validator.Error
+			require.ErrorAs(t, err, &verr)
+			require.Equal(t, tc.code, verr.Code())
+			require.ErrorIs(t, err, tc.code)
+			if tc.inner != "" {
+				require.ErrorIs(t, err, tc.inner)
+			}
+			require.NotErrorIs(t, err, validator.ErrCodeMinItems)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package validator
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/option/v3"
@@ -26,8 +29,34 @@ type evalState struct {
 	// WithDynamicAnchorValidator. It lets a precompiled validator satisfy a
 	// $dynamicRef when no schema document is available at validation time.
 	dynamicAnchorValidators map[string]Interface
+
+	// collectAllErrors is set by WithCollectAllErrors: applicators record a
+	// failure and continue rather than returning it (see failureCollector).
+	collectAllErrors bool
+
+	// depth is how far below the validated value the current one is nested,
+	// counted by evalNested; maxDepth is the WithMaxDepth limit (0: none).
+	depth    int
+	maxDepth int
+
+	// profile records the evaluation for WithProfiler; nil when no Profiler
+	// is attached, which every timing point checks first.
+	profile *profileState
+
+	// instance follows the instance location for WithAnnotationCollection,
+	// and for WithCoercion when the root of the call is a coercionTracker;
+	// nil when neither applies.
+	instance *instanceState
+
+	// reflectionOnly is set by WithReflectionOnly: values are validated as
+	// given, without jsonValue.
+	reflectionOnly bool
 }
 
+// ErrMaxDepthExceeded is wrapped by the error Compile or Validate returns when
+// the schema or the instance is nested deeper than WithMaxDepth allows.
+var ErrMaxDepthExceeded = errors.New("maximum depth exceeded")
+
 // evaluator is the internal recursion contract. Every in-package validator that
 // recurses into child validators implements it so the per-call evalState is
 // shared down the whole evaluation path; a gap (a recursing validator that only
@@ -41,17 +70,79 @@ type evaluator interface {
 func newEvalState(_ context.Context, options []ValidateOption) *evalState {
 	st := &evalState{}
 	for _, o := range options {
-		if o.Ident() == (identDynamicAnchorValidator{}) {
+		switch o.Ident() {
+		case identDynamicAnchorValidator{}:
 			reg := option.MustGet[dynamicAnchorRegistration](o)
 			if st.dynamicAnchorValidators == nil {
 				st.dynamicAnchorValidators = make(map[string]Interface)
 			}
 			st.dynamicAnchorValidators[reg.name] = reg.v
+		case identCollectAllErrors{}:
+			st.collectAllErrors = option.MustGet[bool](o)
+		case identMaxDepth{}:
+			st.maxDepth = option.MustGet[int](o)
+		case identProfiler{}:
+			if p := option.MustGet[*Profiler](o); p != nil {
+				st.profile = newProfileState(p)
+			}
+		case identAnnotationCollection{}:
+			if option.MustGet[bool](o) {
+				st.trackInstance().annotate = true
+			}
+		case identReflectionOnly{}:
+			st.reflectionOnly = option.MustGet[bool](o)
 		}
 	}
 	return st
 }
 
+// instanceState follows the instance location of the value being evaluated
+// and records, by location, the annotations (when annotate is set) and
+// coerced values (when coerce is set) of the schemas that value passes.
+type instanceState struct {
+	location    string
+	annotate    bool
+	coerce      bool
+	annotations []annotationEntry
+	coercions   []coercionEntry
+}
+
+// instanceMark is a point in the entries of an instanceState to go back to.
+type instanceMark struct {
+	annotations int
+	coercions   int
+}
+
+// trackInstance returns st.instance, creating it first if need be.
+func (st *evalState) trackInstance() *instanceState {
+	if st.instance == nil {
+		st.instance = &instanceState{}
+	}
+	return st.instance
+}
+
+// enter moves to the value at the current location followed by the JSON
+// Pointer segment token, returning the location to go back to with leave.
+func (is *instanceState) enter(token string) string {
+	prev := is.location
+	is.location += token
+	return prev
+}
+
+func (is *instanceState) leave(prev string) {
+	is.location = prev
+}
+
+func (is *instanceState) mark() instanceMark {
+	return instanceMark{annotations: len(is.annotations), coercions: len(is.coercions)}
+}
+
+// reset drops the entries recorded since m.
+func (is *instanceState) reset(m instanceMark) {
+	is.annotations = is.annotations[:m.annotations]
+	is.coercions = is.coercions[:m.coercions]
+}
+
 // pushDynamicScope returns a copy of st with s appended to the dynamic scope
 // chain. The slice is copied so sibling evaluations never observe each other's
 // scope — the same fork semantics the old ctx-based WithDynamicScope provided.
@@ -59,7 +150,9 @@ func (st *evalState) pushDynamicScope(s *schema.Schema) *evalState {
 	newScope := make([]*schema.Schema, len(st.dynamicScope)+1)
 	copy(newScope, st.dynamicScope)
 	newScope[len(st.dynamicScope)] = s
-	return &evalState{dynamicScope: newScope, dynamicAnchorValidators: st.dynamicAnchorValidators}
+	next := *st
+	next.dynamicScope = newScope
+	return &next
 }
 
 // evalChild dispatches into a child validator, sharing st when the child is an
@@ -73,8 +166,121 @@ func evalChild(ctx context.Context, child Interface, v any, st *evalState) (Resu
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if st.instance == nil {
+		return profiledDispatch(ctx, child, v, st)
+	}
+	// A schema that fails contributes no annotations or coerced values, and
+	// neither do the subschemas it applied.
+	mark := st.instance.mark()
+	res, err := profiledDispatch(ctx, child, v, st)
+	if err != nil {
+		st.instance.reset(mark)
+		return res, err
+	}
+	if coerced, ok := res.(*coercedResult); ok && coerced != nil && st.instance.coerce {
+		st.instance.recordCoercion(coerced.value)
+	}
+	return res, err
+}
+
+func profiledDispatch(ctx context.Context, child Interface, v any, st *evalState) (Result, error) {
+	if st.profile != nil {
+		// Close whatever keyword frames the child left open on its way out.
+		mark := len(st.profile.frames)
+		res, err := dispatch(ctx, child, v, st)
+		st.profile.end(mark)
+		return res, err
+	}
+	return dispatch(ctx, child, v, st)
+}
+
+func dispatch(ctx context.Context, child Interface, v any, st *evalState) (Result, error) {
+	if !st.reflectionOnly {
+		var err error
+		if v, err = jsonValue(v); err != nil {
+			return nil, err
+		}
+	}
 	if e, ok := child.(evaluator); ok {
 		return e.evaluate(ctx, v, st)
 	}
 	return child.Validate(ctx, v)
 }
+
+// evalNested is evalChild for a property value or array item of v: it
+// evaluates the child one nesting level deeper, failing with
+// ErrMaxDepthExceeded instead once that would exceed WithMaxDepth.
+func evalNested(ctx context.Context, child Interface, v any, st *evalState) (Result, error) {
+	if st.maxDepth > 0 && st.depth >= st.maxDepth {
+		return nil, fmt.Errorf("%w: instance is nested deeper than %d", ErrMaxDepthExceeded, st.maxDepth)
+	}
+	st.depth++
+	res, err := evalChild(ctx, child, v, st)
+	st.depth--
+	return res, err
+}
+
+// evalProperty is evalNested for the value of property name, which
+// WithProfiler times, and WithAnnotationCollection records annotations for, at
+// its own instance location.
+func evalProperty(ctx context.Context, child Interface, name string, v any, st *evalState) (Result, error) {
+	if st.profile == nil && st.instance == nil {
+		return evalNested(ctx, child, v, st)
+	}
+	var mark int
+	if st.profile != nil {
+		mark = st.profile.beginProperty(name)
+	}
+	var prev string
+	if st.instance != nil {
+		prev = st.instance.enter(jsonPointer(name))
+	}
+	res, err := evalNested(ctx, child, v, st)
+	if st.instance != nil {
+		st.instance.leave(prev)
+	}
+	if st.profile != nil {
+		st.profile.end(mark)
+	}
+	return res, err
+}
+
+// evalItem is evalProperty for the array item at index.
+func evalItem(ctx context.Context, child Interface, index int, v any, st *evalState) (Result, error) {
+	if st.profile == nil && st.instance == nil {
+		return evalNested(ctx, child, v, st)
+	}
+	var mark int
+	if st.profile != nil {
+		mark = st.profile.beginItem(index)
+	}
+	var prev string
+	if st.instance != nil {
+		prev = st.instance.enter("/" + strconv.Itoa(index))
+	}
+	res, err := evalNested(ctx, child, v, st)
+	if st.instance != nil {
+		st.instance.leave(prev)
+	}
+	if st.profile != nil {
+		st.profile.end(mark)
+	}
+	return res, err
+}
+
+// beginKeyword starts timing keyword for WithProfiler, returning the mark to
+// pass to endKeyword. Without a Profiler both are a nil check. st may be nil,
+// for a leaf validator called through its Validate method.
+func (st *evalState) beginKeyword(keyword string) int {
+	if st == nil || st.profile == nil {
+		return 0
+	}
+	return st.profile.beginKeyword(keyword)
+}
+
+func (st *evalState) endKeyword(mark int) {
+	if st == nil || st.profile == nil {
+		return
+	}
+	st.profile.end(mark)
+}
@@ -0,0 +1,225 @@
+package validator
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isIPv4 reports whether value is an IPv4 address in dotted-quad notation
+// (RFC 2673 section 3.2). Octets with leading zeros are rejected, as they are
+// ambiguous with octal notation.
+func isIPv4(value string) bool {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is4()
+}
+
+// isIPv6 reports whether value is an IPv6 address as defined by RFC 4291
+// section 2.2, including the compressed "::" form and an embedded IPv4 suffix.
+// Zone identifiers ("fe80::1%eth0") are not part of the address syntax and are
+// rejected.
+func isIPv6(value string) bool {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is6() && addr.Zone() == ""
+}
+
+// isHostname reports whether value is a host name as defined by RFC 1123
+// section 2.1: dot-separated labels of 1 to 63 letters, digits and hyphens
+// that neither start nor end with a hyphen, at most 253 characters in total.
+func isHostname(value string) bool {
+	if value == "" || len(value) > 253 {
+		return false
+	}
+	for label := range strings.SplitSeq(value, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := range len(label) {
+			c := label[i]
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var (
+	fullDatePattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	fullTimePattern = regexp.MustCompile(`^([0-9]{2}):([0-9]{2}):([0-9]{2})(?:\.[0-9]+)?(?:[Zz]|([+-])([0-9]{2}):([0-9]{2}))$`)
+	// durationPattern is the ABNF of RFC 3339 appendix A: the components
+	// appear in order without gaps (P1Y2D is not valid), time components
+	// follow a "T", and weeks stand alone.
+	durationPattern = regexp.MustCompile(`^P(?:(?:[0-9]+D|[0-9]+M(?:[0-9]+D)?|[0-9]+Y(?:[0-9]+M(?:[0-9]+D)?)?)` + durationTime + `?|` + durationTime + `|[0-9]+W)$`)
+)
+
+const durationTime = `(?:T(?:[0-9]+H(?:[0-9]+M(?:[0-9]+S)?)?|[0-9]+M(?:[0-9]+S)?|[0-9]+S))`
+
+// isDate reports whether value is an RFC 3339 full-date, such as
+// "2024-02-29", naming a day that exists.
+func isDate(value string) bool {
+	if !fullDatePattern.MatchString(value) {
+		return false
+	}
+	_, err := time.Parse(time.DateOnly, value)
+	return err == nil
+}
+
+// isTime reports whether value is an RFC 3339 full-time: a time of day with
+// optional fractional seconds and a mandatory offset, such as
+// "23:20:50.52Z" or "08:30:00-05:00". Hours run from 00 to 23, so "24:00:00"
+// is rejected. A leap second (":60") is only accepted at 23:59 UTC, once the
+// offset is applied.
+func isTime(value string) bool {
+	m := fullTimePattern.FindStringSubmatch(value)
+	if m == nil {
+		return false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	second, _ := strconv.Atoi(m[3])
+	if hour > 23 || minute > 59 || second > 60 {
+		return false
+	}
+
+	var offset int // minutes east of UTC
+	if m[4] != "" {
+		offsetHour, _ := strconv.Atoi(m[5])
+		offsetMinute, _ := strconv.Atoi(m[6])
+		if offsetHour > 23 || offsetMinute > 59 {
+			return false
+		}
+		offset = offsetHour*60 + offsetMinute
+		if m[4] == "-" {
+			offset = -offset
+		}
+	}
+	if second == 60 {
+		const minutesPerDay = 24 * 60
+		utc := ((hour*60+minute-offset)%minutesPerDay + minutesPerDay) % minutesPerDay
+		return utc == 23*60+59
+	}
+	return true
+}
+
+// isDateTime reports whether value is an RFC 3339 date-time, a full-date and
+// a full-time joined by "T". The separator and the "Z" offset may be
+// lowercase, as RFC 3339 section 5.6 allows, but the offset may not be
+// omitted.
+func isDateTime(value string) bool {
+	date, clock, ok := strings.Cut(value, "T")
+	if !ok {
+		date, clock, ok = strings.Cut(value, "t")
+	}
+	return ok && isDate(date) && isTime(clock)
+}
+
+// isDuration reports whether value is an ISO 8601 duration in the form
+// RFC 3339 appendix A defines, such as "P1Y2M10DT2H30M", "PT36H" or "P2W".
+func isDuration(value string) bool {
+	return durationPattern.MatchString(value)
+}
+
+// isJSONPointer reports whether value is a JSON Pointer (RFC 6901): empty, or
+// a sequence of "/"-prefixed reference tokens in which "~" only appears
+// escaping "~" ("~0") or "/" ("~1").
+func isJSONPointer(value string) bool {
+	if value == "" {
+		return true
+	}
+	if value[0] != '/' {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] != '~' {
+			continue
+		}
+		if i+1 == len(value) || (value[i+1] != '0' && value[i+1] != '1') {
+			return false
+		}
+	}
+	return true
+}
+
+// isRelativeJSONPointer reports whether value is a Relative JSON Pointer:
+// a non-negative integer without leading zeros, followed by either "#" or a
+// JSON Pointer.
+func isRelativeJSONPointer(value string) bool {
+	digits := 0
+	for digits < len(value) && value[digits] >= '0' && value[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 || (digits > 1 && value[0] == '0') {
+		return false
+	}
+	rest := value[digits:]
+	return rest == "#" || isJSONPointer(rest)
+}
+
+// isURIReference reports whether value is a URI reference (RFC 3986 section
+// 4.1): a URI, or a relative reference such as "../a?b#c". Only the
+// characters RFC 3986 allows may appear, "%" must start a percent-encoded
+// octet, and there is at most one "#".
+func isURIReference(value string) bool {
+	_, ok := parseReference(value, false)
+	return ok
+}
+
+// isIRIReference reports whether value is an IRI reference (RFC 3987): a URI
+// reference that may also contain non-ASCII characters.
+func isIRIReference(value string) bool {
+	_, ok := parseReference(value, true)
+	return ok
+}
+
+// isIRI reports whether value is an IRI (RFC 3987), an IRI reference with a
+// scheme.
+func isIRI(value string) bool {
+	u, ok := parseReference(value, true)
+	return ok && u.Scheme != ""
+}
+
+// parseReference checks the characters of a URI or IRI reference and then
+// parses it.
+func parseReference(value string, international bool) (*url.URL, bool) {
+	fragments := 0
+	for i, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-._~:/?[]@!$&'()*+,;=", r):
+		case r == '#':
+			if fragments++; fragments > 1 {
+				return nil, false
+			}
+		case r == '%':
+			if i+2 >= len(value) || !isHexDigit(value[i+1]) || !isHexDigit(value[i+2]) {
+				return nil, false
+			}
+		case r >= 0xA0 && international:
+		default:
+			return nil, false
+		}
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, false
+	}
+	// An IPv6 address must be bracketed; url.Parse would take the part after
+	// its last colon for a port.
+	if u.Host != "" && u.Host[0] != '[' && strings.Count(u.Host, ":") > 1 {
+		return nil, false
+	}
+	return u, true
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
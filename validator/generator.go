@@ -2,12 +2,14 @@ package validator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 
 	"github.com/lestrrat-go/codegen"
+	schema "github.com/lestrrat-go/json-schema"
 )
 
 // generateObjectBuilderChain creates just the builder chain for object validators
@@ -24,14 +26,24 @@ func (g *codeGenerator) generateObject(dst io.Writer, v *objectValidator) error
 		o.L("MaxProperties(%d).", *v.maxProperties)
 	}
 	if len(v.required) > 0 {
-		o.L("Required(")
-		for i, req := range v.required {
-			if i > 0 {
-				o.R(",")
-			}
-			o.L("%q", req)
+		o.L("Required([]string{")
+		for _, req := range v.required {
+			o.L("%q,", req)
 		}
-		o.L(")")
+		o.L("}).")
+	}
+	if len(v.dependentRequired) > 0 {
+		propNames := make([]string, 0, len(v.dependentRequired))
+		for propName := range v.dependentRequired {
+			propNames = append(propNames, propName)
+		}
+		sort.Strings(propNames)
+
+		o.L("DependentRequired(map[string][]string{")
+		for _, propName := range propNames {
+			o.L("%q: %#v,", propName, v.dependentRequired[propName])
+		}
+		o.L("}).")
 	}
 
 	// Handle complex properties
@@ -117,6 +129,40 @@ func (g *codeGenerator) generateObject(dst io.Writer, v *objectValidator) error
 		o.L(").")
 	}
 
+	// Handle unevaluated properties
+	if v.unevaluatedProperties != nil {
+		switch up := v.unevaluatedProperties.(type) {
+		case bool:
+			o.L("UnevaluatedProperties(%t).", up)
+		case Interface:
+			o.L("UnevaluatedProperties(")
+			if err := g.Generate(&buf, up); err != nil {
+				return fmt.Errorf("failed to generate unevaluated properties validator: %w", err)
+			}
+			o.R(",")
+			o.L(").")
+		}
+	}
+
+	// Handle dependent schemas
+	if len(v.dependentSchemas) > 0 {
+		propNames := make([]string, 0, len(v.dependentSchemas))
+		for propName := range v.dependentSchemas {
+			propNames = append(propNames, propName)
+		}
+		sort.Strings(propNames)
+
+		o.L("DependentSchemas(map[string]validator.Interface{")
+		for _, propName := range propNames {
+			o.L("%q: ", propName)
+			if err := g.Generate(&buf, v.dependentSchemas[propName]); err != nil {
+				return fmt.Errorf("failed to generate dependent schema for %q: %w", propName, err)
+			}
+			o.R(",")
+		}
+		o.L("}).")
+	}
+
 	// For meta-schema, all Object validators should be strict to reject non-objects
 	o.L("StrictObjectType(true).")
 	o.L("MustBuild()")
@@ -191,11 +237,11 @@ func (g *codeGenerator) generateNot(dst io.Writer, v *NotValidator) error {
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
-	o.L("func() validator.Interface { child := ")
+	o.L("validator.Not(")
 	if err := g.Generate(&buf, v.validator); err != nil {
 		return fmt.Errorf("failed to generate child validator for not: %w", err)
 	}
-	o.R("; return &validator.NotValidator{validator: child} }()")
+	o.R(")")
 
 	_, err := buf.WriteTo(dst)
 	return err
@@ -248,6 +294,9 @@ func (g *codeGenerator) generateString(dst io.Writer, v *stringValidator) error
 	if v.format != nil {
 		o.L("Format(%q).", *v.format)
 	}
+	if v.ecmaRegex {
+		o.L("ECMAScriptRegex(true).")
+	}
 	if v.enum != nil {
 		if len(v.enum) == 1 {
 			o.L("Enum(%#v).", v.enum[0])
@@ -273,6 +322,10 @@ func (g *codeGenerator) generateInteger(dst io.Writer, v *integerValidator) erro
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
+	if v.wide != nil {
+		return fmt.Errorf(`integer constraints beyond int64 cannot be generated as code`)
+	}
+
 	o.L("validator.Integer().")
 
 	if v.multipleOf != nil {
@@ -301,52 +354,66 @@ func (g *codeGenerator) generateInteger(dst io.Writer, v *integerValidator) erro
 	if v.constantValue != nil {
 		o.L("Const(%d).", *v.constantValue)
 	}
+	if v.coerce {
+		o.L("Coerce(true).")
+	}
+	if v.useNumber {
+		o.L("UseNumber(true).")
+	}
+	if v.strictInteger {
+		o.L("StrictInteger(true).")
+	}
 
 	o.L("MustBuild()")
 	_, err := buf.WriteTo(dst)
 	return err
 }
 
-// sanitizeVarName sanitizes a property name to be a valid Go variable name
-func sanitizeVarName(name string) string {
-	// Simple sanitization - replace non-alphanumeric with underscore
-	result := ""
-	for _, char := range name {
-		if (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') {
-			result += string(char)
-		} else {
-			result += "_"
-		}
-	}
-	if result == "" {
-		result = "prop"
-	}
-	return result
-}
-
 // generateReferenceBuilderChain creates just the builder chain for reference validators
 func (g *codeGenerator) generateReference(dst io.Writer, v *ReferenceValidator) error {
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
-	// If the reference has been resolved, generate the resolved validator
-	if v.resolved != nil {
-		// Generate the resolved validator, but watch out for circular references
-		if v.resolved == v {
-			// Self-reference - create EmptyValidator to avoid infinite recursion
-			o.R("&validator.EmptyValidator{}")
-		} else {
-			if err := g.Generate(&buf, v.resolved); err != nil {
-				// If we can't generate the resolved validator, fall back to EmptyValidator
-				o.R("&validator.EmptyValidator{}")
-			}
-		}
-	} else {
-		// If not resolved, we can't generate proper code, fall back to EmptyValidator
-		// This happens when references can't be resolved at compile time
-		o.R("&validator.EmptyValidator{}")
+	key := referenceKey{root: v.rootSchema, uri: schema.ResolveURI(v.baseURI, v.reference)}
+	if ref, ok := g.refs[key]; ok {
+		// The target is being generated further up: refer to the variable it
+		// will be assigned to instead of expanding it again.
+		ref.recursive = true
+		o.R("validator.Deferred(func() validator.Interface { return %s })", ref.name)
+		_, err := buf.WriteTo(dst)
+		return err
 	}
 
+	// Recursive references are resolved on first use, which has not
+	// necessarily happened yet. Resolve now, the same way validation would.
+	v.resolvedOnce.Do(func() {
+		v.resolved, v.resolveErr = v.resolveReference(context.Background())
+	})
+	if v.resolveErr != nil {
+		return fmt.Errorf("failed to resolve reference %s: %w", v.reference, v.resolveErr)
+	}
+
+	ref := &generatedReference{name: fmt.Sprintf("ref%d", g.nextRef)}
+	g.nextRef++
+	g.refs[key] = ref
+	defer delete(g.refs, key)
+
+	var target bytes.Buffer
+	if err := g.Generate(&target, v.resolved); err != nil {
+		return fmt.Errorf("failed to generate validator for reference %s: %w", v.reference, err)
+	}
+	if !ref.recursive {
+		_, err := target.WriteTo(dst)
+		return err
+	}
+
+	o.L("func() validator.Interface {")
+	o.L("var %s validator.Interface", ref.name)
+	o.L("%s = ", ref.name)
+	o.R("%s", target.String())
+	o.L("return %s", ref.name)
+	o.L("}()")
+
 	_, err := buf.WriteTo(dst)
 	return err
 }
@@ -356,6 +423,13 @@ func (g *codeGenerator) generateDynamicReference(dst io.Writer, v *DynamicRefere
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
+	// The generated code has no schema document for a $recursiveRef to
+	// resolve against, nor a registry standing in for one as there is for
+	// $dynamicAnchor names.
+	if v.recursive {
+		return fmt.Errorf("cannot generate code for $recursiveRef %s: it is resolved against the schema document at validation time", v.reference)
+	}
+
 	// $dynamicRef resolves against the runtime dynamic scope, which differs per
 	// validation, so always emit a runtime-resolved validator.
 	o.R("validator.NewDynamicReferenceValidator(%q)", v.reference)
@@ -369,35 +443,27 @@ func (g *codeGenerator) generateContent(dst io.Writer, v *contentValidator) erro
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
-	// Content validators are complex but we can create a basic structure
-	var parts []string
+	o.L("validator.Content().")
 
 	if v.contentEncoding != "" {
-		parts = append(parts, fmt.Sprintf("contentEncoding: %q", v.contentEncoding))
+		o.L("ContentEncoding(%q).", v.contentEncoding)
 	}
 	if v.contentMediaType != "" {
-		parts = append(parts, fmt.Sprintf("contentMediaType: %q", v.contentMediaType))
+		o.L("ContentMediaType(%q).", v.contentMediaType)
 	}
-
-	// Handle contentSchema if present
 	if v.contentSchema != nil {
-		var childBuf strings.Builder
-		if err := g.Generate(&childBuf, v.contentSchema); err != nil {
+		o.L("ContentSchema(")
+		if err := g.Generate(&buf, v.contentSchema); err != nil {
 			return fmt.Errorf("failed to generate content schema: %w", err)
 		}
-		contentSchemaStr := fmt.Sprintf("contentSchema: %s", childBuf.String())
-		parts = append(parts, contentSchemaStr)
+		o.R(").")
 	}
-
-	if len(parts) > 0 {
-		fieldsStr := strings.Join(parts, ",\n\t\t")
-		o.L("&validator.contentValidator{")
-		o.L("%s,", fieldsStr)
-		o.L("}")
-	} else {
-		o.R("&validator.contentValidator{}")
+	if v.assert {
+		o.L("Assert(true).")
 	}
 
+	o.L("MustBuild()")
+
 	_, err := buf.WriteTo(dst)
 	return err
 }
@@ -407,47 +473,22 @@ func (g *codeGenerator) generateDependentSchemas(dst io.Writer, v *dependentSche
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
-	// Generate the dependent schemas map
-	if len(v.dependentSchemas) == 0 {
-		o.R("&validator.dependentSchemasValidator{dependentSchemas: make(map[string]validator.Interface)}")
-	} else {
-		var mapEntries []string
-		var childSetup []string
-
-		// Sort property names for deterministic output
-		propNames := make([]string, 0, len(v.dependentSchemas))
-		for propName := range v.dependentSchemas {
-			propNames = append(propNames, propName)
-		}
-		sort.Strings(propNames)
-
-		for _, propName := range propNames {
-			propValidator := v.dependentSchemas[propName]
-			childVar := fmt.Sprintf("dep_%s", sanitizeVarName(propName))
-
-			// Generate child validator code into a buffer
-			var childBuf strings.Builder
-			if err := g.Generate(&childBuf, propValidator); err != nil {
-				return fmt.Errorf("failed to generate dependent schema for %q: %w", propName, err)
-			}
-			childChain := childBuf.String()
+	// Sort property names for deterministic output
+	propNames := make([]string, 0, len(v.dependentSchemas))
+	for propName := range v.dependentSchemas {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
 
-			childSetup = append(childSetup, fmt.Sprintf("\t%s := %s", childVar, childChain))
-			mapEntries = append(mapEntries, fmt.Sprintf("\t\t%q: %s", propName, childVar))
+	o.L("validator.DependentSchemas(map[string]validator.Interface{")
+	for _, propName := range propNames {
+		o.L("%q: ", propName)
+		if err := g.Generate(&buf, v.dependentSchemas[propName]); err != nil {
+			return fmt.Errorf("failed to generate dependent schema for %q: %w", propName, err)
 		}
-
-		setupStr := strings.Join(childSetup, "\n")
-		entriesStr := strings.Join(mapEntries, ",\n")
-
-		o.L("func() validator.Interface {")
-		o.R("%s", setupStr)
-		o.L("return &validator.dependentSchemasValidator{")
-		o.L("dependentSchemas: map[string]validator.Interface{")
-		o.R("%s,", entriesStr)
-		o.L("},")
-		o.L("}")
-		o.L("}()")
+		o.R(",")
 	}
+	o.L("})")
 
 	_, err := buf.WriteTo(dst)
 	return err
@@ -464,6 +505,10 @@ func (g *codeGenerator) generateNumber(dst io.Writer, v *numberValidator) error
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
+	if v.wide != nil {
+		return fmt.Errorf(`number bounds that float64 does not hold exactly cannot be generated as code`)
+	}
+
 	o.L("validator.Number().")
 
 	if v.multipleOf != nil {
@@ -496,6 +541,9 @@ func (g *codeGenerator) generateNumber(dst io.Writer, v *numberValidator) error
 	if v.constantValue != nil {
 		o.L("Const(%g).", *v.constantValue)
 	}
+	if v.coerce {
+		o.L("Coerce(true).")
+	}
 
 	o.L("MustBuild()")
 	_, err := buf.WriteTo(dst)
@@ -523,6 +571,9 @@ func (g *codeGenerator) generateBoolean(dst io.Writer, v *booleanValidator) erro
 	if v.constantValue != nil {
 		o.L("Const(%#v).", v.constantValue)
 	}
+	if v.coerce {
+		o.L("Coerce(true).")
+	}
 
 	o.L("MustBuild()")
 	_, err := buf.WriteTo(dst)
@@ -636,49 +687,26 @@ func (g *codeGenerator) generateIfThenElse(dst io.Writer, v *IfThenElseValidator
 	var buf bytes.Buffer
 	o := codegen.NewOutput(&buf)
 
-	// IfThenElse validators are complex composites with three child validators
-	o.L("func() validator.Interface {")
-
-	// Generate if validator
-	if v.ifValidator != nil {
-		o.L("ifValidator := ")
-		if err := g.Generate(&buf, v.ifValidator); err != nil {
-			return fmt.Errorf("failed to generate if validator: %w", err)
+	// Absent then/else branches are passed as nil, which IfThenElse accepts
+	o.L("validator.IfThenElse(")
+	for _, child := range []struct {
+		name      string
+		validator Interface
+	}{
+		{"if", v.ifValidator},
+		{"then", v.thenValidator},
+		{"else", v.elseValidator},
+	} {
+		if child.validator == nil {
+			o.L("nil,")
+			continue
 		}
-		o.R("")
-	} else {
-		o.L("ifValidator := &validator.EmptyValidator{}")
-	}
-
-	// Generate then validator
-	if v.thenValidator != nil {
-		o.L("thenValidator := ")
-		if err := g.Generate(&buf, v.thenValidator); err != nil {
-			return fmt.Errorf("failed to generate then validator: %w", err)
-		}
-		o.R("")
-	} else {
-		o.L("thenValidator := &validator.EmptyValidator{}")
-	}
-
-	// Generate else validator
-	if v.elseValidator != nil {
-		o.L("elseValidator := ")
-		if err := g.Generate(&buf, v.elseValidator); err != nil {
-			return fmt.Errorf("failed to generate else validator: %w", err)
+		if err := g.Generate(&buf, child.validator); err != nil {
+			return fmt.Errorf("failed to generate %s validator: %w", child.name, err)
 		}
-		o.R("")
-	} else {
-		o.L("elseValidator := &validator.EmptyValidator{}")
+		o.R(",")
 	}
-
-	// Create the IfThenElse validator
-	o.L("return &validator.IfThenElseValidator{")
-	o.L("ifValidator: ifValidator,")
-	o.L("thenValidator: thenValidator,")
-	o.L("elseValidator: elseValidator,")
-	o.L("}")
-	o.L("}()")
+	o.L(")")
 
 	_, err := buf.WriteTo(dst)
 	return err
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/lestrrat-go/option/v3"
+)
+
+// jsonValue returns the value that validators see for v, a Go value that may
+// have a JSON form of its own (see WithReflectionOnly):
+//
+//   - a json.Marshaler, such as time.Time, is the JSON it encodes to, decoded
+//     again with numbers as json.Number, so a time.Time is an RFC 3339 string
+//     that "format": "date-time" accepts;
+//   - a url.URL is the string of the URL, which "format": "uri" accepts;
+//   - an encoding.TextMarshaler, such as net.IP or netip.Addr, is its text;
+//   - a []byte is its base64 encoding, as encoding/json writes it.
+//
+// A nil pointer to any of these is null. Values that implement
+// ObjectFieldResolver or ArrayIndexResolver read themselves, and are returned
+// as is, like any other value.
+func jsonValue(v any) (any, error) {
+	switch v := v.(type) {
+	case nil, map[string]any, []any, string, bool, float64, json.Number:
+		return v, nil // the shapes encoding/json decodes to
+	case ObjectFieldResolver, ArrayIndexResolver:
+		return v, nil
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		return base64.StdEncoding.EncodeToString(v), nil
+	case url.URL:
+		return v.String(), nil
+	case *url.URL:
+		if v == nil {
+			return nil, nil
+		}
+		return v.String(), nil
+	case json.Marshaler:
+		if isNilPointer(v) {
+			return nil, nil
+		}
+		buf, err := v.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(buf))
+		dec.UseNumber()
+		var decoded any
+		if err := dec.Decode(&decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode the JSON of %T: %w", v, err)
+		}
+		return decoded, nil
+	case encoding.TextMarshaler:
+		if isNilPointer(v) {
+			return nil, nil
+		}
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+		}
+		return string(text), nil
+	default:
+		return v, nil
+	}
+}
+
+// leafValue is jsonValue for the Validate method of a validator that does not
+// descend into the value, unless options include WithReflectionOnly(true).
+func leafValue(v any, options []ValidateOption) (any, error) {
+	for _, o := range options {
+		if o.Ident() == (identReflectionOnly{}) && option.MustGet[bool](o) {
+			return v, nil
+		}
+	}
+	return jsonValue(v)
+}
+
+func isNilPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
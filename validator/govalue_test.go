@@ -0,0 +1,125 @@
+package validator_test
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+// temperature encodes as an object, unlike its Go kind.
+type temperature float64
+
+func (t temperature) MarshalJSON() ([]byte, error) {
+	return []byte(`{"unit": "C", "value": ` + strconv.FormatFloat(float64(t), 'f', -1, 64) + `}`), nil
+}
+
+type brokenMarshaler struct{}
+
+func (brokenMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("cannot encode")
+}
+
+func TestGoValues(t *testing.T) {
+	compile := func(t *testing.T, src string) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		v, err := validator.Compile(t.Context(), &s, validator.WithFormatAssertion(true))
+		require.NoError(t, err)
+		return v
+	}
+	when := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("time.Time is a date-time string", func(t *testing.T) {
+		v := compile(t, `{"type": "string", "format": "date-time"}`)
+		_, err := v.Validate(t.Context(), when)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), &when)
+		require.NoError(t, err)
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		type event struct {
+			Name    string      `json:"name"`
+			At      time.Time   `json:"at"`
+			Outside temperature `json:"outside"`
+			Ended   *time.Time  `json:"ended"`
+		}
+		v := compile(t, `{
+			"type": "object",
+			"properties": {
+				"at": {"type": "string", "format": "date-time"},
+				"outside": {
+					"type": "object",
+					"required": ["unit", "value"],
+					"properties": {"value": {"type": "number", "minimum": -273.15}}
+				},
+				"ended": {"type": ["string", "null"]}
+			}
+		}`)
+		_, err := v.Validate(t.Context(), event{Name: "launch", At: when, Outside: 21.5})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), event{Name: "launch", At: when, Outside: -300})
+		require.Error(t, err)
+	})
+
+	t.Run("array items", func(t *testing.T) {
+		v := compile(t, `{"type": "array", "items": {"type": "string", "format": "date-time"}}`)
+		_, err := v.Validate(t.Context(), []time.Time{when, when.Add(time.Hour)})
+		require.NoError(t, err)
+	})
+
+	t.Run("nil pointer is null", func(t *testing.T) {
+		var nothing *time.Time
+		_, err := compile(t, `{"type": "null"}`).Validate(t.Context(), nothing)
+		require.NoError(t, err)
+	})
+
+	t.Run("url.URL is a uri string", func(t *testing.T) {
+		v := compile(t, `{"type": "string", "format": "uri"}`)
+		u, err := url.Parse("https://example.com/a?b=c")
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), u)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), *u)
+		require.NoError(t, err)
+	})
+
+	t.Run("[]byte is a base64 string", func(t *testing.T) {
+		v := compile(t, `{"type": "string", "contentEncoding": "base64", "maxLength": 8}`)
+		_, err := v.Validate(t.Context(), []byte("hello"))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), []byte("hello, world"))
+		require.Error(t, err)
+	})
+
+	t.Run("encoding.TextMarshaler is its text", func(t *testing.T) {
+		v := compile(t, `{"type": "object", "properties": {"addr": {"type": "string", "format": "ipv4"}}}`)
+		_, err := v.Validate(t.Context(), map[string]any{"addr": net.ParseIP("192.0.2.1")})
+		require.NoError(t, err)
+	})
+
+	t.Run("reflection only", func(t *testing.T) {
+		v := compile(t, `{"type": "string"}`)
+		_, err := v.Validate(t.Context(), when, validator.WithReflectionOnly(true))
+		require.Error(t, err)
+		_, err = v.Validate(t.Context(), when, validator.WithReflectionOnly(false))
+		require.NoError(t, err)
+
+		v = compile(t, `{"type": "array", "items": {"type": "integer"}}`)
+		_, err = v.Validate(t.Context(), []byte{1, 2}, validator.WithReflectionOnly(true))
+		require.NoError(t, err)
+	})
+
+	t.Run("marshal failure", func(t *testing.T) {
+		_, err := compile(t, `{"type": "object"}`).Validate(t.Context(), brokenMarshaler{})
+		require.ErrorContains(t, err, "cannot encode")
+	})
+}
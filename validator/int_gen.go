@@ -5,8 +5,6 @@ package validator
 import (
 	"context"
 	"fmt"
-	"math"
-	"reflect"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/vocabulary"
@@ -15,113 +13,93 @@ import (
 var _ Builder = (*IntegerValidatorBuilder)(nil)
 var _ Interface = (*integerValidator)(nil)
 
-func compileIntegerValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (Interface, error) {
-	b := Integer()
+func compileIntegerValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, coerce, useNumber, strictInteger bool) (Interface, error) {
+	b := Integer().Coerce(coerce).UseNumber(useNumber).StrictInteger(strictInteger)
 
 	if s.HasMultipleOf() && vocab.IsKeywordEnabled("multipleOf") {
-		rv := reflect.ValueOf(s.MultipleOf())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
+		tmp, exact, err := integerConstraint(s.MultipleOfNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for multipleOf field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().multipleOf = exact
+		} else {
 			b.MultipleOf(tmp)
-		case reflect.Float32, reflect.Float64:
-			f := rv.Float()
-			// Skip multipleOf constraint for very small values with integer type
-			// Any integer is a multiple of very small numbers like 1e-8
-			if f <= 0 || f >= 1 {
-				tmp = int64(f)
-				b.MultipleOf(tmp)
-			}
-		default:
-			return nil, fmt.Errorf(`invalid type for multipleOf field: expected numeric type, got %T`, rv.Interface())
 		}
 	}
 
 	if s.HasMaximum() && vocab.IsKeywordEnabled("maximum") {
-		rv := reflect.ValueOf(s.Maximum())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = int64(rv.Float())
-		default:
-			return nil, fmt.Errorf(`invalid type for maximum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := integerConstraint(s.MaximumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for maximum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().maximum = exact
+		} else {
+			b.Maximum(tmp)
 		}
-		b.Maximum(tmp)
 	}
 
 	if s.HasExclusiveMaximum() && vocab.IsKeywordEnabled("exclusiveMaximum") {
-		rv := reflect.ValueOf(s.ExclusiveMaximum())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = int64(rv.Float())
-		default:
-			return nil, fmt.Errorf(`invalid type for exclusiveMaximum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := integerConstraint(s.ExclusiveMaximumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for exclusiveMaximum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().exclusiveMaximum = exact
+		} else {
+			b.ExclusiveMaximum(tmp)
 		}
-		b.ExclusiveMaximum(tmp)
 	}
 
 	if s.HasMinimum() && vocab.IsKeywordEnabled("minimum") {
-		rv := reflect.ValueOf(s.Minimum())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = int64(rv.Float())
-		default:
-			return nil, fmt.Errorf(`invalid type for minimum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := integerConstraint(s.MinimumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for minimum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().minimum = exact
+		} else {
+			b.Minimum(tmp)
 		}
-		b.Minimum(tmp)
 	}
 
 	if s.HasExclusiveMinimum() && vocab.IsKeywordEnabled("exclusiveMinimum") {
-		rv := reflect.ValueOf(s.ExclusiveMinimum())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = int64(rv.Float())
-		default:
-			return nil, fmt.Errorf(`invalid type for exclusiveMinimum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := integerConstraint(s.ExclusiveMinimumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for exclusiveMinimum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().exclusiveMinimum = exact
+		} else {
+			b.ExclusiveMinimum(tmp)
 		}
-		b.ExclusiveMinimum(tmp)
 	}
 
 	if s.HasConst() && vocab.IsKeywordEnabled("const") {
-		rv := reflect.ValueOf(s.Const())
-		var tmp int64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = int64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = int64(rv.Float())
-		default:
-			return nil, fmt.Errorf(`invalid type for constantValue field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := integerConstraint(s.Const())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for constantValue field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().constantValue = exact
+		} else {
+			b.Const(tmp)
 		}
-		b.Const(tmp)
 	}
 
 	if s.HasEnum() && vocab.IsKeywordEnabled("enum") {
 		enums := s.Enum()
 		l := make([]int64, 0, len(enums))
-		for i, e := range s.Enum() {
-			rv := reflect.ValueOf(e)
-			var tmp int64
-			switch rv.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				tmp = int64(rv.Int())
-			case reflect.Float32, reflect.Float64:
-				tmp = int64(rv.Float())
-			default:
+		for i, e := range enums {
+			tmp, exact, err := integerConstraint(e)
+			if err != nil {
 				return nil, fmt.Errorf(`invalid element in enum: expected numeric element, got %T for element %d`, e, i)
 			}
+			if exact != nil {
+				b.wide().enum = append(b.wide().enum, exact)
+				continue
+			}
 			l = append(l, tmp)
 		}
 		b.Enum(l...)
@@ -137,6 +115,10 @@ type integerValidator struct {
 	exclusiveMinimum *int64
 	constantValue    *int64
 	enum             []int64
+	wide             *wideIntegerConstraints
+	coerce           bool
+	useNumber        bool
+	strictInteger    bool
 }
 
 type IntegerValidatorBuilder struct {
@@ -205,6 +187,37 @@ func (b *IntegerValidatorBuilder) Enum(v ...int64) *IntegerValidatorBuilder {
 	return b
 }
 
+// Coerce makes the validator accept a string spelling a JSON number, as
+// WithCoercion does for compiled schemas.
+func (b *IntegerValidatorBuilder) Coerce(v bool) *IntegerValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.coerce = v
+	return b
+}
+
+// UseNumber makes the validator reject a float too large to hold every
+// integer exactly, as WithUseNumber does for compiled schemas.
+func (b *IntegerValidatorBuilder) UseNumber(v bool) *IntegerValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.useNumber = v
+	return b
+}
+
+// StrictInteger makes the validator reject numbers written with a
+// fraction or an exponent, such as 30.0, as WithStrictInteger does for
+// compiled schemas.
+func (b *IntegerValidatorBuilder) StrictInteger(v bool) *IntegerValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.strictInteger = v
+	return b
+}
+
 func (b *IntegerValidatorBuilder) Build() (Interface, error) {
 	if b.err != nil {
 		return nil, b.err
@@ -225,54 +238,86 @@ func (b *IntegerValidatorBuilder) Reset() *IntegerValidatorBuilder {
 	return b
 }
 
-func (v *integerValidator) Validate(_ context.Context, in any, _ ...ValidateOption) (Result, error) {
-	n, ok, isInt, err := numericInt(in)
+func (v *integerValidator) Validate(_ context.Context, in any, options ...ValidateOption) (Result, error) {
+	in, err := leafValue(in, options)
 	if err != nil {
-		return nil, fmt.Errorf(`invalid value passed to IntegerValidator: %w`, err)
+		return nil, leafError(err)
 	}
+	res, err := v.check(in)
+	return res, leafError(err)
+}
+
+func (v *integerValidator) check(in any) (Result, error) {
+	var coerced bool
+	if v.coerce {
+		if s, ok := coercibleString(in); ok {
+			num, ok := coerceNumberString(s)
+			if !ok {
+				return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: cannot coerce %q to integer`, s)
+			}
+			in = num
+			coerced = true
+		}
+	}
+	n, ok, isInt, err := numericInt(in)
 	if !ok {
-		return nil, fmt.Errorf(`invalid value passed to IntegerValidator: expected integer, got %T`, in)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got %T`, in)
+	}
+	if v.strictInteger && !integerLiteral(in) {
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got %v written as a float`, in)
+	}
+	if v.useNumber && impreciseFloat(in) {
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: %v is a float too large to hold every integer exactly, and may have been rounded; decode numbers as json.Number`, in)
+	}
+	if err != nil || v.wide != nil {
+		if err := v.checkWide(in); err != nil {
+			return nil, err
+		}
+		if coerced {
+			return &coercedResult{value: in}, nil
+		}
+		return nil, nil
 	}
 	if !isInt {
-		return nil, fmt.Errorf(`invalid value passed to IntegerValidator: expected integer, got non-integer value %v`, in)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got non-integer value %v`, in)
 	}
 
 	if m := v.maximum; m != nil {
 		if n > *m {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value is greater than maximum %d`, *m)
+			return nil, &NumericError{Keyword: "maximum", Limit: *m, Value: n, class: "Integer"}
 		}
 	}
 
 	if em := v.exclusiveMaximum; em != nil {
 		if n >= *em {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value is greater than or equal to exclusiveMaximum %d`, *em)
+			return nil, &NumericError{Keyword: "exclusiveMaximum", Limit: *em, Value: n, class: "Integer"}
 		}
 	}
 
 	if m := v.minimum; m != nil {
 		if n < *m {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value is less than minimum %d`, *m)
+			return nil, &NumericError{Keyword: "minimum", Limit: *m, Value: n, class: "Integer"}
 		}
 	}
 
 	if em := v.exclusiveMinimum; em != nil {
 		if n <= *em {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value is less than or equal to exclusiveMinimum %d`, *em)
+			return nil, &NumericError{Keyword: "exclusiveMinimum", Limit: *em, Value: n, class: "Integer"}
 		}
 	}
 
 	if mo := v.multipleOf; mo != nil {
 		if *mo == 0 {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: multipleOf cannot be zero`)
+			return nil, codeErrorf(ErrCodeMultipleOf, `invalid value passed to IntegerValidator: multipleOf cannot be zero`)
 		}
-		if math.Mod(float64(n), float64(*mo)) != 0 {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value is not multiple of %d`, *mo)
+		if n%*mo != 0 {
+			return nil, &NumericError{Keyword: "multipleOf", Limit: *mo, Value: n, class: "Integer"}
 		}
 	}
 
 	if c := v.constantValue; c != nil {
 		if *c != n {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value must be const value %d`, *c)
+			return nil, codeErrorf(ErrCodeConst, `invalid value passed to IntegerValidator: value must be const value %d`, *c)
 		}
 	}
 
@@ -285,8 +330,11 @@ func (v *integerValidator) Validate(_ context.Context, in any, _ ...ValidateOpti
 			}
 		}
 		if !found {
-			return nil, fmt.Errorf(`invalid value passed to IntegerValidator: value not found in enum`)
+			return nil, codeErrorf(ErrCodeEnum, `invalid value passed to IntegerValidator: value not found in enum`)
 		}
 	}
+	if coerced {
+		return &coercedResult{value: n}, nil
+	}
 	return nil, nil
 }
@@ -2,11 +2,14 @@ package validator_test
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/validator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIntegerConstraintSanity(t *testing.T) {
@@ -172,3 +175,151 @@ func TestIntegerValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegerBeyondInt64(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Schema  string
+		Valid   []string
+		Invalid []string
+	}{
+		{
+			Name:    "no constraints",
+			Schema:  `{"type": "integer"}`,
+			Valid:   []string{`18446744073709551615`, `-99999999999999999999`, `1e30`},
+			Invalid: []string{`18446744073709551615.5`},
+		},
+		{
+			Name:    "maximum",
+			Schema:  `{"type": "integer", "maximum": 100}`,
+			Valid:   []string{`-18446744073709551615`},
+			Invalid: []string{`18446744073709551615`},
+		},
+		{
+			Name:    "exclusiveMinimum beyond int64",
+			Schema:  `{"type": "integer", "exclusiveMinimum": 1e19}`,
+			Valid:   []string{`10000000000000000001`},
+			Invalid: []string{`10000000000000000000`, `42`},
+		},
+		{
+			Name:    "multipleOf",
+			Schema:  `{"type": "integer", "multipleOf": 10}`,
+			Valid:   []string{`18446744073709551610`},
+			Invalid: []string{`18446744073709551615`},
+		},
+		{
+			Name:    "fractional multipleOf",
+			Schema:  `{"type": "integer", "multipleOf": 0.5}`,
+			Valid:   []string{`7`, `18446744073709551615`},
+			Invalid: []string{`7.5`},
+		},
+		{
+			Name:    "fractional maximum",
+			Schema:  `{"type": "integer", "exclusiveMaximum": 1.5}`,
+			Valid:   []string{`1`},
+			Invalid: []string{`2`},
+		},
+		{
+			Name:    "const",
+			Schema:  `{"type": "integer", "const": 18446744073709551615}`,
+			Valid:   []string{`18446744073709551615`},
+			Invalid: []string{`18446744073709551614`, `18446744073709551616`},
+		},
+		{
+			Name:    "enum",
+			Schema:  `{"type": "integer", "enum": [1, 9007199254740993, 18446744073709551615]}`,
+			Valid:   []string{`1`, `9007199254740993`, `18446744073709551615`},
+			Invalid: []string{`9007199254740992`, `18446744073709551614`},
+		},
+		{
+			Name:    "maximum float64 rounds",
+			Schema:  `{"type": "integer", "maximum": 18446744073709551615}`,
+			Valid:   []string{`18446744073709551615`},
+			Invalid: []string{`18446744073709551616`},
+		},
+		{
+			Name:    "untyped maximum float64 rounds",
+			Schema:  `{"maximum": 18446744073709551615}`,
+			Valid:   []string{`18446744073709551615`, `1.5`},
+			Invalid: []string{`18446744073709551616`, `18446744073709551615.5`},
+		},
+		{
+			Name:    "untyped const",
+			Schema:  `{"const": 9007199254740993}`,
+			Valid:   []string{`9007199254740993`},
+			Invalid: []string{`9007199254740992`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tc.Schema)))
+			v, err := validator.Compile(t.Context(), &s)
+			require.NoError(t, err)
+			for _, in := range tc.Valid {
+				_, err := validator.ValidateJSON(t.Context(), v, []byte(in))
+				require.NoError(t, err, "%s should be valid", in)
+			}
+			for _, in := range tc.Invalid {
+				_, err := validator.ValidateJSON(t.Context(), v, []byte(in))
+				require.Error(t, err, "%s should be invalid", in)
+			}
+		})
+	}
+
+	t.Run("native uint64", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), schema.NewBuilder().Types(schema.IntegerType).Minimum(0).MustBuild())
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), uint64(math.MaxUint64))
+		require.NoError(t, err)
+	})
+}
+
+func TestWithStrictInteger(t *testing.T) {
+	s := schema.NewBuilder().Types(schema.IntegerType).MustBuild()
+
+	lenient, err := validator.Compile(t.Context(), s)
+	require.NoError(t, err)
+	strict, err := validator.Compile(t.Context(), s, validator.WithStrictInteger(true))
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name   string
+		value  any
+		strict bool // whether the strict validator accepts value
+	}{
+		{"int", 30, true},
+		{"json.Number integer", json.Number("30"), true},
+		{"json.Number with zero fraction", json.Number("30.0"), false},
+		{"json.Number with exponent", json.Number("3e1"), false},
+		{"float64 with zero fraction", 30.0, false},
+		{"float32 with zero fraction", float32(30), false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := lenient.Validate(t.Context(), tc.value)
+			require.NoError(t, err, "1.0 is an integer by default")
+
+			_, err = strict.Validate(t.Context(), tc.value)
+			if tc.strict {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, validator.ErrCodeType)
+		})
+	}
+
+	t.Run("ValidateJSON keeps the literal", func(t *testing.T) {
+		_, err := validator.ValidateJSON(t.Context(), strict, []byte(`30`))
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), strict, []byte(`30.0`))
+		require.Error(t, err)
+	})
+
+	t.Run("number is unaffected", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), schema.NewBuilder().Types(schema.NumberType).MustBuild(), validator.WithStrictInteger(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 30.0)
+		require.NoError(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/lestrrat-go/codegen"
@@ -72,8 +73,9 @@ func generateValidator(def definition, outputDir string) error {
 	o.L("import (")
 	o.L("\t\"context\"")
 	o.L("\t\"fmt\"")
-	o.L("\t\"math\"")
-	o.L("\t\"reflect\"")
+	if def.class == "Number" {
+		o.L("\t\"math\"")
+	}
 	o.L("")
 	o.L("\tschema \"github.com/lestrrat-go/json-schema\"")
 	o.L("\t\"github.com/lestrrat-go/json-schema/vocabulary\"")
@@ -82,8 +84,13 @@ func generateValidator(def definition, outputDir string) error {
 	o.L("var _ Builder = (*%sValidatorBuilder)(nil)", def.class)
 	o.L("var _ Interface = (*%sValidator)(nil)", xstrings.Snake(def.class))
 
-	o.LL("func compile%sValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (Interface, error) {", def.class)
-	o.L("b := %s()", def.class)
+	if def.class == "Integer" {
+		o.LL("func compileIntegerValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, coerce, useNumber, strictInteger bool) (Interface, error) {")
+		o.L("b := Integer().Coerce(coerce).UseNumber(useNumber).StrictInteger(strictInteger)")
+	} else {
+		o.LL("func compile%sValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, coerce bool) (Interface, error) {", def.class)
+		o.L("b := %s().Coerce(coerce)", def.class)
+	}
 	for _, prop := range props {
 		var methodName string
 		if prop == "constantValue" {
@@ -92,29 +99,31 @@ func generateValidator(def definition, outputDir string) error {
 			methodName = xstrings.Camel(prop)
 		}
 
+		// integerConstraint and numberConstraint (validator/numeric.go)
+		// accept every numeric kind, json.Number included. An integer
+		// constraint that int64 cannot hold exactly (a fraction, or a bound
+		// beyond int64), or a number bound that float64 cannot, is kept as a
+		// *big.Rat in the validator's wide field. The bounds are read as
+		// the schema wrote them, from the Number accessors.
 		if prop == "enum" {
 			o.LL("if s.HasEnum() && vocab.IsKeywordEnabled(\"enum\") {")
 			o.L("enums := s.Enum()")
 			o.L("l := make([]%s, 0, len(enums))", def.typ)
-			o.L("for i, e := range s.Enum() {")
-			o.L("rv := reflect.ValueOf(e)")
-			o.L("var tmp %s", def.typ)
-			o.L("switch rv.Kind() {")
-			o.L("case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:")
+			o.L("for i, e := range enums {")
 			if def.class == "Integer" {
-				o.L("tmp = %s(rv.Int())", def.typ)
+				o.L("tmp, exact, err := integerConstraint(e)")
 			} else {
-				o.L("tmp = float64(rv.Int())")
+				o.L("tmp, err := numberConstraint(e)")
 			}
-			o.L("case reflect.Float32, reflect.Float64:")
+			o.L("if err != nil {")
+			o.L("return nil, fmt.Errorf(`invalid element in enum: expected numeric element, got %%T for element %%d`, e, i)")
+			o.L("}")
 			if def.class == "Integer" {
-				o.L("tmp = %s(rv.Float())", def.typ)
-			} else {
-				o.L("tmp = rv.Float()")
+				o.L("if exact != nil {")
+				o.L("b.wide().enum = append(b.wide().enum, exact)")
+				o.L("continue")
+				o.L("}")
 			}
-			o.L("default:")
-			o.L("return nil, fmt.Errorf(`invalid element in enum: expected numeric element, got %%T for element %%d`, e, i)")
-			o.L("}") // switch
 			o.L("l = append(l, tmp)")
 			o.L("}") // for
 			o.L("b.Enum(l...)")
@@ -124,45 +133,29 @@ func generateValidator(def definition, outputDir string) error {
 			first := runes[0]
 			lower := string(append(append([]rune(nil), unicode.ToLower(first)), runes[1:]...))
 			o.LL("if s.Has%s() && vocab.IsKeywordEnabled(%q) {", methodName, lower)
-			o.L("rv := reflect.ValueOf(s.%s())", methodName)
-			o.L("var tmp %s", def.typ)
-			o.L("switch rv.Kind() {")
-			o.L("case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:")
-			if def.class == "Integer" {
-				o.L("tmp = %s(rv.Int())", def.typ)
-			} else {
-				o.L("tmp = float64(rv.Int())")
+			accessor := methodName + "Number"
+			if prop == "constantValue" {
+				accessor = methodName
 			}
-			if methodName == "MultipleOf" {
-				o.L("b.%s(tmp)", methodName)
-			}
-			o.L("case reflect.Float32, reflect.Float64:")
-			if def.class == "Integer" {
-				if prop == "multipleOf" {
-					o.L("f := rv.Float()")
-					o.L("// Skip multipleOf constraint for very small values with integer type")
-					o.L("// Any integer is a multiple of very small numbers like 1e-8")
-					o.L("if f <= 0 || f >= 1 {")
-					o.L("tmp = %s(f)", def.typ)
-					o.L("b.%s(tmp)", methodName)
-					o.L("}")
-				} else {
-					o.L("tmp = %s(rv.Float())", def.typ)
-				}
-			} else {
-				o.L("tmp = rv.Float()")
+			switch {
+			case def.class == "Integer":
+				o.L("tmp, exact, err := integerConstraint(s.%s())", accessor)
+			case prop == "constantValue":
+				o.L("tmp, err := numberConstraint(s.%s())", accessor)
+			default:
+				o.L("tmp, exact, err := numberBound(s.%s())", accessor)
 			}
-			if def.class != "Integer" || prop != "multipleOf" {
-				o.L("default:")
-				o.L("return nil, fmt.Errorf(`invalid type for %s field: expected numeric type, got %%T`, rv.Interface())", prop)
-				o.L("}") // switch
-				if def.class != "Integer" || prop != "multipleOf" {
-					o.L("b.%s(tmp)", methodName)
-				}
+			o.L("if err != nil {")
+			o.L("return nil, fmt.Errorf(`invalid type for %s field: %%w`, err)", prop)
+			o.L("}")
+			if def.class == "Integer" || prop != "constantValue" {
+				o.L("if exact != nil {")
+				o.L("b.wide().%s = exact", prop)
+				o.L("} else {")
+				o.L("b.%s(tmp)", methodName)
+				o.L("}")
 			} else {
-				o.L("default:")
-				o.L("return nil, fmt.Errorf(`invalid type for %s field: expected numeric type, got %%T`, rv.Interface())", prop)
-				o.L("}") // switch
+				o.L("b.%s(tmp)", methodName)
 			}
 			o.L("}") // if s.Has
 		}
@@ -178,6 +171,16 @@ func generateValidator(def definition, outputDir string) error {
 			o.L("%s *%s", prop, def.typ)
 		}
 	}
+	if def.class == "Integer" {
+		o.L("wide *wideIntegerConstraints")
+	} else {
+		o.L("wide *wideNumberConstraints")
+	}
+	o.L("coerce bool")
+	if def.class == "Integer" {
+		o.L("useNumber bool")
+		o.L("strictInteger bool")
+	}
 	o.L("}")
 
 	o.LL("type %sValidatorBuilder struct {", def.class)
@@ -217,6 +220,39 @@ func generateValidator(def definition, outputDir string) error {
 		}
 	}
 
+	o.LL("// Coerce makes the validator accept a string spelling a JSON number, as")
+	o.L("// WithCoercion does for compiled schemas.")
+	o.L("func (b *%[1]sValidatorBuilder) Coerce(v bool) *%[1]sValidatorBuilder {", def.class)
+	o.L("if b.err != nil {")
+	o.L("return b")
+	o.L("}")
+	o.L("b.c.coerce = v")
+	o.L("return b")
+	o.L("}")
+
+	if def.class == "Integer" {
+		o.LL("// UseNumber makes the validator reject a float too large to hold every")
+		o.L("// integer exactly, as WithUseNumber does for compiled schemas.")
+		o.L("func (b *IntegerValidatorBuilder) UseNumber(v bool) *IntegerValidatorBuilder {")
+		o.L("if b.err != nil {")
+		o.L("return b")
+		o.L("}")
+		o.L("b.c.useNumber = v")
+		o.L("return b")
+		o.L("}")
+
+		o.LL("// StrictInteger makes the validator reject numbers written with a")
+		o.L("// fraction or an exponent, such as 30.0, as WithStrictInteger does for")
+		o.L("// compiled schemas.")
+		o.L("func (b *IntegerValidatorBuilder) StrictInteger(v bool) *IntegerValidatorBuilder {")
+		o.L("if b.err != nil {")
+		o.L("return b")
+		o.L("}")
+		o.L("b.c.strictInteger = v")
+		o.L("return b")
+		o.L("}")
+	}
+
 	o.LL("func (b *%[1]sValidatorBuilder) Build() (Interface, error) {", def.class)
 	o.L("if b.err != nil {")
 	o.L("return nil, b.err")
@@ -243,72 +279,112 @@ func generateValidator(def definition, outputDir string) error {
 	} else {
 		template = "f"
 	}
-	o.LL("func (v *%sValidator) Validate(_ context.Context, in any, _ ...ValidateOption) (Result, error) {", xstrings.Snake(def.class))
+	o.LL("func (v *%sValidator) Validate(_ context.Context, in any, options ...ValidateOption) (Result, error) {", xstrings.Snake(def.class))
+	o.L("in, err := leafValue(in, options)")
+	o.L("if err != nil {")
+	o.L("return nil, leafError(err)")
+	o.L("}")
+	o.L("res, err := v.check(in)")
+	o.L("return res, leafError(err)")
+	o.L("}")
+
+	o.LL("func (v *%sValidator) check(in any) (Result, error) {", xstrings.Snake(def.class))
+	o.L("var coerced bool")
+	o.L("if v.coerce {")
+	o.L("if s, ok := coercibleString(in); ok {")
+	o.L("num, ok := coerceNumberString(s)")
+	o.L("if !ok {")
+	o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to %sValidator: cannot coerce %%q to %s`, s)", def.class, strings.ToLower(def.class))
+	o.L("}")
+	o.L("in = num")
+	o.L("coerced = true")
+	o.L("}")
+	o.L("}")
 	if def.class == "Integer" {
 		// numericInt accepts native numeric kinds and json.Number (UseNumber),
 		// preserving int64 precision. isInt distinguishes a non-integer number
 		// (e.g. 5.5) from a genuine integer; err flags an integer outside int64.
 		o.L("n, ok, isInt, err := numericInt(in)")
-		o.L("if err != nil {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to IntegerValidator: %%w`, err)")
-		o.L("}")
 		o.L("if !ok {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to IntegerValidator: expected integer, got %%T`, in)")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got %%T`, in)")
+		o.L("}")
+		// Values beyond int64, and constraints that int64 cannot hold, are
+		// compared exactly by checkWide (validator/bigint.go).
+		o.L("if v.strictInteger && !integerLiteral(in) {")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got %%v written as a float`, in)")
+		o.L("}")
+		o.L("if v.useNumber && impreciseFloat(in) {")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: %%v is a float too large to hold every integer exactly, and may have been rounded; decode numbers as json.Number`, in)")
+		o.L("}")
+		o.L("if err != nil || v.wide != nil {")
+		o.L("if err := v.checkWide(in); err != nil {")
+		o.L("return nil, err")
+		o.L("}")
+		o.L("if coerced {")
+		o.L("return &coercedResult{value: in}, nil")
+		o.L("}")
+		o.L("return nil, nil")
 		o.L("}")
 		o.L("if !isInt {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to IntegerValidator: expected integer, got non-integer value %%v`, in)")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to IntegerValidator: expected integer, got non-integer value %%v`, in)")
 		o.L("}")
 	} else {
 		// numericFloat accepts native numeric kinds and json.Number (UseNumber).
 		o.L("n, ok, err := numericFloat(in)")
 		o.L("if err != nil {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to NumberValidator: %%w`, err)")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: %%w`, err)")
 		o.L("}")
 		o.L("if !ok {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to NumberValidator: expected number, got %%T`, in)")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: expected number, got %%T`, in)")
 		o.L("}")
 		o.L("")
 		o.L("// Reject NaN but allow infinity")
 		o.L("if math.IsNaN(n) {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is not a valid number (NaN)`)")
+		o.L("return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: value is not a valid number (NaN)`)")
+		o.L("}")
+		// Bounds that float64 cannot hold are compared exactly by checkWide
+		// (validator/bignumber.go); the others below.
+		o.L("if v.wide != nil {")
+		o.L("if err := v.checkWide(in, n); err != nil {")
+		o.L("return nil, err")
+		o.L("}")
 		o.L("}")
 	}
 	o.LL("if m := v.maximum; m != nil {")
 	o.L("if n > *m {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value is greater than maximum %%%s`, *m)", def.class, template)
+	o.L("return nil, &NumericError{Keyword: %q, Limit: *m, Value: n, class: %q}", "maximum", def.class)
 	o.L("}")
 	o.L("}")
 	o.LL("if em := v.exclusiveMaximum; em != nil {")
 	o.L("if n >= *em {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value is greater than or equal to exclusiveMaximum %%%s`, *em)", def.class, template)
+	o.L("return nil, &NumericError{Keyword: %q, Limit: *em, Value: n, class: %q}", "exclusiveMaximum", def.class)
 	o.L("}")
 	o.L("}")
 	o.LL("if m := v.minimum; m != nil {")
 	o.L("if n < *m {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value is less than minimum %%%s`, *m)", def.class, template)
+	o.L("return nil, &NumericError{Keyword: %q, Limit: *m, Value: n, class: %q}", "minimum", def.class)
 	o.L("}")
 	o.L("}")
 	o.LL("if em := v.exclusiveMinimum; em != nil {")
 	o.L("if n <= *em {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value is less than or equal to exclusiveMinimum %%%s`, *em)", def.class, template)
+	o.L("return nil, &NumericError{Keyword: %q, Limit: *em, Value: n, class: %q}", "exclusiveMinimum", def.class)
 	o.L("}")
 	o.L("}")
 	o.LL("if mo := v.multipleOf; mo != nil {")
 	if def.class == "Integer" {
 		o.L("if *mo == 0 {")
-		o.L("return nil, fmt.Errorf(`invalid value passed to IntegerValidator: multipleOf cannot be zero`)")
+		o.L("return nil, codeErrorf(ErrCodeMultipleOf, `invalid value passed to IntegerValidator: multipleOf cannot be zero`)")
 		o.L("}")
-		o.L("if math.Mod(float64(n), float64(*mo)) != 0 {")
+		o.L("if n%%*mo != 0 {")
 	} else {
-		o.L("remainder := math.Mod(n, *mo)")
-		o.L("if math.Abs(remainder) > 1e-9 && math.Abs(remainder - *mo) > 1e-9 {")
+		o.L("if !isMultipleOf(in, *mo) {")
 	}
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value is not multiple of %%%s`, *mo)", def.class, template)
+	o.L("return nil, &NumericError{Keyword: %q, Limit: *mo, Value: n, class: %q}", "multipleOf", def.class)
 	o.L("}")
 	o.L("}")
 	o.LL("if c := v.constantValue; c != nil {")
 	o.L("if *c != n {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value must be const value %%%s`, *c)", def.class, template)
+	o.L("return nil, codeErrorf(ErrCodeConst, `invalid value passed to %sValidator: value must be const value %%%s`, *c)", def.class, template)
 	o.L("}")
 	o.L("}")
 	o.LL("if enums := v.enum; len(enums) > 0 {")
@@ -320,8 +396,11 @@ func generateValidator(def definition, outputDir string) error {
 	o.L("}")
 	o.L("}")
 	o.L("if !found {")
-	o.L("return nil, fmt.Errorf(`invalid value passed to %sValidator: value not found in enum`)", def.class)
+	o.L("return nil, codeErrorf(ErrCodeEnum, `invalid value passed to %sValidator: value not found in enum`)", def.class)
+	o.L("}")
 	o.L("}")
+	o.L("if coerced {")
+	o.L("return &coercedResult{value: n}, nil")
 	o.L("}")
 	o.L("return nil, nil")
 	o.L("}")
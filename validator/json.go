@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -45,3 +47,81 @@ func ValidateJSON(ctx context.Context, v Interface, data []byte, options ...Vali
 
 	return v.Validate(ctx, decoded, options...)
 }
+
+// ValidateReader is like ValidateJSON, but reads the JSON text from r, so a
+// large document can be validated straight from a file or a request body
+// without first being read into a byte slice. r is read until the end of the
+// input, which must hold exactly one top-level JSON value.
+//
+// The value is currently decoded in full before it is validated; the same
+// rules as ValidateJSON apply to numbers. Callers should not rely on the whole
+// of r being consumed when validation fails, as a later version may validate
+// arrays and objects as they are decoded.
+func ValidateReader(ctx context.Context, v Interface, r io.Reader, options ...ValidateOption) (Result, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("failed to decode JSON: empty input")
+		}
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	// Anything but a clean EOF after the value means trailing content, or a
+	// failure to read it.
+	var syntaxErr *json.SyntaxError
+	switch _, err := dec.Token(); {
+	case err == io.EOF:
+	case err == nil || errors.As(err, &syntaxErr):
+		return nil, fmt.Errorf("invalid JSON: trailing data after top-level value")
+	default:
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return v.Validate(ctx, decoded, options...)
+}
+
+// ValidateStream validates newline-delimited JSON (NDJSON, JSON Lines) read
+// from r: each line holds one JSON value, which is decoded and validated as
+// ValidateJSON would. fn is called once per value with the zero-based index of
+// its line and the outcome; a line that is not a single valid JSON value is
+// reported to fn as an error, like a value that fails validation, and does not
+// stop the stream. Blank lines are skipped, but still counted, so index+1 is
+// always the line number. Returning false from fn stops processing.
+//
+// ValidateStream returns nil once r is exhausted or fn stops it. It returns an
+// error only when reading from r fails or ctx is done; in both cases fn is not
+// called again.
+func ValidateStream(ctx context.Context, v Interface, r io.Reader, fn func(index int, res Result, err error) bool, options ...ValidateOption) error {
+	br := bufio.NewReader(r)
+	for index := 0; ; index++ {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("failed to read line %d: %w", index+1, readErr)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if len(bytes.Trim(line, " \t\r\n")) > 0 {
+			res, err := ValidateJSON(ctx, v, line, options...)
+			// A value nested deeper than WithMaxDepth is that line's failure;
+			// only cancellation stops the stream.
+			if interrupted(err) && !errors.Is(err, ErrMaxDepthExceeded) {
+				return err
+			}
+			if !fn(index, res, err) {
+				return nil
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
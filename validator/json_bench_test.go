@@ -1,6 +1,7 @@
 package validator_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"testing"
@@ -78,3 +79,21 @@ func BenchmarkValidate_ValidateJSON(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkValidate_ValidateReader measures ValidateReader over the same
+// document, read from an io.Reader.
+func BenchmarkValidate_ValidateReader(b *testing.B) {
+	ctx := context.Background()
+	v, err := validator.Compile(ctx, benchSchema())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := validator.ValidateReader(ctx, v, bytes.NewReader(benchData)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,7 +1,14 @@
 package validator_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/validator"
@@ -118,3 +125,175 @@ func TestValidateJSON(t *testing.T) {
 		require.Error(t, err, "boolean satisfies neither branch")
 	})
 }
+
+func TestValidateReader(t *testing.T) {
+	ctx := t.Context()
+
+	s := schema.NewBuilder().
+		Types(schema.ArrayType).
+		Items(schema.NewBuilder().
+			Types(schema.ObjectType).
+			Property("level", schema.Enum("info", "error").MustBuild()).
+			Required("level").
+			MustBuild()).
+		MustBuild()
+	v, err := validator.Compile(ctx, s)
+	require.NoError(t, err)
+
+	t.Run("large document", func(t *testing.T) {
+		var sb strings.Builder
+		sb.WriteString("[")
+		for i := range 10000 {
+			if i > 0 {
+				sb.WriteString(",\n")
+			}
+			sb.WriteString(`{"level": "info", "seq": `)
+			sb.WriteString(strconv.Itoa(i))
+			sb.WriteString("}")
+		}
+		sb.WriteString("]\n")
+
+		_, err := validator.ValidateReader(ctx, v, strings.NewReader(sb.String()))
+		require.NoError(t, err)
+
+		bad := strings.Replace(sb.String(), `"info", "seq": 9999`, `"debug", "seq": 9999`, 1)
+		_, err = validator.ValidateReader(ctx, v, strings.NewReader(bad))
+		require.Error(t, err)
+	})
+
+	t.Run("malformed and empty input", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			data string
+		}{
+			{name: "empty", data: ""},
+			{name: "whitespace only", data: "   \n\t "},
+			{name: "invalid json", data: `[{"level":}]`},
+			{name: "trailing value", data: `[] []`},
+			{name: "trailing garbage", data: `[] }`},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := validator.ValidateReader(ctx, v, strings.NewReader(tc.data))
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		_, err := validator.ValidateReader(ctx, v, io.MultiReader(strings.NewReader("[]"), iotest.ErrReader(readErr)))
+		require.ErrorIs(t, err, readErr)
+	})
+
+	t.Run("numbers keep their precision", func(t *testing.T) {
+		s := schema.NewBuilder().Types(schema.IntegerType).Maximum(9007199254740992).MustBuild()
+		v, err := validator.Compile(ctx, s)
+		require.NoError(t, err)
+		_, err = validator.ValidateReader(ctx, v, strings.NewReader("9007199254740993"))
+		require.Error(t, err, "2^53+1 must not round down to the maximum")
+		_, err = validator.ValidateReader(ctx, v, strings.NewReader("9007199254740992"))
+		require.NoError(t, err)
+	})
+}
+
+func TestValidateStream(t *testing.T) {
+	ctx := t.Context()
+
+	s := schema.NewBuilder().
+		Types(schema.ObjectType).
+		Property("level", schema.Enum("info", "error").MustBuild()).
+		Required("level").
+		MustBuild()
+	v, err := validator.Compile(ctx, s)
+	require.NoError(t, err)
+
+	type outcome struct {
+		index int
+		valid bool
+	}
+
+	t.Run("every line is reported", func(t *testing.T) {
+		const input = "{\"level\": \"info\"}\n" +
+			"{\"level\": \"debug\"}\r\n" +
+			"\n" +
+			"{\"level\":\n" +
+			"{} {}\n" +
+			`{"level": "error"}` // no trailing newline
+		var got []outcome
+		err := validator.ValidateStream(ctx, v, strings.NewReader(input), func(index int, _ validator.Result, err error) bool {
+			got = append(got, outcome{index: index, valid: err == nil})
+			return true
+		})
+		require.NoError(t, err)
+		require.Equal(t, []outcome{
+			{index: 0, valid: true},
+			{index: 1, valid: false},
+			{index: 3, valid: false}, // truncated value
+			{index: 4, valid: false}, // two values on one line
+			{index: 5, valid: true},
+		}, got)
+	})
+
+	t.Run("callback stops processing", func(t *testing.T) {
+		input := strings.Repeat("{\"level\": \"info\"}\n", 10)
+		var calls int
+		err := validator.ValidateStream(ctx, v, strings.NewReader(input), func(int, validator.Result, error) bool {
+			calls++
+			return calls < 3
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		err := validator.ValidateStream(ctx, v, strings.NewReader(""), func(int, validator.Result, error) bool {
+			t.Fatal("no values, no callback")
+			return false
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		r := io.MultiReader(strings.NewReader("{\"level\": \"info\"}\n"), iotest.ErrReader(readErr))
+		var calls int
+		err := validator.ValidateStream(ctx, v, r, func(int, validator.Result, error) bool {
+			calls++
+			return true
+		})
+		require.ErrorIs(t, err, readErr)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := validator.ValidateStream(cctx, v, strings.NewReader("{\"level\": \"info\"}\n"), func(int, validator.Result, error) bool {
+			t.Fatal("callback must not run after cancellation")
+			return false
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestWithUseNumber(t *testing.T) {
+	s := schema.NewBuilder().Types(schema.IntegerType).MustBuild()
+	lossy := float64(10000000000000001) // what json.Unmarshal makes of it
+
+	v, err := validator.Compile(t.Context(), s)
+	require.NoError(t, err)
+	_, err = v.Validate(t.Context(), lossy)
+	require.NoError(t, err, "floats are accepted as before without the option")
+
+	v, err = validator.Compile(t.Context(), s, validator.WithUseNumber(true))
+	require.NoError(t, err)
+	_, err = v.Validate(t.Context(), lossy)
+	require.ErrorContains(t, err, "json.Number")
+	_, err = v.Validate(t.Context(), float64(1<<53))
+	require.NoError(t, err, "floats within 2^53 hold integers exactly")
+	_, err = v.Validate(t.Context(), json.Number("10000000000000001"))
+	require.NoError(t, err)
+	_, err = validator.ValidateJSON(t.Context(), v, []byte(`10000000000000001`))
+	require.NoError(t, err)
+}
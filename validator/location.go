@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/keywords"
+)
+
+// locationError records where a validation failure happened: the keyword path
+// (relative to the schema that produced the wrapped error's parent) and the
+// instance path (relative to the value the parent was validating), both as JSON
+// Pointer fragments such as "/properties/name" and "/name".
+//
+// Each applicator wraps the error returned by its child with the segments it
+// contributed, so walking the error chain from the outside in and
+// concatenating the segments yields the full keyword and instance locations.
+// Error() is transparent: it reports the wrapped message unchanged, so the
+// human-readable error text is unaffected by location tracking.
+type locationError struct {
+	keyword  string
+	instance string
+	// absolute, when non-empty, is the absolute URI of the schema location the
+	// keyword path crossed into (a $ref target). Locations below it are
+	// appended to it to form the absolute keyword location.
+	absolute string
+	err      error
+}
+
+func (e *locationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *locationError) Unwrap() error {
+	return e.err
+}
+
+// atLocation wraps err with the keyword and instance pointer segments of the
+// applicator that observed it. A nil err is returned as-is so call sites can
+// wrap unconditionally on their error path.
+func atLocation(err error, keyword, instance string) error {
+	if err == nil {
+		return nil
+	}
+	return &locationError{keyword: keyword, instance: instance, err: err}
+}
+
+// jsonPointer builds a JSON Pointer from unescaped reference tokens, escaping
+// "~" and "/" as required by RFC 6901. No tokens yields the empty pointer,
+// which denotes the whole document.
+func jsonPointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		if strings.ContainsAny(tok, "~/") {
+			tok = strings.ReplaceAll(tok, "~", "~0")
+			tok = strings.ReplaceAll(tok, "/", "~1")
+		}
+		sb.WriteString(tok)
+	}
+	return sb.String()
+}
+
+// locationValidator attributes failures of the wrapped validator to a fixed
+// keyword path. The compiler wraps subschemas reached through keywords that do
+// not descend into the instance (allOf/anyOf/oneOf branches, not, then/else,
+// $ref) so their keyword location is preserved even though several keywords
+// of one schema are compiled into a single combined validator.
+type locationValidator struct {
+	keyword  string
+	absolute string
+	inner    Interface
+}
+
+func (l *locationValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
+	return validateRoot(ctx, l, v, options)
+}
+
+func (l *locationValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
+	var mark int
+	if st.profile != nil {
+		// Each branch of allOf, anyOf and oneOf counts as one evaluation of
+		// the keyword, the first token of the path.
+		keyword, _, _ := strings.Cut(strings.TrimPrefix(l.keyword, "/"), "/")
+		mark = st.profile.beginKeyword(keyword)
+	}
+	res, err := evalChild(ctx, l.inner, v, st)
+	st.endKeyword(mark)
+	if err != nil {
+		return nil, &locationError{keyword: l.keyword, absolute: l.absolute, err: err}
+	}
+	return res, nil
+}
+
+// atReference attributes failures of v, the validator compiled for the target
+// of a $ref, to the "$ref" keyword. When the reference resolves to an absolute
+// URI it is recorded as the absolute keyword location of everything beneath.
+func atReference(v Interface, baseURI, reference string) Interface {
+	lv := &locationValidator{keyword: jsonPointer(keywords.Reference), inner: v}
+	if abs := schema.ResolveURI(baseURI, reference); abs != "" {
+		if u, err := url.Parse(abs); err == nil && u.IsAbs() {
+			lv.absolute = abs
+		}
+	}
+	return lv
+}
@@ -0,0 +1,112 @@
+package validator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxDepth(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+	// nested returns n arrays nested inside one another around 1.
+	nested := func(n int) any {
+		var v any = 1
+		for range n {
+			v = []any{v}
+		}
+		return v
+	}
+
+	t.Run("schema depth", func(t *testing.T) {
+		// Three levels of subschemas below the root.
+		s := parse(t, `{"properties": {"a": {"items": {"not": {"type": "string"}}}}}`)
+
+		_, err := validator.Compile(t.Context(), s, validator.WithMaxDepth(3))
+		require.NoError(t, err)
+
+		_, err = validator.Compile(t.Context(), s, validator.WithMaxDepth(2))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+		require.ErrorContains(t, err, "schema is nested deeper than 2")
+
+		_, err = validator.Compile(t.Context(), s)
+		require.NoError(t, err, "there is no limit by default")
+	})
+
+	t.Run("references count", func(t *testing.T) {
+		s := parse(t, `{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"type": "string"}}, "$ref": "#/$defs/a"}`)
+		_, err := validator.Compile(t.Context(), s, validator.WithMaxDepth(1))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+		_, err = validator.Compile(t.Context(), s, validator.WithMaxDepth(2))
+		require.NoError(t, err)
+	})
+
+	t.Run("instance depth", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"$defs": {"tree": {"items": {"$ref": "#/$defs/tree"}}}, "$ref": "#/$defs/tree"}`))
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), nested(10), validator.WithMaxDepth(10))
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), nested(11), validator.WithMaxDepth(10))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+		require.ErrorContains(t, err, "instance is nested deeper than 10")
+		var verr *validator.Error
+		require.False(t, errors.As(err, &verr), "the instance is neither valid nor invalid")
+
+		_, err = v.Validate(t.Context(), nested(100))
+		require.NoError(t, err, "there is no limit by default")
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"additionalProperties": {"$ref": "#"}}`))
+		require.NoError(t, err)
+		doc := map[string]any{"a": map[string]any{"b": map[string]any{}}}
+		_, err = v.Validate(t.Context(), doc, validator.WithMaxDepth(2))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), doc, validator.WithMaxDepth(1))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+	})
+
+	t.Run("not does not pass on an exceeded depth", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"not": {"items": {"items": {"type": "string"}}}}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), nested(2), validator.WithMaxDepth(1))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+
+		v, err = validator.Compile(t.Context(), parse(t, `{"anyOf": [{"items": {"items": {"type": "string"}}}, true]}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), nested(2), validator.WithMaxDepth(1))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+	})
+
+	t.Run("ValidateJSON", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"items": {"$ref": "#"}}`))
+		require.NoError(t, err)
+		doc := strings.Repeat("[", 5) + strings.Repeat("]", 5)
+		_, err = validator.ValidateJSON(t.Context(), v, []byte(doc), validator.WithMaxDepth(3))
+		require.ErrorIs(t, err, validator.ErrMaxDepthExceeded)
+	})
+
+	t.Run("ValidateStream reports the line", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"items": {"$ref": "#"}}`))
+		require.NoError(t, err)
+		var errs []error
+		err = validator.ValidateStream(t.Context(), v, strings.NewReader("[[[[]]]]\n[]\n"), func(_ int, _ validator.Result, err error) bool {
+			errs = append(errs, err)
+			return true
+		}, validator.WithMaxDepth(2))
+		require.NoError(t, err)
+		require.Len(t, errs, 2)
+		require.ErrorIs(t, errs[0], validator.ErrMaxDepthExceeded)
+		require.NoError(t, errs[1])
+	})
+}
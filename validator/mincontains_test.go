@@ -142,6 +142,79 @@ func TestMinContainsZeroBehavior(t *testing.T) {
 	}
 }
 
+func TestMinContainsZeroSchemas(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		valid   []any
+		invalid map[string][]any // keyword location => instances
+	}{
+		{
+			name:   "minContains 0 alone",
+			schema: `{"contains": {"type": "string"}, "minContains": 0}`,
+			valid:  []any{[]any{}, []any{1, 2}, []any{"a", 1}, "not an array"},
+		},
+		{
+			name:   "contains matching nothing",
+			schema: `{"contains": false, "minContains": 0}`,
+			valid:  []any{[]any{}, []any{1}, []any{"a", nil}},
+		},
+		{
+			name:   "contains matching nothing without minContains",
+			schema: `{"contains": false}`,
+			invalid: map[string][]any{
+				"/contains": {[]any{}, []any{1}},
+			},
+		},
+		{
+			name:   "with maxContains",
+			schema: `{"contains": {"type": "string"}, "minContains": 0, "maxContains": 1}`,
+			valid:  []any{[]any{}, []any{1}, []any{"a", 1}},
+			invalid: map[string][]any{
+				"/maxContains": {[]any{"a", "b"}},
+			},
+		},
+		{
+			name:   "with maxContains 0",
+			schema: `{"contains": {"type": "string"}, "minContains": 0, "maxContains": 0}`,
+			valid:  []any{[]any{}, []any{1, 2}},
+			invalid: map[string][]any{
+				"/maxContains": {[]any{"a"}, []any{1, "a"}},
+			},
+		},
+		{
+			name:   "matched items are still evaluated",
+			schema: `{"contains": {"type": "string"}, "minContains": 0, "unevaluatedItems": false}`,
+			valid:  []any{[]any{}, []any{"a", "b"}},
+			invalid: map[string][]any{
+				"/unevaluatedItems": {[]any{1}, []any{"a", 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tt.schema)))
+			v, err := Compile(t.Context(), &s)
+			require.NoError(t, err)
+
+			for _, value := range tt.valid {
+				_, err := v.Validate(t.Context(), value)
+				require.NoError(t, err, "%#v", value)
+			}
+			for location, values := range tt.invalid {
+				for _, value := range values {
+					_, err := v.Validate(t.Context(), value)
+					var verr *Error
+					require.ErrorAs(t, err, &verr, "%#v", value)
+					require.Equal(t, location, verr.KeywordLocation(), "%#v", value)
+				}
+			}
+		})
+	}
+}
+
 // Helper function to create uint pointer
 func uintPtr(v uint) *uint {
 	return &v
@@ -2,7 +2,9 @@ package validator
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"strconv"
+	"strings"
 )
 
 // AllOf is a convnience function to create a Validator that can handle allOf validation.
@@ -29,7 +31,7 @@ type allOfValidator struct {
 }
 
 func (v *allOfValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, in, newEvalState(ctx, options))
+	return validateRoot(ctx, v, in, options)
 }
 
 func (v *allOfValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
@@ -50,25 +52,31 @@ type anyOfValidator struct {
 }
 
 func (v *anyOfValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, in, newEvalState(ctx, options))
+	return validateRoot(ctx, v, in, options)
 }
 
 func (v *anyOfValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
 	var resultMerger resultMerger
 	anyPassed := false
+	var branchErrs []error
 
 	// According to JSON Schema spec, anyOf must collect annotations from ALL passing validators
-	for _, subv := range v.validators {
+	for i, subv := range v.validators {
 		result, err := evalChild(ctx, subv, in, st)
+		if interrupted(err) {
+			return nil, err
+		}
 		if err == nil {
 			anyPassed = true
 			resultMerger.mergeResult(result)
 			// Continue checking other validators to collect all annotations
+		} else if !anyPassed {
+			branchErrs = recordBranchError(branchErrs, len(v.validators), i, err)
 		}
 	}
 
 	if !anyPassed {
-		return nil, fmt.Errorf(`anyOf validation failed: none of the validators passed`)
+		return nil, noBranchPassed("anyOf", branchErrs, st)
 	}
 
 	return resultMerger.FinalResult(), nil
@@ -79,24 +87,79 @@ type oneOfValidator struct {
 }
 
 func (v *oneOfValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, in, newEvalState(ctx, options))
+	return validateRoot(ctx, v, in, options)
 }
 
 func (v *oneOfValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
-	passedCount := 0
+	var passed []int
 	var validResult Result
-	for _, subv := range v.validators {
+	var branchErrs []error
+	for i, subv := range v.validators {
 		result, err := evalChild(ctx, subv, in, st)
+		if interrupted(err) {
+			return nil, err
+		}
 		if err == nil {
-			passedCount++
+			passed = append(passed, i)
 			validResult = result
+		} else if len(passed) == 0 {
+			branchErrs = recordBranchError(branchErrs, len(v.validators), i, err)
 		}
 	}
-	if passedCount == 0 {
-		return nil, fmt.Errorf(`oneOf validation failed: none of the validators passed`)
+	switch len(passed) {
+	case 0:
+		return nil, noBranchPassed("oneOf", branchErrs, st)
+	case 1:
+		return validResult, nil
+	}
+	indices := make([]string, len(passed))
+	for i, n := range passed {
+		indices[i] = strconv.Itoa(n)
+	}
+	return nil, codeErrorf(ErrCodeOneOf, `oneOf validation failed: %d validators passed (%s), expected exactly one`, len(passed), strings.Join(indices, ", "))
+}
+
+// recordBranchError stores err as the failure of branch i out of n. The slice
+// is only allocated once a branch fails, so an anyOf/oneOf whose first branch
+// passes costs nothing.
+func recordBranchError(errs []error, n, i int, err error) []error {
+	if errs == nil {
+		errs = make([]error, n)
+	}
+	errs[i] = err
+	return errs
+}
+
+// noBranchPassed builds the failure of an anyOf/oneOf none of whose branches
+// passed. With WithCollectAllErrors every branch failure is included;
+// otherwise only the one of the branch that came closest to passing.
+func noBranchPassed(keyword string, errs []error, st *evalState) error {
+	if len(errs) == 0 {
+		return codeErrorf(ErrorCode(keyword), `%s validation failed: none of the validators passed`, keyword)
 	}
-	if passedCount > 1 {
-		return nil, fmt.Errorf(`oneOf validation failed: more than one validator passed (%d), expected exactly one`, passedCount)
+	if st.collectAllErrors {
+		return codeErrorf(ErrorCode(keyword), `%s validation failed: none of the validators passed: %w`, keyword, errors.Join(errs...))
+	}
+	i := closestBranch(errs)
+	return codeErrorf(ErrorCode(keyword), `%s validation failed: none of the validators passed, closest was validator %d: %w`, keyword, i, errs[i])
+}
+
+// closestBranch returns the index of the branch failure that came closest to
+// passing. A branch that failed deeper inside the instance got further than
+// one that failed at its root (a type mismatch, say), and among equally deep
+// failures the one with fewer failures is closer. Ties go to the earliest
+// branch.
+func closestBranch(errs []error) int {
+	best, bestDepth, bestCount := 0, -1, 0
+	for i, err := range errs {
+		leaves := flattenUnits(outputTree(err), nil)
+		depth := 0
+		for _, leaf := range leaves {
+			depth = max(depth, strings.Count(leaf.InstanceLocation, "/"))
+		}
+		if depth > bestDepth || (depth == bestDepth && len(leaves) < bestCount) {
+			best, bestDepth, bestCount = i, depth, len(leaves)
+		}
 	}
-	return validResult, nil
+	return best
 }
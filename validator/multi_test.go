@@ -0,0 +1,58 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeFailures(t *testing.T) {
+	compile := func(t *testing.T, src string) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("anyOf reports the closest branch", func(t *testing.T) {
+		v := compile(t, `{"anyOf": [
+			{"type": "string"},
+			{"type": "object", "properties": {"age": {"type": "integer"}}}
+		]}`)
+		_, err := v.Validate(t.Context(), map[string]any{"age": "ten"})
+		require.ErrorContains(t, err, "closest was validator 1")
+
+		var verr *validator.Error
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, "/anyOf/1/properties/age", verr.KeywordLocation())
+		require.Equal(t, "/age", verr.InstanceLocation())
+	})
+
+	t.Run("ties go to the earliest branch", func(t *testing.T) {
+		v := compile(t, `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`)
+		_, err := v.Validate(t.Context(), true)
+		require.ErrorContains(t, err, "closest was validator 0")
+	})
+
+	t.Run("oneOf with no match reports the closest branch", func(t *testing.T) {
+		v := compile(t, `{"oneOf": [
+			{"type": "object", "required": ["data"], "properties": {"status": {"const": "success"}}},
+			{"type": "object", "required": ["error"], "properties": {"status": {"const": "error"}}}
+		]}`)
+		_, err := v.Validate(t.Context(), map[string]any{"status": "pending", "error": "x"})
+		require.ErrorContains(t, err, "oneOf validation failed: none of the validators passed, closest was validator 1")
+	})
+
+	t.Run("oneOf reports every matching branch", func(t *testing.T) {
+		v := compile(t, `{"oneOf": [{"type": "integer"}, {"minimum": 0}, {"type": "string"}]}`)
+		_, err := v.Validate(t.Context(), 5)
+		require.ErrorContains(t, err, "oneOf validation failed: 2 validators passed (0, 1), expected exactly one")
+		_, err = v.Validate(t.Context(), 5.5)
+		require.NoError(t, err)
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"reflect"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/vocabulary"
@@ -15,90 +14,73 @@ import (
 var _ Builder = (*NumberValidatorBuilder)(nil)
 var _ Interface = (*numberValidator)(nil)
 
-func compileNumberValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (Interface, error) {
-	b := Number()
+func compileNumberValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, coerce bool) (Interface, error) {
+	b := Number().Coerce(coerce)
 
 	if s.HasMultipleOf() && vocab.IsKeywordEnabled("multipleOf") {
-		rv := reflect.ValueOf(s.MultipleOf())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
+		tmp, exact, err := numberBound(s.MultipleOfNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for multipleOf field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().multipleOf = exact
+		} else {
 			b.MultipleOf(tmp)
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for multipleOf field: expected numeric type, got %T`, rv.Interface())
 		}
-		b.MultipleOf(tmp)
 	}
 
 	if s.HasMaximum() && vocab.IsKeywordEnabled("maximum") {
-		rv := reflect.ValueOf(s.Maximum())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for maximum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := numberBound(s.MaximumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for maximum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().maximum = exact
+		} else {
+			b.Maximum(tmp)
 		}
-		b.Maximum(tmp)
 	}
 
 	if s.HasExclusiveMaximum() && vocab.IsKeywordEnabled("exclusiveMaximum") {
-		rv := reflect.ValueOf(s.ExclusiveMaximum())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for exclusiveMaximum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := numberBound(s.ExclusiveMaximumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for exclusiveMaximum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().exclusiveMaximum = exact
+		} else {
+			b.ExclusiveMaximum(tmp)
 		}
-		b.ExclusiveMaximum(tmp)
 	}
 
 	if s.HasMinimum() && vocab.IsKeywordEnabled("minimum") {
-		rv := reflect.ValueOf(s.Minimum())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for minimum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := numberBound(s.MinimumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for minimum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().minimum = exact
+		} else {
+			b.Minimum(tmp)
 		}
-		b.Minimum(tmp)
 	}
 
 	if s.HasExclusiveMinimum() && vocab.IsKeywordEnabled("exclusiveMinimum") {
-		rv := reflect.ValueOf(s.ExclusiveMinimum())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for exclusiveMinimum field: expected numeric type, got %T`, rv.Interface())
+		tmp, exact, err := numberBound(s.ExclusiveMinimumNumber())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for exclusiveMinimum field: %w`, err)
+		}
+		if exact != nil {
+			b.wide().exclusiveMinimum = exact
+		} else {
+			b.ExclusiveMinimum(tmp)
 		}
-		b.ExclusiveMinimum(tmp)
 	}
 
 	if s.HasConst() && vocab.IsKeywordEnabled("const") {
-		rv := reflect.ValueOf(s.Const())
-		var tmp float64
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			tmp = float64(rv.Int())
-		case reflect.Float32, reflect.Float64:
-			tmp = rv.Float()
-		default:
-			return nil, fmt.Errorf(`invalid type for constantValue field: expected numeric type, got %T`, rv.Interface())
+		tmp, err := numberConstraint(s.Const())
+		if err != nil {
+			return nil, fmt.Errorf(`invalid type for constantValue field: %w`, err)
 		}
 		b.Const(tmp)
 	}
@@ -106,15 +88,9 @@ func compileNumberValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (
 	if s.HasEnum() && vocab.IsKeywordEnabled("enum") {
 		enums := s.Enum()
 		l := make([]float64, 0, len(enums))
-		for i, e := range s.Enum() {
-			rv := reflect.ValueOf(e)
-			var tmp float64
-			switch rv.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				tmp = float64(rv.Int())
-			case reflect.Float32, reflect.Float64:
-				tmp = rv.Float()
-			default:
+		for i, e := range enums {
+			tmp, err := numberConstraint(e)
+			if err != nil {
 				return nil, fmt.Errorf(`invalid element in enum: expected numeric element, got %T for element %d`, e, i)
 			}
 			l = append(l, tmp)
@@ -132,6 +108,8 @@ type numberValidator struct {
 	exclusiveMinimum *float64
 	constantValue    *float64
 	enum             []float64
+	wide             *wideNumberConstraints
+	coerce           bool
 }
 
 type NumberValidatorBuilder struct {
@@ -200,6 +178,16 @@ func (b *NumberValidatorBuilder) Enum(v ...float64) *NumberValidatorBuilder {
 	return b
 }
 
+// Coerce makes the validator accept a string spelling a JSON number, as
+// WithCoercion does for compiled schemas.
+func (b *NumberValidatorBuilder) Coerce(v bool) *NumberValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.coerce = v
+	return b
+}
+
 func (b *NumberValidatorBuilder) Build() (Interface, error) {
 	if b.err != nil {
 		return nil, b.err
@@ -220,54 +208,78 @@ func (b *NumberValidatorBuilder) Reset() *NumberValidatorBuilder {
 	return b
 }
 
-func (v *numberValidator) Validate(_ context.Context, in any, _ ...ValidateOption) (Result, error) {
+func (v *numberValidator) Validate(_ context.Context, in any, options ...ValidateOption) (Result, error) {
+	in, err := leafValue(in, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
+	res, err := v.check(in)
+	return res, leafError(err)
+}
+
+func (v *numberValidator) check(in any) (Result, error) {
+	var coerced bool
+	if v.coerce {
+		if s, ok := coercibleString(in); ok {
+			num, ok := coerceNumberString(s)
+			if !ok {
+				return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: cannot coerce %q to number`, s)
+			}
+			in = num
+			coerced = true
+		}
+	}
 	n, ok, err := numericFloat(in)
 	if err != nil {
-		return nil, fmt.Errorf(`invalid value passed to NumberValidator: %w`, err)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: %w`, err)
 	}
 	if !ok {
-		return nil, fmt.Errorf(`invalid value passed to NumberValidator: expected number, got %T`, in)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: expected number, got %T`, in)
 	}
 
 	// Reject NaN but allow infinity
 	if math.IsNaN(n) {
-		return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is not a valid number (NaN)`)
+		return nil, codeErrorf(ErrCodeType, `invalid value passed to NumberValidator: value is not a valid number (NaN)`)
+	}
+	if v.wide != nil {
+		if err := v.checkWide(in, n); err != nil {
+			return nil, err
+		}
 	}
 
 	if m := v.maximum; m != nil {
 		if n > *m {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is greater than maximum %f`, *m)
+			return nil, &NumericError{Keyword: "maximum", Limit: *m, Value: n, class: "Number"}
 		}
 	}
 
 	if em := v.exclusiveMaximum; em != nil {
 		if n >= *em {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is greater than or equal to exclusiveMaximum %f`, *em)
+			return nil, &NumericError{Keyword: "exclusiveMaximum", Limit: *em, Value: n, class: "Number"}
 		}
 	}
 
 	if m := v.minimum; m != nil {
 		if n < *m {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is less than minimum %f`, *m)
+			return nil, &NumericError{Keyword: "minimum", Limit: *m, Value: n, class: "Number"}
 		}
 	}
 
 	if em := v.exclusiveMinimum; em != nil {
 		if n <= *em {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is less than or equal to exclusiveMinimum %f`, *em)
+			return nil, &NumericError{Keyword: "exclusiveMinimum", Limit: *em, Value: n, class: "Number"}
 		}
 	}
 
 	if mo := v.multipleOf; mo != nil {
-		remainder := math.Mod(n, *mo)
-		if math.Abs(remainder) > 1e-9 && math.Abs(remainder-*mo) > 1e-9 {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value is not multiple of %f`, *mo)
+		if !isMultipleOf(in, *mo) {
+			return nil, &NumericError{Keyword: "multipleOf", Limit: *mo, Value: n, class: "Number"}
 		}
 	}
 
 	if c := v.constantValue; c != nil {
 		if *c != n {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value must be const value %f`, *c)
+			return nil, codeErrorf(ErrCodeConst, `invalid value passed to NumberValidator: value must be const value %f`, *c)
 		}
 	}
 
@@ -280,8 +292,11 @@ func (v *numberValidator) Validate(_ context.Context, in any, _ ...ValidateOptio
 			}
 		}
 		if !found {
-			return nil, fmt.Errorf(`invalid value passed to NumberValidator: value not found in enum`)
+			return nil, codeErrorf(ErrCodeEnum, `invalid value passed to NumberValidator: value not found in enum`)
 		}
 	}
+	if coerced {
+		return &coercedResult{value: n}, nil
+	}
 	return nil, nil
 }
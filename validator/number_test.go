@@ -702,6 +702,50 @@ func TestNumberValidatorComprehensive(t *testing.T) {
 				multipleOf: 0.25,
 				wantErr:    true,
 			},
+			{
+				name:       "0.3 is a multiple of 0.1",
+				value:      0.3,
+				multipleOf: 0.1,
+			},
+			{
+				name:       "price in cents",
+				value:      19.99,
+				multipleOf: 0.01,
+			},
+			{
+				name:       "fraction of a cent",
+				value:      19.995,
+				multipleOf: 0.01,
+				wantErr:    true,
+			},
+			{
+				name:       "float sum is not the decimal it approximates",
+				value:      0.30000000000000004, // 0.1 + 0.2 in float64
+				multipleOf: 0.1,
+				wantErr:    true,
+			},
+			{
+				name:       "below the old tolerance",
+				value:      1.0000000001,
+				multipleOf: 1,
+				wantErr:    true,
+			},
+			{
+				name:       "large exponent",
+				value:      1e308,
+				multipleOf: 0.1,
+			},
+			{
+				name:       "small exponent",
+				value:      3e-300,
+				multipleOf: 1e-300,
+			},
+			{
+				name:       "small exponent not multiple",
+				value:      3.5e-300,
+				multipleOf: 1e-300,
+				wantErr:    true,
+			},
 		}
 
 		for _, tc := range testCases {
@@ -728,6 +772,34 @@ func TestNumberValidatorComprehensive(t *testing.T) {
 		}
 	})
 
+	t.Run("Number MultipleOf json.Number", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			Types(schema.NumberType).
+			MultipleOf(0.01).
+			Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(context.Background(), s)
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			data    string
+			wantErr bool
+		}{
+			{data: "19.99"},
+			{data: "1999e-2"},
+			{data: "1e300"},
+			{data: "19.9900000000000001", wantErr: true},
+			{data: "0.001", wantErr: true},
+		} {
+			_, err := validator.ValidateJSON(context.Background(), v, []byte(tc.data))
+			if tc.wantErr {
+				require.Error(t, err, tc.data)
+			} else {
+				require.NoError(t, err, tc.data)
+			}
+		}
+	})
+
 	t.Run("Combined Number Constraints", func(t *testing.T) {
 		testCases := []struct {
 			name    string
@@ -799,6 +871,80 @@ func TestNumberValidatorComprehensive(t *testing.T) {
 	})
 }
 
+func TestNumberBoundsBeyondFloat64(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Schema  string
+		Valid   []string
+		Invalid []string
+	}{
+		{
+			Name:    "maximum",
+			Schema:  `{"type": "number", "maximum": 18446744073709551615}`,
+			Valid:   []string{`18446744073709551615`, `18446744073709551614.5`},
+			Invalid: []string{`18446744073709551616`, `18446744073709551615.5`},
+		},
+		{
+			Name:    "exclusiveMaximum",
+			Schema:  `{"type": "number", "exclusiveMaximum": 18446744073709551615}`,
+			Valid:   []string{`18446744073709551614`},
+			Invalid: []string{`18446744073709551615`},
+		},
+		{
+			Name:    "minimum",
+			Schema:  `{"type": "number", "minimum": 0.30000000000000001}`,
+			Valid:   []string{`0.30000000000000001`, `1`},
+			Invalid: []string{`0.3`},
+		},
+		{
+			Name:    "exclusiveMinimum",
+			Schema:  `{"type": "number", "exclusiveMinimum": -18446744073709551615}`,
+			Valid:   []string{`-18446744073709551614`},
+			Invalid: []string{`-18446744073709551615`},
+		},
+		{
+			Name:    "multipleOf",
+			Schema:  `{"type": "number", "multipleOf": 18446744073709551615}`,
+			Valid:   []string{`36893488147419103230`, `0`},
+			Invalid: []string{`18446744073709551616`},
+		},
+		{
+			Name:    "bounds float64 holds",
+			Schema:  `{"type": "number", "minimum": 0.1, "maximum": 1e21}`,
+			Valid:   []string{`0.1`, `1e21`},
+			Invalid: []string{`0.09`, `1e22`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tc.Schema)))
+			v, err := validator.Compile(t.Context(), &s)
+			require.NoError(t, err)
+			for _, in := range tc.Valid {
+				_, err := validator.ValidateJSON(t.Context(), v, []byte(in))
+				require.NoError(t, err, "%s should be valid", in)
+			}
+			for _, in := range tc.Invalid {
+				_, err := validator.ValidateJSON(t.Context(), v, []byte(in))
+				require.Error(t, err, "%s should be invalid", in)
+			}
+		})
+	}
+
+	t.Run("error reports the exact limit", func(t *testing.T) {
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"type": "number", "maximum": 18446744073709551615}`)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		_, err = validator.ValidateJSON(t.Context(), v, []byte(`18446744073709551616`))
+		require.ErrorContains(t, err, `value is greater than maximum 18446744073709551615`)
+
+		_, err = v.Validate(t.Context(), math.Inf(1))
+		require.Error(t, err, "+Inf exceeds every maximum")
+	})
+}
+
 // Helper function to create float64 pointers
 func float64Ptr(f float64) *float64 {
 	return &f
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // The helpers in this file are the single place that decides whether an
@@ -73,22 +76,29 @@ func numericFloat(v any) (float64, bool, error) {
 //   - ok=true, isInt=false:  numeric but not an integer (e.g. 5.5); n is unset
 //   - ok=true, isInt=true:   n holds the integer value
 //
-// A non-nil err means v is an integer-valued number outside the int64 range
-// (±9.2e18); such values cannot be validated as integers and are reported
-// rather than silently truncated.
+// A non-nil err means v is a number outside the int64 range (±9.2e18), or a
+// json.Number whose text cannot be parsed. The integer validator then falls
+// back to exact comparison (see checkWide) rather than truncating the value.
 func numericInt(v any) (int64, bool, bool, error) {
 	if num, ok := v.(json.Number); ok {
 		if i, err := num.Int64(); err == nil {
 			return i, true, true, nil
 		}
-		// Int64 rejects exponent forms (e.g. "1e2") and out-of-range values.
-		// Fall back to Float64 to tell an integral value apart from a fractional
-		// one, and from one that simply does not fit in int64.
-		f, err := num.Float64()
-		if err != nil {
-			return 0, true, false, err
+		// Int64 rejects exponent and fraction forms (e.g. "1e2", "5.0") as well
+		// as out-of-range values. Parse the text exactly to tell an integral
+		// value apart from a fractional one, and from one that does not fit in
+		// int64; going through float64 would round 9007199254740993.0.
+		r, ok := exactNumeric(num)
+		if !ok {
+			return 0, true, false, fmt.Errorf("invalid number %s", num)
+		}
+		if !r.IsInt() {
+			return 0, true, false, nil
+		}
+		if !r.Num().IsInt64() {
+			return 0, true, false, fmt.Errorf("integer value %s out of int64 range", num)
 		}
-		return integralFloatToInt64(f, num.String())
+		return r.Num().Int64(), true, true, nil
 	}
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
@@ -101,25 +111,213 @@ func numericInt(v any) (int64, bool, bool, error) {
 		}
 		return int64(u), true, true, nil
 	case reflect.Float32, reflect.Float64:
-		return integralFloatToInt64(rv.Float(), "")
+		return integralFloatToInt64(rv.Float())
 	default:
 		return 0, false, false, nil
 	}
 }
 
+// integerLiteral reports whether the numeric value v is written as an
+// integer, without a fraction or an exponent, as WithStrictInteger requires.
+// A float is not: it has no record of how the number was written.
+func integerLiteral(v any) bool {
+	if num, ok := v.(json.Number); ok {
+		return !strings.ContainsAny(string(num), ".eE")
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return false
+	default:
+		return true
+	}
+}
+
 // integralFloatToInt64 reports whether f is an integer value and, if so, returns
-// it as int64. text, when non-empty, is the original json.Number text used for a
-// precise out-of-range error message.
-func integralFloatToInt64(f float64, text string) (int64, bool, bool, error) {
+// it as int64.
+func integralFloatToInt64(f float64) (int64, bool, bool, error) {
 	if f != math.Trunc(f) {
 		return 0, true, false, nil // fractional: numeric but not an integer
 	}
 	// float64(math.MaxInt64) rounds up to 2^63, so the upper bound is exclusive.
 	if f < math.MinInt64 || f >= math.MaxInt64 {
-		if text == "" {
-			return 0, true, false, fmt.Errorf("integer value %v out of int64 range", f)
-		}
-		return 0, true, false, fmt.Errorf("integer value %s out of int64 range", text)
+		return 0, true, false, fmt.Errorf("integer value %v out of int64 range", f)
 	}
 	return int64(f), true, true, nil
 }
+
+// isMultipleOf reports whether the numeric value v is an integral multiple of
+// mo.
+//
+// Floating point remainders cannot answer this: 0.3 is not a multiple of 0.1
+// in binary, and any tolerance lets some wrong answers through. Instead both
+// operands are taken as the decimal numbers they are written as (see
+// exactNumeric) and divided exactly.
+func isMultipleOf(v any, mo float64) bool {
+	x, ok := exactNumeric(v)
+	if !ok {
+		return false
+	}
+	d, ok := exactNumeric(mo)
+	if !ok || d.Sign() == 0 {
+		return false
+	}
+	return x.Quo(x, d).IsInt()
+}
+
+// maxDecimalExponent bounds the exponent of json.Number text that exactNumeric
+// expands. It is beyond the float64 range, so it only turns away numbers no
+// float64 could hold either, whose exact value could take arbitrarily much
+// memory.
+const maxDecimalExponent = 400
+
+// exactNumeric returns the numeric value v as an exact rational: a json.Number
+// as the decimal its text spells, an integer kind exactly, and a float as the
+// shortest decimal that round-trips to it, so that 0.1 is 1/10 rather than the
+// binary fraction nearest to it. ok is false for non-numeric values, NaN, the
+// infinities, and json.Number text that is malformed or has an exponent beyond
+// maxDecimalExponent.
+func exactNumeric(v any) (*big.Rat, bool) {
+	if n, ok := v.(json.Number); ok {
+		text := n.String()
+		if i := strings.IndexAny(text, "eE"); i >= 0 {
+			exp, err := strconv.Atoi(text[i+1:])
+			if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+				return nil, false
+			}
+		}
+		return new(big.Rat).SetString(text)
+	}
+	rv := reflect.ValueOf(v)
+	bits := 64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(rv.Uint()), true
+	case reflect.Float32:
+		bits = 32
+	case reflect.Float64:
+	default:
+		return nil, false
+	}
+	f := rv.Float()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bits))
+}
+
+// impreciseFloat reports whether v is a float beyond the range in which its
+// type holds every integer: 2^53 for float64, 2^24 for float32. An integer
+// decoded into such a float may have been rounded to a neighbor.
+func impreciseFloat(v any) bool {
+	switch f := v.(type) {
+	case float64:
+		return math.Abs(f) > 1<<53
+	case float32:
+		return math.Abs(float64(f)) > 1<<24
+	default:
+		return false
+	}
+}
+
+// numberConstraint converts the value of a numeric keyword of the schema, such
+// as maximum or an enum element, to the float64 the number validator compares
+// with.
+func numberConstraint(v any) (float64, error) {
+	f, ok, err := numericFloat(v)
+	if !ok {
+		return 0, fmt.Errorf(`expected numeric type, got %T`, v)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return f, nil
+}
+
+// coercibleString reports whether v is a plain string that WithCoercion may
+// convert. json.Number is excluded: it already is a number.
+func coercibleString(v any) (string, bool) {
+	if isJSONNumber(v) {
+		return "", false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+	return rv.String(), true
+}
+
+// coerceNumberString parses s as a JSON number literal. Anything json.Unmarshal
+// would not decode as a number, including surrounding whitespace, is rejected.
+func coerceNumberString(s string) (json.Number, bool) {
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return "", false
+	}
+	var num json.Number
+	if err := json.Unmarshal([]byte(s), &num); err != nil || num.String() != s {
+		return "", false
+	}
+	return num, true
+}
+
+// NumericError reports an integer or number value outside one of the numeric
+// bounds of its schema: "maximum", "exclusiveMaximum", "minimum",
+// "exclusiveMinimum" or "multipleOf". Validate returns it wrapped in an *Error,
+// so it is retrieved with errors.As:
+//
+//	var nerr *validator.NumericError
+//	if errors.As(err, &nerr) {
+//		fmt.Println(nerr.Keyword, nerr.Limit, nerr.Value) // maximum 100 150
+//	}
+type NumericError struct {
+	// Keyword is the keyword the value failed, such as "maximum".
+	Keyword string
+	// Limit is the value of the keyword, and Value the value validated (after
+	// coercion, with WithCoercion). Both are int64 for an integer schema and
+	// float64 for a number schema, except that they are *big.Rat where an
+	// integer schema compares them beyond int64, or a number schema compares
+	// them with a limit that float64 does not hold exactly.
+	Limit any
+	Value any
+
+	class string // "Integer" or "Number", naming the validator in the message
+}
+
+func (e *NumericError) Error() string {
+	var limit string
+	switch l := e.Limit.(type) {
+	case *big.Rat:
+		limit = l.RatString()
+	case float64:
+		limit = fmt.Sprintf("%f", l)
+	default:
+		limit = fmt.Sprint(l)
+	}
+
+	var violation string
+	switch e.Keyword {
+	case "maximum":
+		violation = "value is greater than maximum"
+	case "exclusiveMaximum":
+		violation = "value is greater than or equal to exclusiveMaximum"
+	case "minimum":
+		violation = "value is less than minimum"
+	case "exclusiveMinimum":
+		violation = "value is less than or equal to exclusiveMinimum"
+	case "multipleOf":
+		violation = "value is not multiple of"
+	default:
+		violation = "value does not satisfy " + e.Keyword
+	}
+	return fmt.Sprintf(`invalid value passed to %sValidator: %s %s`, e.class, violation, limit)
+}
+
+func (e *NumericError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.errorCode()
+}
+
+func (e *NumericError) errorCode() ErrorCode {
+	return ErrorCode(e.Keyword)
+}
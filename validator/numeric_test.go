@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -149,3 +152,65 @@ func TestNumericValidatorsAcceptJSONNumber(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestNumericError(t *testing.T) {
+	belowInt64, _ := new(big.Rat).SetString("-9223372036854775809")
+	testCases := []struct {
+		name    string
+		v       Interface
+		in      any
+		keyword string
+		limit   any
+		value   any
+		message string
+	}{
+		{
+			name:    "integer maximum",
+			v:       Integer().Maximum(100).MustBuild(),
+			in:      150,
+			keyword: "maximum",
+			limit:   int64(100),
+			value:   int64(150),
+			message: "invalid value passed to IntegerValidator: value is greater than maximum 100",
+		},
+		{
+			name:    "number exclusiveMinimum",
+			v:       Number().ExclusiveMinimum(0).MustBuild(),
+			in:      json.Number("0"),
+			keyword: "exclusiveMinimum",
+			limit:   float64(0),
+			value:   float64(0),
+			message: "invalid value passed to NumberValidator: value is less than or equal to exclusiveMinimum 0.000000",
+		},
+		{
+			name:    "coerced multipleOf",
+			v:       Integer().MultipleOf(5).Coerce(true).MustBuild(),
+			in:      "12",
+			keyword: "multipleOf",
+			limit:   int64(5),
+			value:   int64(12),
+			message: "invalid value passed to IntegerValidator: value is not multiple of 5",
+		},
+		{
+			name:    "integer beyond int64",
+			v:       Integer().Minimum(math.MaxInt64).MustBuild(),
+			in:      json.Number("-9223372036854775809"),
+			keyword: "minimum",
+			limit:   new(big.Rat).SetInt64(math.MaxInt64),
+			value:   belowInt64,
+			message: "invalid value passed to IntegerValidator: value is less than minimum 9223372036854775807",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.v.Validate(context.Background(), tc.in)
+			var nerr *NumericError
+			require.True(t, errors.As(err, &nerr), "expected a *NumericError, got %v", err)
+			require.Equal(t, tc.keyword, nerr.Keyword)
+			require.Equal(t, tc.limit, nerr.Limit)
+			require.Equal(t, tc.value, nerr.Value)
+			require.Equal(t, tc.message, nerr.Error())
+			require.EqualError(t, err, tc.message)
+		})
+	}
+}
@@ -2,13 +2,17 @@ package validator
 
 import (
 	"context"
+	"encoding"
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	schema "github.com/lestrrat-go/json-schema"
-	"github.com/lestrrat-go/json-schema/internal/schemactx"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 var _ Builder = (*ObjectValidatorBuilder)(nil)
@@ -52,9 +56,9 @@ func compileObjectValidator(ctx context.Context, s *schema.Schema, cs compileSta
 	if s.HasPatternProperties() {
 		patternProperties := make(map[*regexp.Regexp]Interface)
 		for pattern, propSchema := range s.PatternProperties() {
-			re, err := regexp.Compile(pattern)
+			re, err := compilePattern(pattern, cs.cfg.ecmaRegex)
 			if err != nil {
-				return nil, fmt.Errorf("failed to compile pattern %s: %w", pattern, err)
+				return nil, fmt.Errorf("failed to compile patternProperties: %w", err)
 			}
 			propValidator, err := compile(ctx, propSchema, cs)
 			if err != nil {
@@ -64,25 +68,14 @@ func compileObjectValidator(ctx context.Context, s *schema.Schema, cs compileSta
 		}
 		v.PatternProperties(patternProperties)
 	}
-	if s.HasAdditionalProperties() {
-		additionalProps := s.AdditionalProperties()
-		if additionalProps != nil {
-			// Handle SchemaOrBool types
-			switch val := additionalProps.(type) {
-			case schema.BoolSchema:
-				// This is a boolean value
-				v.AdditionalProperties(bool(val))
-			case *schema.Schema:
-				// This is a regular schema - validate additional properties with this schema
-				propValidator, err := compile(ctx, val, cs)
-				if err != nil {
-					return nil, fmt.Errorf("failed to compile additional properties validator: %w", err)
-				}
-				v.AdditionalProperties(propValidator)
-			default:
-				return nil, fmt.Errorf("unexpected additionalProperties type: %T", additionalProps)
-			}
+	if b, ok := s.AdditionalPropertiesAsBool(); ok {
+		v.AdditionalProperties(b)
+	} else if sub, ok := s.AdditionalPropertiesAsSchema(); ok {
+		propValidator, err := compile(ctx, sub, cs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile additional properties validator: %w", err)
 		}
+		v.AdditionalProperties(propValidator)
 	}
 	if s.HasPropertyNames() {
 		propertyNamesSchema := s.PropertyNames()
@@ -94,25 +87,14 @@ func compileObjectValidator(ctx context.Context, s *schema.Schema, cs compileSta
 			v.PropertyNames(propertyNamesValidator)
 		}
 	}
-	if s.HasUnevaluatedProperties() {
-		unevaluatedProps := s.UnevaluatedProperties()
-		if unevaluatedProps != nil {
-			// Handle SchemaOrBool types
-			switch val := unevaluatedProps.(type) {
-			case schema.BoolSchema:
-				// This is a boolean value
-				v.UnevaluatedProperties(bool(val))
-			case *schema.Schema:
-				// This is a regular schema - validate unevaluated properties with this schema
-				propValidator, err := compile(ctx, val, cs)
-				if err != nil {
-					return nil, fmt.Errorf("failed to compile unevaluated properties validator: %w", err)
-				}
-				v.UnevaluatedProperties(propValidator)
-			default:
-				return nil, fmt.Errorf("unexpected unevaluatedProperties type: %T", unevaluatedProps)
-			}
+	if b, ok := s.UnevaluatedPropertiesAsBool(); ok {
+		v.UnevaluatedProperties(b)
+	} else if sub, ok := s.UnevaluatedPropertiesAsSchema(); ok {
+		propValidator, err := compile(ctx, sub, cs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile unevaluated properties validator: %w", err)
 		}
+		v.UnevaluatedProperties(propValidator)
 	}
 
 	v.StrictObjectType(strictType)
@@ -289,8 +271,8 @@ func (b *ObjectValidatorBuilder) Reset() *ObjectValidatorBuilder {
 
 // extractObjectProperties reads v as a JSON object into a name->value map. It
 // honors a custom ObjectFieldResolver first, then handles map and struct
-// instances (using the JSON tag's name, ignoring options like ",omitempty").
-// The bool reports whether v is object-like at all.
+// instances (see structProperties). The bool reports whether v is object-like
+// at all.
 func extractObjectProperties(v any) (map[string]any, bool, error) {
 	// Fast path for the standard JSON-decoded shape: return the map directly
 	// instead of reflectively rebuilding it. Callers treat the result as
@@ -321,42 +303,198 @@ func extractObjectProperties(v any) (map[string]any, bool, error) {
 	case reflect.Map:
 		props := make(map[string]any)
 		for _, key := range rv.MapKeys() {
-			props[key.String()] = rv.MapIndex(key).Interface()
+			name, err := mapKeyName(key)
+			if err != nil {
+				return nil, true, err
+			}
+			props[name] = rv.MapIndex(key).Interface()
 		}
 		return props, true, nil
 	case reflect.Struct:
 		props := make(map[string]any)
-		t := rv.Type()
-		for i := range rv.NumField() {
-			field := t.Field(i)
-			if !field.IsExported() {
+		structProperties(rv, props)
+		return props, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// mapKeyName returns the property name encoding/json gives the map key key:
+// a string as is, an encoding.TextMarshaler as its text, and an integer in
+// decimal. Other keys have no name in JSON, and are an error.
+func mapKeyName(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if tm, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal map key %v: %w", key.Interface(), err)
+		}
+		return string(text), nil
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("invalid value passed to ObjectValidator: map key %v of type %s cannot be a property name", key.Interface(), key.Type())
+	}
+}
+
+// structProperties adds the fields of the struct rv to props under the names
+// encoding/json would give them: the name of the json tag, or the field name.
+// Fields tagged json:"-" and unexported fields are left out, and so are
+// fields that encoding/json would omit under ",omitempty" or ",omitzero", so
+// that a typed value validates the same as its JSON encoding; pointer fields
+// hold the value they point to, or nil. The fields of embedded structs are
+// promoted, with the outer struct's fields shadowing them. As with
+// encoding/json, that includes embedded structs of unexported types, held by
+// value or through a pointer; only the fields of a nil embedded pointer are
+// left out.
+func structProperties(rv reflect.Value, props map[string]any) {
+	t := rv.Type()
+	var embedded []reflect.Value
+	for i := range rv.NumField() {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue // json:"-" excludes the field
+		}
+		fieldName, opts, _ := strings.Cut(jsonTag, ",")
+		fv := rv.Field(i)
+		if field.Anonymous && fieldName == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
 				continue
 			}
-			jsonTag := field.Tag.Get("json")
-			if jsonTag == "-" {
-				continue // json:"-" excludes the field
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+		if _, shadowed := props[fieldName]; shadowed {
+			continue
+		}
+		if !fv.CanInterface() || omitField(fv, opts) {
+			continue // omitted
+		}
+		props[fieldName] = fieldValue(fv)
+	}
+	for _, fv := range embedded {
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue // encoding/json skips the fields of a nil embedded pointer
+			}
+			fv = fv.Elem()
+		}
+		structProperties(fv, props)
+	}
+}
+
+// fieldValue returns the value of a struct field as encoding/json sees it:
+// pointers are followed, and a nil pointer or interface is null.
+func fieldValue(fv reflect.Value) any {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	return fv.Interface()
+}
+
+// omitField reports whether encoding/json leaves out the field value fv given
+// the options of its json tag.
+func omitField(fv reflect.Value, opts string) bool {
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "omitempty":
+			switch fv.Kind() {
+			case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+				if fv.Len() == 0 {
+					return true
+				}
+			case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64, reflect.Interface, reflect.Ptr:
+				if fv.IsZero() {
+					return true
+				}
+			}
+		case "omitzero":
+			if (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && fv.IsNil() {
+				return true
 			}
-			fieldName := field.Name
-			if tagName, _, _ := strings.Cut(jsonTag, ","); tagName != "" {
-				fieldName = tagName
+			if z, ok := fv.Interface().(interface{ IsZero() bool }); ok {
+				if z.IsZero() {
+					return true
+				}
+			} else if fv.IsZero() {
+				return true
 			}
-			props[fieldName] = rv.Field(i).Interface()
 		}
-		return props, true, nil
-	default:
-		return nil, false, nil
 	}
+	return false
+}
+
+// objectScratch holds the buffers an object validator needs only while it
+// evaluates one object. They are pooled so that validating an object does not
+// allocate them every time.
+type objectScratch struct {
+	names       []string
+	unevaluated []string
+}
+
+// maxPooledNames bounds the buffers returned to the pool, so that one huge
+// object does not keep its buffers alive for every later evaluation.
+const maxPooledNames = 1024
+
+var objectScratchPool = sync.Pool{
+	New: func() any { return &objectScratch{} },
+}
+
+// propertyNames lists the names of properties in evaluation order (see
+// evaluationOrder).
+func (sc *objectScratch) propertyNames(properties map[string]any, st *evalState) []string {
+	names := sc.names[:0]
+	for name := range properties {
+		names = append(names, name)
+	}
+	if st.collectAllErrors {
+		slices.Sort(names)
+	}
+	sc.names = names
+	return names
+}
+
+func (sc *objectScratch) release() {
+	if cap(sc.names) > maxPooledNames || cap(sc.unevaluated) > maxPooledNames {
+		return
+	}
+	clear(sc.names)
+	clear(sc.unevaluated)
+	sc.names = sc.names[:0]
+	sc.unevaluated = sc.unevaluated[:0]
+	objectScratchPool.Put(sc)
 }
 
 // Validate implements the Interface
 func (c *objectValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return c.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, c, v, options)
 }
 
 func (c *objectValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
-	// Annotations from sibling applicators flow in via returned Results, not here;
-	// this starts from an empty evaluated-property set.
-	var ec schemactx.EvaluationContext
 	properties, isObject, err := extractObjectProperties(v)
 	if err != nil {
 		return nil, fmt.Errorf(`invalid value passed to ObjectValidator: %w`, err)
@@ -365,7 +503,7 @@ func (c *objectValidator) evaluate(ctx context.Context, v any, st *evalState) (R
 		// Handle non-object values based on whether this is strict object type validation
 		if c.strictObjectType {
 			// When schema explicitly declares type: object, non-object values should fail
-			return nil, fmt.Errorf(`invalid value passed to ObjectValidator: expected map or a struct, got %T`, v)
+			return nil, codeErrorf(ErrCodeType, `invalid value passed to ObjectValidator: expected map or a struct, got %T`, v)
 		}
 		// For non-object values with inferred object type, object constraints don't apply
 		// According to JSON Schema spec, object constraints should be ignored for non-objects
@@ -373,134 +511,174 @@ func (c *objectValidator) evaluate(ctx context.Context, v any, st *evalState) (R
 		return nil, nil
 	}
 
+	failures := newFailureCollector(st)
+	scratch := objectScratchPool.Get().(*objectScratch)
+	defer scratch.release()
+	names := scratch.propertyNames(properties, st)
+
 	// Check minProperties constraint
 	if c.minProperties != nil && uint(len(properties)) < *c.minProperties {
-		return nil, fmt.Errorf(`invalid value passed to ObjectValidator: object has %d properties, below minimum properties %d`, len(properties), *c.minProperties)
+		if failures.add(atLocation(codeErrorf(ErrCodeMinProperties, `invalid value passed to ObjectValidator: object has %d properties, below minimum properties %d`, len(properties), *c.minProperties), jsonPointer(keywords.MinProperties), "")) {
+			return nil, failures.err()
+		}
 	}
 
 	// Check maxProperties constraint
 	if c.maxProperties != nil && uint(len(properties)) > *c.maxProperties {
-		return nil, fmt.Errorf(`invalid value passed to ObjectValidator: object has %d properties, exceeds maximum properties %d`, len(properties), *c.maxProperties)
+		if failures.add(atLocation(codeErrorf(ErrCodeMaxProperties, `invalid value passed to ObjectValidator: object has %d properties, exceeds maximum properties %d`, len(properties), *c.maxProperties), jsonPointer(keywords.MaxProperties), "")) {
+			return nil, failures.err()
+		}
 	}
 
 	// Check required properties
-	for _, requiredProp := range c.required {
-		if _, exists := properties[requiredProp]; !exists {
-			return nil, fmt.Errorf(`invalid value passed to ObjectValidator: required property %s is missing`, requiredProp)
+	if len(c.required) > 0 {
+		mark := st.beginKeyword(keywords.Required)
+		for _, requiredProp := range c.required {
+			if _, exists := properties[requiredProp]; !exists {
+				if failures.add(atLocation(codeErrorf(ErrCodeRequired, `invalid value passed to ObjectValidator: required property %s is missing`, requiredProp), jsonPointer(keywords.Required), "")) {
+					return nil, failures.err()
+				}
+			}
 		}
+		st.endKeyword(mark)
 	}
 
 	// Check dependent required properties
-	for triggerProp, dependentProps := range c.dependentRequired {
-		if _, exists := properties[triggerProp]; exists {
-			// If the trigger property is present, all dependent properties must be present
-			for _, dependentProp := range dependentProps {
-				if _, exists := properties[dependentProp]; !exists {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: dependent required property %s is missing when %s is present`, dependentProp, triggerProp)
+	if len(c.dependentRequired) > 0 {
+		mark := st.beginKeyword(keywords.DependentRequired)
+		// Walking the present names visits the triggers in evaluation order
+		// without collecting the keys of dependentRequired.
+		for _, triggerProp := range names {
+			if dependents, exists := c.dependentRequired[triggerProp]; exists {
+				// If the trigger property is present, all dependent properties must be present
+				for _, dependentProp := range dependents {
+					if _, exists := properties[dependentProp]; !exists {
+						if failures.add(atLocation(codeErrorf(ErrCodeDependentRequired, `invalid value passed to ObjectValidator: dependent required property %s is missing when %s is present`, dependentProp, triggerProp), jsonPointer(keywords.DependentRequired), "")) {
+							return nil, failures.err()
+						}
+					}
 				}
 			}
 		}
+		st.endKeyword(mark)
 	}
 
 	// Validate property names
 	if c.propertyNames != nil {
-		for propName := range properties {
-			_, err := evalChild(ctx, c.propertyNames, propName, st)
+		mark := st.beginKeyword(keywords.PropertyNames)
+		for _, propName := range names {
+			_, err := evalDetached(ctx, c.propertyNames, propName, st)
 			if err != nil {
-				return nil, fmt.Errorf(`invalid value passed to ObjectValidator: property name validation failed for %s: %w`, propName, err)
+				// The name is part of the located error, since the instance
+				// location of a property name is the object itself.
+				err = fmt.Errorf(`property name validation failed for %q: %w`, propName, err)
+				if failures.add(fmt.Errorf(`invalid value passed to ObjectValidator: %w`, atLocation(err, jsonPointer(keywords.PropertyNames), ""))) {
+					return nil, failures.err()
+				}
 			}
 		}
+		st.endKeyword(mark)
 	}
 
-	// Track evaluated properties for result reporting
-	var evaluatedProperties schemactx.EvaluatedProperties
-
-	// Include previously evaluated properties from earlier validators (e.g., allOf subschemas)
-	for _, prop := range ec.Properties.Keys() {
-		evaluatedProperties.MarkEvaluated(prop)
-	}
+	// Evaluated properties are recorded straight into the result. Annotations
+	// from sibling applicators flow in via their own Results, not here.
+	result := newObjectResult(len(properties))
 
 	// Validate properties
-	var unevaluatedProps []string
-	for propName, propValue := range properties {
+	unevaluatedProps := scratch.unevaluated
+	for _, propName := range names {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
+		propValue := properties[propName]
 		validated := false
 
-		// Check if this property was already evaluated by a previous validator
-		if ec.Properties.IsEvaluated(propName) {
-			evaluatedProperties.MarkEvaluated(propName)
-			validated = true
-		}
-
 		// Check explicit properties
 		if c.properties != nil {
+			mark := st.beginKeyword(keywords.Properties)
 			if propValidator, exists := c.properties[propName]; exists {
-				_, err := evalChild(ctx, propValidator, propValue, st)
+				_, err := evalProperty(ctx, propValidator, propName, propValue, st)
 				if err != nil {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: property validation failed for %s: %w`, propName, err)
+					if failures.add(fmt.Errorf(`invalid value passed to ObjectValidator: property validation failed for %s: %w`, propName, atLocation(err, jsonPointer(keywords.Properties, propName), jsonPointer(propName)))) {
+						return nil, failures.err()
+					}
 				}
 				validated = true
-				evaluatedProperties.MarkEvaluated(propName)
+				result.SetEvaluatedProperty(propName)
 			}
+			st.endKeyword(mark)
 		}
 
 		// Check pattern properties
 		if c.patternProperties != nil {
+			mark := st.beginKeyword(keywords.PatternProperties)
 			for pattern, propValidator := range c.patternProperties {
 				if pattern.MatchString(propName) {
-					_, err := evalChild(ctx, propValidator, propValue, st)
+					_, err := evalProperty(ctx, propValidator, propName, propValue, st)
 					if err != nil {
-						return nil, fmt.Errorf(`invalid value passed to ObjectValidator: pattern property validation failed for %s: %w`, propName, err)
+						if failures.add(fmt.Errorf(`invalid value passed to ObjectValidator: pattern property validation failed for %s: %w`, propName, atLocation(err, jsonPointer(keywords.PatternProperties, pattern.String()), jsonPointer(propName)))) {
+							return nil, failures.err()
+						}
 					}
 					validated = true
-					evaluatedProperties.MarkEvaluated(propName)
+					result.SetEvaluatedProperty(propName)
 				}
 			}
+			st.endKeyword(mark)
 		}
 
 		// Check additional properties
 		if !validated && c.additionalProperties != nil {
+			mark := st.beginKeyword(keywords.AdditionalProperties)
 			if boolVal, ok := c.additionalProperties.(bool); ok {
 				if !boolVal {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: additional property not allowed: %s`, propName)
+					if failures.add(atLocation(codeErrorf(ErrCodeAdditionalProperties, `invalid value passed to ObjectValidator: additional property not allowed: %s`, propName), jsonPointer(keywords.AdditionalProperties), jsonPointer(propName))) {
+						return nil, failures.err()
+					}
 				}
 				// If additionalProperties is true, it means this property is now "evaluated"
 				validated = true
-				evaluatedProperties.MarkEvaluated(propName)
+				result.SetEvaluatedProperty(propName)
 			} else if propValidator, ok := c.additionalProperties.(Interface); ok {
-				_, err := evalChild(ctx, propValidator, propValue, st)
+				_, err := evalProperty(ctx, propValidator, propName, propValue, st)
 				if err != nil {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: additional property validation failed for %s: %w`, propName, err)
+					if failures.add(fmt.Errorf(`invalid value passed to ObjectValidator: additional property validation failed for %s: %w`, propName, atLocation(err, jsonPointer(keywords.AdditionalProperties), jsonPointer(propName)))) {
+						return nil, failures.err()
+					}
 				}
 				// Property was validated by additionalProperties schema, so it's "evaluated"
 				validated = true
-				evaluatedProperties.MarkEvaluated(propName)
+				result.SetEvaluatedProperty(propName)
 			}
+			st.endKeyword(mark)
 		}
 
 		// Track unevaluated properties for later processing
-		if !validated {
+		if !validated && c.unevaluatedProperties != nil {
 			unevaluatedProps = append(unevaluatedProps, propName)
 		}
 	}
+	scratch.unevaluated = unevaluatedProps
 
 	// Handle dependent schemas if stored in this validator (must happen before unevaluated properties)
 	if len(c.dependentSchemas) > 0 {
-		for propertyName, depValidator := range c.dependentSchemas {
+		mark := st.beginKeyword(keywords.DependentSchemas)
+		for _, propertyName := range names {
 			// If the property exists in the object, validate the entire object with the dependent schema
-			if _, exists := properties[propertyName]; exists {
-				result, err := evalChild(ctx, depValidator, v, st)
+			if depValidator, exists := c.dependentSchemas[propertyName]; exists {
+				depResult, err := evalChild(ctx, depValidator, v, st)
 				if err != nil {
-					return nil, fmt.Errorf("dependent schema validation failed for property %s: %w", propertyName, err)
+					if failures.add(fmt.Errorf("dependent schema validation failed for property %s: %w", propertyName, atLocation(err, jsonPointer(keywords.DependentSchemas, propertyName), ""))) {
+						return nil, failures.err()
+					}
+					continue
 				}
 
 				// Merge evaluated properties from dependent schema validation
-				if objResult, ok := result.(*ObjectResult); ok && objResult != nil {
+				if objResult, ok := depResult.(*ObjectResult); ok && objResult != nil {
 					evaluatedProps := objResult.EvaluatedProperties()
 					for prop := range evaluatedProps {
-						evaluatedProperties.MarkEvaluated(prop)
+						result.SetEvaluatedProperty(prop)
 						// Remove from unevaluated list if it was marked as evaluated by dependent schema
 						for i, unevalProp := range unevaluatedProps {
 							if unevalProp == prop {
@@ -512,33 +690,40 @@ func (c *objectValidator) evaluate(ctx context.Context, v any, st *evalState) (R
 				}
 			}
 		}
+		st.endKeyword(mark)
 	}
 
 	// Handle unevaluated properties (after dependent schemas have been processed)
 	if len(unevaluatedProps) > 0 && c.unevaluatedProperties != nil {
+		mark := st.beginKeyword(keywords.UnevaluatedProperties)
 		for _, propName := range unevaluatedProps {
 			propValue := properties[propName]
 			if boolVal, ok := c.unevaluatedProperties.(bool); ok {
 				if !boolVal {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: unevaluated property not allowed: %s`, propName)
+					if failures.add(atLocation(codeErrorf(ErrCodeUnevaluatedProperties, `invalid value passed to ObjectValidator: unevaluated property not allowed: %s`, propName), jsonPointer(keywords.UnevaluatedProperties), jsonPointer(propName))) {
+						return nil, failures.err()
+					}
 				}
 				// If unevaluatedProperties is true, mark this property as evaluated
-				evaluatedProperties.MarkEvaluated(propName)
+				result.SetEvaluatedProperty(propName)
 			} else if propValidator, ok := c.unevaluatedProperties.(Interface); ok {
-				_, err := evalChild(ctx, propValidator, propValue, st)
+				_, err := evalProperty(ctx, propValidator, propName, propValue, st)
 				if err != nil {
-					return nil, fmt.Errorf(`invalid value passed to ObjectValidator: unevaluated property validation failed for %s: %w`, propName, err)
+					if failures.add(fmt.Errorf(`invalid value passed to ObjectValidator: unevaluated property validation failed for %s: %w`, propName, atLocation(err, jsonPointer(keywords.UnevaluatedProperties), jsonPointer(propName)))) {
+						return nil, failures.err()
+					}
 				}
 				// If property passes unevaluatedProperties schema validation, mark it as evaluated
-				evaluatedProperties.MarkEvaluated(propName)
+				result.SetEvaluatedProperty(propName)
 			}
 		}
+		st.endKeyword(mark)
 	}
 
-	// Always return ObjectResult with evaluated properties information for annotation tracking
-	result := NewObjectResult()
-	for _, prop := range evaluatedProperties.Keys() {
-		result.SetEvaluatedProperty(prop)
+	if err := failures.err(); err != nil {
+		return nil, err
 	}
+
+	// Always return ObjectResult with evaluated properties information for annotation tracking
 	return result, nil
 }
@@ -2,6 +2,8 @@ package validator_test
 
 import (
 	"context"
+	"errors"
+	"net/netip"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -777,3 +779,144 @@ func TestObjectValidatorComprehensive(t *testing.T) {
 func uintPtr(u uint) *uint {
 	return &u
 }
+
+func BenchmarkObjectValidator(b *testing.B) {
+	property := func(typ schema.PrimitiveType) *schema.Schema {
+		return schema.NewBuilder().Types(typ).MustBuild()
+	}
+	schemas := []struct {
+		name   string
+		schema *schema.Schema
+	}{
+		{name: "properties", schema: schema.NewBuilder().
+			Types(schema.ObjectType).
+			Property("id", property(schema.IntegerType)).
+			Property("name", property(schema.StringType)).
+			Property("email", property(schema.StringType)).
+			Property("role", property(schema.StringType)).
+			Property("team", property(schema.StringType)).
+			Required("id", "name").
+			MustBuild()},
+		{name: "additionalProperties", schema: schema.NewBuilder().
+			Types(schema.ObjectType).
+			Property("id", property(schema.IntegerType)).
+			Property("name", property(schema.StringType)).
+			AdditionalProperties(property(schema.StringType)).
+			MustBuild()},
+		{name: "unevaluatedProperties", schema: schema.NewBuilder().
+			Types(schema.ObjectType).
+			Property("id", property(schema.IntegerType)).
+			Property("name", property(schema.StringType)).
+			UnevaluatedProperties(property(schema.StringType)).
+			MustBuild()},
+	}
+	value := map[string]any{
+		"id":    1,
+		"name":  "ada",
+		"email": "ada@example.com",
+		"role":  "admin",
+		"team":  "core",
+	}
+	for _, tc := range schemas {
+		b.Run(tc.name, func(b *testing.B) {
+			v, err := validator.Compile(b.Context(), tc.schema)
+			require.NoError(b, err)
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := v.Validate(b.Context(), value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestPropertyNamesError(t *testing.T) {
+	names := schema.NewBuilder().MaxLength(5).Pattern("^[a-z0-9.]+$").MustBuild()
+	s := schema.NewBuilder().
+		Property("nested", schema.NewBuilder().Types(schema.ObjectType).PropertyNames(names).MustBuild()).
+		MustBuild()
+	v, err := validator.Compile(t.Context(), s)
+	require.NoError(t, err)
+
+	check := func(t *testing.T, value any, key, failure string) {
+		t.Helper()
+		_, err := v.Validate(t.Context(), map[string]any{"nested": value})
+		require.Error(t, err)
+		var verr *validator.Error
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, "/nested", verr.InstanceLocation(), "the location is the object, not the key")
+		require.Equal(t, "/properties/nested/propertyNames", verr.KeywordLocation())
+		require.Contains(t, verr.Message(), key)
+		require.Contains(t, verr.Message(), failure)
+		require.Contains(t, err.Error(), key)
+	}
+	t.Run("too long", func(t *testing.T) {
+		check(t, map[string]any{"ok": 1, "much_too_long": 2}, `"much_too_long"`, "maxLength")
+	})
+	t.Run("pattern", func(t *testing.T) {
+		check(t, map[string]any{"ok": 1, "UP": 2}, `"UP"`, "pattern")
+	})
+	t.Run("integer key", func(t *testing.T) {
+		check(t, map[int]any{1: "a", 123456: "b"}, `"123456"`, "maxLength")
+	})
+	t.Run("TextMarshaler key", func(t *testing.T) {
+		check(t, map[netip.Addr]any{netip.MustParseAddr("10.0.0.1"): "a"}, `"10.0.0.1"`, "maxLength")
+	})
+	t.Run("key with no JSON name", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), map[string]any{"nested": map[float64]any{1.5: "a"}})
+		require.ErrorContains(t, err, "cannot be a property name")
+	})
+}
+
+func TestTypedMapValues(t *testing.T) {
+	type key string
+	s := schema.NewBuilder().
+		Types(schema.ObjectType).
+		Property("id", schema.NewBuilder().Types(schema.IntegerType).Minimum(1).MustBuild()).
+		PatternProperty("^x-", schema.NewBuilder().MaxLength(3).MustBuild()).
+		AdditionalProperties(schema.NewBuilder().Types(schema.StringType, schema.IntegerType).MustBuild()).
+		MustBuild()
+	v, err := validator.Compile(t.Context(), s)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		value    any
+		location string // of the failure; "" when the value is valid
+	}{
+		{"map[string]int", map[string]int{"id": 1, "n": 2}, ""},
+		{"map[string]int with a failing property", map[string]int{"id": 0}, "/id"},
+		{"map[string]string", map[string]string{"x-a": "abc", "name": "x"}, ""},
+		{"map[string]string with a failing pattern property", map[string]string{"x-a": "abcd"}, "/x-a"},
+		{"map[string]string with a failing property", map[string]string{"id": "1"}, "/id"},
+		{"map[string]bool with a failing additional property", map[string]bool{"ok": true}, "/ok"},
+		{"named key type", map[key]int{"id": -1}, "/id"},
+		{"pointer to a map", &map[string]float64{"id": 1.5}, "/id"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Validate(t.Context(), tc.value)
+			if tc.location == "" {
+				require.NoError(t, err)
+				return
+			}
+			var verr *validator.Error
+			require.True(t, errors.As(err, &verr), "%v", err)
+			require.Equal(t, tc.location, verr.InstanceLocation())
+		})
+	}
+
+	t.Run("nested typed collections", func(t *testing.T) {
+		nested := schema.NewBuilder().
+			AdditionalProperties(schema.NewBuilder().AdditionalProperties(schema.NewBuilder().Maximum(10).MustBuild()).MustBuild()).
+			MustBuild()
+		v, err := validator.Compile(t.Context(), nested)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]map[string]int{"a": {"b": 10}})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]map[string]int{"a": {"b": 11}})
+		var verr *validator.Error
+		require.True(t, errors.As(err, &verr), "%v", err)
+		require.Equal(t, "/a/b", verr.InstanceLocation())
+	})
+}
@@ -20,6 +20,16 @@ type identResolver struct{}
 type identVocabularySet struct{}
 type identBaseURI struct{}
 type identBaseSchema struct{}
+type identCoercion struct{}
+type identECMAScriptRegex struct{}
+type identContentAssertion struct{}
+type identUseNumber struct{}
+type identStrictInteger struct{}
+type identFormatAssertion struct{}
+type identReferencesDisabled struct{}
+type identMaxDepth struct{}
+type identVocabulary struct{}
+type identDefaultDraft struct{}
 
 // WithResolver supplies the $ref resolver used during compilation. When omitted,
 // a fresh resolver is created.
@@ -48,6 +58,93 @@ func WithBaseSchema(s *schema.Schema) CompileOption {
 	return compileOption{option.New(identBaseSchema{}, s)}
 }
 
+// WithCoercion makes the validators for "type": "integer", "number" and
+// "boolean" accept a string holding such a value, as found in query strings,
+// form fields and environment variables. "42" then validates as the integer 42,
+// "2.5" as the number 2.5, and "true"/"false" as booleans; the string must be
+// the exact JSON spelling of the value. The converted values are reported by
+// CoercedValue and CoercedValues on the validation Result, including those of
+// nested properties and items. Coercion is off by default.
+func WithCoercion(v bool) CompileOption {
+	return compileOption{option.New(identCoercion{}, v)}
+}
+
+// WithECMAScriptRegex makes Compile translate "pattern" and "patternProperties"
+// regular expressions from ECMA-262, the dialect JSON Schema specifies, to the
+// RE2 syntax of Go's regexp package. This accepts patterns written for
+// JavaScript validators, such as ones using \uXXXX escapes or [^], and gives
+// ".", "\s" and "\S" their ECMA-262 meaning. Lookaround and backreferences
+// cannot be translated and still fail to compile.
+//
+// Without this option patterns are compiled as RE2 directly; a pattern that
+// fails because of ECMA-262 syntax reports the construct at fault.
+func WithECMAScriptRegex(v bool) CompileOption {
+	return compileOption{option.New(identECMAScriptRegex{}, v)}
+}
+
+// WithContentAssertion makes "contentEncoding", "contentMediaType" and
+// "contentSchema" assert instead of only annotating a string. With it, a string
+// whose contentEncoding is "base64" or "base64url" must decode, content whose
+// contentMediaType is "application/json" must parse, and the parsed value must
+// be valid against contentSchema. Encodings and media types the validator does
+// not know are still not checked.
+//
+// JSON Schema defines these keywords as annotations, so by default they never
+// cause validation to fail.
+func WithContentAssertion(v bool) CompileOption {
+	return compileOption{option.New(identContentAssertion{}, v)}
+}
+
+// WithUseNumber declares that the values to validate decode JSON numbers as
+// json.Number, as json.Decoder.UseNumber and the ValidateJSON family do, so that
+// integers keep every digit. With it, the integer validator rejects a float64
+// (or float32) too large for that float type to hold every integer, such as the
+// 10000000000000000 json.Unmarshal produces from 10000000000000001: the value
+// may already have been rounded, and validating it would give an answer about a
+// different number.
+//
+// Numbers given as json.Number are accepted whether or not this option is set.
+func WithUseNumber(v bool) CompileOption {
+	return compileOption{option.New(identUseNumber{}, v)}
+}
+
+// WithStrictInteger makes "type": "integer" accept only numbers written as
+// integers. JSON Schema counts 1.0 as an integer, and so does this package by
+// default; with WithStrictInteger(true), a json.Number such as "30.0" or "3e1"
+// fails, as does any float32 or float64 value, since a float cannot tell 30
+// from 30.0. Pipelines that need the distinction decode numbers as
+// json.Number (see WithUseNumber), or validate with ValidateJSON.
+func WithStrictInteger(v bool) CompileOption {
+	return compileOption{option.New(identStrictInteger{}, v)}
+}
+
+// WithFormatAssertion makes "format" assert (true) or only annotate (false),
+// whatever the vocabulary set in effect says about format-assertion. The rest
+// of the set is left alone: it is still the default set, the one given with
+// WithVocabularySet, or the one the schema declares. Without this option the
+// vocabulary set decides, and by default "format" is an annotation.
+func WithFormatAssertion(v bool) CompileOption {
+	return compileOption{option.New(identFormatAssertion{}, v)}
+}
+
+// WithReferencesDisabled makes Compile reject a schema that contains "$ref",
+// "$dynamicRef" or "$recursiveRef" anywhere in it, before anything is resolved.
+// A metaschema named by a non-standard "$schema" is not retrieved either. Use
+// it for schemas from untrusted sources, where following a reference could
+// reach the network or the filesystem.
+func WithReferencesDisabled() CompileOption {
+	return compileOption{option.New(identReferencesDisabled{}, true)}
+}
+
+// WithDefaultDraft sets the draft whose keyword semantics apply to a schema
+// that declares no recognized "$schema", in place of 2020-12. Use it for
+// draft-07 schemas that never said so, so that their "dependencies" is
+// honored and a "$ref" ignores its sibling keywords. A "$schema" in the schema
+// or one of its subschemas still takes precedence from there down.
+func WithDefaultDraft(d schema.Draft) CompileOption {
+	return compileOption{option.New(identDefaultDraft{}, d)}
+}
+
 // ValidateOption configures a Validate call.
 type ValidateOption interface {
 	option.Interface
@@ -59,6 +156,10 @@ type validateOption struct{ option.Interface }
 func (validateOption) validateOption() {}
 
 type identDynamicAnchorValidator struct{}
+type identCollectAllErrors struct{}
+type identProfiler struct{}
+type identAnnotationCollection struct{}
+type identReflectionOnly struct{}
 
 // dynamicAnchorRegistration pairs a $dynamicAnchor name with the validator that
 // stands in for the outermost resource declaring it.
@@ -74,3 +175,61 @@ type dynamicAnchorRegistration struct {
 func WithDynamicAnchorValidator(name string, v Interface) ValidateOption {
 	return validateOption{option.New(identDynamicAnchorValidator{}, dynamicAnchorRegistration{name: name, v: v})}
 }
+
+// WithCollectAllErrors makes object, array and composite validators keep going
+// after a failure and report every failure they find, instead of returning the
+// first one. When there is more than one, Validate returns a single error whose
+// Unwrap() []error lists them; ValidateWithOutput reports each at its own
+// keyword and instance location. Collecting is off by default, since stopping
+// at the first failure is cheaper.
+func WithCollectAllErrors(v bool) ValidateOption {
+	return validateOption{option.New(identCollectAllErrors{}, v)}
+}
+
+// WithProfiler records in p how long the Validate call spends on each keyword
+// and on each instance location. Without it, the timing points cost no more
+// than a nil check.
+func WithProfiler(p *Profiler) ValidateOption {
+	return validateOption{option.New(identProfiler{}, p)}
+}
+
+// WithAnnotationCollection makes a successful Validate call collect the
+// "title", "description", "default" and "examples" of every schema the value
+// passed, by instance location, for example to render a hint next to each
+// field of a form. Read them from the returned Result with Annotations.
+// Without it, annotations are not recorded; validators generated by
+// CodeGenerator never record them.
+func WithAnnotationCollection() ValidateOption {
+	return validateOption{option.New(identAnnotationCollection{}, true)}
+}
+
+// WithReflectionOnly, given true, turns off the conversion of Go values to
+// their JSON form (see Validate): a time.Time, url.URL, []byte or
+// json.Marshaler is then read by reflection like any other value, so that a
+// time.Time is an object without properties and a []byte an array of
+// integers.
+func WithReflectionOnly(v bool) ValidateOption {
+	return validateOption{option.New(identReflectionOnly{}, v)}
+}
+
+// Option is an option accepted by both Compile and Validate.
+type Option interface {
+	CompileOption
+	ValidateOption
+}
+
+type compileValidateOption struct{ option.Interface }
+
+func (compileValidateOption) compileOption()  {}
+func (compileValidateOption) validateOption() {}
+
+// WithMaxDepth bounds nesting to n levels below the root, so that a hostile or
+// runaway document cannot exhaust the stack. Given to Compile, it limits how
+// deeply subschemas nest, a $ref counting as one level; given to Validate, it
+// limits how deeply the properties and items of the instance nest. Exceeding
+// it makes Compile or Validate fail with an error wrapping
+// ErrMaxDepthExceeded; a Validate stopped this way has found the instance
+// neither valid nor invalid. n <= 0 means no limit, the default.
+func WithMaxDepth(n int) Option {
+	return compileValidateOption{option.New(identMaxDepth{}, n)}
+}
@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"context"
+)
+
+// OutputFormat selects the shape of the structured output produced by
+// ValidateWithOutput, following the output formats defined in section 12 of
+// the JSON Schema 2020-12 core specification.
+type OutputFormat int
+
+const (
+	// OutputFlag reports only whether the instance is valid.
+	OutputFlag OutputFormat = iota
+	// OutputBasic reports a flat list of the failing keywords.
+	OutputBasic
+	// OutputDetailed reports the failures as a tree that follows the structure
+	// of the schema, collapsing nodes that have a single child.
+	OutputDetailed
+)
+
+func (f OutputFormat) String() string {
+	switch f {
+	case OutputFlag:
+		return "flag"
+	case OutputBasic:
+		return "basic"
+	case OutputDetailed:
+		return "detailed"
+	default:
+		return "unknown"
+	}
+}
+
+// Output is the top-level structured validation result. It marshals to the
+// JSON shape described by the specification, e.g.
+//
+//	{"valid": false, "errors": [{"keywordLocation": "/properties/name", ...}]}
+type Output struct {
+	Valid  bool          `json:"valid"`
+	Errors []*OutputUnit `json:"errors,omitempty"`
+}
+
+// OutputUnit describes a single failure (or, in the detailed format, a group
+// of failures) at a keyword and instance location. Locations are JSON
+// Pointers: KeywordLocation is the path through the schema that was followed,
+// including any "$ref" crossed, and InstanceLocation is the path into the
+// validated value. AbsoluteKeywordLocation is only set once the evaluation
+// path has crossed a reference that resolved to an absolute URI.
+type OutputUnit struct {
+	Valid                   bool          `json:"valid"`
+	KeywordLocation         string        `json:"keywordLocation"`
+	AbsoluteKeywordLocation string        `json:"absoluteKeywordLocation,omitempty"`
+	InstanceLocation        string        `json:"instanceLocation"`
+	Error                   string        `json:"error,omitempty"`
+	Errors                  []*OutputUnit `json:"errors,omitempty"`
+
+	// cause is the error Error was taken from.
+	cause error
+}
+
+// ValidateWithOutput validates instance against v and reports the outcome as
+// structured output in the requested format. A validation failure is not an
+// error: it is reported through the returned Output. The error return is
+// reserved for failures to run the validation at all, such as cancellation of
+// ctx.
+func ValidateWithOutput(ctx context.Context, v Interface, instance any, format OutputFormat, options ...ValidateOption) (*Output, error) {
+	// The unconverted error keeps the shape of the evaluation; Validate would
+	// flatten several failures into a list.
+	_, err := evalChild(ctx, v, instance, newEvalState(ctx, options))
+	if err == nil {
+		return &Output{Valid: true}, nil
+	}
+	if interrupted(err) {
+		return nil, err
+	}
+
+	out := &Output{Valid: false}
+	switch format {
+	case OutputFlag:
+		return out, nil
+	case OutputBasic:
+		out.Errors = flattenUnits(outputTree(err), nil)
+	default:
+		root := outputTree(err)
+		condenseUnits(root)
+		out.Errors = root.Errors
+		if len(out.Errors) == 0 {
+			out.Errors = []*OutputUnit{root}
+		}
+	}
+	return out, nil
+}
+
+// outputTree builds the full tree of output units for err. The root unit
+// stands for the schema root applied to the instance root; every
+// locationError found in the error chain becomes a child unit located at the
+// concatenation of the segments seen on the way down.
+func outputTree(err error) *OutputUnit {
+	root := &OutputUnit{}
+	collectUnits(root, err)
+	if len(root.Errors) == 0 {
+		root.Error = err.Error()
+		root.cause = err
+	}
+	return root
+}
+
+func collectUnits(parent *OutputUnit, err error) {
+	for err != nil {
+		switch e := err.(type) {
+		case *locationError:
+			unit := &OutputUnit{
+				KeywordLocation:  parent.KeywordLocation + e.keyword,
+				InstanceLocation: parent.InstanceLocation + e.instance,
+			}
+			switch {
+			case e.absolute != "":
+				unit.AbsoluteKeywordLocation = e.absolute
+			case parent.AbsoluteKeywordLocation != "":
+				unit.AbsoluteKeywordLocation = parent.AbsoluteKeywordLocation + e.keyword
+			}
+			collectUnits(unit, e.err)
+			if len(unit.Errors) == 0 {
+				unit.Error = e.err.Error()
+				unit.cause = e.err
+			}
+			parent.Errors = append(parent.Errors, unit)
+			return
+		case interface{ Unwrap() []error }:
+			for _, sub := range e.Unwrap() {
+				// A failure without a location of its own belongs to the
+				// parent's location; it would otherwise be lost among its
+				// located siblings.
+				n := len(parent.Errors)
+				collectUnits(parent, sub)
+				if len(parent.Errors) == n {
+					parent.Errors = append(parent.Errors, &OutputUnit{
+						KeywordLocation:         parent.KeywordLocation,
+						AbsoluteKeywordLocation: parent.AbsoluteKeywordLocation,
+						InstanceLocation:        parent.InstanceLocation,
+						Error:                   sub.Error(),
+						cause:                   sub,
+					})
+				}
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// flattenUnits appends the leaves of the tree rooted at u to dst, which is
+// the "basic" output format.
+func flattenUnits(u *OutputUnit, dst []*OutputUnit) []*OutputUnit {
+	if len(u.Errors) == 0 {
+		return append(dst, u)
+	}
+	for _, child := range u.Errors {
+		dst = flattenUnits(child, dst)
+	}
+	return dst
+}
+
+// condenseUnits replaces every unit that has exactly one child with that
+// child, leaving only the nodes where failures actually branch. This is the
+// "detailed" output format.
+func condenseUnits(u *OutputUnit) {
+	for i, child := range u.Errors {
+		condenseUnits(child)
+		for len(child.Errors) == 1 {
+			child = child.Errors[0]
+		}
+		u.Errors[i] = child
+	}
+}
@@ -0,0 +1,103 @@
+package validator_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func compileOutputSchema(t *testing.T, src string) validator.Interface {
+	t.Helper()
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(src)))
+	v, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestValidateWithOutput(t *testing.T) {
+	const src = `{
+		"$id": "https://example.com/root.json",
+		"type": "object",
+		"properties": {
+			"user": {
+				"type": "object",
+				"properties": {
+					"roles": {"type": "array", "items": {"type": "string"}}
+				}
+			},
+			"point": {"$ref": "#/$defs/point"},
+			"tag": {"allOf": [{"type": "string"}, {"minLength": 3}]}
+		},
+		"$defs": {
+			"point": {"type": "object", "properties": {"x": {"type": "number"}}}
+		}
+	}`
+	v := compileOutputSchema(t, src)
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := validator.ValidateWithOutput(t.Context(), v, map[string]any{"tag": "abc"}, validator.OutputBasic)
+		require.NoError(t, err)
+		require.True(t, out.Valid)
+		require.Empty(t, out.Errors)
+	})
+	t.Run("flag", func(t *testing.T) {
+		out, err := validator.ValidateWithOutput(t.Context(), v, map[string]any{"tag": 1}, validator.OutputFlag)
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		require.Empty(t, out.Errors)
+
+		buf, err := json.Marshal(out)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"valid":false}`, string(buf))
+	})
+	t.Run("nested items", func(t *testing.T) {
+		instance := map[string]any{
+			"user": map[string]any{"roles": []any{"admin", "dev", 3}},
+		}
+		out, err := validator.ValidateWithOutput(t.Context(), v, instance, validator.OutputBasic)
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		require.Len(t, out.Errors, 1)
+		require.Equal(t, "/properties/user/properties/roles/items", out.Errors[0].KeywordLocation)
+		require.Equal(t, "/user/roles/2", out.Errors[0].InstanceLocation)
+		require.NotEmpty(t, out.Errors[0].Error)
+	})
+	t.Run("reference", func(t *testing.T) {
+		instance := map[string]any{"point": map[string]any{"x": "nope"}}
+		out, err := validator.ValidateWithOutput(t.Context(), v, instance, validator.OutputBasic)
+		require.NoError(t, err)
+		require.Len(t, out.Errors, 1)
+		require.Equal(t, "/properties/point/$ref/properties/x", out.Errors[0].KeywordLocation)
+		require.Equal(t, "https://example.com/root.json#/$defs/point/properties/x", out.Errors[0].AbsoluteKeywordLocation)
+		require.Equal(t, "/point/x", out.Errors[0].InstanceLocation)
+	})
+	t.Run("composition", func(t *testing.T) {
+		out, err := validator.ValidateWithOutput(t.Context(), v, map[string]any{"tag": "ab"}, validator.OutputDetailed)
+		require.NoError(t, err)
+		require.Len(t, out.Errors, 1)
+		require.Equal(t, "/properties/tag/allOf/1", out.Errors[0].KeywordLocation)
+		require.Equal(t, "/tag", out.Errors[0].InstanceLocation)
+	})
+}
+
+func TestValidateWithOutputEscapesPointers(t *testing.T) {
+	v := compileOutputSchema(t, `{"properties": {"a/b~c": {"type": "integer"}}}`)
+	out, err := validator.ValidateWithOutput(t.Context(), v, map[string]any{"a/b~c": "x"}, validator.OutputBasic)
+	require.NoError(t, err)
+	require.Len(t, out.Errors, 1)
+	require.Equal(t, "/properties/a~1b~0c", out.Errors[0].KeywordLocation)
+	require.Equal(t, "/a~1b~0c", out.Errors[0].InstanceLocation)
+}
+
+func TestValidateWithOutputCancellation(t *testing.T) {
+	v := compileOutputSchema(t, `{"properties": {"a": {"type": "string"}}}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := validator.ValidateWithOutput(ctx, v, map[string]any{"a": "x"}, validator.OutputBasic)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Profiler accumulates the time Validate spends on each keyword and on each
+// instance location, to find which parts of a schema dominate validation of
+// real payloads. Attach it to a Validate call with WithProfiler; one Profiler
+// may be shared by concurrent calls, and keeps adding up until Reset.
+//
+// The keywords timed are those that apply subschemas, and the assertions whose
+// cost grows with the schema or the value: pattern, format, enum, const,
+// required, dependentRequired, uniqueItems and the content keywords. The time
+// of the other assertions, such as type or minimum, counts toward the keyword
+// that applied the subschema holding them.
+type Profiler struct {
+	mu        sync.Mutex
+	keywords  map[string]*ProfileEntry
+	locations map[string]*ProfileEntry
+}
+
+// ProfileEntry is the time a Profiler recorded for one keyword or one
+// instance location.
+type ProfileEntry struct {
+	// Name is the keyword, or the instance location as a JSON Pointer ("" is
+	// the validated value itself).
+	Name string
+	// Calls counts the evaluations. Keywords applied to each property or
+	// item, such as properties or items, are counted once per property or
+	// item.
+	Calls int
+	// Self excludes the time of what was evaluated within: for a keyword, the
+	// timed keywords of the subschemas it applied; for a location, the values
+	// nested in it.
+	Self time.Duration
+	// Total includes the time of what was evaluated within. A keyword nested
+	// in itself, as with properties of properties, adds to Total at each
+	// level.
+	Total time.Duration
+}
+
+// NewProfiler creates an empty Profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{}
+}
+
+// Keywords returns the time recorded per keyword, the most expensive (by
+// Self) first.
+func (p *Profiler) Keywords() []ProfileEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return sortedEntries(p.keywords)
+}
+
+// Locations returns the time recorded per instance location, the most
+// expensive (by Self) first.
+func (p *Profiler) Locations() []ProfileEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return sortedEntries(p.locations)
+}
+
+// Reset discards everything recorded so far.
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keywords = nil
+	p.locations = nil
+}
+
+func (p *Profiler) merge(ps *profileState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keywords = mergeEntries(p.keywords, ps.keywords)
+	p.locations = mergeEntries(p.locations, ps.locations)
+}
+
+func mergeEntries(dst, src map[string]*ProfileEntry) map[string]*ProfileEntry {
+	if dst == nil {
+		dst = make(map[string]*ProfileEntry, len(src))
+	}
+	for name, e := range src {
+		recordEntry(dst, name, e.Calls, e.Total, e.Self)
+	}
+	return dst
+}
+
+func recordEntry(m map[string]*ProfileEntry, name string, calls int, total, self time.Duration) {
+	e, ok := m[name]
+	if !ok {
+		e = &ProfileEntry{Name: name}
+		m[name] = e
+	}
+	e.Calls += calls
+	e.Total += total
+	e.Self += self
+}
+
+func sortedEntries(m map[string]*ProfileEntry) []ProfileEntry {
+	entries := make([]ProfileEntry, 0, len(m))
+	for _, e := range m {
+		entries = append(entries, *e)
+	}
+	slices.SortFunc(entries, func(a, b ProfileEntry) int {
+		if c := cmp.Compare(b.Self, a.Self); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+// profileState records one Validate call for a Profiler, which receives it
+// in one piece when the call returns. Its frames mirror the evaluation: a
+// keyword frame for each timed keyword being evaluated, and a location frame
+// for each value being evaluated, the validated value at the bottom.
+type profileState struct {
+	profiler  *Profiler
+	frames    []profileFrame
+	location  string // instance location of the innermost location frame
+	locFrame  int    // index of the innermost location frame, -1 for none
+	keywords  map[string]*ProfileEntry
+	locations map[string]*ProfileEntry
+}
+
+type profileFrame struct {
+	name     string
+	location bool
+	start    time.Time
+	// children is the time of the frames directly within this one; nested,
+	// for a location frame, that of the location frames within it.
+	children time.Duration
+	nested   time.Duration
+	// prevLocation and prevLocFrame restore the enclosing location.
+	prevLocation string
+	prevLocFrame int
+}
+
+func newProfileState(p *Profiler) *profileState {
+	return &profileState{
+		profiler:  p,
+		locFrame:  -1,
+		keywords:  make(map[string]*ProfileEntry),
+		locations: make(map[string]*ProfileEntry),
+	}
+}
+
+// begin opens a frame, returning the mark to close it with.
+func (ps *profileState) begin(f profileFrame) int {
+	mark := len(ps.frames)
+	f.start = time.Now()
+	ps.frames = append(ps.frames, f)
+	return mark
+}
+
+func (ps *profileState) beginKeyword(keyword string) int {
+	return ps.begin(profileFrame{name: keyword})
+}
+
+// beginLocation opens the frame of the value at the current location
+// followed by the JSON Pointer segment token.
+func (ps *profileState) beginLocation(token string) int {
+	location := ps.location + token
+	mark := ps.begin(profileFrame{
+		name:         location,
+		location:     true,
+		prevLocation: ps.location,
+		prevLocFrame: ps.locFrame,
+	})
+	ps.location, ps.locFrame = location, mark
+	return mark
+}
+
+func (ps *profileState) beginProperty(name string) int {
+	return ps.beginLocation(jsonPointer(name))
+}
+
+func (ps *profileState) beginItem(index int) int {
+	return ps.beginLocation("/" + strconv.Itoa(index))
+}
+
+// end closes the frame at mark along with any left open above it, which a
+// validator returning early on a failure does not close itself.
+func (ps *profileState) end(mark int) {
+	now := time.Now()
+	for len(ps.frames) > mark {
+		f := ps.frames[len(ps.frames)-1]
+		ps.frames = ps.frames[:len(ps.frames)-1]
+		elapsed := now.Sub(f.start)
+		if n := len(ps.frames); n > 0 {
+			ps.frames[n-1].children += elapsed
+		}
+		if !f.location {
+			recordEntry(ps.keywords, f.name, 1, elapsed, elapsed-f.children)
+			continue
+		}
+		ps.location, ps.locFrame = f.prevLocation, f.prevLocFrame
+		if ps.locFrame >= 0 {
+			ps.frames[ps.locFrame].nested += elapsed
+		}
+		recordEntry(ps.locations, f.name, 1, elapsed, elapsed-f.nested)
+	}
+}
+
+// finish closes every frame and hands the recording to the Profiler.
+func (ps *profileState) finish() {
+	ps.end(0)
+	ps.profiler.merge(ps)
+}
@@ -0,0 +1,159 @@
+package validator_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiler(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "pattern": "^[a-z]+$"},
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}}
+		},
+		"patternProperties": {"^x-": {"anyOf": [{"type": "integer"}, {"type": "string"}]}},
+		"required": ["name"],
+		"$defs": {"tag": {"enum": ["a", "b", "c"]}}
+	}`)))
+	v, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	value := map[string]any{
+		"name":  "ada",
+		"tags":  []any{"a", "b"},
+		"x-one": "1",
+	}
+
+	names := func(entries []validator.ProfileEntry) map[string]validator.ProfileEntry {
+		m := make(map[string]validator.ProfileEntry, len(entries))
+		for _, e := range entries {
+			m[e.Name] = e
+		}
+		return m
+	}
+
+	t.Run("keywords and locations", func(t *testing.T) {
+		p := validator.NewProfiler()
+		_, err := v.Validate(t.Context(), value, validator.WithProfiler(p))
+		require.NoError(t, err)
+
+		kw := names(p.Keywords())
+		for _, name := range []string{"properties", "patternProperties", "required", "pattern", "items", "$ref", "enum", "anyOf"} {
+			require.Contains(t, kw, name)
+		}
+		require.Equal(t, 3, kw["properties"].Calls, "once per property")
+		require.Equal(t, 3, kw["patternProperties"].Calls)
+		require.Equal(t, 1, kw["pattern"].Calls)
+		require.Equal(t, 2, kw["$ref"].Calls, "once per tag")
+		require.Equal(t, 2, kw["enum"].Calls)
+		require.Equal(t, 2, kw["anyOf"].Calls, "once per branch")
+		require.NotContains(t, kw, "type", "cheap assertions are not timed on their own")
+
+		locations := names(p.Locations())
+		require.Len(t, locations, 6)
+		for _, name := range []string{"", "/name", "/tags", "/tags/0", "/tags/1", "/x-one"} {
+			require.Contains(t, locations, name)
+			require.Equal(t, 1, locations[name].Calls, name)
+		}
+
+		entries := append(p.Keywords(), p.Locations()...)
+		for _, e := range entries {
+			require.GreaterOrEqual(t, e.Total, e.Self, e.Name)
+			require.GreaterOrEqual(t, e.Self, time.Duration(0), e.Name)
+		}
+		require.GreaterOrEqual(t, locations[""].Total, locations["/tags"].Total)
+
+		keywords := p.Keywords()
+		for i := 1; i < len(keywords); i++ {
+			require.GreaterOrEqual(t, keywords[i-1].Self, keywords[i].Self, "sorted by Self")
+		}
+	})
+
+	t.Run("accumulates until Reset", func(t *testing.T) {
+		p := validator.NewProfiler()
+		for range 3 {
+			_, err := v.Validate(t.Context(), value, validator.WithProfiler(p))
+			require.NoError(t, err)
+		}
+		require.Equal(t, 3, names(p.Locations())[""].Calls)
+
+		p.Reset()
+		require.Empty(t, p.Keywords())
+		require.Empty(t, p.Locations())
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		// The failing item returns early, leaving its keywords for the
+		// enclosing evaluation to close.
+		p := validator.NewProfiler()
+		_, err := v.Validate(t.Context(), map[string]any{"name": "ada", "tags": []any{"z"}}, validator.WithProfiler(p))
+		require.Error(t, err)
+
+		locations := names(p.Locations())
+		require.Contains(t, locations, "/tags/0")
+		require.Contains(t, names(p.Keywords()), "enum")
+		for _, e := range append(p.Keywords(), p.Locations()...) {
+			require.GreaterOrEqual(t, e.Total, e.Self, e.Name)
+		}
+	})
+
+	t.Run("concurrent calls", func(t *testing.T) {
+		p := validator.NewProfiler()
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = v.Validate(context.Background(), value, validator.WithProfiler(p))
+			}()
+		}
+		wg.Wait()
+		require.Equal(t, 8, names(p.Locations())[""].Calls)
+	})
+
+	t.Run("nil profiler", func(t *testing.T) {
+		_, err := v.Validate(t.Context(), value, validator.WithProfiler(nil))
+		require.NoError(t, err)
+	})
+}
+
+func BenchmarkValidate_Profiler(b *testing.B) {
+	ctx := context.Background()
+	v, err := validator.Compile(ctx, benchSchema())
+	if err != nil {
+		b.Fatal(err)
+	}
+	value := map[string]any{
+		"id":      1,
+		"role":    "admin",
+		"score":   87.5,
+		"tags":    []any{"a", "b", "c"},
+		"profile": map[string]any{"age": 42, "active": true},
+	}
+
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := v.Validate(ctx, value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("enabled", func(b *testing.B) {
+		p := validator.NewProfiler()
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := v.Validate(ctx, value, validator.WithProfiler(p)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
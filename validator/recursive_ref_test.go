@@ -1,6 +1,8 @@
 package validator_test
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -84,3 +86,96 @@ func TestRecursiveReference(t *testing.T) {
 		require.Contains(t, err.Error(), "circular reference")
 	})
 }
+
+// TestRecursiveRefKeyword covers the draft 2019-09 $recursiveRef and
+// $recursiveAnchor keywords.
+func TestRecursiveRefKeyword(t *testing.T) {
+	compile := func(t *testing.T, jsonSchema string) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(jsonSchema)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("tree", func(t *testing.T) {
+		v := compile(t, `{
+			"$schema": "https://json-schema.org/draft/2019-09/schema",
+			"$recursiveAnchor": true,
+			"type": "object",
+			"required": ["value"],
+			"properties": {
+				"value": {"type": "integer"},
+				"children": {"type": "array", "items": {"$recursiveRef": "#"}}
+			}
+		}`)
+
+		tree := map[string]any{"value": 1}
+		for i := range 20 {
+			tree = map[string]any{"value": i, "children": []any{tree}}
+		}
+		_, err := v.Validate(t.Context(), tree)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{
+			"value":    1,
+			"children": []any{map[string]any{"value": 2, "children": []any{map[string]any{"value": "x"}}}},
+		})
+		require.Error(t, err)
+	})
+
+	// The outer schema extends the tree; with "$recursiveAnchor": true on both,
+	// the tree's $recursiveRef refers back to the outer schema, so its
+	// "unevaluatedProperties": false applies at every level.
+	extended := func(outerAnchor bool) string {
+		return `{
+			"$schema": "https://json-schema.org/draft/2019-09/schema",
+			"$id": "https://example.com/strict-tree",
+			"$recursiveAnchor": ` + strconv.FormatBool(outerAnchor) + `,
+			"$ref": "tree",
+			"unevaluatedProperties": false,
+			"$defs": {
+				"tree": {
+					"$id": "https://example.com/tree",
+					"$recursiveAnchor": true,
+					"type": "object",
+					"properties": {
+						"data": true,
+						"children": {"type": "array", "items": {"$recursiveRef": "#"}}
+					}
+				}
+			}
+		}`
+	}
+	misspelled := map[string]any{"children": []any{map[string]any{"daat": 1}}}
+
+	t.Run("extended through the dynamic scope", func(t *testing.T) {
+		v := compile(t, extended(true))
+		_, err := v.Validate(t.Context(), map[string]any{"children": []any{map[string]any{"data": 1}}})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), misspelled)
+		require.Error(t, err)
+	})
+
+	t.Run("a value other than # resolves like $ref", func(t *testing.T) {
+		v := compile(t, strings.Replace(extended(true), `"$recursiveRef": "#"`, `"$recursiveRef": "tree"`, 1))
+		_, err := v.Validate(t.Context(), misspelled)
+		require.NoError(t, err, "the dynamic scope is not consulted")
+	})
+
+	t.Run("outer resource without $recursiveAnchor", func(t *testing.T) {
+		v := compile(t, extended(false))
+		_, err := v.Validate(t.Context(), misspelled)
+		require.NoError(t, err, "the reference stays within the tree")
+	})
+
+	t.Run("not a 2020-12 keyword", func(t *testing.T) {
+		v := compile(t, `{
+			"properties": {"children": {"type": "array", "items": {"$recursiveRef": "#"}}},
+			"required": ["value"]
+		}`)
+		_, err := v.Validate(t.Context(), map[string]any{"value": 1, "children": []any{map[string]any{}}})
+		require.NoError(t, err)
+	})
+}
@@ -1,4 +1,5 @@
-package validator // ReferenceValidator handles schema references ($ref) with lazy resolution and circular detection
+package validator
+
 import (
 	"context"
 	"fmt"
@@ -9,6 +10,14 @@ import (
 	"github.com/lestrrat-go/json-schema/vocabulary"
 )
 
+// ReferenceValidator validates against the target of a $ref that is resolved
+// when it is first needed instead of at compile time. Compile resolves other
+// references eagerly, so that one that does not resolve makes Compile fail; it
+// creates a ReferenceValidator for a reference back into a schema it is still
+// compiling, such as "$ref": "#" below "items", where resolving eagerly would
+// expand the schema forever. The first Validate that reaches it resolves and
+// compiles the target, and the result is kept for every later call; calls
+// that reach it concurrently before then wait for that one resolution.
 type ReferenceValidator struct {
 	reference    string
 	resolvedOnce sync.Once
@@ -18,10 +27,12 @@ type ReferenceValidator struct {
 	rootSchema   *schema.Schema
 	baseSchema   *schema.Schema // Enclosing resource captured at compile time (nil = use root)
 	baseURI      string         // Enclosing resource's base URI captured at compile time
+	draft        schema.Draft   // Draft in effect where the reference appears
+	cfg          *compileConfig // Compile options in effect (nil = defaults)
 }
 
 func (r *ReferenceValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return r.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, r, v, options)
 }
 
 func (r *ReferenceValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
@@ -37,6 +48,16 @@ func (r *ReferenceValidator) evaluate(ctx context.Context, v any, st *evalState)
 	return evalChild(ctx, r.resolved, v, st)
 }
 
+// lazyCompileConfig returns the configuration a validate-time compilation
+// runs with: the one captured from the original Compile call, or the defaults
+// when the validator was constructed outside of it.
+func lazyCompileConfig(cfg *compileConfig, resolver *schema.Resolver) *compileConfig {
+	if cfg != nil {
+		return cfg
+	}
+	return &compileConfig{resolver: resolver, vocab: vocabulary.DefaultSet()}
+}
+
 func (r *ReferenceValidator) resolveReference(ctx context.Context) (Interface, error) {
 	// All resolution inputs were captured into the validator at compile time, so
 	// this lazy (validate-time) resolution is self-contained.
@@ -67,13 +88,15 @@ func (r *ReferenceValidator) resolveReference(ctx context.Context) (Interface, e
 	// Recompile the resolved schema. The reference is seeded onto the recompile's
 	// reference stack so any cycle within the target is classified the same way
 	// the original compile would have classified it.
+	location := schema.ResolveURI(baseURI, r.reference)
 	cs := compileState{
-		cfg:            &compileConfig{resolver: resolver, vocab: vocabulary.DefaultSet()},
+		cfg:            lazyCompileConfig(r.cfg, resolver),
 		rootSchema:     rootSchema,
 		baseSchema:     baseSchema,
 		baseURI:        baseURI,
-		referenceStack: []string{r.reference},
-		refDepths:      map[string]int{r.reference: 0},
+		draft:          r.draft,
+		referenceStack: []string{location},
+		refDepths:      map[string]int{location: 0},
 	}
 	compiled, err := compile(ctx, &targetSchema, cs)
 	if err != nil {
@@ -83,7 +106,7 @@ func (r *ReferenceValidator) resolveReference(ctx context.Context) (Interface, e
 	// Following a $ref into another resource enters that resource's dynamic
 	// scope, even when the reference targets a subschema within it. Push the
 	// enclosing resource so $dynamicRef bookending sees it.
-	if resource := resolver.ResourceFor(schema.ResolveURI(baseURI, r.reference)); resource != nil && resource != &targetSchema {
+	if resource := resolver.ResourceFor(location); resource != nil && resource != &targetSchema {
 		compiled = &dynamicScopeValidator{schema: resource, inner: compiled}
 	}
 	return compiled, nil
@@ -99,7 +122,7 @@ type dynamicScopeValidator struct {
 }
 
 func (d *dynamicScopeValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return d.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, d, v, options)
 }
 
 func (d *dynamicScopeValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
@@ -108,16 +131,25 @@ func (d *dynamicScopeValidator) evaluate(ctx context.Context, v any, st *evalSta
 
 // DynamicReferenceValidator handles $dynamicRef. Unlike $ref, a $dynamicRef can
 // resolve to different targets on different validations depending on the runtime
-// dynamic scope, so resolution happens per-Validate (not memoized once).
+// dynamic scope, so resolution happens per-Validate (not memoized once). The
+// validator compiled for each target is kept, and compiled once even when
+// concurrent calls first reach that target together.
+//
+// It also handles the draft 2019-09 $recursiveRef, which resolves the same way
+// except that it is bookended by "$recursiveAnchor": true instead of a named
+// $dynamicAnchor (see resolveRecursiveRef).
 type DynamicReferenceValidator struct {
 	reference  string
+	recursive  bool // $recursiveRef rather than $dynamicRef
 	resolver   *schema.Resolver
 	rootSchema *schema.Schema
 	baseSchema *schema.Schema // Enclosing resource for non-dynamic fallback resolution
 	baseURI    string         // Enclosing resource's base URI
+	draft      schema.Draft   // Draft in effect where the reference appears
+	cfg        *compileConfig // Compile options in effect (nil = defaults)
 
 	mu    sync.Mutex
-	cache map[*schema.Schema]Interface // compiled validators keyed by resolved target
+	cache map[*schema.Schema]*dynamicTarget // compiled validators keyed by resolved target
 }
 
 // NewDynamicReferenceValidator creates a new DynamicReferenceValidator for the given reference
@@ -128,7 +160,7 @@ func NewDynamicReferenceValidator(reference string) *DynamicReferenceValidator {
 }
 
 func (dr *DynamicReferenceValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return dr.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, dr, v, options)
 }
 
 func (dr *DynamicReferenceValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
@@ -139,22 +171,29 @@ func (dr *DynamicReferenceValidator) evaluate(ctx context.Context, v any, st *ev
 	// registers itself under "meta" and recurses, since no schema document is
 	// available to resolve against at validation time. The registered validator
 	// represents an outermost resource, so it re-enters with fresh state.
-	if name := plainAnchorFragment(dr.reference); name != "" {
+	if name := plainAnchorFragment(dr.reference); name != "" && !dr.recursive {
 		if rv := st.dynamicAnchorValidators[name]; rv != nil {
 			// The registered validator stands in for an outermost resource, so it
-			// re-enters with fresh dynamic scope; the anchor registry is carried
-			// forward so nested $dynamicRefs to the same anchor still resolve.
-			return evalChild(ctx, rv, v, &evalState{dynamicAnchorValidators: st.dynamicAnchorValidators})
+			// re-enters with fresh dynamic scope; the anchor registry and the
+			// rest of the state are carried forward so nested $dynamicRefs to
+			// the same anchor still resolve.
+			fresh := *st
+			fresh.dynamicScope = nil
+			return evalChild(ctx, rv, v, &fresh)
 		}
 	}
 
+	kind := "dynamic"
+	if dr.recursive {
+		kind = "recursive"
+	}
 	target, err := dr.resolveTarget(ctx, st)
 	if err != nil {
-		return nil, fmt.Errorf("dynamic reference resolution failed for %s: %w", dr.reference, err)
+		return nil, fmt.Errorf("%s reference resolution failed for %s: %w", kind, dr.reference, err)
 	}
 	validator, err := dr.validatorFor(ctx, target)
 	if err != nil {
-		return nil, fmt.Errorf("dynamic reference resolution failed for %s: %w", dr.reference, err)
+		return nil, fmt.Errorf("%s reference resolution failed for %s: %w", kind, dr.reference, err)
 	}
 	return evalChild(ctx, validator, v, st)
 }
@@ -170,31 +209,63 @@ func (dr *DynamicReferenceValidator) resolveTarget(ctx context.Context, st *eval
 	if baseSchema == nil {
 		baseSchema = dr.rootSchema
 	}
+	if dr.recursive {
+		return resolveRecursiveRef(ctx, resolver, baseSchema, dr.baseURI, dr.reference, st.dynamicScope)
+	}
 	return resolveDynamicRef(ctx, resolver, baseSchema, dr.baseURI, dr.reference, st.dynamicScope)
 }
 
+// dynamicTarget is the validator compiled for one target of a
+// DynamicReferenceValidator. Concurrent evaluations reaching the same target
+// wait on once, so each target is compiled once and every caller sees the
+// same validator.
+type dynamicTarget struct {
+	once      sync.Once
+	validator Interface
+	err       error
+}
+
 // validatorFor compiles (and caches) the validator for a resolved target schema.
 func (dr *DynamicReferenceValidator) validatorFor(ctx context.Context, target *schema.Schema) (Interface, error) {
 	dr.mu.Lock()
 	if dr.cache == nil {
-		dr.cache = make(map[*schema.Schema]Interface)
+		dr.cache = make(map[*schema.Schema]*dynamicTarget)
 	}
-	if v, ok := dr.cache[target]; ok {
-		dr.mu.Unlock()
-		return v, nil
+	entry, ok := dr.cache[target]
+	if !ok {
+		entry = &dynamicTarget{}
+		dr.cache[target] = entry
 	}
 	dr.mu.Unlock()
 
+	entry.once.Do(func() {
+		entry.validator, entry.err = dr.compileTarget(ctx, target)
+	})
+	if entry.err != nil {
+		// A failure is not kept: the callers waiting on this attempt share
+		// it, and the next evaluation tries again.
+		dr.mu.Lock()
+		if dr.cache[target] == entry {
+			delete(dr.cache, target)
+		}
+		dr.mu.Unlock()
+		return nil, entry.err
+	}
+	return entry.validator, nil
+}
+
+func (dr *DynamicReferenceValidator) compileTarget(ctx context.Context, target *schema.Schema) (Interface, error) {
 	resolver := dr.resolver
 	if resolver == nil {
 		resolver = schema.NewResolver()
 	}
 	cs := compileState{
-		cfg:        &compileConfig{resolver: resolver, vocab: vocabulary.DefaultSet()},
+		cfg:        lazyCompileConfig(dr.cfg, resolver),
 		rootSchema: dr.rootSchema,
 		baseSchema: target,
+		draft:      dr.draft,
 	}
-	if target.HasID() && target.ID() != "" {
+	if target.HasID() && schema.IsResourceID(target.ID()) {
 		// Resolve the target's (possibly relative) $id against the base URI under
 		// which the $dynamicRef itself was resolved, so the target's own relative
 		// references resolve within its resource.
@@ -210,10 +281,6 @@ func (dr *DynamicReferenceValidator) validatorFor(ctx context.Context, target *s
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile dynamic reference target %s: %w", dr.reference, err)
 	}
-
-	dr.mu.Lock()
-	dr.cache[target] = v
-	dr.mu.Unlock()
 	return v, nil
 }
 
@@ -263,3 +330,33 @@ func resolveDynamicRef(ctx context.Context, resolver *schema.Resolver, baseSchem
 	}
 	return &lexical, nil
 }
+
+// resolveRecursiveRef resolves a draft 2019-09 $recursiveRef. 2019-09 defines
+// the keyword only for the value "#", the resource the reference appears in.
+// When that resource declares "$recursiveAnchor": true, the reference is
+// instead resolved to the outermost resource of the runtime dynamic scope that
+// also declares "$recursiveAnchor": true. Any other value is resolved the way
+// $ref would resolve it, without consulting the dynamic scope.
+func resolveRecursiveRef(ctx context.Context, resolver *schema.Resolver, baseSchema *schema.Schema, baseURI string, recursiveRef string, scopeChain []*schema.Schema) (*schema.Schema, error) {
+	if recursiveRef != "#" {
+		var target schema.Schema
+		if err := resolver.ResolveReference(ctx, &target, recursiveRef, baseSchema, baseURI); err != nil {
+			return nil, fmt.Errorf("failed to resolve recursive reference %s: %w", recursiveRef, err)
+		}
+		return &target, nil
+	}
+	// The resource is returned as is, which keeps validatorFor's cache
+	// effective.
+	if baseSchema == nil {
+		return nil, fmt.Errorf("no base schema available for resolving %s", recursiveRef)
+	}
+	if !baseSchema.HasRecursiveAnchor() || !baseSchema.RecursiveAnchor() {
+		return baseSchema, nil
+	}
+	for _, resource := range scopeChain {
+		if resource.HasRecursiveAnchor() && resource.RecursiveAnchor() {
+			return resource, nil
+		}
+	}
+	return baseSchema, nil
+}
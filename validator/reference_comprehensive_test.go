@@ -460,6 +460,106 @@ func TestCircularReferenceDetection(t *testing.T) {
 	require.Contains(t, err.Error(), "circular reference")
 }
 
+func TestRecursiveSchemasWithoutID(t *testing.T) {
+	compile := func(t *testing.T, src string) (validator.Interface, error) {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return validator.Compile(t.Context(), &s)
+	}
+	// tree returns a node nested depth levels deep whose innermost node has
+	// the given name.
+	tree := func(depth int, name any) any {
+		var v any = map[string]any{"name": name}
+		for range depth {
+			v = map[string]any{"name": "node", "children": []any{v}}
+		}
+		return v
+	}
+
+	recursive := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name: "root",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#"}}
+				}
+			}`,
+		},
+		{
+			name: "anchor",
+			schema: `{
+				"$defs": {"node": {
+					"$anchor": "node",
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#node"}}
+					}
+				}},
+				"$ref": "#node"
+			}`,
+		},
+		{
+			name: "mutually recursive definitions",
+			schema: `{
+				"$defs": {
+					"node": {
+						"type": "object",
+						"properties": {
+							"name": {"type": "string"},
+							"children": {"$ref": "#/$defs/children"}
+						}
+					},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+				},
+				"$ref": "#/$defs/node"
+			}`,
+		},
+	}
+	for _, tc := range recursive {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := compile(t, tc.schema)
+			require.NoError(t, err)
+
+			_, err = v.Validate(t.Context(), tree(5000, "leaf"))
+			require.NoError(t, err)
+			// Every level adds to the error message, so fail less deep.
+			_, err = v.Validate(t.Context(), tree(100, 42))
+			require.Error(t, err, "the innermost node is checked too")
+		})
+	}
+
+	t.Run("same pointer in another resource is not a cycle", func(t *testing.T) {
+		v, err := compile(t, `{
+			"$defs": {
+				"a": {"$ref": "https://example.com/inner"},
+				"inner": {
+					"$id": "https://example.com/inner",
+					"$defs": {"a": {"type": "string"}},
+					"$ref": "#/$defs/a"
+				}
+			},
+			"$ref": "#/$defs/a"
+		}`)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "x")
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 1)
+		require.Error(t, err)
+	})
+
+	t.Run("a cycle through an anchor is still rejected", func(t *testing.T) {
+		_, err := compile(t, `{"$defs": {"a": {"$anchor": "a", "$ref": "#/$defs/a"}}, "$ref": "#a"}`)
+		require.ErrorContains(t, err, "circular reference")
+	})
+}
+
 func TestBasicReferenceResolution(t *testing.T) {
 	t.Run("local reference", func(t *testing.T) {
 		// Schema with a local reference
@@ -91,4 +91,103 @@ func TestReferenceIDResolution(t *testing.T) {
 		_, err = v.Validate(t.Context(), map[string]any{"percent": "not an integer"})
 		require.Error(t, err)
 	})
+
+	t.Run("$id examples of the draft-07 specification", func(t *testing.T) {
+		// The example document of draft-07 section 8.2.4, each subschema made
+		// to accept a single value so that every reference can be told apart.
+		v := compile(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"$id": "http://example.com/root.json",
+			"definitions": {
+				"A": {"$id": "#foo", "const": "A"},
+				"B": {
+					"$id": "other.json",
+					"definitions": {
+						"X": {"$id": "#bar", "const": "X"},
+						"Y": {"$id": "t/inner.json", "const": "Y"}
+					},
+					"const": "B"
+				},
+				"C": {"$id": "urn:uuid:ee564b8a-7a87-4125-8c96-e9f123d6766f", "const": "C"}
+			},
+			"properties": {
+				"a": {"$ref": "#foo"},
+				"a2": {"$ref": "http://example.com/root.json#foo"},
+				"b": {"$ref": "other.json"},
+				"x": {"$ref": "other.json#bar"},
+				"y": {"$ref": "t/inner.json"},
+				"y2": {"$ref": "http://example.com/t/inner.json"},
+				"c": {"$ref": "urn:uuid:ee564b8a-7a87-4125-8c96-e9f123d6766f"}
+			}
+		}`)
+
+		for property, want := range map[string]string{"a": "A", "a2": "A", "b": "B", "x": "X", "y": "Y", "y2": "Y", "c": "C"} {
+			_, err := v.Validate(t.Context(), map[string]any{property: want})
+			require.NoError(t, err, property)
+			_, err = v.Validate(t.Context(), map[string]any{property: "other"})
+			require.Error(t, err, property)
+		}
+	})
+
+	t.Run("fragment-only $id does not change the base", func(t *testing.T) {
+		// "#foo" names A within the root resource, so the "#/$defs/c" inside
+		// it still points into the root, not into A.
+		v := compile(t, `{
+			"$id": "https://example.com/root.json",
+			"$defs": {
+				"A": {"$id": "#foo", "properties": {"y": {"$ref": "#/$defs/c"}}},
+				"c": {"type": "string"}
+			},
+			"properties": {"x": {"$ref": "#foo"}}
+		}`)
+
+		_, err := v.Validate(t.Context(), map[string]any{"x": map[string]any{"y": "a string"}})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"x": map[string]any{"y": 12}})
+		require.Error(t, err)
+	})
+
+	t.Run("nested relative $id without an absolute base", func(t *testing.T) {
+		// Each $id resolves against the one enclosing it, even when the
+		// document itself has no base URI to start from.
+		v := compile(t, `{
+			"$defs": {
+				"sub": {
+					"$id": "sub/",
+					"$defs": {
+						"t": {"$id": "t.json", "type": "integer"},
+						"u": {"$id": "../u.json", "type": "string"}
+					}
+				}
+			},
+			"properties": {
+				"t": {"$ref": "sub/t.json"},
+				"u": {"$ref": "u.json"}
+			}
+		}`)
+
+		_, err := v.Validate(t.Context(), map[string]any{"t": 1, "u": "a string"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"t": "not an integer"})
+		require.Error(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"u": 12})
+		require.Error(t, err)
+	})
+
+	for _, id := range []string{"rel/", "./rel/"} {
+		t.Run("relative $id "+id+" on the root", func(t *testing.T) {
+			// The root's own $id is the base the nested "x.json" and the
+			// reference to it both resolve against, once.
+			v := compile(t, `{
+				"$id": "`+id+`",
+				"$defs": {"s": {"$id": "x.json", "type": "string"}},
+				"$ref": "x.json"
+			}`)
+
+			_, err := v.Validate(t.Context(), "a string")
+			require.NoError(t, err)
+			_, err = v.Validate(t.Context(), 12)
+			require.Error(t, err)
+		})
+	}
 }
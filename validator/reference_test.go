@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceValidatorResolvesOnFirstUse(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"value": {"type": "integer"},
+			"next": {"$ref": "#"}
+		},
+		"required": ["value"]
+	}`)))
+	list := func(values ...any) any {
+		var v any
+		for i := len(values) - 1; i >= 0; i-- {
+			node := map[string]any{"value": values[i]}
+			if v != nil {
+				node["next"] = v
+			}
+			v = node
+		}
+		return v
+	}
+
+	rv := &ReferenceValidator{reference: "#", rootSchema: &s}
+	require.Nil(t, rv.resolved, "nothing is resolved before Validate")
+
+	_, err := rv.Validate(t.Context(), list(1, 2, 3, 4, 5))
+	require.NoError(t, err)
+	resolved := rv.resolved
+	require.NotNil(t, resolved)
+
+	_, err = rv.Validate(t.Context(), list(1, 2, 3, "four", 5))
+	require.ErrorContains(t, err, "/next/next/next/value")
+	require.Same(t, resolved, rv.resolved, "the target is compiled once")
+
+	_, err = rv.Validate(t.Context(), list(1, 2, map[string]any{}))
+	require.Error(t, err)
+}
+
+func TestRecursiveReferences(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"$defs": {
+			"tree": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"},
+					"left": {"$ref": "#/$defs/tree"},
+					"right": {"$ref": "#/$defs/tree"}
+				},
+				"additionalProperties": false
+			}
+		},
+		"$ref": "#/$defs/tree"
+	}`)))
+	v, err := Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	leaf := func(value any) map[string]any { return map[string]any{"value": value} }
+	node := func(value string, left, right map[string]any) map[string]any {
+		return map[string]any{"value": value, "left": left, "right": right}
+	}
+
+	_, err = v.Validate(t.Context(), node("a", node("b", leaf("c"), leaf("d")), node("e", leaf("f"), node("g", leaf("h"), leaf("i")))))
+	require.NoError(t, err)
+
+	_, err = v.Validate(t.Context(), node("a", node("b", leaf("c"), leaf("d")), node("e", leaf("f"), node("g", leaf("h"), leaf(9)))))
+	require.ErrorContains(t, err, "/right/right/right/value")
+
+	_, err = v.Validate(t.Context(), node("a", leaf("b"), map[string]any{"value": "c", "middle": leaf("d")}))
+	require.Error(t, err, "additionalProperties applies at every level")
+}
+
+func TestRecursiveReferenceWithSiblingKeywords(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"$defs": {
+			"node": {
+				"type": "array",
+				"items": {"$ref": "#/$defs/node", "maxItems": 1}
+			}
+		},
+		"$ref": "#/$defs/node"
+	}`)))
+	v, err := Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	_, err = v.Validate(t.Context(), []any{[]any{[]any{}}, []any{}})
+	require.NoError(t, err)
+
+	_, err = v.Validate(t.Context(), []any{[]any{[]any{}, []any{}}})
+	require.Error(t, err, "maxItems next to the deferred $ref applies")
+
+	_, err = v.Validate(t.Context(), []any{[]any{[]any{[]any{}, []any{}}}})
+	require.Error(t, err, "and keeps applying a level further down")
+}
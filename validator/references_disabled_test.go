@@ -0,0 +1,81 @@
+package validator_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReferencesDisabled(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+
+	testcases := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{
+			name:   "$ref at the root",
+			schema: `{"$ref": "https://example.com/schema.json"}`,
+			err:    "references are disabled: $ref found at #",
+		},
+		{
+			name:   "nested $ref",
+			schema: `{"properties": {"a": {"items": {"$ref": "#/$defs/a"}}}, "$defs": {"a": {}}}`,
+			err:    "references are disabled: $ref found at #/properties/a/items",
+		},
+		{
+			name:   "$dynamicRef",
+			schema: `{"$defs": {"node": {"$dynamicRef": "#node"}}}`,
+			err:    "references are disabled: $dynamicRef found at #/$defs/node",
+		},
+		{
+			name:   "$recursiveRef",
+			schema: `{"$schema": "https://json-schema.org/draft/2019-09/schema", "anyOf": [{"$recursiveRef": "#"}]}`,
+			err:    "references are disabled: $recursiveRef found at #/anyOf/0",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validator.Compile(t.Context(), parse(t, tc.schema), validator.WithReferencesDisabled())
+			require.EqualError(t, err, tc.err)
+
+			_, err = validator.Compile(t.Context(), parse(t, tc.schema))
+			if err != nil {
+				require.NotContains(t, err.Error(), "references are disabled")
+			}
+		})
+	}
+
+	t.Run("schemas without references compile", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{"type": "object", "properties": {"a": {"type": "string"}}}`), validator.WithReferencesDisabled())
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"a": 1})
+		require.Error(t, err)
+	})
+
+	t.Run("metaschema is not retrieved", func(t *testing.T) {
+		resolver := schema.NewResolver()
+		resolver.RegisterDocument("https://example.com/meta/no-validation", parse(t, `{
+			"$id": "https://example.com/meta/no-validation",
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://json-schema.org/draft/2020-12/vocab/applicator": true
+			}
+		}`))
+		v, err := validator.Compile(t.Context(), parse(t, `{
+			"$schema": "https://example.com/meta/no-validation",
+			"minimum": 10
+		}`), validator.WithResolver(resolver), validator.WithReferencesDisabled())
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), 1)
+		require.Error(t, err, "the default vocabulary set stays in effect")
+	})
+}
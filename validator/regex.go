@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// JSON Schema patterns are ECMA-262 regular expressions, while Go's regexp
+// package implements RE2. The two agree on most everyday syntax, but RE2 has no
+// lookaround or backreferences, and it does not know ECMA-262 escapes such as
+// \uXXXX. compilePattern reports such constructs by name instead of passing
+// the raw RE2 error through, and with WithECMAScriptRegex it first rewrites the
+// ones that have an RE2 equivalent.
+
+// ecmaWhitespace is the ECMA-262 WhiteSpace and LineTerminator set matched by
+// \s, for use inside a character class. RE2's \s covers only ASCII.
+const ecmaWhitespace = `\s\x{0B}\x{FEFF}\p{Zs}\x{2028}\x{2029}`
+
+// unsupportedPatternError describes a construct in an ECMA-262 pattern that
+// RE2 has no equivalent for.
+type unsupportedPatternError struct {
+	construct string
+	offset    int
+}
+
+func (e *unsupportedPatternError) Error() string {
+	return fmt.Sprintf(`%s at offset %d is not supported by Go regular expressions (RE2)`, e.construct, e.offset)
+}
+
+// compilePattern compiles the ECMA-262 pattern p. When ecma is true, p is
+// first translated with translateECMAPattern.
+func compilePattern(p string, ecma bool) (*regexp.Regexp, error) {
+	src := p
+	if ecma {
+		translated, err := translateECMAPattern(p)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid pattern %q: %w`, p, err)
+		}
+		src = translated
+	}
+	re, err := regexp.Compile(src)
+	if err != nil {
+		return nil, describePatternError(p, err, ecma)
+	}
+	return re, nil
+}
+
+// describePatternError explains why p failed to compile. The translator
+// recognizes every ECMA-262 construct RE2 rejects, so it is run to find out
+// whether one is to blame, even when translation was not requested.
+func describePatternError(p string, err error, ecma bool) error {
+	translated, terr := translateECMAPattern(p)
+	switch {
+	case terr != nil:
+		return fmt.Errorf(`invalid pattern %q: %w`, p, terr)
+	case !ecma && translated != p:
+		if _, cerr := regexp.Compile(translated); cerr == nil {
+			return fmt.Errorf(`invalid pattern %q: it uses ECMA-262 syntax that Go regular expressions (RE2) do not accept; compile with validator.WithECMAScriptRegex(true) to translate it: %w`, p, err)
+		}
+	}
+	return fmt.Errorf(`invalid pattern %q: %w`, p, err)
+}
+
+// translateECMAPattern rewrites the ECMA-262 pattern p into an RE2 pattern
+// with the same meaning:
+//
+//   - \uXXXX, \u{X...} (surrogate pairs combined), \cX and \0 become \x{...}
+//   - \s, and \S outside a character class, match the ECMA-262 whitespace
+//     set rather than ASCII whitespace only
+//   - . excludes every line terminator (\n, \r, U+2028, U+2029), not just \n
+//   - [^] and [] become the classes matching any and no character
+//   - \p{Script=Greek} and the other long forms of \p become \p{Greek}
+//
+// Lookahead, lookbehind, and backreferences (numbered or named) have no RE2
+// equivalent and are reported as an *unsupportedPatternError.
+func translateECMAPattern(p string) (string, error) {
+	var sb strings.Builder
+	inClass := false
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(p) {
+				sb.WriteByte(c)
+				continue
+			}
+			n, err := translateEscape(&sb, p, i, inClass)
+			if err != nil {
+				return "", err
+			}
+			i += n - 1
+		case inClass:
+			switch c {
+			case ']':
+				inClass = false
+			case '[':
+				// a literal "[" in ECMA-262; RE2 would look for [:name:]
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(c)
+		case c == '[':
+			switch {
+			case strings.HasPrefix(p[i:], "[^]"):
+				sb.WriteString(`[\x{0}-\x{10FFFF}]`)
+				i += 2
+			case strings.HasPrefix(p[i:], "[]"):
+				sb.WriteString(`[^\x{0}-\x{10FFFF}]`)
+				i++
+			default:
+				inClass = true
+				sb.WriteByte(c)
+				if i+1 < len(p) && p[i+1] == '^' {
+					sb.WriteByte('^')
+					i++
+				}
+			}
+		case c == '(':
+			for _, look := range []struct{ prefix, name string }{
+				{"(?<=", "lookbehind"},
+				{"(?<!", "negative lookbehind"},
+				{"(?=", "lookahead"},
+				{"(?!", "negative lookahead"},
+			} {
+				if strings.HasPrefix(p[i:], look.prefix) {
+					return "", &unsupportedPatternError{construct: fmt.Sprintf(`%s %q`, look.name, look.prefix), offset: i}
+				}
+			}
+			sb.WriteByte(c)
+		case c == '.':
+			sb.WriteString(`[^\n\r\x{2028}\x{2029}]`)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), nil
+}
+
+// translateEscape writes the RE2 form of the escape sequence starting at
+// p[i] (a backslash) and returns the number of bytes of p it consumed.
+func translateEscape(sb *strings.Builder, p string, i int, inClass bool) (int, error) {
+	c := p[i+1]
+	switch {
+	case c == 'u':
+		r, n, ok := parseUnicodeEscape(p[i:])
+		if !ok {
+			break
+		}
+		if utf16.IsSurrogate(r) {
+			if r2, n2, ok := parseUnicodeEscape(p[i+n:]); ok {
+				if combined := utf16.DecodeRune(r, r2); combined != unicode.ReplacementChar {
+					fmt.Fprintf(sb, `\x{%X}`, combined)
+					return n + n2, nil
+				}
+			}
+		}
+		fmt.Fprintf(sb, `\x{%X}`, r)
+		return n, nil
+	case c == 'c':
+		if i+2 < len(p) && isASCIILetter(p[i+2]) {
+			fmt.Fprintf(sb, `\x{%X}`, p[i+2]%32)
+			return 3, nil
+		}
+	case c == '0' && (i+2 >= len(p) || !isDigit(p[i+2])):
+		sb.WriteString(`\x{0}`)
+		return 2, nil
+	case c >= '1' && c <= '9':
+		j := i + 1
+		for j < len(p) && isDigit(p[j]) {
+			j++
+		}
+		return 0, &unsupportedPatternError{construct: fmt.Sprintf(`backreference %q`, p[i:j]), offset: i}
+	case c == 'k' && i+2 < len(p) && p[i+2] == '<':
+		j := strings.IndexByte(p[i:], '>')
+		ref := p[i:]
+		if j >= 0 {
+			ref = p[i : i+j+1]
+		}
+		return 0, &unsupportedPatternError{construct: fmt.Sprintf(`named backreference %q`, ref), offset: i}
+	case c == 's':
+		if inClass {
+			sb.WriteString(ecmaWhitespace)
+		} else {
+			sb.WriteString(`[` + ecmaWhitespace + `]`)
+		}
+		return 2, nil
+	case c == 'S' && !inClass:
+		// Inside a class \S has no RE2 rewrite and keeps RE2's ASCII meaning.
+		sb.WriteString(`[^` + ecmaWhitespace + `]`)
+		return 2, nil
+	case c == 'b' && inClass:
+		// backspace, in a class
+		sb.WriteString(`\x{8}`)
+		return 2, nil
+	case (c == 'p' || c == 'P') && i+2 < len(p) && p[i+2] == '{':
+		end := strings.IndexByte(p[i:], '}')
+		if end < 0 {
+			break
+		}
+		name := p[i+3 : i+end]
+		if eq := strings.IndexByte(name, '='); eq >= 0 {
+			switch name[:eq] {
+			case "Script", "sc", "Script_Extensions", "scx", "General_Category", "gc":
+				name = name[eq+1:]
+			}
+		}
+		fmt.Fprintf(sb, `\%c{%s}`, c, name)
+		return end + 1, nil
+	}
+	sb.WriteString(p[i : i+2])
+	return 2, nil
+}
+
+// parseUnicodeEscape parses \uXXXX or \u{X...} at the start of s.
+func parseUnicodeEscape(s string) (rune, int, bool) {
+	if !strings.HasPrefix(s, `\u`) {
+		return 0, 0, false
+	}
+	if strings.HasPrefix(s, `\u{`) {
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return 0, 0, false
+		}
+		v, err := strconv.ParseUint(s[3:end], 16, 32)
+		if err != nil || v > 0x10FFFF {
+			return 0, 0, false
+		}
+		return rune(v), end + 1, true
+	}
+	if len(s) < 6 {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(s[2:6], 16, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	return rune(v), 6, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
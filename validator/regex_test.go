@@ -0,0 +1,149 @@
+package validator_test
+
+import (
+	"strconv"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternUnsupportedConstructs(t *testing.T) {
+	testcases := []struct {
+		Name      string
+		Pattern   string
+		Construct string
+	}{
+		{Name: "lookahead", Pattern: `^(?=.*\d)\w+$`, Construct: `lookahead "(?="`},
+		{Name: "negative lookahead", Pattern: `^(?!foo)`, Construct: `negative lookahead "(?!"`},
+		{Name: "lookbehind", Pattern: `(?<=\$)\d+`, Construct: `lookbehind "(?<="`},
+		{Name: "backreference", Pattern: `^(a+)\1$`, Construct: `backreference "\\1"`},
+		{Name: "named backreference", Pattern: `^(?<q>["'])x\k<q>$`, Construct: `named backreference "\\k<q>"`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s, err := schema.NewBuilder().Pattern(tc.Pattern).Build()
+			require.NoError(t, err)
+			for _, ecma := range []bool{false, true} {
+				_, err = validator.Compile(t.Context(), s, validator.WithECMAScriptRegex(ecma))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.Construct)
+				require.Contains(t, err.Error(), strconv.Quote(tc.Pattern), "error should quote the original pattern")
+			}
+		})
+	}
+}
+
+func TestECMAScriptRegex(t *testing.T) {
+	t.Run("translatable pattern suggests the option", func(t *testing.T) {
+		s, err := schema.NewBuilder().Pattern(`^\u0041+$`).Build()
+		require.NoError(t, err)
+		_, err = validator.Compile(t.Context(), s)
+		require.ErrorContains(t, err, `validator.WithECMAScriptRegex(true)`)
+	})
+
+	testcases := []struct {
+		Name    string
+		Pattern string
+		Valid   []string
+		Invalid []string
+	}{
+		{
+			Name:    "unicode escape",
+			Pattern: `^\u0041+$`,
+			Valid:   []string{"AAA"},
+			Invalid: []string{"aaa"},
+		},
+		{
+			Name:    "surrogate pair",
+			Pattern: `^\uD83D\uDE00$`,
+			Valid:   []string{"😀"},
+			Invalid: []string{"x"},
+		},
+		{
+			Name:    "dot excludes line terminators",
+			Pattern: `^a.b$`,
+			Valid:   []string{"axb"},
+			Invalid: []string{"a\rb", "a\u2028b"},
+		},
+		{
+			Name:    "whitespace is unicode aware",
+			Pattern: `^\s$`,
+			Valid:   []string{" ", "\u00a0", "\ufeff"},
+			Invalid: []string{"x"},
+		},
+		{
+			Name:    "non-whitespace",
+			Pattern: `^\S+$`,
+			Valid:   []string{"abc"},
+			Invalid: []string{"a b"},
+		},
+		{
+			Name:    "empty negated class matches anything",
+			Pattern: `^a[^]b$`,
+			Valid:   []string{"a\nb", "axb"},
+			Invalid: []string{"ab"},
+		},
+		{
+			Name:    "long form property escape",
+			Pattern: `^\p{Script=Greek}+$`,
+			Valid:   []string{"αβγ"},
+			Invalid: []string{"abc"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s, err := schema.NewBuilder().Types(schema.StringType).Pattern(tc.Pattern).Build()
+			require.NoError(t, err)
+			v, err := validator.Compile(t.Context(), s, validator.WithECMAScriptRegex(true))
+			require.NoError(t, err)
+			for _, in := range tc.Valid {
+				_, err := v.Validate(t.Context(), in)
+				require.NoError(t, err, "%q should match %s", in, tc.Pattern)
+			}
+			for _, in := range tc.Invalid {
+				_, err := v.Validate(t.Context(), in)
+				require.Error(t, err, "%q should not match %s", in, tc.Pattern)
+			}
+		})
+	}
+
+	t.Run("patternProperties", func(t *testing.T) {
+		s, err := schema.NewBuilder().
+			PatternProperty(`^\u0078_`, schema.NewBuilder().Types(schema.IntegerType).MustBuild()).
+			Build()
+		require.NoError(t, err)
+
+		_, err = validator.Compile(t.Context(), s)
+		require.Error(t, err)
+
+		v, err := validator.Compile(t.Context(), s, validator.WithECMAScriptRegex(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"x_a": 1, "y_a": "s"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"x_a": "s"})
+		require.Error(t, err)
+	})
+
+	t.Run("regex format", func(t *testing.T) {
+		s, err := schema.NewBuilder().Types(schema.StringType).Format("regex").Build()
+		require.NoError(t, err)
+
+		v, err := validator.Compile(t.Context(), s, validator.WithFormatAssertion(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `^[a-z]+$`)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `^(abc`)
+		require.ErrorContains(t, err, "invalid regex format")
+		_, err = v.Validate(t.Context(), `^\u0041+$`)
+		require.ErrorContains(t, err, `validator.WithECMAScriptRegex(true)`)
+
+		v, err = validator.Compile(t.Context(), s, validator.WithFormatAssertion(true), validator.WithECMAScriptRegex(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `^\u0041+$`)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), `^(?=a)`)
+		require.ErrorContains(t, err, `lookahead "(?="`)
+	})
+}
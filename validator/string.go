@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
-	"time"
 	"unicode/utf8"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -29,12 +28,27 @@ type stringValidator struct {
 	minLength        *uint
 	pattern          *regexp.Regexp
 	format           *string
+	ecmaRegex        bool // "regex" format values are ECMA-262 patterns to translate
 	enum             []any
 	constantValue    any
 	strictStringType bool // true when schema explicitly declares type: string
 }
 
-func (v *stringValidator) Validate(ctx context.Context, in any, _ ...ValidateOption) (Result, error) {
+func (v *stringValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
+	in, err := leafValue(in, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
+	res, err := v.check(ctx, in, nil)
+	return res, leafError(err)
+}
+
+func (v *stringValidator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
+	res, err := v.check(ctx, in, st)
+	return res, leafError(err)
+}
+
+func (v *stringValidator) check(ctx context.Context, in any, st *evalState) (Result, error) {
 	logger := TraceSlogFromContext(ctx)
 	logger.InfoContext(ctx, "string validator starting", "value", in, "type", fmt.Sprintf("%T", in))
 	rv := reflect.ValueOf(in)
@@ -51,7 +65,7 @@ func (v *stringValidator) Validate(ctx context.Context, in any, _ ...ValidateOpt
 		if v.strictStringType {
 			// When schema explicitly declares type: string, non-string values should fail
 			logger.InfoContext(ctx, "string validator rejecting non-string for strict type", "strict", true)
-			return nil, fmt.Errorf(`invalid value passed to StringValidator: expected string, got %T`, in)
+			return nil, codeErrorf(ErrCodeType, `invalid value passed to StringValidator: expected string, got %T`, in)
 		}
 		// For non-string values with inferred string type, string constraints don't apply
 		// According to JSON Schema spec, string constraints should be ignored for non-strings
@@ -67,7 +81,10 @@ func (v *stringValidator) Validate(ctx context.Context, in any, _ ...ValidateOpt
 	logger.InfoContext(ctx, "string validator checking constraints", "length", l, "value_preview", truncateString(str, 50))
 
 	if v.constantValue != nil {
-		if err := validateConst(ctx, str, v.constantValue); err != nil {
+		mark := st.beginKeyword(keywords.Const)
+		err := validateConst(ctx, str, v.constantValue)
+		st.endKeyword(mark)
+		if err != nil {
 			return nil, fmt.Errorf(`invalid value passed to StringValidator: %w`, err)
 		}
 	}
@@ -75,34 +92,43 @@ func (v *stringValidator) Validate(ctx context.Context, in any, _ ...ValidateOpt
 	if ml := v.minLength; ml != nil {
 		logger.InfoContext(ctx, "string validator checking minLength", "minLength", *ml, "actual", l)
 		if l < *ml {
-			return nil, fmt.Errorf(`invalid value passed to StringValidator: string length (%d) shorter then minLength (%d)`, l, *ml)
+			return nil, codeErrorf(ErrCodeMinLength, `invalid value passed to StringValidator: string length (%d) shorter than minLength (%d)`, l, *ml)
 		}
 	}
 
 	if ml := v.maxLength; ml != nil {
 		logger.InfoContext(ctx, "string validator checking maxLength", "maxLength", *ml, "actual", l)
 		if l > *ml {
-			return nil, fmt.Errorf(`invalid value passed to StringValidator: string length (%d) longer then maxLength (%d)`, l, *ml)
+			return nil, codeErrorf(ErrCodeMaxLength, `invalid value passed to StringValidator: string length (%d) longer than maxLength (%d)`, l, *ml)
 		}
 	}
 
 	if pat := v.pattern; pat != nil {
 		logger.InfoContext(ctx, "string validator checking pattern", "pattern", pat.String())
-		if !pat.MatchString(str) {
-			return nil, fmt.Errorf(`invalid value passed to StringValidator: string did not match pattern %s`, pat.String())
+		mark := st.beginKeyword(keywords.Pattern)
+		matched := pat.MatchString(str)
+		st.endKeyword(mark)
+		if !matched {
+			return nil, codeErrorf(ErrCodePattern, `invalid value passed to StringValidator: string did not match pattern %s`, pat.String())
 		}
 	}
 
 	if len(v.enum) > 0 {
-		if err := validateEnum(ctx, str, v.enum); err != nil {
+		mark := st.beginKeyword(keywords.Enum)
+		err := validateEnum(ctx, str, v.enum)
+		st.endKeyword(mark)
+		if err != nil {
 			return nil, fmt.Errorf(`invalid value passed to StringValidator: %w`, err)
 		}
 	}
 
 	if format := v.format; format != nil {
 		logger.InfoContext(ctx, "string validator checking format", "format", *format, "value", str)
-		if err := validateFormat(str, *format); err != nil {
-			return nil, fmt.Errorf(`invalid value passed to StringValidator: %w`, err)
+		mark := st.beginKeyword(keywords.Format)
+		err := v.validateFormat(str, *format)
+		st.endKeyword(mark)
+		if err != nil {
+			return nil, codeErrorf(ErrCodeFormat, `invalid value passed to StringValidator: %w`, err)
 		}
 	}
 
@@ -111,7 +137,7 @@ func (v *stringValidator) Validate(ctx context.Context, in any, _ ...ValidateOpt
 }
 
 // validateFormat validates a string against the specified format
-func validateFormat(value, format string) error {
+func (v *stringValidator) validateFormat(value, format string) error {
 	switch format {
 	case keywords.FormatEmail:
 		_, err := mail.ParseAddress(value)
@@ -119,25 +145,62 @@ func validateFormat(value, format string) error {
 			return fmt.Errorf("invalid email format")
 		}
 	case keywords.FormatDate:
-		_, err := time.Parse("2006-01-02", value)
-		if err != nil {
+		if !isDate(value) {
 			return fmt.Errorf("invalid date format")
 		}
 	case keywords.FormatDateTime:
-		// Try RFC3339 format first (with timezone)
-		_, err := time.Parse(time.RFC3339, value)
-		if err != nil {
-			// Try ISO 8601 format without timezone
-			_, err = time.Parse("2006-01-02T15:04:05", value)
-			if err != nil {
-				return fmt.Errorf("invalid date-time format")
-			}
+		if !isDateTime(value) {
+			return fmt.Errorf("invalid date-time format")
+		}
+	case keywords.FormatTime:
+		if !isTime(value) {
+			return fmt.Errorf("invalid time format")
+		}
+	case keywords.FormatDuration:
+		if !isDuration(value) {
+			return fmt.Errorf("invalid duration format")
+		}
+	case keywords.FormatHostname:
+		if !isHostname(value) {
+			return fmt.Errorf("invalid hostname format")
+		}
+	case keywords.FormatIPv4:
+		if !isIPv4(value) {
+			return fmt.Errorf("invalid IPv4 address format")
+		}
+	case keywords.FormatIPv6:
+		if !isIPv6(value) {
+			return fmt.Errorf("invalid IPv6 address format")
 		}
 	case keywords.FormatURI:
 		_, err := url.ParseRequestURI(value)
 		if err != nil {
 			return fmt.Errorf("invalid URI format")
 		}
+	case keywords.FormatURIReference:
+		if !isURIReference(value) {
+			return fmt.Errorf("invalid URI reference format")
+		}
+	case keywords.FormatIRI:
+		if !isIRI(value) {
+			return fmt.Errorf("invalid IRI format")
+		}
+	case keywords.FormatIRIReference:
+		if !isIRIReference(value) {
+			return fmt.Errorf("invalid IRI reference format")
+		}
+	case keywords.FormatJSONPointer:
+		if !isJSONPointer(value) {
+			return fmt.Errorf("invalid JSON pointer format")
+		}
+	case keywords.FormatRelativeJSONPointer:
+		if !isRelativeJSONPointer(value) {
+			return fmt.Errorf("invalid relative JSON pointer format")
+		}
+	case keywords.FormatRegex:
+		if _, err := compilePattern(value, v.ecmaRegex); err != nil {
+			return fmt.Errorf("invalid regex format: %w", err)
+		}
 	case keywords.FormatUUID:
 		// UUID format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 		uuidRegex := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
@@ -160,7 +223,7 @@ func truncateString(s string, maxLength int) string {
 	return string(runes[:maxLength]) + "..."
 }
 
-func compileStringValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, strictType bool) (Interface, error) {
+func compileStringValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, strictType, ecmaRegex bool) (Interface, error) {
 	v := String()
 	v.StrictStringType(strictType)
 	if s.HasConst() && vocab.IsKeywordEnabled(keywords.Const) {
@@ -173,13 +236,20 @@ func compileStringValidator(s *schema.Schema, vocab *vocabulary.VocabularySet, s
 		v.MinLength(s.MinLength())
 	}
 	if s.HasPattern() && vocab.IsKeywordEnabled(keywords.Pattern) {
-		v.Pattern(s.Pattern())
+		if ecmaRegex {
+			v.ECMAScriptPattern(s.Pattern())
+		} else {
+			v.Pattern(s.Pattern())
+		}
 	}
 	// Format validation should only be enforced when format-assertion vocabulary is enabled
 	// When only format-annotation is enabled, format should be treated as annotation-only
 	if s.HasFormat() {
 		if vocab.IsEnabled("https://json-schema.org/draft/2020-12/vocab/format-assertion") {
 			v.Format(s.Format())
+			if s.Format() == keywords.FormatRegex {
+				v.ECMAScriptRegex(ecmaRegex)
+			}
 		}
 		// If only format-annotation is enabled, we skip format validation (annotation-only behavior)
 	}
@@ -225,14 +295,27 @@ func (b *StringValidatorBuilder) MinLength(v int) *StringValidatorBuilder {
 	return b
 }
 
+// Pattern sets the regular expression a string must match. s is compiled as a
+// Go (RE2) regular expression; when it fails because it uses ECMA-262 syntax
+// RE2 lacks, the error names the construct. See also ECMAScriptPattern.
 func (b *StringValidatorBuilder) Pattern(s string) *StringValidatorBuilder {
+	return b.pattern(s, false)
+}
+
+// ECMAScriptPattern is like Pattern, but first translates s from the ECMA-262
+// dialect that JSON Schema specifies to RE2, as WithECMAScriptRegex does.
+func (b *StringValidatorBuilder) ECMAScriptPattern(s string) *StringValidatorBuilder {
+	return b.pattern(s, true)
+}
+
+func (b *StringValidatorBuilder) pattern(s string, ecma bool) *StringValidatorBuilder {
 	if b.err != nil {
 		return b
 	}
 
 	// https://json-schema.org/draft/2020-12/json-schema-validation.html#rfc.section.6.3.3
-	// says "ECMA-262 regular expression dialect, but there's little we can do here :/
-	re, err := regexp.Compile(s)
+	// specifies the ECMA-262 regular expression dialect; see compilePattern.
+	re, err := compilePattern(s, ecma)
 	if err != nil {
 		b.err = err
 		return b
@@ -251,6 +334,17 @@ func (b *StringValidatorBuilder) Format(format string) *StringValidatorBuilder {
 	return b
 }
 
+// ECMAScriptRegex makes the "regex" format translate values from ECMA-262 to
+// RE2 before compiling them, as WithECMAScriptRegex does for patterns. Without
+// it, a value is valid for "regex" if it compiles as a Go regular expression.
+func (b *StringValidatorBuilder) ECMAScriptRegex(v bool) *StringValidatorBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.c.ecmaRegex = v
+	return b
+}
+
 func (b *StringValidatorBuilder) Enum(enums ...any) *StringValidatorBuilder {
 	if b.err != nil {
 		return b
@@ -2,6 +2,7 @@ package validator_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -334,7 +335,7 @@ func TestStringValidatorComprehensive(t *testing.T) {
 				value:     "hi",
 				minLength: intPtr(5),
 				wantErr:   true,
-				errMsg:    "shorter then minLength",
+				errMsg:    "shorter than minLength",
 			},
 			{
 				name:      "empty string with minLength 1",
@@ -366,7 +367,7 @@ func TestStringValidatorComprehensive(t *testing.T) {
 				value:     "hello world",
 				maxLength: intPtr(5),
 				wantErr:   true,
-				errMsg:    "longer then maxLength",
+				errMsg:    "longer than maxLength",
 			},
 			// Combined tests
 			{
@@ -843,6 +844,103 @@ func TestStringValidatorComprehensive(t *testing.T) {
 				format:  keywords.FormatUUID,
 				wantErr: false,
 			},
+			{name: "valid ipv4", value: "192.168.0.1", format: keywords.FormatIPv4},
+			{name: "ipv4 with leading zero", value: "192.168.01.1", format: keywords.FormatIPv4, wantErr: true},
+			{name: "ipv4 octet out of range", value: "256.1.1.1", format: keywords.FormatIPv4, wantErr: true},
+			{name: "ipv4 with too few octets", value: "10.0.1", format: keywords.FormatIPv4, wantErr: true},
+			{name: "ipv6 is not ipv4", value: "::1", format: keywords.FormatIPv4, wantErr: true},
+			{name: "valid ipv6", value: "2001:db8:85a3:0:0:8a2e:370:7334", format: keywords.FormatIPv6},
+			{name: "compressed ipv6", value: "2001:db8::1", format: keywords.FormatIPv6},
+			{name: "ipv6 loopback", value: "::1", format: keywords.FormatIPv6},
+			{name: "ipv6 with embedded ipv4", value: "::ffff:192.168.0.1", format: keywords.FormatIPv6},
+			{name: "ipv6 with oversized group", value: "2001:db8::12345", format: keywords.FormatIPv6, wantErr: true},
+			{name: "ipv6 with two compressions", value: "1::2::3", format: keywords.FormatIPv6, wantErr: true},
+			{name: "ipv6 with zone", value: "fe80::1%eth0", format: keywords.FormatIPv6, wantErr: true},
+			{name: "ipv4 is not ipv6", value: "127.0.0.1", format: keywords.FormatIPv6, wantErr: true},
+			{name: "valid hostname", value: "www.example.com", format: keywords.FormatHostname},
+			{name: "single label hostname", value: "localhost", format: keywords.FormatHostname},
+			{name: "hostname label starting with digit", value: "3com.example", format: keywords.FormatHostname},
+			{name: "hostname label starting with hyphen", value: "-example.com", format: keywords.FormatHostname, wantErr: true},
+			{name: "hostname label ending with hyphen", value: "example-.com", format: keywords.FormatHostname, wantErr: true},
+			{name: "hostname with underscore", value: "ex_ample.com", format: keywords.FormatHostname, wantErr: true},
+			{name: "hostname with empty label", value: "example..com", format: keywords.FormatHostname, wantErr: true},
+			{name: "hostname label too long", value: strings.Repeat("a", 64) + ".com", format: keywords.FormatHostname, wantErr: true},
+			{name: "empty hostname", value: "", format: keywords.FormatHostname, wantErr: true},
+			{name: "date on a leap day", value: "2024-02-29", format: keywords.FormatDate},
+			{name: "date on a missing leap day", value: "2023-02-29", format: keywords.FormatDate, wantErr: true},
+			{name: "date with single-digit month", value: "2023-1-05", format: keywords.FormatDate, wantErr: true},
+			{name: "date-time with fractional seconds", value: "1963-06-19T08:30:06.283185Z", format: keywords.FormatDateTime},
+			{name: "date-time with positive offset", value: "1937-01-01T12:00:27.87+00:20", format: keywords.FormatDateTime},
+			{name: "date-time with negative offset", value: "1990-12-31T15:59:50.123-08:00", format: keywords.FormatDateTime},
+			{name: "date-time with negative zero offset", value: "1990-12-31T15:59:50-00:00", format: keywords.FormatDateTime},
+			{name: "date-time with lowercase separators", value: "1963-06-19t08:30:06z", format: keywords.FormatDateTime},
+			{name: "date-time with leap second", value: "1998-12-31T23:59:60Z", format: keywords.FormatDateTime},
+			{name: "date-time with offset leap second", value: "1998-12-31T15:59:60.123-08:00", format: keywords.FormatDateTime},
+			{name: "date-time with misplaced leap second", value: "1998-12-31T22:59:60Z", format: keywords.FormatDateTime, wantErr: true},
+			{name: "date-time without offset", value: "2023-12-25T10:30:00", format: keywords.FormatDateTime, wantErr: true},
+			{name: "date-time with space separator", value: "2023-12-25 10:30:00Z", format: keywords.FormatDateTime, wantErr: true},
+			{name: "date-time at hour 24", value: "2023-12-25T24:00:00Z", format: keywords.FormatDateTime, wantErr: true},
+			{name: "date-time with invalid day", value: "2023-02-30T10:30:00Z", format: keywords.FormatDateTime, wantErr: true},
+			{name: "date-time with invalid offset", value: "2023-12-25T10:30:00+24:00", format: keywords.FormatDateTime, wantErr: true},
+			{name: "valid time", value: "08:30:06Z", format: keywords.FormatTime},
+			{name: "time with fractional seconds", value: "23:20:50.52Z", format: keywords.FormatTime},
+			{name: "time with negative offset", value: "08:30:06-05:00", format: keywords.FormatTime},
+			{name: "time with zero offset", value: "08:30:06+00:00", format: keywords.FormatTime},
+			{name: "time with leap second", value: "23:59:60Z", format: keywords.FormatTime},
+			{name: "time with offset leap second", value: "01:29:60+01:30", format: keywords.FormatTime},
+			{name: "time with misplaced leap second", value: "22:59:60Z", format: keywords.FormatTime, wantErr: true},
+			{name: "time at hour 24", value: "24:00:00Z", format: keywords.FormatTime, wantErr: true},
+			{name: "time without seconds", value: "08:30Z", format: keywords.FormatTime, wantErr: true},
+			{name: "time without offset", value: "08:30:06", format: keywords.FormatTime, wantErr: true},
+			{name: "time with empty fraction", value: "08:30:06.Z", format: keywords.FormatTime, wantErr: true},
+			{name: "time with minute out of range", value: "08:60:06Z", format: keywords.FormatTime, wantErr: true},
+			{name: "valid duration", value: "P1Y2M10DT2H30M", format: keywords.FormatDuration},
+			{name: "duration of days", value: "P4D", format: keywords.FormatDuration},
+			{name: "duration of hours", value: "PT36H", format: keywords.FormatDuration},
+			{name: "duration of zero seconds", value: "PT0S", format: keywords.FormatDuration},
+			{name: "duration of weeks", value: "P2W", format: keywords.FormatDuration},
+			{name: "duration without components", value: "P", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration with empty time", value: "P1DT", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration with time but no T", value: "P1D2H", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration with components out of order", value: "P2D1Y", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration mixing weeks", value: "P1Y2W", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration with fraction", value: "PT1.5S", format: keywords.FormatDuration, wantErr: true},
+			{name: "duration with missing unit", value: "P1", format: keywords.FormatDuration, wantErr: true},
+			{name: "absolute uri-reference", value: "http://foo.bar/?baz=qux#quux", format: keywords.FormatURIReference},
+			{name: "protocol-relative uri-reference", value: "//foo.bar/?baz=qux#quux", format: keywords.FormatURIReference},
+			{name: "relative path uri-reference", value: "../schemas/person.json#/$defs/name", format: keywords.FormatURIReference},
+			{name: "fragment-only uri-reference", value: "#fragment", format: keywords.FormatURIReference},
+			{name: "empty uri-reference", value: "", format: keywords.FormatURIReference},
+			{name: "percent-encoded uri-reference", value: "a%20b", format: keywords.FormatURIReference},
+			{name: "uri-reference with backslashes", value: "\\\\WINDOWS\\fileshare", format: keywords.FormatURIReference, wantErr: true},
+			{name: "uri-reference with backslash in fragment", value: "abc#\\", format: keywords.FormatURIReference, wantErr: true},
+			{name: "uri-reference with space", value: "a b", format: keywords.FormatURIReference, wantErr: true},
+			{name: "uri-reference with two fragments", value: "#a#b", format: keywords.FormatURIReference, wantErr: true},
+			{name: "uri-reference with bad escape", value: "a%2", format: keywords.FormatURIReference, wantErr: true},
+			{name: "uri-reference with non-ascii", value: "http://ƒøø.ßår/?∂éœ=πîx#πîüx", format: keywords.FormatURIReference, wantErr: true},
+			{name: "valid iri", value: "http://ƒøø.ßår/?∂éœ=πîx#πîüx", format: keywords.FormatIRI},
+			{name: "iri with bracketed ipv6", value: "http://[2001:db8::1]/", format: keywords.FormatIRI},
+			{name: "iri with unbracketed ipv6", value: "http://2001:0db8:85a3:0000:0000:8a2e:0370:7334", format: keywords.FormatIRI, wantErr: true},
+			{name: "relative iri", value: "/abc", format: keywords.FormatIRI, wantErr: true},
+			{name: "relative iri-reference", value: "//ƒøø.ßår/?∂éœ=πîx#πîüx", format: keywords.FormatIRIReference},
+			{name: "iri-reference with backslash", value: "\\\\WINDOWS\\filëßåré", format: keywords.FormatIRIReference, wantErr: true},
+			{name: "valid json-pointer", value: "/foo/bar~0/baz~1/%a", format: keywords.FormatJSONPointer},
+			{name: "empty json-pointer", value: "", format: keywords.FormatJSONPointer},
+			{name: "json-pointer to the root member", value: "/", format: keywords.FormatJSONPointer},
+			{name: "json-pointer without leading slash", value: "foo/bar", format: keywords.FormatJSONPointer, wantErr: true},
+			{name: "json-pointer with invalid escape", value: "/foo/bar~2", format: keywords.FormatJSONPointer, wantErr: true},
+			{name: "json-pointer with trailing tilde", value: "/foo/bar~", format: keywords.FormatJSONPointer, wantErr: true},
+			{name: "json-pointer with fragment", value: "#/foo", format: keywords.FormatJSONPointer, wantErr: true},
+			{name: "upwards relative-json-pointer", value: "1", format: keywords.FormatRelativeJSONPointer},
+			{name: "downwards relative-json-pointer", value: "0/foo/bar", format: keywords.FormatRelativeJSONPointer},
+			{name: "multi-digit relative-json-pointer", value: "120/foo/bar", format: keywords.FormatRelativeJSONPointer},
+			{name: "relative-json-pointer to the name", value: "0#", format: keywords.FormatRelativeJSONPointer},
+			{name: "relative-json-pointer without integer", value: "/foo/bar", format: keywords.FormatRelativeJSONPointer, wantErr: true},
+			{name: "negative relative-json-pointer", value: "-1/foo/bar", format: keywords.FormatRelativeJSONPointer, wantErr: true},
+			{name: "relative-json-pointer with explicit sign", value: "+1/foo/bar", format: keywords.FormatRelativeJSONPointer, wantErr: true},
+			{name: "relative-json-pointer with leading zero", value: "01/a", format: keywords.FormatRelativeJSONPointer, wantErr: true},
+			{name: "relative-json-pointer with two hashes", value: "0##", format: keywords.FormatRelativeJSONPointer, wantErr: true},
+			{name: "empty relative-json-pointer", value: "", format: keywords.FormatRelativeJSONPointer, wantErr: true},
 		}
 
 		for _, tc := range testCases {
@@ -930,8 +1028,8 @@ func TestCommonPatterns(t *testing.T) {
 		{
 			name:    "DateTime",
 			builder: schema.DateTime(),
-			valid:   []any{"2020-01-15T10:30:00Z", "2020-01-15T10:30:00"},
-			invalid: []any{"not-a-datetime", "2020-01-15", 123},
+			valid:   []any{"2020-01-15T10:30:00Z", "2020-01-15T10:30:00+09:00"},
+			invalid: []any{"not-a-datetime", "2020-01-15", "2020-01-15T10:30:00", 123},
 		},
 		{
 			// Optional allows the wrapped schema's type or null.
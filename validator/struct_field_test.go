@@ -2,6 +2,7 @@ package validator_test
 
 import (
 	"testing"
+	"time"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/validator"
@@ -9,7 +10,7 @@ import (
 )
 
 // When validating a Go struct, the object validator must read the property name
-// from the JSON tag's name portion, ignoring tag options like ",omitempty", and
+// from the JSON tag's name portion, without its options like ",omitempty", and
 // exclude json:"-" fields. validation_targets_test.go covers this at the
 // extraction-helper level; this exercises the same behavior end-to-end through
 // the public Compile/Validate API.
@@ -34,7 +35,7 @@ func TestStructJSONTagOptions(t *testing.T) {
 	})
 
 	t.Run("properties keyword keys on the json tag name", func(t *testing.T) {
-		nameSchema, err := schema.NewBuilder().Types(schema.StringType).MinLength(1).Build()
+		nameSchema, err := schema.NewBuilder().Types(schema.StringType).MinLength(2).Build()
 		require.NoError(t, err)
 		s, err := schema.NewBuilder().
 			Types(schema.ObjectType).
@@ -46,8 +47,8 @@ func TestStructJSONTagOptions(t *testing.T) {
 
 		_, err = v.Validate(t.Context(), payload{Name: "ok"})
 		require.NoError(t, err)
-		_, err = v.Validate(t.Context(), payload{Name: ""})
-		require.Error(t, err, "empty name should fail minLength via the json-tag-named property")
+		_, err = v.Validate(t.Context(), payload{Name: "x"})
+		require.Error(t, err, "short name should fail minLength via the json-tag-named property")
 	})
 
 	t.Run("json:\"-\" field is excluded", func(t *testing.T) {
@@ -66,3 +67,137 @@ func TestStructJSONTagOptions(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// A struct validates the same as its encoding/json encoding: fields omitted by
+// ",omitempty" or ",omitzero" are absent, and the fields of embedded structs
+// are promoted.
+func TestStructFieldValues(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `json:"created_by"`
+		Note      string `json:"note,omitempty"`
+	}
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type order struct {
+		Audit
+		*Meta
+		ID       int            `json:"id"`
+		Note     string         `json:"note"` // shadows Audit.Note
+		Coupon   *string        `json:"coupon,omitempty"`
+		Gift     *bool          `json:"gift"`
+		Items    []string       `json:"items,omitempty"`
+		Extra    map[string]any `json:"extra,omitempty"`
+		Shipping struct {
+			City string `json:"city"`
+		} `json:"shipping,omitzero"`
+		Paid time.Time `json:"paid,omitzero"`
+	}
+
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"created_by": {"type": "string", "minLength": 1},
+			"note": {"type": "string", "maxLength": 5},
+			"coupon": {"type": "string", "pattern": "^[A-Z]+$"},
+			"gift": {"type": ["boolean", "null"]},
+			"items": {"type": "array", "minItems": 1},
+			"version": {"type": "integer", "minimum": 2},
+			"shipping": {"type": "object", "required": ["city"], "properties": {"city": {"minLength": 1}}}
+		},
+		"required": ["id", "created_by", "note", "gift"],
+		"additionalProperties": false
+	}`)))
+	v, err := validator.Compile(t.Context(), &s)
+	require.NoError(t, err)
+
+	valid := order{ID: 1, Audit: Audit{CreatedBy: "ada"}}
+	_, err = v.Validate(t.Context(), valid)
+	require.NoError(t, err, "omitted fields are absent, a nil pointer without omitempty is null")
+
+	coupon := "SAVE"
+	ok := valid
+	ok.Coupon = &coupon
+	ok.Items = []string{"book"}
+	ok.Meta = &Meta{Version: 2}
+	ok.Shipping.City = "Rome"
+	_, err = v.Validate(t.Context(), &ok)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		Name   string
+		Modify func(*order)
+		Error  string
+	}{
+		{Name: "field value", Modify: func(o *order) { o.ID = 0 }, Error: "minimum"},
+		{Name: "embedded field value", Modify: func(o *order) { o.CreatedBy = "" }, Error: "length"},
+		{Name: "shadowing field value", Modify: func(o *order) { o.Note = "too long" }, Error: "length"},
+		{Name: "pointer field value", Modify: func(o *order) { coupon := "save"; o.Coupon = &coupon }, Error: "pattern"},
+		{Name: "empty but present slice", Modify: func(o *order) { o.Items = []string{} }, Error: ""},
+		{Name: "embedded pointer field value", Modify: func(o *order) { o.Meta = &Meta{Version: 1} }, Error: "minimum"},
+		{Name: "non-zero struct field value", Modify: func(o *order) { o.Shipping.City = "" }, Error: ""},
+		{Name: "omitzero uses IsZero", Modify: func(o *order) { o.Paid = time.Now() }, Error: "paid"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			o := valid
+			tc.Modify(&o)
+			_, err := v.Validate(t.Context(), o)
+			if tc.Error == "" {
+				// The value is omitted, as encoding/json would.
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.Error)
+		})
+	}
+
+	t.Run("required omitted field", func(t *testing.T) {
+		type optional struct {
+			Name string `json:"name,omitempty"`
+		}
+		s, err := schema.NewBuilder().Types(schema.ObjectType).Required("name").Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), optional{})
+		require.ErrorContains(t, err, "required property name is missing")
+		_, err = v.Validate(t.Context(), optional{Name: "x"})
+		require.NoError(t, err)
+	})
+
+	t.Run("unexported embedded struct", func(t *testing.T) {
+		type inner struct {
+			Name string `json:"name"`
+		}
+		type outer struct {
+			inner
+			ID int `json:"id"`
+		}
+		type outerPtr struct {
+			*inner
+			ID int `json:"id"`
+		}
+		s, err := schema.NewBuilder().
+			Types(schema.ObjectType).
+			Property("name", schema.NewBuilder().MinLength(1).MustBuild()).
+			Required("id", "name").
+			Build()
+		require.NoError(t, err)
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+		require.NotPanics(t, func() {
+			_, err = v.Validate(t.Context(), outer{inner: inner{Name: "x"}, ID: 1})
+		})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), outer{ID: 1})
+		require.ErrorContains(t, err, "length", "the promoted field is validated")
+
+		_, err = v.Validate(t.Context(), outerPtr{inner: &inner{Name: "x"}, ID: 1})
+		require.NoError(t, err, "so is one promoted through a pointer")
+		_, err = v.Validate(t.Context(), outerPtr{ID: 1})
+		require.ErrorContains(t, err, "name", "a nil embedded pointer contributes nothing")
+	})
+}
@@ -9,8 +9,8 @@ import (
 )
 
 // These tests exercise the interaction between unevaluatedProperties /
-// unevaluatedItems and the in-place applicators anyOf / oneOf / if-then-else
-// strictly through the public Compile -> Validate path. Per the JSON Schema
+// unevaluatedItems and the in-place applicators allOf / anyOf / oneOf /
+// if-then-else / not / dependentSchemas / $ref strictly through the public Compile -> Validate path. Per the JSON Schema
 // spec, properties (or items) evaluated by an applicator subschema that
 // *successfully applies* are considered evaluated and are therefore exempt
 // from "unevaluatedProperties": false; properties seen only by a subschema
@@ -23,6 +23,86 @@ func mustBuild(t *testing.T, b *schema.Builder) *schema.Schema {
 	return s
 }
 
+func TestUnevaluatedPropertiesWithAllOf(t *testing.T) {
+	stringSchema := mustBuild(t, schema.NewBuilder().Types(schema.StringType))
+
+	t.Run("properties evaluated by every branch are allowed", func(t *testing.T) {
+		s := mustBuild(t, schema.NewBuilder().
+			Types(schema.ObjectType).
+			AllOf(
+				mustBuild(t, schema.NewBuilder().Property("foo", stringSchema)),
+				mustBuild(t, schema.NewBuilder().Property("bar", stringSchema)),
+			).
+			UnevaluatedProperties(schema.FalseSchema()))
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "bar": "y"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "extra": "y"})
+		require.Error(t, err)
+	})
+
+	t.Run("annotations from nested allOf reach the outer schema", func(t *testing.T) {
+		inner := mustBuild(t, schema.NewBuilder().
+			AllOf(mustBuild(t, schema.NewBuilder().Property("bar", stringSchema))))
+		s := mustBuild(t, schema.NewBuilder().
+			Property("foo", stringSchema).
+			AllOf(inner).
+			UnevaluatedProperties(schema.FalseSchema()))
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "bar": "y"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "baz": "y"})
+		require.Error(t, err)
+	})
+
+	t.Run("properties evaluated behind a $ref are allowed", func(t *testing.T) {
+		s := mustBuild(t, schema.NewBuilder().
+			Definitions("bar", mustBuild(t, schema.NewBuilder().Property("bar", stringSchema))).
+			AllOf(mustBuild(t, schema.NewBuilder().Reference("#/$defs/bar"))).
+			UnevaluatedProperties(schema.FalseSchema()))
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"bar": "y"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"bar": "y", "baz": "z"})
+		require.Error(t, err)
+	})
+
+	t.Run("dependentSchemas evaluate properties when triggered", func(t *testing.T) {
+		s := mustBuild(t, schema.NewBuilder().
+			Property("foo", stringSchema).
+			DependentSchemas(map[string]schema.SchemaOrBool{
+				"foo": mustBuild(t, schema.NewBuilder().Property("bar", stringSchema)),
+			}).
+			UnevaluatedProperties(schema.FalseSchema()))
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "bar": "y"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"bar": "y"})
+		require.Error(t, err, "bar is only evaluated when foo is present")
+	})
+
+	t.Run("not never evaluates properties", func(t *testing.T) {
+		s := mustBuild(t, schema.NewBuilder().
+			Not(mustBuild(t, schema.NewBuilder().
+				Property("bar", mustBuild(t, schema.NewBuilder().Const("bar"))).
+				Required("bar"))).
+			UnevaluatedProperties(schema.FalseSchema()))
+		v, err := validator.Compile(t.Context(), s)
+		require.NoError(t, err)
+
+		_, err = v.Validate(t.Context(), map[string]any{"bar": "other"})
+		require.Error(t, err)
+	})
+}
+
 func TestUnevaluatedPropertiesWithAnyOf(t *testing.T) {
 	// foo via properties; bar/baz only reachable through anyOf branches that
 	// must actually match (required + const) to evaluate their property.
@@ -165,3 +245,79 @@ func TestUnevaluatedItemsWithAnyOf(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestUnevaluatedPropertiesWithReference(t *testing.T) {
+	compile := func(t *testing.T, src string) validator.Interface {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		v, err := validator.Compile(t.Context(), &s)
+		require.NoError(t, err)
+		return v
+	}
+
+	testcases := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name: "definition",
+			schema: `{
+				"$defs": {"foo": {"properties": {"foo": {"type": "string"}}}},
+				"$ref": "#/$defs/foo",
+				"unevaluatedProperties": false
+			}`,
+		},
+		{
+			name: "definition referencing another definition",
+			schema: `{
+				"$defs": {
+					"foo": {"$ref": "#/$defs/bar"},
+					"bar": {"properties": {"foo": {"type": "string"}}}
+				},
+				"$ref": "#/$defs/foo",
+				"unevaluatedProperties": false
+			}`,
+		},
+		{
+			name: "definition with an applicator",
+			schema: `{
+				"$defs": {"foo": {"allOf": [{"properties": {"foo": {"type": "string"}}}]}},
+				"$ref": "#/$defs/foo",
+				"unevaluatedProperties": false
+			}`,
+		},
+		{
+			name: "definition with its own $id",
+			schema: `{
+				"$id": "https://example.com/root",
+				"$defs": {"foo": {"$id": "foo", "properties": {"foo": {"type": "string"}}}},
+				"$ref": "foo",
+				"unevaluatedProperties": false
+			}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := compile(t, tc.schema)
+			_, err := v.Validate(t.Context(), map[string]any{"foo": "x"})
+			require.NoError(t, err, "foo is evaluated by the referenced schema")
+			_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "bar": "y"})
+			require.Error(t, err, "bar is not evaluated by anything")
+		})
+	}
+
+	t.Run("sibling properties are evaluated too", func(t *testing.T) {
+		v := compile(t, `{
+			"$defs": {"foo": {"properties": {"foo": {"type": "string"}}}},
+			"$ref": "#/$defs/foo",
+			"properties": {"bar": {"type": "string"}},
+			"unevaluatedProperties": false
+		}`)
+		_, err := v.Validate(t.Context(), map[string]any{"foo": "x", "bar": "y"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"foo": "x", "baz": "z"})
+		require.Error(t, err)
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"strconv"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/internal/schemactx"
+	"github.com/lestrrat-go/json-schema/keywords"
 )
 
 // unevaluatedCoordinator orchestrates validation when schemas have unevaluated constraints.
@@ -23,7 +25,7 @@ type unevaluatedCoordinator struct {
 
 // Validate orchestrates validation phases: execute all child validators, then apply unevaluated constraints
 func (v *unevaluatedCoordinator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
-	return v.evaluate(ctx, in, newEvalState(ctx, options))
+	return validateRoot(ctx, v, in, options)
 }
 
 func (v *unevaluatedCoordinator) evaluate(ctx context.Context, in any, st *evalState) (Result, error) {
@@ -63,7 +65,9 @@ func (v *unevaluatedCoordinator) applyUnevaluatedConstraints(ctx context.Context
 	}
 	// Apply unevaluatedProperties if present
 	if v.unevaluatedProps != nil {
+		mark := st.beginKeyword(keywords.UnevaluatedProperties)
 		err := v.validateUnevaluatedProperties(ctx, in, merger.ObjectResult(), additional, st)
+		st.endKeyword(mark)
 		if err != nil {
 			return nil, err
 		}
@@ -71,7 +75,9 @@ func (v *unevaluatedCoordinator) applyUnevaluatedConstraints(ctx context.Context
 
 	// Apply unevaluatedItems if present
 	if v.unevaluatedItems != nil {
+		mark := st.beginKeyword(keywords.UnevaluatedItems)
 		err := v.validateUnevaluatedItems(ctx, in, merger.ArrayResult(), additional, st)
+		st.endKeyword(mark)
 		if err != nil {
 			return nil, err
 		}
@@ -117,7 +123,7 @@ func (v *unevaluatedCoordinator) validateUnevaluatedProperties(ctx context.Conte
 			// This property was not evaluated by any validator
 			err := v.handleUnevaluatedProperty(ctx, propName, obj[propName], additional, st)
 			if err != nil {
-				return fmt.Errorf("unevaluated property %q: %w", propName, err)
+				return fmt.Errorf("unevaluated property %q: %w", propName, atLocation(err, jsonPointer(keywords.UnevaluatedProperties), jsonPointer(propName)))
 			}
 		}
 	}
@@ -131,7 +137,7 @@ func (v *unevaluatedCoordinator) handleUnevaluatedProperty(ctx context.Context,
 	case schema.BoolSchema:
 		if !bool(constraint) {
 			// unevaluatedProperties: false - unevaluated properties not allowed
-			return fmt.Errorf("property not allowed")
+			return codeErrorf(ErrCodeUnevaluatedProperties, "property not allowed")
 		}
 		// unevaluatedProperties: true - allow any unevaluated properties
 		// Mark this property as evaluated by the unevaluated constraint
@@ -145,7 +151,7 @@ func (v *unevaluatedCoordinator) handleUnevaluatedProperty(ctx context.Context,
 			return fmt.Errorf("failed to compile unevaluatedProperties schema: %w", err)
 		}
 
-		_, err = evalChild(ctx, validator, propValue, st)
+		_, err = evalProperty(ctx, validator, propName, propValue, st)
 		if err != nil {
 			return fmt.Errorf("validation failed: %w", err)
 		}
@@ -202,7 +208,7 @@ func (v *unevaluatedCoordinator) validateUnevaluatedItems(ctx context.Context, i
 			itemValue := arr.Index(i).Interface()
 			err := v.handleUnevaluatedItem(ctx, i, itemValue, additional, st)
 			if err != nil {
-				return fmt.Errorf("unevaluated item at index %d: %w", i, err)
+				return fmt.Errorf("unevaluated item at index %d: %w", i, atLocation(err, jsonPointer(keywords.UnevaluatedItems), jsonPointer(strconv.Itoa(i))))
 			}
 		}
 	}
@@ -216,7 +222,7 @@ func (v *unevaluatedCoordinator) handleUnevaluatedItem(ctx context.Context, inde
 	case schema.BoolSchema:
 		if !bool(constraint) {
 			// unevaluatedItems: false - unevaluated items not allowed
-			return fmt.Errorf("item not allowed")
+			return codeErrorf(ErrCodeUnevaluatedItems, "item not allowed")
 		}
 		// unevaluatedItems: true - allow any unevaluated items
 		// Mark this item as evaluated by the unevaluated constraint
@@ -234,7 +240,7 @@ func (v *unevaluatedCoordinator) handleUnevaluatedItem(ctx context.Context, inde
 			return fmt.Errorf("failed to compile unevaluatedItems schema: %w", err)
 		}
 
-		_, err = evalChild(ctx, validator, itemValue, st)
+		_, err = evalItem(ctx, validator, index, itemValue, st)
 		if err != nil {
 			return fmt.Errorf("validation failed: %w", err)
 		}
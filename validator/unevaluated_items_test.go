@@ -118,3 +118,237 @@ func TestUnevaluatedItems(t *testing.T) {
 		})
 	})
 }
+
+// TestUnevaluatedItemsComposition mirrors the unevaluatedItems cases of the
+// JSON Schema Test Suite (draft 2020-12, and 2019-09 for additionalItems) in
+// which the evaluated items come from prefixItems, items and contains, in the
+// schema itself, in its in-place applicators or behind a $ref.
+func TestUnevaluatedItemsComposition(t *testing.T) {
+	type instance struct {
+		data  string
+		valid bool
+	}
+	testcases := []struct {
+		name      string
+		schema    string
+		instances []instance
+	}{
+		{
+			name:      "with tuple",
+			schema:    `{"prefixItems": [{"type": "string"}], "unevaluatedItems": false}`,
+			instances: []instance{{`["foo"]`, true}, {`["foo", "bar"]`, false}},
+		},
+		{
+			name:      "with items and prefixItems",
+			schema:    `{"prefixItems": [{"type": "string"}], "items": true, "unevaluatedItems": false}`,
+			instances: []instance{{`["foo", 42]`, true}},
+		},
+		{
+			name:      "with items",
+			schema:    `{"items": {"type": "number"}, "unevaluatedItems": {"type": "string"}}`,
+			instances: []instance{{`[5, 6, 7, 8]`, true}, {`["foo", "bar", "baz"]`, false}},
+		},
+		{
+			name:      "with nested tuple",
+			schema:    `{"prefixItems": [{"type": "string"}], "allOf": [{"prefixItems": [true, {"type": "number"}]}], "unevaluatedItems": false}`,
+			instances: []instance{{`["foo", 42]`, true}, {`["foo", 42, true]`, false}},
+		},
+		{
+			name:      "with nested items",
+			schema:    `{"unevaluatedItems": {"type": "boolean"}, "anyOf": [{"items": {"type": "string"}}, true]}`,
+			instances: []instance{{`[true, false]`, true}, {`["yes", "no"]`, true}, {`["yes", false]`, false}},
+		},
+		{
+			name:      "with nested prefixItems and items",
+			schema:    `{"allOf": [{"prefixItems": [{"type": "string"}], "items": true}], "unevaluatedItems": false}`,
+			instances: []instance{{`["foo"]`, true}, {`["foo", 42, true]`, true}},
+		},
+		{
+			name:      "with nested unevaluatedItems",
+			schema:    `{"allOf": [{"prefixItems": [{"type": "string"}]}, {"unevaluatedItems": true}], "unevaluatedItems": false}`,
+			instances: []instance{{`["foo"]`, true}, {`["foo", 42, true]`, true}},
+		},
+		{
+			name: "with anyOf",
+			schema: `{
+				"prefixItems": [{"const": "foo"}],
+				"anyOf": [
+					{"prefixItems": [true, {"const": "bar"}]},
+					{"prefixItems": [true, true, {"const": "baz"}]}
+				],
+				"unevaluatedItems": false
+			}`,
+			instances: []instance{
+				{`["foo", "bar"]`, true},
+				{`["foo", "bar", 42]`, false},
+				{`["foo", "bar", "baz"]`, true},
+				{`["foo", "bar", "baz", 42]`, false},
+			},
+		},
+		{
+			name: "with oneOf",
+			schema: `{
+				"prefixItems": [{"const": "foo"}],
+				"oneOf": [
+					{"prefixItems": [true, {"const": "bar"}]},
+					{"prefixItems": [true, {"const": "baz"}]}
+				],
+				"unevaluatedItems": false
+			}`,
+			instances: []instance{{`["foo", "bar"]`, true}, {`["foo", "bar", 42]`, false}},
+		},
+		{
+			name: "with not",
+			schema: `{
+				"prefixItems": [{"const": "foo"}],
+				"not": {"not": {"prefixItems": [true, {"const": "bar"}]}},
+				"unevaluatedItems": false
+			}`,
+			instances: []instance{{`["foo", "bar"]`, false}},
+		},
+		{
+			name: "with if/then/else",
+			schema: `{
+				"prefixItems": [{"const": "foo"}],
+				"if": {"prefixItems": [true, {"const": "bar"}]},
+				"then": {"prefixItems": [true, true, {"const": "then"}]},
+				"else": {"prefixItems": [true, true, true, {"const": "else"}]},
+				"unevaluatedItems": false
+			}`,
+			instances: []instance{
+				{`["foo", "bar", "then"]`, true},
+				{`["foo", "bar", "then", "else"]`, false},
+				{`["foo", 42, 42, "else"]`, true},
+				{`["foo", 42, 42, "else", 42]`, false},
+			},
+		},
+		{
+			name:      "with boolean schemas",
+			schema:    `{"allOf": [true], "unevaluatedItems": false}`,
+			instances: []instance{{`[]`, true}, {`["foo"]`, false}},
+		},
+		{
+			name: "with $ref",
+			schema: `{
+				"$ref": "#/$defs/bar",
+				"prefixItems": [{"type": "string"}],
+				"unevaluatedItems": false,
+				"$defs": {"bar": {"prefixItems": [true, {"type": "string"}]}}
+			}`,
+			instances: []instance{{`["foo", "bar"]`, true}, {`["foo", "bar", "baz"]`, false}},
+		},
+		{
+			name: "before $ref",
+			schema: `{
+				"unevaluatedItems": false,
+				"prefixItems": [{"type": "string"}],
+				"$ref": "#/$defs/bar",
+				"$defs": {"bar": {"prefixItems": [true, {"type": "string"}]}}
+			}`,
+			instances: []instance{{`["foo", "bar"]`, true}, {`["foo", "bar", "baz"]`, false}},
+		},
+		{
+			name: "with $dynamicRef",
+			schema: `{
+				"$id": "https://example.com/unevaluated-items-with-dynamic-ref/derived",
+				"$ref": "./baseSchema",
+				"$defs": {
+					"derived": {"$dynamicAnchor": "addons", "prefixItems": [true, {"type": "string"}]},
+					"baseSchema": {
+						"$id": "./baseSchema",
+						"$comment": "unevaluatedItems comes first so it is more likely to catch bugs with implementations that are sensitive to keyword ordering",
+						"unevaluatedItems": false,
+						"type": "array",
+						"prefixItems": [{"type": "string"}],
+						"$dynamicRef": "#addons",
+						"$defs": {
+							"defaultAddons": {"$comment": "Needed to satisfy the bookending requirement", "$dynamicAnchor": "addons"}
+						}
+					}
+				}
+			}`,
+			instances: []instance{{`["foo", "bar"]`, true}, {`["foo", "bar", "baz"]`, false}},
+		},
+		{
+			name:      "can't see inside cousins",
+			schema:    `{"allOf": [{"prefixItems": [true]}, {"unevaluatedItems": false}]}`,
+			instances: []instance{{`[1]`, false}},
+		},
+		{
+			name: "item is evaluated in an uncle schema to unevaluatedItems",
+			schema: `{
+				"properties": {"foo": {"prefixItems": [{"type": "string"}], "unevaluatedItems": false}},
+				"anyOf": [{"properties": {"foo": {"prefixItems": [true, {"type": "string"}]}}}]
+			}`,
+			instances: []instance{{`{"foo": ["test"]}`, true}, {`{"foo": ["test", "test"]}`, false}},
+		},
+		{
+			name:      "depends on adjacent contains",
+			schema:    `{"prefixItems": [true], "contains": {"type": "string"}, "unevaluatedItems": false}`,
+			instances: []instance{{`[1, "foo"]`, true}, {`[1, 2]`, false}, {`[1, 2, "foo"]`, false}},
+		},
+		{
+			name:      "depends on multiple nested contains",
+			schema:    `{"allOf": [{"contains": {"multipleOf": 2}}, {"contains": {"multipleOf": 3}}], "unevaluatedItems": {"multipleOf": 5}}`,
+			instances: []instance{{`[2, 3, 4, 5, 6]`, true}, {`[2, 3, 4, 7, 8]`, false}},
+		},
+		{
+			name: "contains controls item dependencies",
+			schema: `{
+				"if": {"contains": {"const": "a"}},
+				"then": {
+					"if": {"contains": {"const": "b"}},
+					"then": {"if": {"contains": {"const": "c"}}}
+				},
+				"unevaluatedItems": false
+			}`,
+			instances: []instance{
+				{`[]`, true},
+				{`["a", "a"]`, true},
+				{`["a", "b", "a", "b", "a"]`, true},
+				{`["c", "a", "c", "c", "b", "a"]`, true},
+				{`["b", "b"]`, false},
+				{`["c", "c"]`, false},
+				{`["c", "b", "c", "b", "c"]`, false},
+				{`["c", "a", "c", "a", "c"]`, false},
+			},
+		},
+		{
+			name:      "non-array instances are valid",
+			schema:    `{"unevaluatedItems": false}`,
+			instances: []instance{{`true`, true}, {`123`, true}, {`"foo"`, true}, {`{}`, true}, {`null`, true}},
+		},
+		{
+			name:      "with items and additionalItems (2019-09)",
+			schema:    `{"$schema": "https://json-schema.org/draft/2019-09/schema", "items": [{"type": "string"}], "additionalItems": true, "unevaluatedItems": false}`,
+			instances: []instance{{`["foo", 42]`, true}},
+		},
+		{
+			name:      "with ignored applicator additionalItems (2019-09)",
+			schema:    `{"$schema": "https://json-schema.org/draft/2019-09/schema", "allOf": [{"additionalItems": {"type": "number"}}], "unevaluatedItems": false}`,
+			instances: []instance{{`["foo"]`, false}, {`[]`, true}},
+		},
+		{
+			name:      "can see annotations from if without then and else",
+			schema:    `{"if": {"prefixItems": [{"const": "a"}]}, "unevaluatedItems": false}`,
+			instances: []instance{{`["a"]`, true}, {`["b"]`, false}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s schema.Schema
+			require.NoError(t, s.UnmarshalJSON([]byte(tc.schema)))
+			v, err := validator.Compile(t.Context(), &s)
+			require.NoError(t, err)
+			for _, inst := range tc.instances {
+				_, err := validator.ValidateJSON(t.Context(), v, []byte(inst.data))
+				if inst.valid {
+					require.NoError(t, err, inst.data)
+				} else {
+					require.Error(t, err, inst.data)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// uniqueItems support. JSON Schema compares array items as JSON values: 1 and
+// 1.0 are the same number, 1 and "1" are different, and objects are equal when
+// they have the same members in any order. Instead of comparing every pair of
+// items, each item is reduced to a canonical key, and only items whose keys
+// match are compared with jsonValueEqual. The key normalizes numbers through
+// float64, so distinct numbers that float64 cannot tell apart (large integers
+// given as json.Number, say) share a key; the exact comparison keeps them
+// apart. For JSON-decoded data, a shared key nearly always means a real
+// duplicate, so the scan stays linear.
+
+// uniqueItemKey returns the canonical key of v. Values that are not one of the
+// shapes encoding/json decodes to are converted to that form first; ok is
+// false if v cannot be represented as JSON at all.
+func uniqueItemKey(v any) (string, bool) {
+	var sb strings.Builder
+	if !writeUniqueItemKey(&sb, v) {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+func writeUniqueItemKey(sb *strings.Builder, v any) bool {
+	switch val := v.(type) {
+	case nil:
+		sb.WriteString("null")
+	case bool:
+		sb.WriteString(strconv.FormatBool(val))
+	case string:
+		sb.WriteString(strconv.Quote(val))
+	case map[string]any:
+		sb.WriteByte('{')
+		for i, k := range slices.Sorted(maps.Keys(val)) {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.Quote(k))
+			sb.WriteByte(':')
+			if !writeUniqueItemKey(sb, val[k]) {
+				return false
+			}
+		}
+		sb.WriteByte('}')
+	case []any:
+		sb.WriteByte('[')
+		for i, elem := range val {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if !writeUniqueItemKey(sb, elem) {
+				return false
+			}
+		}
+		sb.WriteByte(']')
+	default:
+		if isNumeric(v) {
+			f, _, err := numericFloat(v)
+			if err != nil {
+				return false
+			}
+			// Integral values print without an exponent or fraction so that
+			// 1, 1.0 and json.Number("1e0") all render as "1".
+			if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				sb.WriteString(strconv.FormatInt(int64(f), 10))
+			} else {
+				sb.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+			}
+			return true
+		}
+		generic, ok := toJSONValue(v)
+		if !ok {
+			return false
+		}
+		return writeUniqueItemKey(sb, generic)
+	}
+	return true
+}
+
+// jsonValueEqual reports whether a and b are the same JSON value. Numbers are
+// compared by their exact mathematical value.
+func jsonValueEqual(a, b any) bool {
+	if isNumeric(a) && isNumeric(b) {
+		return numbersEqual(a, b)
+	}
+	switch va := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		vb, ok := b.(bool)
+		return ok && va == vb
+	case string:
+		vb, ok := b.(string)
+		return ok && va == vb
+	case map[string]any:
+		vb, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		if len(va) != len(vb) {
+			return false
+		}
+		for k, elem := range va {
+			other, ok := vb[k]
+			if !ok || !jsonValueEqual(elem, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		vb, ok := b.([]any)
+		if !ok {
+			break
+		}
+		return slices.EqualFunc(va, vb, jsonValueEqual)
+	}
+
+	if isDecodedJSON(a) && isDecodedJSON(b) {
+		// different kinds of JSON value
+		return false
+	}
+	ga, okA := toJSONValue(a)
+	gb, okB := toJSONValue(b)
+	if !okA || !okB {
+		return reflect.DeepEqual(a, b)
+	}
+	return jsonValueEqual(ga, gb)
+}
+
+func numbersEqual(a, b any) bool {
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			return x == y
+		}
+	}
+	ra, okA := exactNumeric(a)
+	rb, okB := exactNumeric(b)
+	if !okA || !okB {
+		x, _, errA := numericFloat(a)
+		y, _, errB := numericFloat(b)
+		return errA == nil && errB == nil && x == y
+	}
+	return ra.Cmp(rb) == 0
+}
+
+// isDecodedJSON reports whether v is one of the types encoding/json decodes
+// JSON values to, with numbers as float64 or json.Number.
+func isDecodedJSON(v any) bool {
+	switch v.(type) {
+	case nil, bool, string, float64, json.Number, map[string]any, []any:
+		return true
+	default:
+		return false
+	}
+}
+
+// toJSONValue converts an arbitrary Go value (a struct, a typed slice or map)
+// to the form encoding/json decodes it to, keeping numbers as json.Number.
+// Values already in that form are returned unchanged.
+func toJSONValue(v any) (any, bool) {
+	if isDecodedJSON(v) {
+		return v, true
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, false
+	}
+	return out, true
+}
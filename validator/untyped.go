@@ -2,10 +2,10 @@ package validator
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
 	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/keywords"
 	"github.com/lestrrat-go/json-schema/vocabulary"
 )
 
@@ -81,10 +81,27 @@ func compileUntypedValidator(s *schema.Schema, vocab *vocabulary.VocabularySet)
 	return v.Build()
 }
 
-func (u *untypedValidator) Validate(ctx context.Context, value any, _ ...ValidateOption) (Result, error) {
+func (u *untypedValidator) Validate(ctx context.Context, value any, options ...ValidateOption) (Result, error) {
+	value, err := leafValue(value, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
+	res, err := u.check(ctx, value, nil)
+	return res, leafError(err)
+}
+
+func (u *untypedValidator) evaluate(ctx context.Context, value any, st *evalState) (Result, error) {
+	res, err := u.check(ctx, value, st)
+	return res, leafError(err)
+}
+
+func (u *untypedValidator) check(ctx context.Context, value any, st *evalState) (Result, error) {
 	// Check const first (more specific)
 	if u.constantValue != nil {
-		if err := validateConst(ctx, value, *u.constantValue); err != nil {
+		mark := st.beginKeyword(keywords.Const)
+		err := validateConst(ctx, value, *u.constantValue)
+		st.endKeyword(mark)
+		if err != nil {
 			return nil, err
 		}
 		//nolint: nilnil
@@ -94,7 +111,10 @@ func (u *untypedValidator) Validate(ctx context.Context, value any, _ ...Validat
 	// Check enum. An empty enum is a valid constraint that rejects every value,
 	// so gate on whether enum was set rather than on its length.
 	if u.hasEnum {
-		if err := validateEnum(ctx, value, u.enum); err != nil {
+		mark := st.beginKeyword(keywords.Enum)
+		err := validateEnum(ctx, value, u.enum)
+		st.endKeyword(mark)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -109,7 +129,7 @@ func validateConst(ctx context.Context, value any, constValue any) error {
 	logger.InfoContext(ctx, "validating const constraint", "expected", constValue, "actual", value)
 
 	if !jsonSchemaEqual(value, constValue) {
-		return fmt.Errorf(`must be const value %v`, constValue)
+		return codeErrorf(ErrCodeConst, `must be const value %v`, constValue)
 	}
 	return nil
 }
@@ -124,37 +144,16 @@ func validateEnum(ctx context.Context, value any, enumValues []any) error {
 			return nil
 		}
 	}
-	return fmt.Errorf(`invalid value: %v not found in enum %v`, value, enumValues)
+	return codeErrorf(ErrCodeEnum, `invalid value: %v not found in enum %v`, value, enumValues)
 }
 
-// jsonSchemaEqual compares two values according to JSON Schema equality rules
-// This handles numeric type equivalence (5 == 5.0) as required by JSON Schema spec
+// jsonSchemaEqual compares two values according to JSON Schema equality rules.
+// Numbers are equal when their values are (5 == 5.0), compared exactly so that
+// large integers float64 would round stay distinct (see jsonValueEqual).
 func jsonSchemaEqual(a, b any) bool {
 	// First try direct equality (handles same types efficiently)
 	if reflect.DeepEqual(a, b) {
 		return true
 	}
-
-	// Handle numeric comparisons specially
-	aNum, aIsNum := convertToNumber(a)
-	bNum, bIsNum := convertToNumber(b)
-
-	if aIsNum && bIsNum {
-		// Both are numbers - compare their mathematical values
-		return aNum == bNum
-	}
-
-	// For non-numeric types, fall back to reflect.DeepEqual
-	return false
-}
-
-// convertToNumber converts a value to float64 if it's a numeric type. It
-// recognizes native numeric kinds and json.Number (see validator/numeric.go),
-// so enum/const equality treats 5, 5.0, and json.Number("5") as equal.
-func convertToNumber(v any) (float64, bool) {
-	f, ok, err := numericFloat(v)
-	if err != nil || !ok {
-		return 0, false
-	}
-	return f, true
+	return jsonValueEqual(a, b)
 }
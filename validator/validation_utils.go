@@ -2,7 +2,11 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
 )
 
 // resultMerger handles the common pattern of merging ObjectResult and ArrayResult
@@ -10,6 +14,7 @@ import (
 type resultMerger struct {
 	objectResult *ObjectResult
 	arrayResult  *ArrayResult
+	coerced      *coercedResult
 }
 
 // mergeResult merges a validation result into the accumulated results
@@ -23,6 +28,10 @@ func (rm *resultMerger) mergeResult(result Result) {
 		if res != nil {
 			rm.mergeArrayResult(res)
 		}
+	case *coercedResult:
+		if res != nil && rm.coerced == nil {
+			rm.coerced = res
+		}
 	}
 }
 
@@ -66,6 +75,12 @@ func (rm *resultMerger) FinalResult() Result {
 		return rm.arrayResult
 	}
 
+	// Only scalar validators coerce, so a coerced value never competes with
+	// object or array annotations.
+	if rm.coerced != nil {
+		return rm.coerced
+	}
+
 	return nil
 }
 
@@ -83,14 +98,73 @@ func (rm *resultMerger) ArrayResult() *ArrayResult {
 // Returns the result merger and any error encountered
 func executeValidatorsAndMergeResults(ctx context.Context, validators []Interface, input any, st *evalState, validatorType string) (*resultMerger, error) {
 	var merger resultMerger
+	failures := newFailureCollector(st)
 
 	for i, validator := range validators {
 		result, err := evalChild(ctx, validator, input, st)
 		if err != nil {
-			return nil, fmt.Errorf(`%s validation failed: validator #%d failed: %w`, validatorType, i, err)
+			if failures.add(fmt.Errorf(`%s validation failed: validator #%d failed: %w`, validatorType, i, err)) {
+				return nil, failures.err()
+			}
+			continue
 		}
 		merger.mergeResult(result)
 	}
+	if err := failures.err(); err != nil {
+		return nil, err
+	}
 
 	return &merger, nil
 }
+
+// failureCollector gathers the failures of one validator. By default the first
+// failure ends the evaluation; under WithCollectAllErrors every failure is
+// kept and they are reported together.
+type failureCollector struct {
+	all  bool
+	errs []error
+}
+
+func newFailureCollector(st *evalState) failureCollector {
+	return failureCollector{all: st.collectAllErrors}
+}
+
+// add records err and reports whether the caller should stop and return
+// c.err() now. Cancellation always stops the evaluation.
+func (c *failureCollector) add(err error) bool {
+	c.errs = append(c.errs, err)
+	return !c.all || interrupted(err)
+}
+
+// interrupted reports whether err comes from the cancellation of the
+// validation context, or from WithMaxDepth stopping it, rather than from a
+// failed assertion. Validators that act on a child's failure instead of
+// propagating it (not, anyOf, oneOf, if, contains) must return such an error
+// as-is: treating it as a failure could turn a cancelled validation into a
+// successful one.
+func interrupted(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrMaxDepthExceeded)
+}
+
+// err returns nil when nothing failed, the failure itself when there was one,
+// and otherwise all failures joined by errors.Join.
+func (c *failureCollector) err() error {
+	switch len(c.errs) {
+	case 0:
+		return nil
+	case 1:
+		return c.errs[0]
+	default:
+		return errors.Join(c.errs...)
+	}
+}
+
+// evaluationOrder yields the keys of m in the order a validator should visit
+// them: map order normally, and sorted when failures are collected so the
+// joined error lists them deterministically.
+func evaluationOrder[V any](m map[string]V, st *evalState) iter.Seq[string] {
+	if !st.collectAllErrors {
+		return maps.Keys(m)
+	}
+	return slices.Values(slices.Sorted(maps.Keys(m)))
+}
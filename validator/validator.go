@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 
 	schema "github.com/lestrrat-go/json-schema"
 )
@@ -48,8 +49,13 @@ type ArrayResult struct {
 
 // NewObjectResult creates a new ObjectResult with an initialized map
 func NewObjectResult() *ObjectResult {
+	return newObjectResult(0)
+}
+
+// newObjectResult is NewObjectResult with room for capacity properties.
+func newObjectResult(capacity int) *ObjectResult {
 	return &ObjectResult{
-		evaluatedProperties: make(map[string]bool),
+		evaluatedProperties: make(map[string]bool, capacity),
 	}
 }
 
@@ -59,6 +65,11 @@ func NewArrayResult(size ...int) *ArrayResult {
 	if len(size) > 0 {
 		capacity = size[0]
 	}
+	return newArrayResult(capacity)
+}
+
+// newArrayResult is NewArrayResult with room for capacity items.
+func newArrayResult(capacity int) *ArrayResult {
 	return &ArrayResult{
 		evaluatedItems: make([]bool, 0, capacity),
 	}
@@ -112,6 +123,87 @@ func (r *ArrayResult) SetEvaluatedItems(items []bool) {
 	copy(r.evaluatedItems, items)
 }
 
+// EvaluatedProperties returns the names of the object properties that the
+// validation producing r evaluated, sorted. It reports what properties,
+// patternProperties, additionalProperties, dependentSchemas and the
+// unevaluatedProperties keyword itself touched, including annotations merged
+// up from allOf/anyOf/oneOf/if-then-else and $ref branches. It returns nil
+// when r carries no object annotations (e.g. the value was not an object).
+func EvaluatedProperties(r Result) []string {
+	objResult, ok := unwrapResult(r).(*ObjectResult)
+	if !ok || objResult == nil || len(objResult.evaluatedProperties) == 0 {
+		return nil
+	}
+	props := make([]string, 0, len(objResult.evaluatedProperties))
+	for prop, evaluated := range objResult.evaluatedProperties {
+		if evaluated {
+			props = append(props, prop)
+		}
+	}
+	slices.Sort(props)
+	return props
+}
+
+// EvaluatedItems returns the indices of the array items that the validation
+// producing r evaluated, in ascending order. It is the array counterpart of
+// EvaluatedProperties, covering prefixItems, items, contains and
+// unevaluatedItems. It returns nil when r carries no array annotations.
+func EvaluatedItems(r Result) []int {
+	arrResult, ok := unwrapResult(r).(*ArrayResult)
+	if !ok || arrResult == nil {
+		return nil
+	}
+	var indices []int
+	for i, evaluated := range arrResult.evaluatedItems {
+		if evaluated {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// coercedResult is the Result of a scalar validator that converted a string
+// input under WithCoercion.
+type coercedResult struct {
+	value any
+}
+
+// CoercedValue returns the value validated under WithCoercion, with every
+// string that was converted replaced by what it was converted to: an int64 for
+// "type": "integer", a float64 for "type": "number" and a bool for "type":
+// "boolean". A coerced scalar is returned as is; for an object or array,
+// CoercedValue returns a copy of it with the coerced properties and items
+// replaced, so {"age": "42"} validated against {"properties": {"age":
+// {"type": "integer"}}} gives {"age": int64(42)}.
+//
+// The second return value is false when r records no coercion, for example
+// because the input already had the right types, and when the coerced values
+// sit in Go values other than map[string]any and []any, such as structs; see
+// CoercedValues for those.
+func CoercedValue(r Result) (any, bool) {
+	if res, ok := r.(*annotatedResult); ok && res != nil && res.coerced != nil {
+		return withCoercions(res.instance, res.coerced)
+	}
+	res, ok := unwrapResult(r).(*coercedResult)
+	if !ok || res == nil {
+		return nil, false
+	}
+	return res.value, true
+}
+
+// CoercedValues returns the values converted under WithCoercion keyed by
+// instance location: a JSON Pointer into the validated value, "" for the value
+// itself. It returns nil when r records no coercion.
+func CoercedValues(r Result) map[string]any {
+	if res, ok := r.(*annotatedResult); ok && res != nil && res.coerced != nil {
+		return maps.Clone(res.coerced)
+	}
+	if res, ok := unwrapResult(r).(*coercedResult); ok && res != nil {
+		return map[string]any{"": res.value}
+	}
+	return nil
+}
+
 // mergeObjectResults merges multiple ObjectResult instances into a single result
 func mergeObjectResults(results ...*ObjectResult) *ObjectResult {
 	merged := NewObjectResult()
@@ -165,20 +257,15 @@ type Builder interface {
 // When the value is already a *Schema, it returns the schema as-is.
 // When the value is a map[string]any from JSON unmarshaling, it converts it to a Schema.
 func convertSchemaOrBool(v schema.SchemaOrBool) *schema.Schema {
-	switch val := v.(type) {
-	case schema.BoolSchema:
-		if bool(val) {
-			// true schema accepts everything
-			return schema.New()
-		}
-		// false schema rejects everything
-		return schema.NewBuilder().Not(schema.New()).MustBuild()
-	case *schema.Schema:
-		return val
-	default:
+	if s, ok := v.(*schema.Schema); ok {
+		return s
+	}
+	s, ok := schema.AsSchema(v)
+	if !ok {
 		// This shouldn't happen if validation is working correctly
 		panic(fmt.Sprintf("invalid SchemaOrBool type: %T", v))
 	}
+	return s
 }
 
 // hasOtherConstraints checks if a schema has constraints other than $ref/$dynamicRef
@@ -204,14 +291,17 @@ func hasOtherConstraints(s *schema.Schema) bool {
 	return s.HasAny(constraintFields)
 }
 
-// createSchemaWithoutRef creates a copy of the schema without the $ref/$dynamicRef constraint
+// createSchemaWithoutRef creates a copy of the schema without the $ref/$dynamicRef/$recursiveRef constraint
 func createSchemaWithoutRef(s *schema.Schema) (*schema.Schema, error) {
 	// Use the new Clone Builder pattern to create a copy without the $ref/$dynamicRef field
 	builder := schema.NewBuilder().Clone(s).ResetReference()
 	if s.HasDynamicReference() {
 		builder = builder.ResetDynamicReference()
 	}
-	return builder.Build()
+	if s.HasRecursiveReference() {
+		builder = builder.ResetRecursiveReference()
+	}
+	return builder.Build(schema.WithBoundsCheck(false))
 }
 
 // mergeGenericResults merges two results, handling both ObjectResult and ArrayResult types
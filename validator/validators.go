@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	schema "github.com/lestrrat-go/json-schema"
 	"github.com/lestrrat-go/json-schema/vocabulary"
@@ -15,8 +16,9 @@ type inferredNumberValidator struct {
 }
 
 func compileInferredNumberValidator(s *schema.Schema, vocab *vocabulary.VocabularySet) (Interface, error) {
-	// Create the underlying number validator
-	numValidator, err := compileNumberValidator(s, vocab)
+	// Create the underlying number validator. Without a declared type there is
+	// nothing to coerce a string to, so coercion never applies here.
+	numValidator, err := compileNumberValidator(s, vocab, false)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +28,11 @@ func compileInferredNumberValidator(s *schema.Schema, vocab *vocabulary.Vocabula
 	}, nil
 }
 
-func (v *inferredNumberValidator) Validate(ctx context.Context, in any, _ ...ValidateOption) (Result, error) {
+func (v *inferredNumberValidator) Validate(ctx context.Context, in any, options ...ValidateOption) (Result, error) {
+	in, err := leafValue(in, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
 	// isNumeric recognizes native numeric kinds and json.Number (see
 	// validator/numeric.go); non-numeric values ignore numeric constraints per
 	// the JSON Schema spec.
@@ -49,29 +55,72 @@ type NotValidator struct {
 	validator Interface
 }
 
+// Not creates a validator that accepts a value only if v rejects it.
+func Not(v Interface) Interface {
+	return &NotValidator{validator: v}
+}
+
 func (n *NotValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
-	return n.evaluate(ctx, v, newEvalState(ctx, options))
+	return validateRoot(ctx, n, v, options)
 }
 
 func (n *NotValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
 	_, err := evalChild(ctx, n.validator, v, st)
+	if interrupted(err) {
+		return nil, err
+	}
 	if err == nil {
-		return nil, fmt.Errorf(`not validation failed: value should not validate against the schema`)
+		return nil, codeErrorf(ErrCodeNot, `not validation failed: value should not validate against the schema`)
 	}
 	//nolint: nilnil
 	return nil, nil
 }
 
+// deferredValidator delegates to a validator that may not exist yet when it
+// is created, such as the validator of a recursive schema that refers to
+// itself.
+type deferredValidator struct {
+	once     sync.Once
+	get      func() Interface
+	resolved Interface
+}
+
+// Deferred creates a validator that delegates to the validator returned by f.
+// f is called once, when the validator is first used, so it may return a
+// variable that is assigned after Deferred is called. The code generator uses
+// it for recursive references.
+func Deferred(f func() Interface) Interface {
+	return &deferredValidator{get: f}
+}
+
+func (d *deferredValidator) Validate(ctx context.Context, v any, options ...ValidateOption) (Result, error) {
+	return validateRoot(ctx, d, v, options)
+}
+
+func (d *deferredValidator) evaluate(ctx context.Context, v any, st *evalState) (Result, error) {
+	d.once.Do(func() {
+		d.resolved = d.get()
+	})
+	if d.resolved == nil {
+		return nil, fmt.Errorf(`deferred validator is not available`)
+	}
+	return evalChild(ctx, d.resolved, v, st)
+}
+
 type nullValidator struct{}
 
 func Null() Interface {
 	return nullValidator{}
 }
 
-func (nullValidator) Validate(_ context.Context, v any, _ ...ValidateOption) (Result, error) {
+func (nullValidator) Validate(_ context.Context, v any, options ...ValidateOption) (Result, error) {
+	v, err := leafValue(v, options)
+	if err != nil {
+		return nil, leafError(err)
+	}
 	if v == nil {
 		//nolint: nilnil
 		return nil, nil
 	}
-	return nil, fmt.Errorf(`invalid value passed to NullValidator: expected null, got %T`, v)
+	return nil, leafError(codeErrorf(ErrCodeType, `invalid value passed to NullValidator: expected null, got %T`, v))
 }
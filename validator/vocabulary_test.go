@@ -0,0 +1,166 @@
+package validator_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/validator"
+	"github.com/lestrrat-go/json-schema/vocabulary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeclaredVocabularies(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+
+	const formatAssertion = `{
+		"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://json-schema.org/draft/2020-12/vocab/applicator": true,
+			"https://json-schema.org/draft/2020-12/vocab/validation": true,
+			"https://json-schema.org/draft/2020-12/vocab/format-assertion": true
+		},
+		"type": "string",
+		"format": "email"
+	}`
+
+	t.Run("format assertion", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, formatAssertion))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "ada@example.com")
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "not an email")
+		require.ErrorContains(t, err, "invalid email format")
+
+		// Without the declaration format is only an annotation.
+		v, err = validator.Compile(t.Context(), parse(t, `{"type": "string", "format": "email"}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "not an email")
+		require.NoError(t, err)
+	})
+
+	t.Run("undeclared vocabularies are not in use", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://json-schema.org/draft/2020-12/vocab/applicator": true
+			},
+			"properties": {"n": {"minimum": 10}, "forbidden": false}
+		}`))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"n": 1})
+		require.NoError(t, err, "minimum belongs to the validation vocabulary")
+		_, err = v.Validate(t.Context(), map[string]any{"forbidden": 1})
+		require.Error(t, err, "properties belongs to the applicator vocabulary")
+	})
+
+	t.Run("metaschema", func(t *testing.T) {
+		resolver := schema.NewResolver()
+		resolver.RegisterDocument("https://example.com/meta/no-validation", parse(t, `{
+			"$id": "https://example.com/meta/no-validation",
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://json-schema.org/draft/2020-12/vocab/applicator": true
+			}
+		}`))
+		v, err := validator.Compile(t.Context(), parse(t, `{
+			"$schema": "https://example.com/meta/no-validation",
+			"properties": {"n": {"minimum": 10}, "forbidden": false}
+		}`), validator.WithResolver(resolver))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"n": 1})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"forbidden": 1})
+		require.Error(t, err)
+	})
+
+	t.Run("WithVocabularySet takes precedence", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), parse(t, formatAssertion), validator.WithVocabularySet(vocabulary.DefaultSet()))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "not an email")
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown vocabularies", func(t *testing.T) {
+		_, err := validator.Compile(t.Context(), parse(t, `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://example.com/vocab/custom": false
+			}
+		}`))
+		require.NoError(t, err, "an optional vocabulary can be ignored")
+
+		_, err = validator.Compile(t.Context(), parse(t, `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"https://example.com/vocab/custom": true
+			}
+		}`))
+		require.ErrorContains(t, err, "unsupported vocabulary https://example.com/vocab/custom is required")
+
+		_, err = validator.Compile(t.Context(), parse(t, `{
+			"$vocabulary": {
+				"https://json-schema.org/draft/2020-12/vocab/core": true,
+				"vocab/relative": false
+			}
+		}`))
+		require.ErrorContains(t, err, "not an absolute URI")
+	})
+}
+
+func TestWithFormatAssertion(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, s.UnmarshalJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"contact": {"$ref": "#/$defs/contact"},
+			"children": {"type": "array", "items": {"$ref": "#"}}
+		},
+		"$defs": {"contact": {"type": "string", "format": "email"}}
+	}`)))
+
+	t.Run("enabled", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), &s, validator.WithFormatAssertion(true))
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"email": "ada@example.com", "contact": "bob@example.com"})
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"email": "not an email"})
+		require.ErrorContains(t, err, "invalid email format")
+		_, err = v.Validate(t.Context(), map[string]any{"contact": "not an email"})
+		require.Error(t, err, "formats behind a $ref assert too")
+		_, err = v.Validate(t.Context(), map[string]any{"children": []any{map[string]any{"email": "not an email"}}})
+		require.Error(t, err, "formats behind a recursive $ref assert too")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		v, err := validator.Compile(t.Context(), &s,
+			validator.WithVocabularySet(vocabulary.AllEnabled()),
+			validator.WithFormatAssertion(false),
+		)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), map[string]any{"email": "not an email"})
+		require.NoError(t, err)
+	})
+
+	t.Run("leaves the rest of the set alone", func(t *testing.T) {
+		vs := vocabulary.DefaultSet()
+		vs.Disable(vocabulary.ValidationURL)
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(`{"format": "email", "minLength": 10}`)))
+		v, err := validator.Compile(t.Context(), &s,
+			validator.WithVocabularySet(vs),
+			validator.WithFormatAssertion(true),
+		)
+		require.NoError(t, err)
+		_, err = v.Validate(t.Context(), "a@b.c")
+		require.NoError(t, err, "minLength belongs to the disabled validation vocabulary")
+		_, err = v.Validate(t.Context(), "not an email")
+		require.Error(t, err)
+		require.False(t, vs.IsEnabled(vocabulary.FormatAssertionURL), "the given set is not modified")
+	})
+}
@@ -0,0 +1,180 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// The typed accessors below read the values of "const", "default" and "enum"
+// without a type assertion at the call site. Numbers are accepted in every
+// form they are held in: float64 and json.Number when the schema was
+// unmarshaled (see preserveLargeIntegers), and any Go numeric type given to
+// the builder. Each reports false when the keyword is absent, or when its
+// value is not of the requested type.
+
+// ConstString returns the value of "const" when it is a string.
+func (s *Schema) ConstString() (string, bool) {
+	if !s.HasConst() {
+		return "", false
+	}
+	return stringValue(s.Const())
+}
+
+// ConstFloat returns the value of "const" when it is a number.
+func (s *Schema) ConstFloat() (float64, bool) {
+	if !s.HasConst() {
+		return 0, false
+	}
+	return floatValue(s.Const())
+}
+
+// ConstInt returns the value of "const" when it is an integer that int64
+// holds exactly. 2.0 is an integer; 2.5 and 1e20 are not.
+func (s *Schema) ConstInt() (int64, bool) {
+	if !s.HasConst() {
+		return 0, false
+	}
+	return intValue(s.Const())
+}
+
+// ConstBool returns the value of "const" when it is a boolean.
+func (s *Schema) ConstBool() (bool, bool) {
+	if !s.HasConst() {
+		return false, false
+	}
+	return boolValue(s.Const())
+}
+
+// DefaultString returns the value of "default" when it is a string.
+func (s *Schema) DefaultString() (string, bool) {
+	if !s.HasDefault() {
+		return "", false
+	}
+	return stringValue(s.Default())
+}
+
+// DefaultFloat returns the value of "default" when it is a number.
+func (s *Schema) DefaultFloat() (float64, bool) {
+	if !s.HasDefault() {
+		return 0, false
+	}
+	return floatValue(s.Default())
+}
+
+// DefaultInt returns the value of "default" when it is an integer that int64
+// holds exactly, as ConstInt does.
+func (s *Schema) DefaultInt() (int64, bool) {
+	if !s.HasDefault() {
+		return 0, false
+	}
+	return intValue(s.Default())
+}
+
+// DefaultBool returns the value of "default" when it is a boolean.
+func (s *Schema) DefaultBool() (bool, bool) {
+	if !s.HasDefault() {
+		return false, false
+	}
+	return boolValue(s.Default())
+}
+
+// EnumStrings returns the values of "enum" when every one is a string.
+func (s *Schema) EnumStrings() ([]string, bool) {
+	return enumValues(s, stringValue)
+}
+
+// EnumFloats returns the values of "enum" when every one is a number.
+func (s *Schema) EnumFloats() ([]float64, bool) {
+	return enumValues(s, floatValue)
+}
+
+// EnumInts returns the values of "enum" when every one is an integer that
+// int64 holds exactly, as ConstInt does.
+func (s *Schema) EnumInts() ([]int64, bool) {
+	return enumValues(s, intValue)
+}
+
+func enumValues[T any](s *Schema, convert func(any) (T, bool)) ([]T, bool) {
+	if !s.HasEnum() {
+		return nil, false
+	}
+	values := make([]T, 0, len(s.Enum()))
+	for _, v := range s.Enum() {
+		tv, ok := convert(v)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, tv)
+	}
+	return values, true
+}
+
+func stringValue(v any) (string, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func boolValue(v any) (bool, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func floatValue(v any) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return 0, false
+	case rv.CanFloat():
+		return rv.Float(), true
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+func intValue(v any) (int64, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+			return i, true
+		}
+		// A number such as 1e3 or 2.0 can still be an integer.
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt(f)
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return 0, false
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint():
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(u), true
+	case rv.CanFloat():
+		return floatToInt(rv.Float())
+	}
+	return 0, false
+}
+
+// floatToInt converts f when it is an integer in the range of int64.
+func floatToInt(f float64) (int64, bool) {
+	// -2^63 is exact as a float64; 2^63 is already out of range.
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
@@ -0,0 +1,130 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedValueAccessors(t *testing.T) {
+	t.Run("const", func(t *testing.T) {
+		s := mustParseSchema(t, `{"const": "ada"}`)
+		str, ok := s.ConstString()
+		require.True(t, ok)
+		require.Equal(t, "ada", str)
+		_, ok = s.ConstFloat()
+		require.False(t, ok)
+		_, ok = s.ConstBool()
+		require.False(t, ok)
+
+		s = mustParseSchema(t, `{"const": 2}`)
+		f, ok := s.ConstFloat()
+		require.True(t, ok)
+		require.Equal(t, 2.0, f)
+		i, ok := s.ConstInt()
+		require.True(t, ok)
+		require.Equal(t, int64(2), i)
+		_, ok = s.ConstString()
+		require.False(t, ok)
+
+		s = mustParseSchema(t, `{"const": true}`)
+		b, ok := s.ConstBool()
+		require.True(t, ok)
+		require.True(t, b)
+
+		s = mustParseSchema(t, `{"type": "string"}`)
+		_, ok = s.ConstString()
+		require.False(t, ok, "absent")
+	})
+
+	t.Run("default", func(t *testing.T) {
+		s := mustParseSchema(t, `{"default": 2.5}`)
+		f, ok := s.DefaultFloat()
+		require.True(t, ok)
+		require.Equal(t, 2.5, f)
+		_, ok = s.DefaultInt()
+		require.False(t, ok, "not an integer")
+
+		s = mustParseSchema(t, `{"default": "x"}`)
+		str, ok := s.DefaultString()
+		require.True(t, ok)
+		require.Equal(t, "x", str)
+
+		s = mustParseSchema(t, `{"default": false}`)
+		b, ok := s.DefaultBool()
+		require.True(t, ok)
+		require.False(t, b)
+
+		_, ok = mustParseSchema(t, `{"default": null}`).DefaultBool()
+		require.False(t, ok)
+		_, ok = mustParseSchema(t, `{}`).DefaultFloat()
+		require.False(t, ok)
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		testcases := []struct {
+			name  string
+			value any
+			float float64
+			int   int64
+			isInt bool
+		}{
+			{name: "int", value: 3, float: 3, int: 3, isInt: true},
+			{name: "uint8", value: uint8(7), float: 7, int: 7, isInt: true},
+			{name: "float32", value: float32(1.5), float: 1.5},
+			{name: "integral float64", value: 1e3, float: 1e3, int: 1000, isInt: true},
+			{name: "json.Number integer", value: json.Number("9007199254740993"), float: 9007199254740992, int: 9007199254740993, isInt: true},
+			{name: "json.Number exponent", value: json.Number("1e2"), float: 100, int: 100, isInt: true},
+			{name: "uint64 beyond int64", value: uint64(1 << 63), float: 1 << 63},
+			{name: "float beyond int64", value: 1e20, float: 1e20},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				s := schema.NewBuilder().Const(tc.value).Default(tc.value).MustBuild()
+				f, ok := s.ConstFloat()
+				require.True(t, ok)
+				require.Equal(t, tc.float, f)
+				i, ok := s.ConstInt()
+				require.Equal(t, tc.isInt, ok)
+				require.Equal(t, tc.int, i)
+				i, ok = s.DefaultInt()
+				require.Equal(t, tc.isInt, ok)
+				require.Equal(t, tc.int, i)
+			})
+		}
+
+		s := mustParseSchema(t, `{"const": 18446744073709551615}`)
+		_, ok := s.ConstInt()
+		require.False(t, ok, "large integers are kept as json.Number")
+		f, ok := s.ConstFloat()
+		require.True(t, ok)
+		require.Equal(t, 18446744073709551615.0, f)
+	})
+
+	t.Run("enum", func(t *testing.T) {
+		s := mustParseSchema(t, `{"enum": ["a", "b"]}`)
+		strs, ok := s.EnumStrings()
+		require.True(t, ok)
+		require.Equal(t, []string{"a", "b"}, strs)
+		_, ok = s.EnumFloats()
+		require.False(t, ok)
+
+		s = mustParseSchema(t, `{"enum": [1, 2.5]}`)
+		floats, ok := s.EnumFloats()
+		require.True(t, ok)
+		require.Equal(t, []float64{1, 2.5}, floats)
+		_, ok = s.EnumInts()
+		require.False(t, ok, "2.5 is not an integer")
+
+		ints, ok := mustParseSchema(t, `{"enum": [1, 2]}`).EnumInts()
+		require.True(t, ok)
+		require.Equal(t, []int64{1, 2}, ints)
+
+		_, ok = mustParseSchema(t, `{"enum": ["a", 1]}`).EnumStrings()
+		require.False(t, ok, "mixed")
+		_, ok = mustParseSchema(t, `{}`).EnumStrings()
+		require.False(t, ok)
+	})
+}
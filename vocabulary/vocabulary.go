@@ -3,7 +3,9 @@ package vocabulary
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"sync"
 
 	schema "github.com/lestrrat-go/json-schema"
@@ -201,6 +203,20 @@ func (vs *VocabularySet) Disable(vocabularyURI string) {
 	}
 }
 
+// Clone returns an independent copy of the set, which can be changed without
+// affecting vs.
+func (vs *VocabularySet) Clone() *VocabularySet {
+	clone := NewVocabularySet()
+	if vs == nil {
+		return clone
+	}
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	maps.Copy(clone.enabled, vs.enabled)
+	maps.Copy(clone.vocabularies, vs.vocabularies)
+	return clone
+}
+
 // IsEnabled checks if a vocabulary is enabled
 func (vs *VocabularySet) IsEnabled(vocabularyURI string) bool {
 	if vs == nil {
@@ -257,7 +273,14 @@ func DefaultSet() *VocabularySet {
 	return vs
 }
 
-// ExtractVocabularySet extracts the vocabulary set from a schema's $vocabulary declaration
+// ExtractVocabularySet extracts the vocabulary set from a schema's $vocabulary
+// declaration. The declaration lists every vocabulary in use, so the standard
+// vocabularies it leaves out are disabled; the core vocabulary is always
+// enabled. A listed vocabulary is in use whether its value is true (required)
+// or false (optional). Draft 2019-09 vocabulary URIs are mapped onto their
+// 2020-12 counterparts.
+//
+// A schema without a $vocabulary declaration yields AllEnabled.
 func ExtractVocabularySet(s *schema.Schema) *VocabularySet {
 	if s == nil || !s.HasVocabulary() {
 		return AllEnabled() // Default to all enabled if no vocabulary declaration
@@ -269,17 +292,84 @@ func ExtractVocabularySet(s *schema.Schema) *VocabularySet {
 	}
 
 	vs := NewVocabularySet()
-	for uri, enabled := range vocabMap {
-		if enabled {
-			vs.Enable(uri)
-		} else {
-			vs.Disable(uri)
+	for _, uri := range standardVocabularies {
+		vs.Disable(uri)
+	}
+	vs.Enable(CoreURL)
+	for uri := range vocabMap {
+		if mapped, ok := draft201909Vocabularies[uri]; ok {
+			for _, u := range mapped {
+				vs.Enable(u)
+			}
+			continue
 		}
+		vs.Enable(uri)
 	}
 
 	return vs
 }
 
+// SetFromSchema builds the set a schema's $vocabulary declares, as
+// ExtractVocabularySet does, after checking the declaration: every key must be
+// an absolute URI, and a vocabulary declared true (required) must be one this
+// package implements. A vocabulary declared false (optional) that it does not
+// know is ignored, as the specification allows. Use it to compile a custom
+// metaschema, whose $vocabulary picks the vocabularies the schemas written
+// against it may use.
+//
+// The custom sets name vocabularies implemented outside this package: they
+// count as known, and one the declaration does not list is disabled.
+//
+// A schema without a $vocabulary declaration yields AllEnabled.
+func SetFromSchema(s *schema.Schema, custom ...*Set) (*VocabularySet, error) {
+	if s == nil || !s.HasVocabulary() {
+		return AllEnabled(), nil
+	}
+	decl := s.Vocabulary()
+	for _, uri := range slices.Sorted(maps.Keys(decl)) {
+		if err := ValidateVocabularyURI(uri); err != nil {
+			return nil, err
+		}
+		if decl[uri] && !IsKnown(uri) && !slices.ContainsFunc(custom, func(set *Set) bool { return set.URI() == uri }) {
+			return nil, fmt.Errorf("unsupported vocabulary %s is required", uri)
+		}
+	}
+	vs := ExtractVocabularySet(s)
+	for _, set := range custom {
+		if _, ok := decl[set.URI()]; !ok {
+			vs.Disable(set.URI())
+		}
+	}
+	return vs, nil
+}
+
+// IsKnown reports whether uri names a vocabulary this package implements,
+// either a 2020-12 vocabulary or its draft 2019-09 equivalent.
+func IsKnown(uri string) bool {
+	if _, ok := draft201909Vocabularies[uri]; ok {
+		return true
+	}
+	return DefaultRegistry().Get(uri) != nil
+}
+
+var standardVocabularies = []string{
+	CoreURL, ApplicatorURL, UnevaluatedURL, ValidationURL,
+	FormatAnnotationURL, FormatAssertionURL, ContentURL, MetaDataURL,
+}
+
+// draft201909Vocabularies maps the draft 2019-09 vocabularies to the 2020-12
+// ones holding the same keywords. The unevaluated keywords were part of the
+// 2019-09 applicator vocabulary, and its format vocabulary is the annotation
+// one.
+var draft201909Vocabularies = map[string][]string{
+	"https://json-schema.org/draft/2019-09/vocab/core":       {CoreURL},
+	"https://json-schema.org/draft/2019-09/vocab/applicator": {ApplicatorURL, UnevaluatedURL},
+	"https://json-schema.org/draft/2019-09/vocab/validation": {ValidationURL},
+	"https://json-schema.org/draft/2019-09/vocab/format":     {FormatAnnotationURL},
+	"https://json-schema.org/draft/2019-09/vocab/content":    {ContentURL},
+	"https://json-schema.org/draft/2019-09/vocab/meta-data":  {MetaDataURL},
+}
+
 // ResolveVocabularyFromMetaschema resolves the vocabulary set declared by the
 // metaschema at metaschemaURI, resolving it against the given resolver and root
 // schema. It falls back to AllEnabled when the URI is empty, no root schema is
@@ -305,16 +395,20 @@ func ResolveVocabularyFromMetaschema(ctx context.Context, resolver *schema.Resol
 	return ExtractVocabularySet(&metaschema), nil
 }
 
-// ValidateVocabularyURI validates that a vocabulary URI is well-formed
+// ValidateVocabularyURI validates that a vocabulary URI is well-formed: the
+// keys of $vocabulary are absolute URIs, with a scheme.
 func ValidateVocabularyURI(uri string) error {
 	if uri == "" {
 		return fmt.Errorf("vocabulary URI cannot be empty")
 	}
 
-	_, err := url.Parse(uri)
+	u, err := url.Parse(uri)
 	if err != nil {
 		return fmt.Errorf("invalid vocabulary URI: %w", err)
 	}
+	if !u.IsAbs() {
+		return fmt.Errorf("invalid vocabulary URI %q: not an absolute URI", uri)
+	}
 
 	return nil
 }
@@ -0,0 +1,81 @@
+package vocabulary_test
+
+import (
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/lestrrat-go/json-schema/vocabulary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFromSchema(t *testing.T) {
+	parse := func(t *testing.T, src string) *schema.Schema {
+		t.Helper()
+		var s schema.Schema
+		require.NoError(t, s.UnmarshalJSON([]byte(src)))
+		return &s
+	}
+
+	t.Run("declared vocabularies", func(t *testing.T) {
+		vs, err := vocabulary.SetFromSchema(parse(t, `{"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://json-schema.org/draft/2020-12/vocab/applicator": true,
+			"https://json-schema.org/draft/2020-12/vocab/format-assertion": false,
+			"https://example.com/vocab/optional": false
+		}}`))
+		require.NoError(t, err)
+		require.True(t, vs.IsEnabled(vocabulary.CoreURL))
+		require.True(t, vs.IsEnabled(vocabulary.ApplicatorURL))
+		require.True(t, vs.IsEnabled(vocabulary.FormatAssertionURL), "an optional vocabulary is in use too")
+		require.False(t, vs.IsEnabled(vocabulary.ValidationURL), "undeclared")
+		require.False(t, vs.IsKeywordEnabled("minimum"))
+		require.True(t, vs.IsKeywordEnabled("properties"))
+	})
+
+	t.Run("draft 2019-09", func(t *testing.T) {
+		vs, err := vocabulary.SetFromSchema(parse(t, `{"$vocabulary": {
+			"https://json-schema.org/draft/2019-09/vocab/core": true,
+			"https://json-schema.org/draft/2019-09/vocab/applicator": true
+		}}`))
+		require.NoError(t, err)
+		require.True(t, vs.IsEnabled(vocabulary.UnevaluatedURL))
+		require.False(t, vs.IsEnabled(vocabulary.ValidationURL))
+	})
+
+	t.Run("no declaration", func(t *testing.T) {
+		vs, err := vocabulary.SetFromSchema(parse(t, `{"type": "string"}`))
+		require.NoError(t, err)
+		require.True(t, vs.IsEnabled(vocabulary.FormatAssertionURL))
+
+		vs, err = vocabulary.SetFromSchema(nil)
+		require.NoError(t, err)
+		require.True(t, vs.IsEnabled(vocabulary.ValidationURL))
+	})
+
+	t.Run("unknown required vocabulary", func(t *testing.T) {
+		_, err := vocabulary.SetFromSchema(parse(t, `{"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://example.com/vocab/custom": true
+		}}`))
+		require.ErrorContains(t, err, "unsupported vocabulary https://example.com/vocab/custom is required")
+	})
+
+	t.Run("custom vocabularies", func(t *testing.T) {
+		units := vocabulary.NewSet("https://example.com/vocab/units").Add("x-units")
+		other := vocabulary.NewSet("https://example.com/vocab/other").Add("x-other")
+		vs, err := vocabulary.SetFromSchema(parse(t, `{"$vocabulary": {
+			"https://json-schema.org/draft/2020-12/vocab/core": true,
+			"https://example.com/vocab/units": true
+		}}`), units, other)
+		require.NoError(t, err)
+		require.True(t, vs.IsEnabled(units.URI()))
+		require.False(t, vs.IsEnabled(other.URI()), "undeclared")
+	})
+
+	t.Run("malformed URIs", func(t *testing.T) {
+		for _, uri := range []string{"vocab/core", "", "https://example.com/%zz"} {
+			_, err := vocabulary.SetFromSchema(parse(t, `{"$vocabulary": {"`+uri+`": false}}`))
+			require.Error(t, err, uri)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+)
+
+// SkipSubschemas can be returned by a WalkFunc to skip the subschemas of the
+// schema it was called with. Walk continues with the next sibling.
+var SkipSubschemas = errors.New("skip subschemas") //nolint:errname,revive // named after fs.SkipDir
+
+// SkipAll can be returned by a WalkFunc to stop the walk. Walk then returns
+// nil.
+var SkipAll = errors.New("skip all subschemas") //nolint:errname,revive // named after fs.SkipAll
+
+// WalkFunc is called by Walk for every schema it visits. path is the JSON
+// Pointer of sub relative to the schema Walk was called on, "" for that
+// schema itself.
+type WalkFunc func(path string, sub *Schema) error
+
+// Walk visits s and every subschema nested in it, depth first and parent
+// before children, calling fn for each. It follows every keyword that holds a
+// schema — $defs, properties, patternProperties, additionalProperties, items,
+// prefixItems, allOf/anyOf/oneOf, not, if/then/else and the rest, including
+// the legacy definitions and dependencies — visiting map entries in key
+// order. Boolean schemas (true/false) are not visited. Walk does not follow
+// $ref.
+//
+// If fn returns SkipSubschemas, the subschemas of that schema are skipped. If
+// it returns SkipAll, the walk stops and Walk returns nil. Any other non-nil
+// error stops the walk and is returned by Walk.
+func (s *Schema) Walk(fn WalkFunc) error {
+	if s == nil {
+		return nil
+	}
+	if err := walk(s, "", fn); err != nil && !errors.Is(err, SkipAll) {
+		return err
+	}
+	return nil
+}
+
+func walk(s *Schema, path string, fn WalkFunc) error {
+	if err := fn(path, s); err != nil {
+		if errors.Is(err, SkipSubschemas) {
+			return nil
+		}
+		return err
+	}
+	for _, sub := range subschemas(s) {
+		if err := walk(sub.schema, path+pointerSuffix(sub.tokens), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointerSuffix renders tokens as JSON Pointer segments ("/a/b"), escaping
+// "~" and "/" per RFC 6901.
+func pointerSuffix(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(tok))
+	}
+	return sb.String()
+}
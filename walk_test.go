@@ -0,0 +1,92 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	schema "github.com/lestrrat-go/json-schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	s := mustParseSchema(t, `{
+		"$defs": {"a/b": {"type": "string"}},
+		"properties": {
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/a~1b"}},
+			"point": {"prefixItems": [{"type": "number"}, true]}
+		},
+		"patternProperties": {"^x-": {"format": "uri"}},
+		"additionalProperties": {"not": {"type": "null"}},
+		"allOf": [{"if": {"required": ["a"]}, "then": {"required": ["b"]}, "else": false}]
+	}`)
+
+	t.Run("visits every subschema", func(t *testing.T) {
+		var paths []string
+		require.NoError(t, s.Walk(func(path string, _ *schema.Schema) error {
+			paths = append(paths, path)
+			return nil
+		}))
+		require.Equal(t, []string{
+			"",
+			"/$defs/a~1b",
+			"/properties/point",
+			"/properties/point/prefixItems/0",
+			"/properties/tags",
+			"/properties/tags/items",
+			"/patternProperties/^x-",
+			"/additionalProperties",
+			"/additionalProperties/not",
+			"/allOf/0",
+			"/allOf/0/if",
+			"/allOf/0/then",
+		}, paths)
+	})
+
+	t.Run("collects references", func(t *testing.T) {
+		var refs []string
+		require.NoError(t, s.Walk(func(_ string, sub *schema.Schema) error {
+			if sub.HasReference() {
+				refs = append(refs, sub.Reference())
+			}
+			return nil
+		}))
+		require.Equal(t, []string{"#/$defs/a~1b"}, refs)
+	})
+
+	t.Run("SkipSubschemas", func(t *testing.T) {
+		var paths []string
+		require.NoError(t, s.Walk(func(path string, _ *schema.Schema) error {
+			paths = append(paths, path)
+			if path == "/properties/tags" || path == "/allOf/0" {
+				return schema.SkipSubschemas
+			}
+			return nil
+		}))
+		require.NotContains(t, paths, "/properties/tags/items")
+		require.NotContains(t, paths, "/allOf/0/if")
+		require.Contains(t, paths, "/allOf/0")
+	})
+
+	t.Run("SkipAll", func(t *testing.T) {
+		var count int
+		require.NoError(t, s.Walk(func(string, *schema.Schema) error {
+			count++
+			if count == 3 {
+				return schema.SkipAll
+			}
+			return nil
+		}))
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("errors are returned", func(t *testing.T) {
+		errStop := errors.New("stop")
+		err := s.Walk(func(path string, _ *schema.Schema) error {
+			if path == "/properties/tags/items" {
+				return errStop
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+	})
+}